@@ -0,0 +1,14 @@
+// Package emf provides a vector backend that writes Windows Enhanced
+// Metafiles (EMF).
+//
+// EMF is the native vector format of Microsoft Office, so exported figures
+// can be pasted into Word or PowerPoint and stay sharp and editable
+// (ungroup the picture to edit shapes and text). The backend supports:
+//   - Fill and stroke operations with joins, caps, and dashes
+//   - Rectangular and path clipping via SaveDC/RestoreDC
+//   - Text via ExtTextOutW in Arial (metrics are estimates)
+//   - Embedded bitmaps with per-pixel alpha, used for rasterized artists
+//
+// Plain EMF has no opacity for vector shapes, so translucent colors are
+// written opaque. EMF+ records and glyph runs are not supported yet.
+package emf
@@ -0,0 +1,353 @@
+package emf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"unicode/utf16"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// EMF record types used by the renderer (MS-EMF 2.1.1).
+const (
+	emrHeader                 = 1
+	emrPolyBezierTo           = 5
+	emrEOF                    = 14
+	emrSetBkMode              = 18
+	emrSetPolyFillMode        = 19
+	emrSetTextAlign           = 22
+	emrSetTextColor           = 24
+	emrMoveToEx               = 27
+	emrIntersectClipRect      = 30
+	emrSaveDC                 = 33
+	emrRestoreDC              = 34
+	emrSetWorldTransform      = 35
+	emrSelectObject           = 37
+	emrCreateBrushIndirect    = 39
+	emrDeleteObject           = 40
+	emrLineTo                 = 54
+	emrSetMiterLimit          = 58
+	emrBeginPath              = 59
+	emrEndPath                = 60
+	emrCloseFigure            = 61
+	emrFillPath               = 62
+	emrStrokeAndFillPath      = 63
+	emrStrokePath             = 64
+	emrSelectClipPath         = 67
+	emrExtCreateFontIndirectW = 82
+	emrExtTextOutW            = 84
+	emrExtCreatePen           = 95
+	emrAlphaBlend             = 114
+)
+
+// Object handles. Objects are created, used, and deleted immediately, so a
+// single slot per object kind is enough.
+const (
+	hPen   = 1
+	hBrush = 2
+	hFont  = 3
+
+	numHandles = 4 // index 0 is reserved for the metafile itself
+
+	stockNullBrush  = 0x80000005
+	stockNullPen    = 0x80000008
+	stockSystemFont = 0x8000000D
+)
+
+// Pen styles and GDI modes.
+const (
+	psUserStyle   = 0x00000007
+	psEndcapRound = 0x00000000
+	psEndcapSq    = 0x00000100
+	psEndcapFlat  = 0x00000200
+	psJoinRound   = 0x00000000
+	psJoinBevel   = 0x00001000
+	psJoinMiter   = 0x00002000
+	psGeometric   = 0x00010000
+
+	bkTransparent = 1
+	fillAlternate = 1
+	fillWinding   = 2
+	taBaseline    = 24
+	rgnAnd        = 1
+	gmAdvanced    = 2
+	etoNone       = 0
+)
+
+// subpixel is the number of logical units per pixel. EMF coordinates are
+// integers, so drawing happens on a finer grid scaled down by the world
+// transform to keep sub-pixel precision.
+const subpixel = 16
+
+// Renderer implements render.Renderer by recording Enhanced Metafile (EMF)
+// records.
+//
+// EMF has no per-primitive opacity, so colors are written opaque and fully
+// transparent fills or strokes are skipped.
+type Renderer struct {
+	width  int // canvas width in pixels
+	height int // canvas height in pixels
+	dpi    float64
+	bg     render.Color
+
+	body    bytes.Buffer
+	records uint32
+	began   bool
+	saved   int
+	done    []byte // output of the last completed session
+}
+
+var _ render.Renderer = (*Renderer)(nil)
+var _ render.TextDrawer = (*Renderer)(nil)
+var _ render.RotatedTextDrawer = (*Renderer)(nil)
+var _ render.VectorOutput = (*Renderer)(nil)
+
+// New creates an EMF renderer for a w×h pixel canvas. A non-positive dpi
+// defaults to 96, matching style.Default.
+func New(w, h int, bg render.Color, dpi float64) *Renderer {
+	if dpi <= 0 {
+		dpi = 96
+	}
+	return &Renderer{width: w, height: h, dpi: dpi, bg: bg}
+}
+
+// Begin starts a drawing session with the given viewport.
+func (r *Renderer) Begin(_ geom.Rect) error {
+	if r.began {
+		return errors.New("Begin called twice")
+	}
+	r.began = true
+	r.saved = 0
+	r.records = 0
+	r.body.Reset()
+
+	// Playback always happens in GM_ADVANCED, so the world transform applies.
+	s := float32(1) / subpixel
+	r.record(emrSetWorldTransform, math.Float32bits(s), 0, 0, math.Float32bits(s), 0, 0)
+	r.record(emrSetPolyFillMode, fillWinding)
+	r.record(emrSetBkMode, bkTransparent)
+	r.record(emrSetTextAlign, taBaseline)
+
+	if r.bg.A > 0 {
+		var p geom.Path
+		p.MoveTo(geom.Pt{})
+		p.LineTo(geom.Pt{X: float64(r.width)})
+		p.LineTo(geom.Pt{X: float64(r.width), Y: float64(r.height)})
+		p.LineTo(geom.Pt{Y: float64(r.height)})
+		p.Close()
+		r.Path(p, &render.Paint{Fill: r.bg})
+	}
+	return nil
+}
+
+// End finishes the drawing session, restoring any saved states left open.
+func (r *Renderer) End() error {
+	if !r.began {
+		return errors.New("End called before Begin")
+	}
+	for r.saved > 0 {
+		r.Restore()
+	}
+	r.record(emrEOF, 0, 16, 20)
+	r.began = false
+
+	var out bytes.Buffer
+	r.writeHeader(&out)
+	out.Write(r.body.Bytes())
+	r.done = out.Bytes()
+	return nil
+}
+
+// Save pushes the device context state.
+func (r *Renderer) Save() {
+	if !r.began {
+		return
+	}
+	r.record(emrSaveDC)
+	r.saved++
+}
+
+// Restore pops the device context state; underflow is ignored.
+func (r *Renderer) Restore() {
+	if !r.began || r.saved == 0 {
+		return
+	}
+	r.record(emrRestoreDC, math.MaxUint32) // -1: the most recent save
+	r.saved--
+}
+
+// ClipRect intersects the current clip with a rectangle.
+func (r *Renderer) ClipRect(rect geom.Rect) {
+	if !r.began {
+		return
+	}
+	r.record(emrIntersectClipRect,
+		coord(rect.Min.X), coord(rect.Min.Y), coord(rect.Max.X), coord(rect.Max.Y))
+}
+
+// ClipPath intersects the current clip with an arbitrary path.
+func (r *Renderer) ClipPath(p geom.Path) {
+	if !r.began || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	r.writePath(p)
+	r.record(emrSelectClipPath, rgnAnd)
+}
+
+// Path fills and/or strokes a path.
+func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
+	if !r.began || paint == nil || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	fill := paint.Fill.A > 0
+	stroke := paint.Stroke.A > 0 && paint.LineWidth > 0
+	if !fill && !stroke {
+		return
+	}
+
+	if fill {
+		r.record(emrCreateBrushIndirect, hBrush, 0, colorRef(paint.Fill), 0)
+		r.record(emrSelectObject, hBrush)
+	} else {
+		r.record(emrSelectObject, stockNullBrush)
+	}
+	if stroke {
+		r.createPen(paint)
+		r.record(emrSelectObject, hPen)
+	} else {
+		r.record(emrSelectObject, stockNullPen)
+	}
+
+	evenOdd := fill && paint.FillRule == render.FillEvenOdd
+	if evenOdd {
+		r.record(emrSetPolyFillMode, fillAlternate)
+	}
+	r.writePath(p)
+	bounds := pathBounds(p)
+	switch {
+	case fill && stroke:
+		r.record(emrStrokeAndFillPath, bounds[:]...)
+	case fill:
+		r.record(emrFillPath, bounds[:]...)
+	default:
+		r.record(emrStrokePath, bounds[:]...)
+	}
+
+	if evenOdd {
+		r.record(emrSetPolyFillMode, fillWinding)
+	}
+	r.record(emrSelectObject, stockNullBrush)
+	r.record(emrSelectObject, stockNullPen)
+	if fill {
+		r.record(emrDeleteObject, hBrush)
+	}
+	if stroke {
+		r.record(emrDeleteObject, hPen)
+	}
+}
+
+// Image embeds a bitmap scaled to dst using an AlphaBlend record, so
+// translucent pixels composite over earlier drawing. Only render.RGBAImage
+// is supported; other images are ignored.
+func (r *Renderer) Image(img render.Image, dst geom.Rect) {
+	src, ok := img.(render.RGBAImage)
+	if !r.began || !ok || src.RGBA == nil {
+		return
+	}
+	w, h := src.Size()
+	if w == 0 || h == 0 {
+		return
+	}
+	r.recordBytes(emrAlphaBlend, alphaBlendParams(dst, w, h), dib(src))
+}
+
+// VectorOutput reports that images passed to Image are embedded in the
+// metafile.
+func (r *Renderer) VectorOutput() bool { return true }
+
+// GlyphRun is a no-op: EMF output keeps text editable, so strings are
+// emitted via DrawText instead of positioned glyphs.
+func (r *Renderer) GlyphRun(_ render.GlyphRun, _ render.Color) {}
+
+// MeasureText estimates text extents. The real metrics depend on the font
+// installed on the machine that plays back the metafile.
+func (r *Renderer) MeasureText(text string, size float64, _ string) render.TextMetrics {
+	if text == "" {
+		return render.TextMetrics{}
+	}
+	n := float64(len([]rune(text)))
+	return render.TextMetrics{
+		W:       n * size * 0.5,
+		H:       size * 1.2,
+		Ascent:  size * 0.8,
+		Descent: size * 0.2,
+	}
+}
+
+// DrawText emits text anchored at its left baseline as an editable string
+// in the Arial font.
+func (r *Renderer) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
+	r.DrawTextRotated(text, origin, size, 0, textColor)
+}
+
+// DrawTextRotated emits text turned by angle radians counterclockwise
+// around its left baseline, using the font's escapement.
+func (r *Renderer) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	if !r.began || text == "" || textColor.A == 0 {
+		return
+	}
+
+	// LOGFONTW: negative height selects the character (em) height.
+	font := make([]byte, 92)
+	binary.LittleEndian.PutUint32(font[0:], coord(-size))
+	tenths := uint32(int32(math.Round(angle * 1800 / math.Pi))) // escapement and orientation in 0.1°
+	binary.LittleEndian.PutUint32(font[8:], tenths)
+	binary.LittleEndian.PutUint32(font[12:], tenths)
+	binary.LittleEndian.PutUint32(font[16:], 400) // FW_NORMAL
+	font[23] = 1                                  // DEFAULT_CHARSET
+	font[26] = 5                                  // CLEARTYPE_QUALITY
+	for i, c := range utf16.Encode([]rune("Arial")) {
+		binary.LittleEndian.PutUint16(font[28+2*i:], c)
+	}
+	r.recordBytes(emrExtCreateFontIndirectW, le(hFont), font)
+	r.record(emrSelectObject, hFont)
+	r.record(emrSetTextColor, colorRef(textColor))
+
+	chars := utf16.Encode([]rune(text))
+	str := make([]byte, (len(chars)*2+3)&^3)
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(str[2*i:], c)
+	}
+	const fixed = 76 // record header + bounds + EmrText fields
+	x, y := coord(origin.X), coord(origin.Y)
+	r.recordBytes(emrExtTextOutW,
+		le(0, 0, math.MaxUint32, math.MaxUint32, // bounds: not computed
+			gmAdvanced, 0, 0, // exScale/eyScale are ignored in GM_ADVANCED
+			x, y, uint32(len(chars)), fixed, etoNone, 0, 0, 0, 0, 0),
+		str)
+
+	r.record(emrSelectObject, stockSystemFont)
+	r.record(emrDeleteObject, hFont)
+}
+
+// Bytes returns the metafile produced by the last completed session.
+func (r *Renderer) Bytes() []byte { return r.done }
+
+// WriteTo writes the metafile of the last completed session to w.
+func (r *Renderer) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(r.done)
+	return int64(n), err
+}
+
+// SaveEMF writes the metafile of the last completed session to a file.
+func (r *Renderer) SaveEMF(path string) error {
+	if r.done == nil {
+		return errors.New("nothing rendered: call End before SaveEMF")
+	}
+	return os.WriteFile(path, r.done, 0o644)
+}
@@ -0,0 +1,155 @@
+package emf
+
+import (
+	"encoding/binary"
+	"image"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// parseRecords walks an EMF stream and returns the record types in order.
+func parseRecords(t *testing.T, data []byte) []uint32 {
+	t.Helper()
+	var types []uint32
+	for off := 0; off < len(data); {
+		if off+8 > len(data) {
+			t.Fatalf("truncated record at %d", off)
+		}
+		typ := binary.LittleEndian.Uint32(data[off:])
+		size := int(binary.LittleEndian.Uint32(data[off+4:]))
+		if size < 8 || size%4 != 0 || off+size > len(data) {
+			t.Fatalf("bad record size %d for type %d at %d", size, typ, off)
+		}
+		types = append(types, typ)
+		off += size
+	}
+	return types
+}
+
+func TestLifecycleAndHeader(t *testing.T) {
+	r := New(200, 100, render.Color{R: 1, G: 1, B: 1, A: 1}, 96)
+	vp := geom.Rect{Max: geom.Pt{X: 200, Y: 100}}
+	if err := r.Begin(vp); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := r.Begin(vp); err == nil {
+		t.Error("expected double Begin to fail")
+	}
+	r.Save()
+	r.ClipRect(geom.Rect{Min: geom.Pt{X: 10, Y: 10}, Max: geom.Pt{X: 50, Y: 50}})
+	r.DrawText("Hi", geom.Pt{X: 20, Y: 30}, 12, render.Color{A: 1})
+	if err := r.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if err := r.End(); err == nil {
+		t.Error("expected End without Begin to fail")
+	}
+
+	data := r.Bytes()
+	types := parseRecords(t, data)
+	if types[0] != emrHeader || types[len(types)-1] != emrEOF {
+		t.Fatalf("expected header first and EOF last, got %v", types)
+	}
+	if sig := binary.LittleEndian.Uint32(data[40:]); sig != 0x464D4520 {
+		t.Errorf("bad signature %#x", sig)
+	}
+	if n := binary.LittleEndian.Uint32(data[48:]); int(n) != len(data) {
+		t.Errorf("header size %d, file size %d", n, len(data))
+	}
+	if n := binary.LittleEndian.Uint32(data[52:]); int(n) != len(types) {
+		t.Errorf("header records %d, actual %d", n, len(types))
+	}
+
+	saves, restores := 0, 0
+	for _, typ := range types {
+		switch typ {
+		case emrSaveDC:
+			saves++
+		case emrRestoreDC:
+			restores++
+		}
+	}
+	if saves != 1 || restores != 1 {
+		t.Errorf("unbalanced SaveDC/RestoreDC: %d/%d", saves, restores)
+	}
+}
+
+func TestPathRecords(t *testing.T) {
+	r := New(100, 100, render.Color{}, 96)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 100}})
+
+	p := geom.Path{}
+	p.MoveTo(geom.Pt{X: 10, Y: 10})
+	p.QuadTo(geom.Pt{X: 50, Y: 0}, geom.Pt{X: 90, Y: 10})
+	p.LineTo(geom.Pt{X: 90, Y: 90})
+	p.Close()
+	r.Path(p, &render.Paint{
+		LineWidth: 2,
+		Stroke:    render.Color{R: 1, A: 1},
+		Fill:      render.Color{B: 1, A: 1},
+		Dashes:    []float64{4},
+	})
+	_ = r.End()
+
+	want := []uint32{
+		emrCreateBrushIndirect, emrSelectObject,
+		emrExtCreatePen, emrSelectObject,
+		emrBeginPath, emrMoveToEx, emrPolyBezierTo, emrLineTo, emrCloseFigure, emrEndPath,
+		emrStrokeAndFillPath,
+	}
+	types := parseRecords(t, r.Bytes())
+	start := -1
+	for i, typ := range types {
+		if typ == emrCreateBrushIndirect {
+			start = i
+			break
+		}
+	}
+	if start < 0 || start+len(want) > len(types) {
+		t.Fatalf("path records not found in %v", types)
+	}
+	for i, typ := range want {
+		if types[start+i] != typ {
+			t.Fatalf("record %d: got %d want %d (all: %v)", i, types[start+i], typ, types)
+		}
+	}
+}
+
+func TestColorRef(t *testing.T) {
+	if got := colorRef(render.Color{R: 1, G: 0.5, B: 0, A: 1}); got != 0x000080FF {
+		t.Errorf("colorRef = %#08x", got)
+	}
+}
+
+func TestImageAlphaBlend(t *testing.T) {
+	r := New(100, 100, render.Color{}, 96)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 100}})
+	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+	img.Pix[0], img.Pix[3] = 255, 255 // top-left pixel opaque red
+	r.Image(render.RGBAImage{RGBA: img}, geom.Rect{Min: geom.Pt{X: 10, Y: 10}, Max: geom.Pt{X: 12, Y: 13}})
+	_ = r.End()
+
+	data := r.Bytes()
+	found := false
+	for off := 0; off < len(data); {
+		typ := binary.LittleEndian.Uint32(data[off:])
+		size := int(binary.LittleEndian.Uint32(data[off+4:]))
+		if typ == emrAlphaBlend {
+			found = true
+			if want := 108 + 40 + 4*2*3; size != want {
+				t.Fatalf("record size %d, want %d", size, want)
+			}
+			// Bottom-up: the top-left pixel is the first pixel of the last row.
+			px := data[off+148+4*2*2:]
+			if px[2] != 255 || px[3] != 255 {
+				t.Errorf("expected BGRA red at top-left, got %v", px[:4])
+			}
+		}
+		off += size
+	}
+	if !found {
+		t.Fatal("no AlphaBlend record written")
+	}
+}
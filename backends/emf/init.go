@@ -0,0 +1,22 @@
+package emf
+
+import (
+	"matplotlib-go/backends"
+	"matplotlib-go/render"
+)
+
+func init() {
+	// Register EMF backend with the global registry
+	backends.Register(backends.EMF, &backends.BackendInfo{
+		Name:        "EMF",
+		Description: "Windows Enhanced Metafile for editable figures in Office documents",
+		Capabilities: []backends.Capability{
+			backends.PathClip,
+			backends.VectorOutput,
+		},
+		Factory: func(config backends.Config) (render.Renderer, error) {
+			return New(config.Width, config.Height, config.Background, config.DPI), nil
+		},
+		Available: true, // Pure Go binary output
+	})
+}
@@ -0,0 +1,223 @@
+package emf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// record appends an EMF record whose payload is a sequence of 32-bit values.
+func (r *Renderer) record(typ uint32, args ...uint32) {
+	r.recordBytes(typ, le(args...))
+}
+
+// recordBytes appends an EMF record built from raw payload parts. Parts must
+// keep the record size a multiple of four.
+func (r *Renderer) recordBytes(typ uint32, parts ...[]byte) {
+	size := 8
+	for _, p := range parts {
+		size += len(p)
+	}
+	r.body.Write(le(typ, uint32(size)))
+	for _, p := range parts {
+		r.body.Write(p)
+	}
+	r.records++
+}
+
+// writeHeader writes the EMR_HEADER record describing the finished body.
+func (r *Renderer) writeHeader(out *bytes.Buffer) {
+	const headerSize = 108
+	w, h := uint32(r.width), uint32(r.height)
+	mmW := uint32(math.Round(float64(r.width) / r.dpi * 25.4))
+	mmH := uint32(math.Round(float64(r.height) / r.dpi * 25.4))
+	out.Write(le(
+		emrHeader, headerSize,
+		0, 0, w-1, h-1, // bounds in device pixels, inclusive
+		0, 0, mmW*100, mmH*100, // frame in 0.01 mm
+		0x464D4520, // " EMF"
+		0x00010000, // version
+		uint32(headerSize+r.body.Len()),
+		r.records+1,
+		numHandles, // handles (uint16) + reserved (uint16)
+		0, 0, 0,    // description and palette
+		w, h, // reference device size in pixels
+		mmW, mmH, // reference device size in millimeters
+		0, 0, 0, // pixel format and OpenGL
+		mmW*1000, mmH*1000, // reference device size in micrometers
+	))
+}
+
+// createPen records an EMR_EXTCREATEPEN for the stroke settings of paint.
+func (r *Renderer) createPen(paint *render.Paint) {
+	style := uint32(psGeometric)
+	switch paint.StrokeCap() {
+	case render.CapRound:
+		style |= psEndcapRound
+	case render.CapSquare:
+		style |= psEndcapSq
+	default:
+		style |= psEndcapFlat
+	}
+	switch paint.LineJoin {
+	case render.JoinRound:
+		style |= psJoinRound
+	case render.JoinBevel:
+		style |= psJoinBevel
+	default:
+		style |= psJoinMiter
+		if paint.MiterLimit > 0 {
+			r.record(emrSetMiterLimit, uint32(math.Max(1, math.Round(paint.MiterLimit))))
+		}
+	}
+
+	var dashes []uint32
+	if len(paint.Dashes) > 0 {
+		style |= psUserStyle
+		for _, d := range paint.Dashes {
+			dashes = append(dashes, coord(d))
+		}
+		// GDI requires an even number of entries; repeat odd patterns.
+		if len(dashes)%2 == 1 {
+			dashes = append(dashes, dashes...)
+		}
+	}
+
+	args := []uint32{
+		hPen,
+		0, 0, 0, 0, // no brush bitmap
+		style, coord(paint.LineWidth),
+		0, // BS_SOLID
+		colorRef(paint.Stroke),
+		0, // hatch
+		uint32(len(dashes)),
+	}
+	r.record(emrExtCreatePen, append(args, dashes...)...)
+}
+
+// writePath records a GDI path bracket for p. Quadratic segments are raised
+// to cubics since EMF only has cubic Béziers.
+func (r *Renderer) writePath(p geom.Path) {
+	r.record(emrBeginPath)
+	var cur, start geom.Pt
+	vi := 0
+	for _, cmd := range p.C {
+		switch cmd {
+		case geom.MoveTo:
+			cur, start = p.V[vi], p.V[vi]
+			r.record(emrMoveToEx, coord(cur.X), coord(cur.Y))
+			vi++
+		case geom.LineTo:
+			cur = p.V[vi]
+			r.record(emrLineTo, coord(cur.X), coord(cur.Y))
+			vi++
+		case geom.QuadTo:
+			q, end := p.V[vi], p.V[vi+1]
+			c1 := geom.Pt{X: cur.X + 2.0/3*(q.X-cur.X), Y: cur.Y + 2.0/3*(q.Y-cur.Y)}
+			c2 := geom.Pt{X: end.X + 2.0/3*(q.X-end.X), Y: end.Y + 2.0/3*(q.Y-end.Y)}
+			r.bezierTo(cur, c1, c2, end)
+			cur = end
+			vi += 2
+		case geom.CubicTo:
+			r.bezierTo(cur, p.V[vi], p.V[vi+1], p.V[vi+2])
+			cur = p.V[vi+2]
+			vi += 3
+		case geom.ClosePath:
+			r.record(emrCloseFigure)
+			cur = start
+		}
+	}
+	r.record(emrEndPath)
+}
+
+func (r *Renderer) bezierTo(from, c1, c2, to geom.Pt) {
+	b := boundsOf([]geom.Pt{from, c1, c2, to})
+	r.record(emrPolyBezierTo,
+		b[0], b[1], b[2], b[3], 3,
+		coord(c1.X), coord(c1.Y), coord(c2.X), coord(c2.Y), coord(to.X), coord(to.Y))
+}
+
+// alphaBlendParams encodes the fixed part of an EMR_ALPHABLEND record that
+// draws a w×h bitmap into dst. The BITMAPINFOHEADER and pixels follow it.
+func alphaBlendParams(dst geom.Rect, w, h int) []byte {
+	const fixed, bmiSize = 108, 40
+	one := math.Float32bits(1)
+	b := boundsOf([]geom.Pt{dst.Min, dst.Max})
+	return le(
+		b[0], b[1], b[2], b[3],
+		coord(dst.Min.X), coord(dst.Min.Y), coord(dst.W()), coord(dst.H()),
+		0x01FF0000, // AC_SRC_OVER, constant alpha 255, AC_SRC_ALPHA
+		0, 0,       // source origin
+		one, 0, 0, one, 0, 0, // identity source transform
+		0, // background color
+		0, // DIB_RGB_COLORS
+		fixed, bmiSize, fixed+bmiSize, uint32(4*w*h),
+		uint32(w), uint32(h),
+	)
+}
+
+// dib encodes img as a bottom-up 32-bit BGRA device-independent bitmap with
+// its header. Pixels stay premultiplied as AlphaBlend expects.
+func dib(img render.RGBAImage) []byte {
+	w, h := img.Size()
+	out := make([]byte, 40+4*w*h)
+	binary.LittleEndian.PutUint32(out[0:], 40)
+	binary.LittleEndian.PutUint32(out[4:], uint32(w))
+	binary.LittleEndian.PutUint32(out[8:], uint32(h)) // positive: bottom-up
+	binary.LittleEndian.PutUint16(out[12:], 1)        // planes
+	binary.LittleEndian.PutUint16(out[14:], 32)       // bits per pixel
+	binary.LittleEndian.PutUint32(out[20:], uint32(4*w*h))
+
+	b := img.Bounds()
+	px := out[40:]
+	for y := 0; y < h; y++ {
+		row := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+		o := 4 * w * (h - 1 - y)
+		for x := 0; x < w; x++ {
+			px[o+4*x+0] = row[4*x+2]
+			px[o+4*x+1] = row[4*x+1]
+			px[o+4*x+2] = row[4*x+0]
+			px[o+4*x+3] = row[4*x+3]
+		}
+	}
+	return out
+}
+
+// pathBounds returns the logical bounds of all path vertices.
+func pathBounds(p geom.Path) [4]uint32 { return boundsOf(p.V) }
+
+func boundsOf(pts []geom.Pt) [4]uint32 {
+	if len(pts) == 0 {
+		return [4]uint32{}
+	}
+	minX, minY, maxX, maxY := pts[0].X, pts[0].Y, pts[0].X, pts[0].Y
+	for _, p := range pts[1:] {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	return [4]uint32{coord(minX), coord(minY), coord(maxX), coord(maxY)}
+}
+
+// coord converts a pixel value to a logical EMF coordinate, stored as the
+// bit pattern of a signed 32-bit integer.
+func coord(px float64) uint32 {
+	return uint32(int32(math.Round(px * subpixel)))
+}
+
+// colorRef packs a color as a GDI COLORREF (0x00BBGGRR). Alpha is dropped.
+func colorRef(c render.Color) uint32 {
+	b := func(v float64) uint32 { return uint32(math.Max(0, math.Min(255, math.Round(v*255)))) }
+	return b(c.R) | b(c.G)<<8 | b(c.B)<<16
+}
+
+// le encodes values as consecutive little-endian 32-bit integers.
+func le(vals ...uint32) []byte {
+	out := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		binary.LittleEndian.PutUint32(out[4*i:], v)
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package gobasic
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// crossing is where a path edge crosses a scanline, with the edge's
+// direction for the winding number.
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// aliasedMask returns the coverage of p within bounds without
+// anti-aliasing, with the mask origin at bounds.Min: a pixel is covered
+// fully if its center is inside p by the fill rule, else not at all. It
+// scans one sample per pixel instead of computing area coverage, so it is
+// also cheaper than the rasterizer.
+func aliasedMask(p geom.Path, bounds image.Rectangle, rule render.FillRule) *image.Alpha {
+	var edges []segment
+	for _, sp := range splitIntoSubpaths(p) {
+		segs := pathToSegments(sp)
+		if len(segs) == 0 {
+			continue
+		}
+		// Filling closes open subpaths.
+		if first, last := segs[0].Start, segs[len(segs)-1].End; first != last {
+			segs = append(segs, segment{Start: last, End: first})
+		}
+		edges = append(edges, segs...)
+	}
+
+	mask := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	var xs []crossing
+	for row := range bounds.Dy() {
+		y := float64(bounds.Min.Y+row) + 0.5
+		xs = xs[:0]
+		for _, e := range edges {
+			y0, y1 := e.Start.Y, e.End.Y
+			if (y0 <= y) == (y1 <= y) {
+				continue // the edge does not cross this scanline
+			}
+			dir := 1
+			if y1 < y0 {
+				dir = -1
+			}
+			xs = append(xs, crossing{e.Start.X + (y-y0)*(e.End.X-e.Start.X)/(y1-y0), dir})
+		}
+		sort.Slice(xs, func(i, j int) bool { return xs[i].x < xs[j].x })
+
+		winding := 0
+		for i := 0; i+1 < len(xs); i++ {
+			winding += xs[i].dir
+			inside := winding != 0
+			if rule == render.FillEvenOdd {
+				inside = (i+1)%2 == 1
+			}
+			if !inside {
+				continue
+			}
+			// Pixels whose centers lie in [xs[i].x, xs[i+1].x).
+			lo := max(int(math.Ceil(xs[i].x-0.5))-bounds.Min.X, 0)
+			hi := min(int(math.Ceil(xs[i+1].x-0.5))-bounds.Min.X, bounds.Dx())
+			for x := lo; x < hi; x++ {
+				mask.Pix[row*mask.Stride+x] = 0xff
+			}
+		}
+	}
+	return mask
+}
@@ -0,0 +1,170 @@
+package gobasic
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"time"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// FrameStats reports timing for an AnimRenderer.
+type FrameStats struct {
+	Frames    uint64        // frames presented so far
+	Dropped   uint64        // frames that took longer than the frame interval
+	LastFrame time.Duration // render time of the most recent frame
+}
+
+// AnimRenderer is a double-buffered renderer for animations and live plots.
+//
+// Drawing calls go to a back buffer, which is cleared on Begin. End swaps
+// it with the front buffer, so a display can keep presenting the previous
+// frame while the next one is rendered, without tearing or reallocating:
+//
+//	anim := gobasic.NewAnimRenderer(640, 480, white, 30)
+//	for {
+//		update(fig)
+//		core.DrawFigure(fig, anim) // renders into the back buffer and swaps
+//		anim.Present(show)         // e.g. from the UI goroutine
+//		anim.Wait()                // pace to 30 frames per second
+//	}
+//
+// Drawing must happen on one goroutine; Present may be called concurrently.
+type AnimRenderer struct {
+	mu     sync.RWMutex // guards front and the buffer exchange
+	bufs   [2]*Renderer
+	front  int
+	began  time.Time
+	next   time.Time
+	period time.Duration
+	stats  FrameStats
+}
+
+var _ render.Renderer = (*AnimRenderer)(nil)
+var _ render.TextDrawer = (*AnimRenderer)(nil)
+var _ render.RotatedTextDrawer = (*AnimRenderer)(nil)
+var _ render.TextConfigurer = (*AnimRenderer)(nil)
+
+// NewAnimRenderer creates a double-buffered renderer. fps sets the target
+// frame rate used by Wait and for counting dropped frames; zero disables
+// pacing.
+func NewAnimRenderer(w, h int, bg render.Color, fps float64) *AnimRenderer {
+	a := &AnimRenderer{
+		bufs: [2]*Renderer{New(w, h, bg), New(w, h, bg)},
+	}
+	if fps > 0 {
+		a.period = time.Duration(float64(time.Second) / fps)
+	}
+	return a
+}
+
+// back returns the buffer being drawn. Only the drawing goroutine changes
+// front (in End), so no lock is needed here.
+func (a *AnimRenderer) back() *Renderer { return a.bufs[1-a.front] }
+
+// Begin clears the back buffer and starts drawing a new frame.
+func (a *AnimRenderer) Begin(viewport geom.Rect) error {
+	b := a.back()
+	if b.began {
+		return errors.New("Begin called twice")
+	}
+	a.began = time.Now()
+	b.Clear()
+	return b.Begin(viewport)
+}
+
+// End finishes the frame and swaps it to the front.
+func (a *AnimRenderer) End() error {
+	if err := a.back().End(); err != nil {
+		return err
+	}
+	elapsed := time.Since(a.began)
+
+	a.mu.Lock()
+	a.front = 1 - a.front
+	a.stats.Frames++
+	a.stats.LastFrame = elapsed
+	if a.period > 0 && elapsed > a.period {
+		a.stats.Dropped++
+	}
+	a.mu.Unlock()
+	return nil
+}
+
+// Present calls fn with the most recently completed frame. The image must
+// not be retained after fn returns; the next End waits for fn to finish
+// before reusing the buffer.
+func (a *AnimRenderer) Present(fn func(img *image.RGBA)) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	fn(a.bufs[a.front].GetImage())
+}
+
+// Wait blocks until the next frame is due at the target frame rate. If
+// rendering fell behind, the schedule restarts from now instead of
+// bursting to catch up.
+func (a *AnimRenderer) Wait() {
+	if a.period <= 0 {
+		return
+	}
+	now := time.Now()
+	if a.next.IsZero() || now.Sub(a.next) > a.period {
+		a.next = now
+	}
+	a.next = a.next.Add(a.period)
+	time.Sleep(a.next.Sub(now))
+}
+
+// Stats returns frame counters and timing.
+func (a *AnimRenderer) Stats() FrameStats {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.stats
+}
+
+// Save pushes the graphics state of the back buffer.
+func (a *AnimRenderer) Save() { a.back().Save() }
+
+// Restore pops the graphics state of the back buffer.
+func (a *AnimRenderer) Restore() { a.back().Restore() }
+
+// ClipRect intersects the back buffer clip with a rectangle.
+func (a *AnimRenderer) ClipRect(r geom.Rect) { a.back().ClipRect(r) }
+
+// ClipPath sets a path clip on the back buffer.
+func (a *AnimRenderer) ClipPath(p geom.Path) { a.back().ClipPath(p) }
+
+// Path draws a path into the back buffer.
+func (a *AnimRenderer) Path(p geom.Path, paint *render.Paint) { a.back().Path(p, paint) }
+
+// Image draws an image into the back buffer.
+func (a *AnimRenderer) Image(img render.Image, dst geom.Rect) { a.back().Image(img, dst) }
+
+// GlyphRun draws glyphs into the back buffer.
+func (a *AnimRenderer) GlyphRun(run render.GlyphRun, textColor render.Color) {
+	a.back().GlyphRun(run, textColor)
+}
+
+// MeasureText measures text with the back buffer's font.
+func (a *AnimRenderer) MeasureText(text string, size float64, fontKey string) render.TextMetrics {
+	return a.back().MeasureText(text, size, fontKey)
+}
+
+// SetTextOptions sets the text options of both buffers.
+func (a *AnimRenderer) SetTextOptions(opts render.TextOptions) {
+	for _, b := range a.bufs {
+		b.SetTextOptions(opts)
+	}
+}
+
+// DrawText draws text into the back buffer.
+func (a *AnimRenderer) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
+	a.back().DrawText(text, origin, size, textColor)
+}
+
+// DrawTextRotated draws rotated text into the back buffer.
+func (a *AnimRenderer) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	a.back().DrawTextRotated(text, origin, size, angle, textColor)
+}
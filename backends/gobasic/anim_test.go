@@ -0,0 +1,87 @@
+package gobasic
+
+import (
+	"image"
+	"image/color"
+	"sync"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func drawFrame(t *testing.T, a *AnimRenderer, filled bool) {
+	t.Helper()
+	if err := a.Begin(geom.Rect{Max: geom.Pt{X: 20, Y: 20}}); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if filled {
+		p := geom.Path{}
+		p.MoveTo(geom.Pt{X: 0, Y: 0})
+		p.LineTo(geom.Pt{X: 20, Y: 0})
+		p.LineTo(geom.Pt{X: 20, Y: 20})
+		p.LineTo(geom.Pt{X: 0, Y: 20})
+		p.Close()
+		a.Path(p, &render.Paint{Fill: render.Color{R: 1, A: 1}})
+	}
+	if err := a.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+}
+
+func TestAnimRendererSwap(t *testing.T) {
+	a := NewAnimRenderer(20, 20, render.Color{R: 1, G: 1, B: 1, A: 1}, 0)
+	red := color.RGBA{R: 255, A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+	drawFrame(t, a, true)
+	var got color.RGBA
+	a.Present(func(img *image.RGBA) { got = img.RGBAAt(10, 10) })
+	if got != red {
+		t.Errorf("frame 1: got %v, want %v", got, red)
+	}
+
+	// The next frame starts from a cleared buffer, not the previous content.
+	drawFrame(t, a, false)
+	a.Present(func(img *image.RGBA) { got = img.RGBAAt(10, 10) })
+	if got != white {
+		t.Errorf("frame 2: got %v, want %v", got, white)
+	}
+	drawFrame(t, a, false)
+	a.Present(func(img *image.RGBA) { got = img.RGBAAt(10, 10) })
+	if got != white {
+		t.Errorf("frame 3: stale content %v", got)
+	}
+
+	if s := a.Stats(); s.Frames != 3 || s.Dropped != 0 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestAnimRendererConcurrentPresent(t *testing.T) {
+	a := NewAnimRenderer(20, 20, render.Color{R: 1, G: 1, B: 1, A: 1}, 0)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				a.Present(func(img *image.RGBA) {
+					// Frames are either fully red or fully white, never mixed.
+					if img.RGBAAt(0, 0) != img.RGBAAt(19, 19) {
+						t.Error("presented a partially drawn frame")
+					}
+				})
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		drawFrame(t, a, i%2 == 0)
+	}
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,50 @@
+package gobasic
+
+import (
+	"image"
+	"image/draw"
+
+	"matplotlib-go/internal/geom"
+)
+
+// ClipPath restricts drawing to the inside of p (nonzero rule) in addition
+// to the current clip, until the state is restored. The clip is an
+// anti-aliased coverage mask over the canvas, so curved clip edges such as
+// polar axes or wedges stay smooth.
+func (r *Renderer) ClipPath(p geom.Path) {
+	if !p.Validate() {
+		return
+	}
+	bounds := r.dst.Bounds()
+	mask := image.NewAlpha(bounds)
+	if !bounds.Empty() {
+		r.rasterize(quantizePath(p), bounds)
+		r.rasterizer.DrawOp = draw.Src
+		r.rasterizer.Draw(mask, bounds, image.Opaque, image.Point{})
+		r.rasterizer.DrawOp = draw.Over
+	}
+	if r.clipMask != nil {
+		// Nested clips intersect: multiply the coverages.
+		for i, m := range mask.Pix {
+			mask.Pix[i] = uint8((int(m)*int(r.clipMask.Pix[i]) + 127) / 255)
+		}
+	}
+	r.clipMask = mask
+}
+
+// target returns the image to draw the pixels of box into. Without a clip
+// path that is the canvas itself; with one it is a transparent layer, and
+// flush composites the layer onto the canvas through the clip mask.
+func (r *Renderer) target(box image.Rectangle) (dst draw.Image, flush func()) {
+	mask := r.clipMask
+	if mask == nil {
+		return r.dst, func() {}
+	}
+	var layer draw.Image
+	if r.depth == Depth16 {
+		layer = image.NewRGBA64(box)
+	} else {
+		layer = image.NewRGBA(box)
+	}
+	return layer, func() { draw.DrawMask(r.dst, box, layer, box.Min, mask, box.Min, draw.Over) }
+}
@@ -0,0 +1,79 @@
+package gobasic
+
+import (
+	"image"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestClipPath(t *testing.T) {
+	rect := func(x0, y0, x1, y1 float64) geom.Path {
+		var p geom.Path
+		p.MoveTo(geom.Pt{X: x0, Y: y0})
+		p.LineTo(geom.Pt{X: x1, Y: y0})
+		p.LineTo(geom.Pt{X: x1, Y: y1})
+		p.LineTo(geom.Pt{X: x0, Y: y1})
+		p.Close()
+		return p
+	}
+	// A triangle with its right angle at the top left.
+	var triangle geom.Path
+	triangle.MoveTo(geom.Pt{X: 0, Y: 0})
+	triangle.LineTo(geom.Pt{X: 40, Y: 0})
+	triangle.LineTo(geom.Pt{X: 0, Y: 40})
+	triangle.Close()
+	black := &render.Paint{Fill: render.Color{A: 1}}
+	inked := func(img *image.RGBA, x, y int) bool { return img.RGBAAt(x, y).R == 0 }
+
+	r := New(40, 40, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 40}})
+	r.Save()
+	r.ClipPath(triangle)
+	r.Save()
+	r.ClipPath(rect(0, 0, 40, 10)) // nested: the top strip of the triangle
+	r.Path(rect(0, 0, 40, 40), black)
+	r.Restore()
+	img := r.GetImage()
+	if !inked(img, 5, 5) || !inked(img, 25, 5) {
+		t.Error("pixels inside both clips not drawn")
+	}
+	if inked(img, 5, 20) || inked(img, 35, 5) {
+		t.Error("pixels outside the nested clip drawn")
+	}
+
+	// After Restore only the triangle clips.
+	r.Path(rect(0, 0, 40, 40), black)
+	img = r.GetImage()
+	if !inked(img, 5, 20) || inked(img, 30, 30) {
+		t.Error("restored clip is not the triangle")
+	}
+	r.Restore()
+
+	// Without clip everything is drawn again.
+	r.Path(rect(0, 0, 40, 40), black)
+	if !inked(r.GetImage(), 30, 30) {
+		t.Error("clip not removed by the outer Restore")
+	}
+	_ = r.End()
+
+	r = New(40, 40, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 40}})
+	r.ClipPath(rect(0, 0, 20, 40))
+	r.DrawText("MMMMM", geom.Pt{X: 2, Y: 20}, 13, render.Color{A: 1})
+	img = r.GetImage()
+	left, right := false, false
+	for y := range 40 {
+		for x := range 40 {
+			if inked(img, x, y) {
+				left = left || x < 20
+				right = right || x >= 20
+			}
+		}
+	}
+	if !left || right {
+		t.Errorf("clipped text inked left %v, right %v, want only the left half", left, right)
+	}
+	_ = r.End()
+}
@@ -0,0 +1,115 @@
+package gobasic
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"matplotlib-go/internal/geom"
+)
+
+// RegisterColorGlyph makes text drawn by r draw img for the rune ch instead
+// of the font's glyph, e.g. for emoji in dashboard labels. The image keeps
+// its own colors and aspect ratio and is scaled to the line height of the
+// text font. Color fonts store such images directly: the PNG strikes of
+// CBDT and sbix tables can be decoded with image/png and registered per
+// rune. A nil img removes the glyph. Glyphs stay registered across Clear,
+// like the text options.
+func (r *Renderer) RegisterColorGlyph(ch rune, img image.Image) {
+	if img == nil || img.Bounds().Empty() {
+		delete(r.colorGlyphs, ch)
+		return
+	}
+	if r.colorGlyphs == nil {
+		r.colorGlyphs = make(map[rune]image.Image)
+	}
+	r.colorGlyphs[ch] = img
+}
+
+// colorGlyph returns the registered image of ch and its advance in pixels
+// when scaled to height.
+func (r *Renderer) colorGlyph(ch rune, height int) (image.Image, int, bool) {
+	img, ok := r.colorGlyphs[ch]
+	if !ok {
+		return nil, 0, false
+	}
+	b := img.Bounds()
+	return img, max(int(math.Round(float64(height*b.Dx())/float64(b.Dy()))), 1), true
+}
+
+// hasColorGlyphs reports whether text contains a registered color glyph.
+func (r *Renderer) hasColorGlyphs(text string) bool {
+	if len(r.colorGlyphs) == 0 {
+		return false
+	}
+	for _, ch := range text {
+		if _, ok := r.colorGlyphs[ch]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// drawColorTextRotated is DrawTextRotated for text with color glyphs: the
+// upright line is drawn in color and resampled bilinearly into place.
+func (r *Renderer) drawColorTextRotated(text string, origin geom.Pt, size, angle float64, src image.Image) {
+	ascent, descent := r.lineExtent(size)
+	w := int(math.Ceil(r.textWidth(text, size, r.text.FontKey)))
+	h := ascent + descent
+	line := image.NewRGBA(image.Rect(0, 0, w, h))
+	r.drawTextLine(line, text, size, geom.Pt{Y: float64(ascent)}, src)
+
+	sin, cos := math.Sincos(angle)
+	at := func(u, v float64) geom.Pt {
+		return geom.Pt{X: origin.X + u*cos + v*sin, Y: origin.Y - u*sin + v*cos}
+	}
+	corners := []geom.Pt{
+		at(0, float64(-ascent)), at(float64(w), float64(-ascent)),
+		at(0, float64(h-ascent)), at(float64(w), float64(h-ascent)),
+	}
+	minX, minY, maxX, maxY := corners[0].X, corners[0].Y, corners[0].X, corners[0].Y
+	for _, c := range corners[1:] {
+		minX, minY = math.Min(minX, c.X), math.Min(minY, c.Y)
+		maxX, maxY = math.Max(maxX, c.X), math.Max(maxY, c.Y)
+	}
+	box := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY)))
+	box = box.Intersect(r.dst.Bounds())
+	if box.Empty() {
+		return
+	}
+
+	rot := image.NewRGBA(box)
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			dx, dy := float64(x)+0.5-origin.X, float64(y)+0.5-origin.Y
+			u := dx*cos - dy*sin
+			v := dx*sin + dy*cos + float64(ascent)
+			rot.SetRGBA(x, y, sampleRGBA(line, u-0.5, v-0.5))
+		}
+	}
+	dst, flush := r.target(box)
+	defer flush()
+	draw.Draw(dst, box, rot, box.Min, draw.Over)
+}
+
+// sampleRGBA interpolates the premultiplied m bilinearly at (x, y) in
+// pixel-center units; outside m it is transparent.
+func sampleRGBA(m *image.RGBA, x, y float64) color.RGBA {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	ix, iy := int(x0), int(y0)
+	var out [4]float64
+	for k, wt := range [4]float64{(1 - fx) * (1 - fy), fx * (1 - fy), (1 - fx) * fy, fx * fy} {
+		p := image.Point{X: ix + k%2, Y: iy + k/2}
+		if wt == 0 || !p.In(m.Rect) {
+			continue
+		}
+		c := m.RGBAAt(p.X, p.Y)
+		out[0] += wt * float64(c.R)
+		out[1] += wt * float64(c.G)
+		out[2] += wt * float64(c.B)
+		out[3] += wt * float64(c.A)
+	}
+	return color.RGBA{R: uint8(out[0] + 0.5), G: uint8(out[1] + 0.5), B: uint8(out[2] + 0.5), A: uint8(out[3] + 0.5)}
+}
@@ -0,0 +1,54 @@
+package gobasic
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestColorGlyph(t *testing.T) {
+	const smiley = '\U0001F600'
+	red := image.NewRGBA(image.Rect(0, 0, 26, 26))
+	draw.Draw(red, red.Rect, image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+	r := New(100, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+	r.RegisterColorGlyph(smiley, red)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 100}})
+	defer r.End()
+
+	// The 26px square glyph is scaled to the line height.
+	a, b := r.MeasureText("a", 13, ""), r.MeasureText("b", 13, "")
+	ascent, descent := r.lineExtent(13)
+	h := ascent + descent
+	if m := r.MeasureText("a\U0001F600b", 13, ""); m.W != quantize(a.W+float64(h)+b.W) {
+		t.Errorf("width %v, want %v + %v + %v", m.W, a.W, h, b.W)
+	}
+	r.DrawText("a\U0001F600b", geom.Pt{X: 10, Y: 30}, 13, render.Color{A: 1})
+	img := r.GetImage()
+	x0, y := int(math.Round(10+a.W)), 30-ascent+h/2
+	if c := img.RGBAAt(x0+h/2, y); c != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("glyph pixel %v, want red", c)
+	}
+	if c := img.RGBAAt(x0+h+1, y); c.R == 255 && c.G == 0 {
+		t.Errorf("pixel after the glyph %v, want the next letter or background", c)
+	}
+
+	// Turned by 90°, the glyph sits above the origin.
+	r.DrawTextRotated("\U0001F600", geom.Pt{X: 60, Y: 90}, 13, math.Pi/2, render.Color{A: 1})
+	if c := r.GetImage().RGBAAt(56, 84); c.R < 200 || c.G > 50 {
+		t.Errorf("rotated glyph pixel %v, want red", c)
+	}
+
+	// Other renderers do not see the glyph.
+	if New(10, 10, render.Color{}).hasColorGlyphs("\U0001F600") {
+		t.Error("glyph registered with another renderer")
+	}
+	r.RegisterColorGlyph(smiley, nil)
+	if r.hasColorGlyphs("\U0001F600") {
+		t.Error("glyph still registered after removal")
+	}
+}
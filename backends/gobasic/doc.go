@@ -1,14 +1,19 @@
 // Package gobasic provides a pure Go renderer backend using golang.org/x/image/vector.
 //
-// This backend uses image.RGBA as the drawing surface and vector.Rasterizer for
+// This backend uses image.RGBA (or image.RGBA64) as the drawing surface and vector.Rasterizer for
 // path filling and stroking. It is designed to be deterministic and work without
 // CGO dependencies.
 //
 // The GoBasic renderer supports:
 //   - Fill and stroke operations (no dashes in Phase B)
-//   - Rectangular clipping
+//   - Rectangular and path clipping, nested via Save/Restore
 //   - State stack for Save/Restore operations
 //   - PNG export via image/png package
+//   - Optional 16-bit (image.RGBA64) backing buffer via NewWithDepth
+//   - Double-buffered animation rendering via AnimRenderer
+//   - TrueType and OpenType text at any size and angle via the font package
+//   - Color glyphs such as emoji in text via Renderer.RegisterColorGlyph
+//   - Right-to-left and mixed-direction text in display order (render.VisualOrder)
 //
 // This is the primary backend for Phase B of matplotlib-go development.
 package gobasic
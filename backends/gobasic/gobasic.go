@@ -4,14 +4,14 @@ import (
 	"errors"
 	"image"
 	"image/color"
+	"image/draw"
 	"image/png"
 	"math"
 	"os"
 
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/basicfont"
-	"golang.org/x/image/math/fixed"
+	xdraw "golang.org/x/image/draw"
 	"golang.org/x/image/vector"
+	mplfont "matplotlib-go/font"
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
 )
@@ -52,39 +52,151 @@ func quantizePath(p geom.Path) geom.Path {
 // state represents a saved graphics state.
 type state struct {
 	clipRect *geom.Rect
+	clipMask *image.Alpha
 }
 
+// Depth selects the per-channel precision of the renderer's backing buffer.
+type Depth uint8
+
+const (
+	Depth8  Depth = iota // image.RGBA, 8 bits per channel
+	Depth16              // image.RGBA64, 16 bits per channel
+)
+
 // Renderer implements render.Renderer using pure Go dependencies.
 type Renderer struct {
-	dst        *image.RGBA
+	dst        draw.Image // *image.RGBA or *image.RGBA64
+	depth      Depth
+	bg         render.Color
 	viewport   geom.Rect
 	began      bool
 	stack      []state
 	clipRect   *geom.Rect
+	clipMask   *image.Alpha // coverage of the clip paths over the canvas, nil for none; never modified in place
 	rasterizer *vector.Rasterizer
+	fonts      mplfont.Cache
+	text       render.TextOptions
+	// colorGlyphs are drawn instead of font glyphs, see RegisterColorGlyph.
+	colorGlyphs map[rune]image.Image
 }
 
 var _ render.Renderer = (*Renderer)(nil)
+var _ render.RotatedTextDrawer = (*Renderer)(nil)
+var _ render.TextConfigurer = (*Renderer)(nil)
 
 // New creates a new GoBasic renderer with the specified dimensions and background color.
 func New(w, h int, bg render.Color) *Renderer {
-	dst := image.NewRGBA(image.Rect(0, 0, w, h))
-
-	// Fill with background color using premultiplied alpha
-	red, green, blue, alpha := bg.ToPremultipliedRGBA()
-	bgColor := color.RGBA{R: red, G: green, B: blue, A: alpha}
+	return NewWithDepth(w, h, bg, Depth8)
+}
 
-	// Fill the entire image with background color
-	for y := 0; y < h; y++ {
-		for x := 0; x < w; x++ {
-			dst.Set(x, y, bgColor)
-		}
+// NewWithDepth creates a renderer whose backing buffer has the given precision.
+// Depth16 avoids banding in smooth gradients and repeated translucent
+// compositing; the result is quantized to 8 bits only when exported.
+func NewWithDepth(w, h int, bg render.Color, depth Depth) *Renderer {
+	var dst draw.Image
+	if depth == Depth16 {
+		dst = image.NewRGBA64(image.Rect(0, 0, w, h))
+	} else {
+		depth = Depth8
+		dst = image.NewRGBA(image.Rect(0, 0, w, h))
 	}
 
-	return &Renderer{
+	r := &Renderer{
 		dst:        dst,
+		depth:      depth,
+		bg:         bg,
 		rasterizer: vector.NewRasterizer(w, h),
 	}
+	r.fillBackground()
+	return r
+}
+
+// Depth reports the precision of the backing buffer.
+func (r *Renderer) Depth() Depth { return r.depth }
+
+// Background returns the color the buffer is filled with on creation and Clear.
+func (r *Renderer) Background() render.Color { return r.bg }
+
+// Size returns the canvas dimensions in pixels.
+func (r *Renderer) Size() (w, h int) {
+	b := r.dst.Bounds()
+	return b.Dx(), b.Dy()
+}
+
+// Clear refills the buffer with the background color and resets the state
+// stack, so the renderer can be reused for the next figure or frame without
+// reallocating.
+func (r *Renderer) Clear() {
+	r.began = false
+	r.stack = r.stack[:0]
+	r.clipRect = nil
+	r.clipMask = nil
+	r.fillBackground()
+}
+
+// ClearRect refills the pixels inside rect with the background color, e.g.
+// before redrawing a dirty region of a retained figure.
+func (r *Renderer) ClearRect(rect image.Rectangle) {
+	r.fillRect(rect.Intersect(r.dst.Bounds()))
+}
+
+// fillBackground fills the entire image with the background color.
+func (r *Renderer) fillBackground() { r.fillRect(r.dst.Bounds()) }
+
+// fillRect fills rect with the background color. The first row is filled
+// pixel by pixel and then copied to the remaining rows, which is much faster
+// than setting every pixel on large canvases.
+func (r *Renderer) fillRect(rect image.Rectangle) {
+	if rect.Empty() {
+		return
+	}
+	bgColor := r.premultiplied(r.bg)
+	switch dst := r.dst.(type) {
+	case *image.RGBA:
+		c := bgColor.(color.RGBA)
+		pix := dst.Pix[dst.PixOffset(rect.Min.X, rect.Min.Y):]
+		fillRows(pix, dst.Stride, rect.Dx()*4, rect.Dy(), []byte{c.R, c.G, c.B, c.A})
+	case *image.RGBA64:
+		c := bgColor.(color.RGBA64)
+		pix := dst.Pix[dst.PixOffset(rect.Min.X, rect.Min.Y):]
+		fillRows(pix, dst.Stride, rect.Dx()*8, rect.Dy(), []byte{
+			byte(c.R >> 8), byte(c.R), byte(c.G >> 8), byte(c.G),
+			byte(c.B >> 8), byte(c.B), byte(c.A >> 8), byte(c.A),
+		})
+	default:
+		draw.Draw(r.dst, rect, image.NewUniform(bgColor), image.Point{}, draw.Src)
+	}
+}
+
+// fillRows repeats pixel across the first rowLen bytes of pix, then copies
+// that row to the other rows using doubling copies.
+func fillRows(pix []byte, stride, rowLen, rows int, pixel []byte) {
+	if rowLen == 0 || rows == 0 {
+		return
+	}
+	row := pix[:rowLen]
+	n := copy(row, pixel)
+	for n < rowLen {
+		n += copy(row[n:], row[:n])
+	}
+	for y := 1; y < rows; y++ {
+		copy(pix[y*stride:y*stride+rowLen], row)
+	}
+}
+
+// premultiplied converts c to a premultiplied color at the buffer's precision.
+func (r *Renderer) premultiplied(c render.Color) color.Color {
+	if r.depth == Depth16 {
+		p := c.Premultiply()
+		return color.RGBA64{
+			R: uint16(p.R*0xffff + 0.5),
+			G: uint16(p.G*0xffff + 0.5),
+			B: uint16(p.B*0xffff + 0.5),
+			A: uint16(p.A*0xffff + 0.5),
+		}
+	}
+	red, green, blue, alpha := c.ToPremultipliedRGBA()
+	return color.RGBA{R: red, G: green, B: blue, A: alpha}
 }
 
 // Begin starts a drawing session with the given viewport.
@@ -96,6 +208,7 @@ func (r *Renderer) Begin(viewport geom.Rect) error {
 	r.viewport = viewport
 	r.stack = r.stack[:0]
 	r.clipRect = nil
+	r.clipMask = nil
 	return nil
 }
 
@@ -107,6 +220,7 @@ func (r *Renderer) End() error {
 	r.began = false
 	r.stack = r.stack[:0]
 	r.clipRect = nil
+	r.clipMask = nil
 	return nil
 }
 
@@ -119,6 +233,7 @@ func (r *Renderer) Save() {
 	}
 	r.stack = append(r.stack, state{
 		clipRect: clipCopy,
+		clipMask: r.clipMask,
 	})
 }
 
@@ -134,6 +249,7 @@ func (r *Renderer) Restore() {
 
 	// Restore state
 	r.clipRect = s.clipRect
+	r.clipMask = s.clipMask
 }
 
 // ClipRect sets a rectangular clip region.
@@ -147,12 +263,6 @@ func (r *Renderer) ClipRect(rect geom.Rect) {
 	}
 }
 
-// ClipPath sets a path-based clip region (stub implementation for Phase B).
-func (r *Renderer) ClipPath(p geom.Path) {
-	// For Phase B, we only support rectangular clipping
-	// This is a no-op for now
-}
-
 // Path draws a path with the given paint style.
 func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
 	if !p.Validate() {
@@ -171,6 +281,9 @@ func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
 		Stroke:     paint.Stroke,
 		Fill:       paint.Fill,
 		Dashes:     make([]float64, len(paint.Dashes)),
+		DashCap:    paint.DashCap,
+		Aliased:    paint.Aliased,
+		FillRule:   paint.FillRule,
 	}
 
 	// Quantize dash pattern
@@ -180,7 +293,7 @@ func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
 
 	// Fill first if requested
 	if quantizedPaint.Fill.A > 0 {
-		r.fillPath(p, quantizedPaint.Fill)
+		r.fillPath(p, quantizedPaint.Fill, quantizedPaint.FillRule, quantizedPaint.Aliased)
 	}
 
 	// Then stroke if requested
@@ -189,62 +302,101 @@ func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
 	}
 }
 
-// fillPath fills a path with the given color.
-func (r *Renderer) fillPath(p geom.Path, fillColor render.Color) {
-	// Reset and rebuild path for filling
-	r.rasterizer.Reset(r.dst.Bounds().Dx(), r.dst.Bounds().Dy())
+// fillPath fills a path with the given color and fill rule, anti-aliased
+// unless aliased is set.
+func (r *Renderer) fillPath(p geom.Path, fillColor render.Color, rule render.FillRule, aliased bool) {
+	// Apply clipping if set
+	bounds := r.dst.Bounds()
+	if r.clipRect != nil {
+		clipBounds := image.Rect(
+			int(math.Floor(r.clipRect.Min.X)),
+			int(math.Floor(r.clipRect.Min.Y)),
+			int(math.Ceil(r.clipRect.Max.X)),
+			int(math.Ceil(r.clipRect.Max.Y)),
+		)
+		bounds = bounds.Intersect(clipBounds)
+	}
+	if bounds.Empty() {
+		return
+	}
+
+	// Draw the filled path using premultiplied alpha
+	c := image.NewUniform(r.premultiplied(fillColor))
+	dst, flush := r.target(bounds)
+	defer flush()
+	if aliased {
+		draw.DrawMask(dst, bounds, c, image.Point{}, aliasedMask(p, bounds, rule), image.Point{}, draw.Over)
+		return
+	}
+	if rule == render.FillEvenOdd {
+		if subpaths := splitIntoSubpaths(p); len(subpaths) > 1 {
+			mask := r.evenOddMask(subpaths, bounds)
+			draw.DrawMask(dst, bounds, c, image.Point{}, mask, image.Point{}, draw.Over)
+			return
+		}
+	}
+	r.rasterize(p, bounds)
+	r.rasterizer.Draw(dst, bounds, c, image.Point{})
+}
+
+// rasterize resets the rasterizer to bounds and adds the path to it.
+func (r *Renderer) rasterize(p geom.Path, bounds image.Rectangle) {
+	// The rasterizer's mask origin is drawn at bounds.Min, so size it to the
+	// clipped area and shift the path into its local coordinates.
+	r.rasterizer.Reset(bounds.Dx(), bounds.Dy())
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	// Apply explicit rounding to ensure consistent float32 conversion
+	at := func(pt geom.Pt) (float32, float32) {
+		return float32(math.Round(pt.X*1e6)/1e6 - ox), float32(math.Round(pt.Y*1e6)/1e6 - oy)
+	}
 
 	vi := 0 // vertex index
 
 	for _, cmd := range p.C {
 		switch cmd {
 		case geom.MoveTo:
-			pt := p.V[vi]
-			// Apply explicit rounding to ensure consistent float32 conversion
-			r.rasterizer.MoveTo(float32(math.Round(pt.X*1e6)/1e6), float32(math.Round(pt.Y*1e6)/1e6))
+			r.rasterizer.MoveTo(at(p.V[vi]))
 			vi++
 		case geom.LineTo:
-			pt := p.V[vi]
-			r.rasterizer.LineTo(float32(math.Round(pt.X*1e6)/1e6), float32(math.Round(pt.Y*1e6)/1e6))
+			r.rasterizer.LineTo(at(p.V[vi]))
 			vi++
 		case geom.QuadTo:
-			ctrl := p.V[vi]
-			to := p.V[vi+1]
-			r.rasterizer.QuadTo(
-				float32(math.Round(ctrl.X*1e6)/1e6), float32(math.Round(ctrl.Y*1e6)/1e6),
-				float32(math.Round(to.X*1e6)/1e6), float32(math.Round(to.Y*1e6)/1e6))
+			cx, cy := at(p.V[vi])
+			tx, ty := at(p.V[vi+1])
+			r.rasterizer.QuadTo(cx, cy, tx, ty)
 			vi += 2
 		case geom.CubicTo:
-			c1 := p.V[vi]
-			c2 := p.V[vi+1]
-			to := p.V[vi+2]
-			r.rasterizer.CubeTo(
-				float32(math.Round(c1.X*1e6)/1e6), float32(math.Round(c1.Y*1e6)/1e6),
-				float32(math.Round(c2.X*1e6)/1e6), float32(math.Round(c2.Y*1e6)/1e6),
-				float32(math.Round(to.X*1e6)/1e6), float32(math.Round(to.Y*1e6)/1e6))
+			c1x, c1y := at(p.V[vi])
+			c2x, c2y := at(p.V[vi+1])
+			tx, ty := at(p.V[vi+2])
+			r.rasterizer.CubeTo(c1x, c1y, c2x, c2y, tx, ty)
 			vi += 3
 		case geom.ClosePath:
 			r.rasterizer.ClosePath()
 		}
 	}
+}
 
-	// Draw the filled path using premultiplied alpha
-	red, green, blue, alpha := fillColor.ToPremultipliedRGBA()
-	c := color.RGBA{R: red, G: green, B: blue, A: alpha}
-
-	// Apply clipping if set
-	bounds := r.dst.Bounds()
-	if r.clipRect != nil {
-		clipBounds := image.Rect(
-			int(math.Floor(r.clipRect.Min.X)),
-			int(math.Floor(r.clipRect.Min.Y)),
-			int(math.Ceil(r.clipRect.Max.X)),
-			int(math.Ceil(r.clipRect.Max.Y)),
-		)
-		bounds = bounds.Intersect(clipBounds)
+// evenOddMask returns the coverage of subpaths under the even-odd rule,
+// relative to bounds.Min. The rasterizer only implements the nonzero rule,
+// so each subpath is rasterized alone and the coverages are combined by an
+// antialiased exclusive or: subpaths inside others become holes whatever
+// their direction. A single self-intersecting subpath still fills nonzero.
+func (r *Renderer) evenOddMask(subpaths []geom.Path, bounds image.Rectangle) *image.Alpha {
+	acc := image.NewAlpha(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	one := image.NewAlpha(acc.Rect)
+	for _, sp := range subpaths {
+		clear(one.Pix)
+		r.rasterize(sp, bounds)
+		r.rasterizer.DrawOp = draw.Src
+		r.rasterizer.Draw(one, one.Rect, image.Opaque, image.Point{})
+		for i, m := range one.Pix {
+			a, b := int(acc.Pix[i]), int(m)
+			acc.Pix[i] = uint8(a + b - (2*a*b+127)/255)
+		}
 	}
-
-	r.rasterizer.Draw(r.dst, bounds, image.NewUniform(c), image.Point{})
+	r.rasterizer.DrawOp = draw.Over
+	return acc
 }
 
 // drawStroke handles stroke drawing for paths using proper stroke geometry.
@@ -256,76 +408,99 @@ func (r *Renderer) drawStroke(p geom.Path, paint *render.Paint) {
 	}
 
 	// Fill the stroke geometry with the stroke color
-	r.fillPath(strokePath, paint.Stroke)
+	r.fillPath(strokePath, paint.Stroke, render.FillNonZero, paint.Aliased)
 }
 
-// Image draws an image within the destination rectangle (stub implementation).
+// Image composites img over the destination rectangle, scaling it with
+// bilinear filtering and honoring the clip rectangle. Only render.RGBAImage
+// sources can be read; other images are ignored.
 func (r *Renderer) Image(img render.Image, dst geom.Rect) {
-	// Stub implementation for Phase B
-	// Will be implemented in later phases
+	src, ok := img.(render.RGBAImage)
+	if !ok || src.RGBA == nil || src.Bounds().Empty() {
+		return
+	}
+	dr := image.Rect(
+		int(math.Round(quantize(dst.Min.X))),
+		int(math.Round(quantize(dst.Min.Y))),
+		int(math.Round(quantize(dst.Max.X))),
+		int(math.Round(quantize(dst.Max.Y))),
+	)
+	bounds := r.dst.Bounds()
+	if r.clipRect != nil {
+		bounds = bounds.Intersect(image.Rect(
+			int(math.Floor(r.clipRect.Min.X)),
+			int(math.Floor(r.clipRect.Min.Y)),
+			int(math.Ceil(r.clipRect.Max.X)),
+			int(math.Ceil(r.clipRect.Max.Y)),
+		))
+	}
+	if dr.Intersect(bounds).Empty() {
+		return
+	}
+	dstImg, flush := r.target(dr.Intersect(bounds))
+	defer flush()
+	// Scaling into a sub-image keeps the result inside the clip.
+	target := dstImg.(interface {
+		SubImage(image.Rectangle) image.Image
+	}).SubImage(bounds).(draw.Image)
+	xdraw.BiLinear.Scale(target, dr, src.RGBA, src.Bounds(), xdraw.Over, nil)
 }
 
-// GlyphRun draws a run of glyphs using basicfont.
-// Note: This is a basic implementation that works with the available glyph information.
+// GlyphRun draws a run of glyphs from their outlines in the run's font,
+// so runs shaped by font.Cache render at any size.
 func (r *Renderer) GlyphRun(run render.GlyphRun, textColor render.Color) {
-	if len(run.Glyphs) == 0 {
-		return
+	pen := quantizePt(run.Origin)
+	for _, g := range run.Glyphs {
+		at := geom.Pt{X: pen.X + g.Offset.X, Y: pen.Y + g.Offset.Y}
+		if p := r.fonts.GlyphPath(run.FontKey, g.ID, run.Size, at); len(p.C) > 0 {
+			r.fillPath(quantizePath(p), textColor, render.FillNonZero, false)
+		}
+		pen.X += g.Advance
 	}
-
-	// For a basic implementation, we can't easily map glyph IDs back to characters
-	// without additional font metadata. This method provides the interface but
-	// requires higher-level text drawing to work through other means.
-	// A complete implementation would need a glyph ID to character mapping.
-	
-	// For now, this is a stub that maintains the interface contract
-	// Real text rendering would happen through higher-level text drawing functions
 }
 
-// MeasureText measures text dimensions using basicfont.
+// MeasureText measures text in the font registered as fontKey, with
+// kerning; unregistered keys measure in font.DefaultFamily.
 func (r *Renderer) MeasureText(text string, size float64, fontKey string) render.TextMetrics {
 	if text == "" {
 		return render.TextMetrics{}
 	}
-
-	// Use basicfont.Face7x13 as the default font for now
-	face := basicfont.Face7x13
-	metrics := face.Metrics()
-
-	// Calculate text width by summing character advances
-	width := 0
-	for _, ch := range text {
-		advance, ok := face.GlyphAdvance(ch)
-		if ok {
-			width += int(advance >> 6) // Convert from fixed.Int26_6 to pixels
-		} else {
-			// Use advance for missing character replacement
-			width += face.Advance
-		}
+	m := r.fonts.Measure(text, size, fontKey)
+	if r.hasColorGlyphs(text) {
+		m.W = r.textWidth(text, size, fontKey)
 	}
-
-	// Convert fixed.Int26_6 metrics to float64
-	height := float64(metrics.Height >> 6)
-	ascent := float64(metrics.Ascent >> 6)
-	descent := float64(metrics.Descent >> 6)
-
-	// Apply size scaling - basicfont.Face7x13 is a fixed-size font,
-	// so we scale the measurements by the requested size relative to the font's natural size
-	scale := size / 13.0 // Face7x13 is 13 pixels tall
-	
 	return render.TextMetrics{
-		W:       quantize(float64(width) * scale),
-		H:       quantize(height * scale),
-		Ascent:  quantize(ascent * scale),
-		Descent: quantize(descent * scale),
+		W:       quantize(m.W),
+		H:       quantize(m.H),
+		Ascent:  quantize(m.Ascent),
+		Descent: quantize(m.Descent),
 	}
 }
 
-// GetImage returns the underlying image.RGBA for PNG export.
+// GetImage returns the rendered image as 8-bit RGBA for PNG export.
+// For Depth8 renderers this is the underlying buffer; for Depth16 it is a
+// quantized copy.
 func (r *Renderer) GetImage() *image.RGBA {
-	return r.dst
+	if img, ok := r.dst.(*image.RGBA); ok {
+		return img
+	}
+	img := image.NewRGBA(r.dst.Bounds())
+	draw.Draw(img, img.Bounds(), r.dst, img.Bounds().Min, draw.Src)
+	return img
+}
+
+// GetImage64 returns the rendered image at 16 bits per channel. For Depth16
+// renderers this is the underlying buffer; for Depth8 it is a widened copy.
+func (r *Renderer) GetImage64() *image.RGBA64 {
+	if img, ok := r.dst.(*image.RGBA64); ok {
+		return img
+	}
+	img := image.NewRGBA64(r.dst.Bounds())
+	draw.Draw(img, img.Bounds(), r.dst, img.Bounds().Min, draw.Src)
+	return img
 }
 
-// SavePNG saves the rendered image to a PNG file.
+// SavePNG saves the rendered image to an 8-bit PNG file.
 func (r *Renderer) SavePNG(path string) error {
 	file, err := os.Create(path)
 	if err != nil {
@@ -333,40 +508,21 @@ func (r *Renderer) SavePNG(path string) error {
 	}
 	defer file.Close()
 
-	return png.Encode(file, r.dst)
+	return png.Encode(file, r.GetImage())
 }
 
-// DrawText is a helper method to draw text directly (not part of the Renderer interface).
-// This provides a practical way to render text using basicfont.
+// DrawText draws text with its left baseline origin at origin, in the font
+// family set by SetTextOptions at size pixels per em.
 func (r *Renderer) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
 	if text == "" {
 		return
 	}
-
-	// Use basicfont.Face7x13 as the default font
-	face := basicfont.Face7x13
-
-	// Convert text color to image.Image for font.Drawer
-	red, green, blue, alpha := textColor.ToPremultipliedRGBA()
-	src := image.NewUniform(color.RGBA{R: red, G: green, B: blue, A: alpha})
-
-	// Create font drawer
-	drawer := &font.Drawer{
-		Dst:  r.dst,
-		Src:  src,
-		Face: face,
-	}
+	// Glyphs are placed left to right, so right-to-left runs are reordered.
+	text = render.VisualOrder(text)
 
 	// Quantize origin for deterministic rendering
 	origin = geom.Pt{X: quantize(origin.X), Y: quantize(origin.Y)}
 
-	// Convert to fixed.Point26_6 and set as drawer dot
-	// With the corrected coordinate system, origin.Y is already in screen coordinates
-	drawer.Dot = fixed.Point26_6{
-		X: fixed.Int26_6(origin.X * 64), // Convert to fixed point
-		Y: fixed.Int26_6(origin.Y * 64),  // Use Y coordinate directly
-	}
-
 	// Apply clipping if set
 	if r.clipRect != nil {
 		// Simple clipping check - only draw if the text origin is within clip bounds
@@ -377,5 +533,56 @@ func (r *Renderer) DrawText(text string, origin geom.Pt, size float64, textColor
 	}
 
 	// Draw the text
-	drawer.DrawString(text)
+	ascent, descent := r.lineExtent(size)
+	pad := int(size/4) + 1 // accents and swashes beyond the line metrics
+	box := image.Rect(
+		int(math.Floor(origin.X))-pad, int(math.Floor(origin.Y))-ascent-pad,
+		int(math.Ceil(origin.X+r.textWidth(text, size, r.text.FontKey)))+pad, int(math.Ceil(origin.Y))+descent+pad,
+	)
+	dst, flush := r.target(box.Intersect(r.dst.Bounds()))
+	defer flush()
+	r.drawTextLine(dst, text, size, origin, image.NewUniform(r.premultiplied(textColor)))
+}
+
+// DrawTextRotated draws text turned by angle radians counterclockwise around
+// its left baseline origin. The glyph outlines are turned and filled like
+// paths, so rotated text stays anti-aliased and sharp at any size.
+func (r *Renderer) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	if angle == 0 {
+		r.DrawText(text, origin, size, textColor)
+		return
+	}
+	if text == "" {
+		return
+	}
+	text = render.VisualOrder(text)
+	origin = geom.Pt{X: quantize(origin.X), Y: quantize(origin.Y)}
+	if r.clipRect != nil {
+		// Same rule as DrawText: the origin must lie within the clip.
+		if origin.X < r.clipRect.Min.X || origin.X > r.clipRect.Max.X ||
+			origin.Y < r.clipRect.Min.Y || origin.Y > r.clipRect.Max.Y {
+			return
+		}
+	}
+
+	if r.hasColorGlyphs(text) {
+		r.drawColorTextRotated(text, origin, size, angle, image.NewUniform(r.premultiplied(textColor)))
+		return
+	}
+
+	// Text-space (u, v), v pointing down the glyphs, to pixels.
+	sin, cos := math.Sincos(angle)
+	var outline geom.Path
+	run := r.fonts.Shape(text, geom.Pt{}, size, r.text.FontKey)
+	for _, g := range run.Glyphs {
+		p := r.fonts.GlyphPath(run.FontKey, g.ID, size, run.Origin)
+		for _, v := range p.V {
+			outline.V = append(outline.V, geom.Pt{X: origin.X + v.X*cos + v.Y*sin, Y: origin.Y - v.X*sin + v.Y*cos})
+		}
+		outline.C = append(outline.C, p.C...)
+		run.Origin.X += g.Advance
+	}
+	if len(outline.C) > 0 {
+		r.fillPath(quantizePath(outline), textColor, render.FillNonZero, false)
+	}
 }
@@ -3,6 +3,7 @@ package gobasic
 import (
 	"image"
 	"image/color"
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
@@ -234,6 +235,31 @@ func TestDrawText(t *testing.T) {
 	}
 }
 
+func TestDrawTextRotated(t *testing.T) {
+	r := New(100, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 100}})
+	defer r.End()
+
+	// Turned by 90°, the text runs upwards from the origin.
+	r.DrawTextRotated("Hello", geom.Pt{X: 50, Y: 80}, 13, math.Pi/2, render.Color{A: 1})
+
+	img := r.GetImage()
+	ink := image.Rectangle{}
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			if c := img.RGBAAt(x, y); c.R < 128 {
+				ink = ink.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if ink.Empty() {
+		t.Fatal("no text drawn")
+	}
+	if ink.Dy() <= ink.Dx() || ink.Max.Y > 81 || ink.Min.X < 36 || ink.Max.X > 53 {
+		t.Errorf("ink bounds %v, want a tall strip above (50,80) left of x=53", ink)
+	}
+}
+
 func TestGlyphRun(t *testing.T) {
 	r := New(200, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
 	
@@ -255,3 +281,209 @@ func TestGlyphRun(t *testing.T) {
 	// Should not panic
 	r.GlyphRun(glyphRun, textColor)
 }
+
+func TestDepth16(t *testing.T) {
+	r := NewWithDepth(20, 10, render.Color{R: 1, G: 1, B: 1, A: 1}, Depth16)
+	if r.Depth() != Depth16 {
+		t.Fatalf("expected Depth16, got %v", r.Depth())
+	}
+	if err := r.Begin(geom.Rect{Max: geom.Pt{X: 20, Y: 10}}); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	// Stack many faint layers; 16-bit accumulation should keep the result
+	// within one 8-bit step of the analytic value.
+	rect := geom.Path{
+		C: []geom.Cmd{geom.MoveTo, geom.LineTo, geom.LineTo, geom.LineTo, geom.ClosePath},
+		V: []geom.Pt{{X: 0, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 10}, {X: 0, Y: 10}},
+	}
+	const layers = 40
+	const alpha = 0.01
+	for i := 0; i < layers; i++ {
+		r.Path(rect, &render.Paint{Fill: render.Color{A: alpha}})
+	}
+	r.End()
+
+	want := 1.0
+	for i := 0; i < layers; i++ {
+		want *= 1 - alpha
+	}
+	got64 := r.GetImage64().RGBA64At(5, 5)
+	if d := float64(got64.R)/0xffff - want; d > 1.0/255 || d < -1.0/255 {
+		t.Errorf("16-bit value %v too far from %v", float64(got64.R)/0xffff, want)
+	}
+
+	got8 := r.GetImage().RGBAAt(5, 5)
+	if got8.A != 255 || got8.R != uint8(float64(got64.R)/257+0.5) {
+		t.Errorf("8-bit export %v inconsistent with 16-bit buffer %v", got8, got64)
+	}
+}
+
+func TestClear(t *testing.T) {
+	r := New(10, 10, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 10, Y: 10}})
+	p := geom.Path{}
+	p.MoveTo(geom.Pt{X: 0, Y: 0})
+	p.LineTo(geom.Pt{X: 10, Y: 0})
+	p.LineTo(geom.Pt{X: 10, Y: 10})
+	p.LineTo(geom.Pt{X: 0, Y: 10})
+	p.Close()
+	r.Path(p, &render.Paint{Fill: render.Color{A: 1}})
+	_ = r.End()
+
+	r.Clear()
+	if c := r.GetImage().RGBAAt(5, 5); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("expected white after Clear, got %v", c)
+	}
+	if err := r.Begin(geom.Rect{Max: geom.Pt{X: 10, Y: 10}}); err != nil {
+		t.Errorf("Begin after Clear failed: %v", err)
+	}
+}
+
+func TestBackgroundFill16(t *testing.T) {
+	r := NewWithDepth(7, 3, render.Color{R: 0.25, G: 0.5, B: 1, A: 1}, Depth16)
+	img := r.GetImage64()
+	want := img.RGBA64At(0, 0)
+	if want.A != 0xffff || want.B != 0xffff {
+		t.Fatalf("unexpected background %v", want)
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 7; x++ {
+			if c := img.RGBA64At(x, y); c != want {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, c, want)
+			}
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		New(1920, 1080, render.Color{R: 1, G: 1, B: 1, A: 1})
+	}
+}
+
+func BenchmarkClear(b *testing.B) {
+	r := New(1920, 1080, render.Color{R: 1, G: 1, B: 1, A: 1})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Clear()
+	}
+}
+
+func TestImage(t *testing.T) {
+	r := New(40, 20, render.Color{R: 1, G: 1, B: 1, A: 1})
+	if err := r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 20}}); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	// A 2x2 half-transparent red image, scaled up and partly clipped.
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			src.SetRGBA(x, y, color.RGBA{R: 128, A: 128})
+		}
+	}
+	r.ClipRect(geom.Rect{Max: geom.Pt{X: 20, Y: 20}})
+	r.Image(render.RGBAImage{RGBA: src}, geom.Rect{Min: geom.Pt{X: 10, Y: 5}, Max: geom.Pt{X: 30, Y: 15}})
+	r.End()
+
+	img := r.GetImage()
+	if c := img.RGBAAt(15, 10); c.R != 255 || c.G < 125 || c.G > 129 || c.A != 255 {
+		t.Errorf("inside image: got %v, want half red over white", c)
+	}
+	if c := img.RGBAAt(25, 10); c != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("clipped part drawn: got %v", c)
+	}
+	if c := img.RGBAAt(15, 2); c != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("outside dst drawn: got %v", c)
+	}
+}
+
+func TestPathFillRule(t *testing.T) {
+	square := func(p *geom.Path, x0, y0, x1, y1 float64, ccw bool) {
+		pts := []geom.Pt{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}
+		if ccw {
+			pts[1], pts[3] = pts[3], pts[1]
+		}
+		p.MoveTo(pts[0])
+		for _, v := range pts[1:] {
+			p.LineTo(v)
+		}
+		p.Close()
+	}
+	tests := []struct {
+		name     string
+		rule     render.FillRule
+		ccwInner bool
+		hole     bool
+	}{
+		{"nonzero same direction", render.FillNonZero, false, false},
+		{"nonzero opposite direction", render.FillNonZero, true, true},
+		{"evenodd same direction", render.FillEvenOdd, false, true},
+		{"evenodd opposite direction", render.FillEvenOdd, true, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := New(40, 40, render.Color{R: 1, G: 1, B: 1, A: 1})
+			_ = r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 40}})
+			var p geom.Path
+			square(&p, 5, 5, 35, 35, false)
+			square(&p, 15, 15, 25, 25, tc.ccwInner)
+			r.Path(p, &render.Paint{Fill: render.Color{A: 1}, FillRule: tc.rule})
+			_ = r.End()
+
+			img := r.GetImage()
+			if ring := img.RGBAAt(10, 20).R; ring != 0 {
+				t.Errorf("ring pixel = %d, want filled", ring)
+			}
+			if got := img.RGBAAt(20, 20).R == 255; got != tc.hole {
+				t.Errorf("center pixel %v, want hole=%v", img.RGBAAt(20, 20), tc.hole)
+			}
+		})
+	}
+}
+
+func TestPathAliased(t *testing.T) {
+	draw := func(aliased bool, rule render.FillRule) *image.RGBA {
+		r := New(40, 40, render.Color{R: 1, G: 1, B: 1, A: 1})
+		_ = r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 40}})
+		var p geom.Path
+		p.MoveTo(geom.Pt{X: 2.3, Y: 2.7})
+		p.LineTo(geom.Pt{X: 37.6, Y: 9.2})
+		p.LineTo(geom.Pt{X: 11.1, Y: 36.4})
+		p.Close()
+		p.MoveTo(geom.Pt{X: 12, Y: 12})
+		p.LineTo(geom.Pt{X: 18, Y: 12})
+		p.LineTo(geom.Pt{X: 18, Y: 18})
+		p.LineTo(geom.Pt{X: 12, Y: 18})
+		p.Close()
+		r.Path(p, &render.Paint{Fill: render.Color{A: 1}, FillRule: rule, Aliased: aliased})
+		_ = r.End()
+		return r.GetImage()
+	}
+	partial := func(img *image.RGBA) int {
+		n := 0
+		for i := 0; i < len(img.Pix); i += 4 {
+			if v := img.Pix[i]; v != 0 && v != 255 {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := partial(draw(false, render.FillNonZero)); n == 0 {
+		t.Fatal("anti-aliased fill has no partially covered pixels")
+	}
+	for _, rule := range []render.FillRule{render.FillNonZero, render.FillEvenOdd} {
+		img := draw(true, rule)
+		if n := partial(img); n != 0 {
+			t.Errorf("rule %v: aliased fill has %d partially covered pixels", rule, n)
+		}
+		if img.RGBAAt(8, 8).R != 0 {
+			t.Errorf("rule %v: pixel inside the triangle not filled", rule)
+		}
+		if hole := img.RGBAAt(15, 15).R == 255; hole != (rule == render.FillEvenOdd) {
+			t.Errorf("rule %v: square center %v, want hole only for even-odd", rule, img.RGBAAt(15, 15))
+		}
+	}
+}
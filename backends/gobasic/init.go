@@ -12,11 +12,15 @@ func init() {
 		Description: "Pure Go renderer using golang.org/x/image/vector",
 		Capabilities: []backends.Capability{
 			backends.AntiAliasing, // Basic AA via vector rasterizer
-			backends.PathClip,     // Rectangular clipping implemented
+			backends.PathClip,     // Rectangles and paths, via coverage masks
 			backends.VectorOutput, // Can generate vector-like output
 		},
 		Factory: func(config backends.Config) (render.Renderer, error) {
-			return New(config.Width, config.Height, config.Background), nil
+			depth := Depth8
+			if opts, ok := config.Options.(backends.GoBasicConfig); ok && opts.BitDepth == 16 {
+				depth = Depth16
+			}
+			return NewWithDepth(config.Width, config.Height, config.Background, depth), nil
 		},
 		Available: true, // Always available - pure Go
 	})
@@ -17,6 +17,12 @@ func strokeToPath(p geom.Path, paint *render.Paint) geom.Path {
 	// Quantize the path for deterministic stroke calculation
 	p = quantizePath(p)
 
+	// Dashes with their own cap are stroked piece by piece, keeping the
+	// line cap at the ends of open subpaths.
+	if len(paint.Dashes) > 0 && len(paint.Dashes)%2 == 0 && paint.DashCap.Cap(paint.LineCap) != paint.LineCap {
+		return quantizePath(strokeDashed(p, paint))
+	}
+
 	// Handle dashes first if present
 	if len(paint.Dashes) > 0 {
 		p = applyDashes(p, paint.Dashes)
@@ -77,8 +83,40 @@ func splitIntoSubpaths(p geom.Path) []geom.Path {
 	return subpaths
 }
 
+// strokeDashed strokes the dashes of p with the dash cap, except where a
+// dash starts or ends an open subpath, which gets the line cap.
+func strokeDashed(p geom.Path, paint *render.Paint) geom.Path {
+	dashCap := paint.DashCap.Cap(paint.LineCap)
+	var result geom.Path
+	for _, subpath := range splitIntoSubpaths(p) {
+		if len(subpath.V) == 0 {
+			continue
+		}
+		open := subpath.C[len(subpath.C)-1] != geom.ClosePath
+		first, last := subpath.V[0], subpath.V[len(subpath.V)-1]
+		dashes := splitIntoSubpaths(applyDashesToSubpath(subpath, paint.Dashes))
+		for i, dash := range dashes {
+			startCap, endCap := dashCap, dashCap
+			if open && i == 0 && distance(dash.V[0], first) < 1e-6 {
+				startCap = paint.LineCap
+			}
+			if open && i == len(dashes)-1 && distance(dash.V[len(dash.V)-1], last) < 1e-6 {
+				endCap = paint.LineCap
+			}
+			result = appendPath(result, strokeSubpathCaps(dash, paint, startCap, endCap))
+		}
+	}
+	return result
+}
+
 // strokeSubpath generates the stroke geometry for a single subpath.
 func strokeSubpath(p geom.Path, paint *render.Paint) geom.Path {
+	return strokeSubpathCaps(p, paint, paint.LineCap, paint.LineCap)
+}
+
+// strokeSubpathCaps is strokeSubpath with separate caps at the start and
+// end of open paths.
+func strokeSubpathCaps(p geom.Path, paint *render.Paint, startCapStyle, endCapStyle render.LineCap) geom.Path {
 	if len(p.C) == 0 || paint.LineWidth <= 0 {
 		return geom.Path{}
 	}
@@ -92,30 +130,21 @@ func strokeSubpath(p geom.Path, paint *render.Paint) geom.Path {
 	halfWidth := quantize(paint.LineWidth / 2.0)
 	isClosed := len(p.C) > 0 && p.C[len(p.C)-1] == geom.ClosePath
 
-	// Generate offset curves on both sides
-	leftOffsets := make([]geom.Pt, len(segments)+1)
-	rightOffsets := make([]geom.Pt, len(segments)+1)
-
-	for i, seg := range segments {
-		normal := segmentNormal(seg, halfWidth)
-		leftOffsets[i] = quantizePt(geom.Pt{X: seg.Start.X + normal.X, Y: seg.Start.Y + normal.Y})
-		rightOffsets[i] = quantizePt(geom.Pt{X: seg.Start.X - normal.X, Y: seg.Start.Y - normal.Y})
+	// Outline points per vertex on both sides, in path order. Interior
+	// vertices may contribute several points (bevels, round joins).
+	leftOffsets := make([][]geom.Pt, len(segments)+1)
+	rightOffsets := make([][]geom.Pt, len(segments)+1)
 
-		// Handle the end point of the last segment
-		if i == len(segments)-1 {
-			leftOffsets[i+1] = quantizePt(geom.Pt{X: seg.End.X + normal.X, Y: seg.End.Y + normal.Y})
-			rightOffsets[i+1] = quantizePt(geom.Pt{X: seg.End.X - normal.X, Y: seg.End.Y - normal.Y})
-		}
-	}
+	first, last := segments[0], segments[len(segments)-1]
+	n0, n1 := segmentNormal(first, halfWidth), segmentNormal(last, halfWidth)
+	leftOffsets[0] = []geom.Pt{quantizePt(geom.Pt{X: first.Start.X + n0.X, Y: first.Start.Y + n0.Y})}
+	rightOffsets[0] = []geom.Pt{quantizePt(geom.Pt{X: first.Start.X - n0.X, Y: first.Start.Y - n0.Y})}
+	leftOffsets[len(segments)] = []geom.Pt{quantizePt(geom.Pt{X: last.End.X + n1.X, Y: last.End.Y + n1.Y})}
+	rightOffsets[len(segments)] = []geom.Pt{quantizePt(geom.Pt{X: last.End.X - n1.X, Y: last.End.Y - n1.Y})}
 
 	// Apply line joins at interior vertices
 	for i := 1; i < len(segments); i++ {
-		prev := segments[i-1]
-		curr := segments[i]
-
-		leftJoin, rightJoin := calculateJoin(prev, curr, halfWidth, paint.LineJoin, paint.MiterLimit)
-		leftOffsets[i] = leftJoin
-		rightOffsets[i] = rightJoin
+		leftOffsets[i], rightOffsets[i] = calculateJoin(segments[i-1], segments[i], halfWidth, paint.LineJoin, paint.MiterLimit)
 	}
 
 	// Create the stroke polygon
@@ -123,32 +152,34 @@ func strokeSubpath(p geom.Path, paint *render.Paint) geom.Path {
 
 	if !isClosed {
 		// Add start cap
-		startCap := calculateCap(segments[0], true, halfWidth, paint.LineCap)
+		startCap := calculateCap(segments[0], true, halfWidth, startCapStyle)
 		result = appendPath(result, startCap)
 	}
 
-	// Build the main stroke body
-	if len(leftOffsets) > 0 {
-		// Left side
-		result.C = append(result.C, geom.MoveTo)
-		result.V = append(result.V, leftOffsets[0])
-		for i := 1; i < len(leftOffsets); i++ {
+	// Build the main stroke body: left side forward, right side in reverse
+	result.C = append(result.C, geom.MoveTo)
+	result.V = append(result.V, leftOffsets[0][0])
+	for i, pts := range leftOffsets {
+		for j, pt := range pts {
+			if i == 0 && j == 0 {
+				continue
+			}
 			result.C = append(result.C, geom.LineTo)
-			result.V = append(result.V, leftOffsets[i])
+			result.V = append(result.V, pt)
 		}
-
-		// Right side (in reverse)
-		for i := len(rightOffsets) - 1; i >= 0; i-- {
+	}
+	for i := len(rightOffsets) - 1; i >= 0; i-- {
+		pts := rightOffsets[i]
+		for j := len(pts) - 1; j >= 0; j-- {
 			result.C = append(result.C, geom.LineTo)
-			result.V = append(result.V, rightOffsets[i])
+			result.V = append(result.V, pts[j])
 		}
-
-		result.C = append(result.C, geom.ClosePath)
 	}
+	result.C = append(result.C, geom.ClosePath)
 
 	if !isClosed {
 		// Add end cap
-		endCap := calculateCap(segments[len(segments)-1], false, halfWidth, paint.LineCap)
+		endCap := calculateCap(segments[len(segments)-1], false, halfWidth, endCapStyle)
 		result = appendPath(result, endCap)
 	}
 
@@ -222,126 +253,97 @@ func segmentNormal(seg segment, halfWidth float64) geom.Pt {
 	})
 }
 
-// calculateJoin computes the join point between two segments.
-func calculateJoin(prev, curr segment, halfWidth float64, joinStyle render.LineJoin, miterLimit float64) (left, right geom.Pt) {
-	// Get normals for both segments
+// calculateJoin computes the outline points at the vertex between two
+// segments, on the left and right side in path order.
+//
+// The outer side of the turn gets the join geometry: the miter tip, the two
+// bevel corners, or an arc. The inner side pivots through the vertex, which
+// covers the overlap of the two segments under the nonzero fill rule without
+// having to find where the inner edges meet.
+func calculateJoin(prev, curr segment, halfWidth float64, joinStyle render.LineJoin, miterLimit float64) (left, right []geom.Pt) {
 	prevNormal := segmentNormal(prev, halfWidth)
 	currNormal := segmentNormal(curr, halfWidth)
-
-	// Join point (where segments meet)
 	joinPt := prev.End // Should be same as curr.Start
 
-	// Default to bevel join
-	left = geom.Pt{X: joinPt.X + currNormal.X, Y: joinPt.Y + currNormal.Y}
-	right = geom.Pt{X: joinPt.X - currNormal.X, Y: joinPt.Y - currNormal.Y}
+	prevLeft := quantizePt(geom.Pt{X: joinPt.X + prevNormal.X, Y: joinPt.Y + prevNormal.Y})
+	prevRight := quantizePt(geom.Pt{X: joinPt.X - prevNormal.X, Y: joinPt.Y - prevNormal.Y})
+	currLeft := quantizePt(geom.Pt{X: joinPt.X + currNormal.X, Y: joinPt.Y + currNormal.Y})
+	currRight := quantizePt(geom.Pt{X: joinPt.X - currNormal.X, Y: joinPt.Y - currNormal.Y})
 
+	prevDir, okPrev := direction(prev)
+	currDir, okCurr := direction(curr)
+	if !okPrev || !okCurr {
+		return []geom.Pt{currLeft}, []geom.Pt{currRight}
+	}
+	dot := prevDir.X*currDir.X + prevDir.Y*currDir.Y
+	cross := prevDir.X*currDir.Y - prevDir.Y*currDir.X
+	if dot > 0.999 { // Nearly straight (< 2.5 degrees): no join needed
+		return []geom.Pt{currLeft}, []geom.Pt{currRight}
+	}
+
+	// Turning toward the left normal puts the left side on the inside.
+	leftInner := cross > 0
+	var outerFrom, outerTo, outerNormal geom.Pt
+	if leftInner {
+		outerFrom, outerTo, outerNormal = prevRight, currRight, geom.Pt{X: -prevNormal.X, Y: -prevNormal.Y}
+	} else {
+		outerFrom, outerTo, outerNormal = prevLeft, currLeft, prevNormal
+	}
+
+	outer := []geom.Pt{outerFrom, outerTo} // bevel
 	switch joinStyle {
 	case render.JoinMiter:
-		// Calculate direction vectors
-		prevDir := geom.Pt{X: prev.End.X - prev.Start.X, Y: prev.End.Y - prev.Start.Y}
-		currDir := geom.Pt{X: curr.End.X - curr.Start.X, Y: curr.End.Y - curr.Start.Y}
-		
-		// Normalize direction vectors
-		prevLen := math.Sqrt(prevDir.X*prevDir.X + prevDir.Y*prevDir.Y)
-		currLen := math.Sqrt(currDir.X*currDir.X + currDir.Y*currDir.Y)
-		
-		if prevLen > 0 && currLen > 0 {
-			prevDir.X /= prevLen
-			prevDir.Y /= prevLen  
-			currDir.X /= currLen
-			currDir.Y /= currLen
-			
-			// Calculate the angle between the segments
-			dot := prevDir.X*currDir.X + prevDir.Y*currDir.Y
-			
-			// Avoid miter for nearly parallel or opposite directions
-			if math.Abs(dot) > 0.999 { // Nearly parallel (< 2.5 degrees)
-				// Fall back to bevel for nearly parallel lines
-				left = geom.Pt{X: joinPt.X + currNormal.X, Y: joinPt.Y + currNormal.Y}
-				right = geom.Pt{X: joinPt.X - currNormal.X, Y: joinPt.Y - currNormal.Y}
-			} else {
-				// Calculate miter length using sin of half-angle
-				halfAngleSin := math.Sqrt((1 - dot) / 2)
-				if halfAngleSin > 0 {
-					miterLength := halfWidth / halfAngleSin
-					
-					// Check miter limit
-					if miterLength <= miterLimit * halfWidth {
-						// Calculate miter intersection points
-						leftMiter := intersectLines(
-							geom.Pt{X: prev.Start.X + prevNormal.X, Y: prev.Start.Y + prevNormal.Y},
-							geom.Pt{X: prev.End.X + prevNormal.X, Y: prev.End.Y + prevNormal.Y},
-							geom.Pt{X: curr.Start.X + currNormal.X, Y: curr.Start.Y + currNormal.Y},
-							geom.Pt{X: curr.End.X + currNormal.X, Y: curr.End.Y + currNormal.Y},
-						)
-						rightMiter := intersectLines(
-							geom.Pt{X: prev.Start.X - prevNormal.X, Y: prev.Start.Y - prevNormal.Y},
-							geom.Pt{X: prev.End.X - prevNormal.X, Y: prev.End.Y - prevNormal.Y},
-							geom.Pt{X: curr.Start.X - currNormal.X, Y: curr.Start.Y - currNormal.Y},
-							geom.Pt{X: curr.End.X - currNormal.X, Y: curr.End.Y - currNormal.Y},
-						)
-						
-						left = quantizePt(leftMiter)
-						right = quantizePt(rightMiter)
-					} else {
-						// Miter limit exceeded, fall back to bevel
-						left = geom.Pt{X: joinPt.X + currNormal.X, Y: joinPt.Y + currNormal.Y}
-						right = geom.Pt{X: joinPt.X - currNormal.X, Y: joinPt.Y - currNormal.Y}
-					}
-				}
+		// The miter tip lies halfWidth/cos(turn/2) from the vertex.
+		halfTurnCos := math.Sqrt((1 + dot) / 2)
+		if halfTurnCos > 0 && 1/halfTurnCos <= miterLimit {
+			bisector := geom.Pt{X: outerFrom.X + outerTo.X - 2*joinPt.X, Y: outerFrom.Y + outerTo.Y - 2*joinPt.Y}
+			blen := math.Hypot(bisector.X, bisector.Y)
+			if blen > 0 {
+				miterLength := halfWidth / halfTurnCos
+				outer = []geom.Pt{quantizePt(geom.Pt{
+					X: joinPt.X + bisector.X/blen*miterLength,
+					Y: joinPt.Y + bisector.Y/blen*miterLength,
+				})}
 			}
 		}
 
 	case render.JoinRound:
-		// Calculate the angle between segments to determine arc
-		prevDir := geom.Pt{X: prev.End.X - prev.Start.X, Y: prev.End.Y - prev.Start.Y}
-		currDir := geom.Pt{X: curr.End.X - curr.Start.X, Y: curr.End.Y - curr.Start.Y}
-		
-		// Normalize directions
-		prevLen := math.Sqrt(prevDir.X*prevDir.X + prevDir.Y*prevDir.Y)
-		currLen := math.Sqrt(currDir.X*currDir.X + currDir.Y*currDir.Y)
-		
-		if prevLen > 0 && currLen > 0 {
-			prevDir.X /= prevLen
-			prevDir.Y /= prevLen
-			currDir.X /= currLen
-			currDir.Y /= currLen
-			
-			// Calculate angle between directions using dot product and cross product
-			dot := prevDir.X*currDir.X + prevDir.Y*currDir.Y
-			cross := prevDir.X*currDir.Y - prevDir.Y*currDir.X
-			angle := math.Atan2(cross, dot)
-			
-			if math.Abs(angle) > 0.01 { // Only create round join if there's significant angle
-				// Use miter intersection as approximation for round join center
-				leftMiter := intersectLines(
-					geom.Pt{X: prev.Start.X + prevNormal.X, Y: prev.Start.Y + prevNormal.Y},
-					geom.Pt{X: prev.End.X + prevNormal.X, Y: prev.End.Y + prevNormal.Y},
-					geom.Pt{X: curr.Start.X + currNormal.X, Y: curr.Start.Y + currNormal.Y},
-					geom.Pt{X: curr.End.X + currNormal.X, Y: curr.End.Y + currNormal.Y},
-				)
-				rightMiter := intersectLines(
-					geom.Pt{X: prev.Start.X - prevNormal.X, Y: prev.Start.Y - prevNormal.Y},
-					geom.Pt{X: prev.End.X - prevNormal.X, Y: prev.End.Y - prevNormal.Y},
-					geom.Pt{X: curr.Start.X - currNormal.X, Y: curr.Start.Y - currNormal.Y},
-					geom.Pt{X: curr.End.X - currNormal.X, Y: curr.End.Y - currNormal.Y},
-				)
-				
-				// Use the miter points if they're reasonable, otherwise default to bevel
-				if distance(joinPt, leftMiter) < miterLimit*halfWidth {
-					left = leftMiter
-				}
-				if distance(joinPt, rightMiter) < miterLimit*halfWidth {
-					right = rightMiter
-				}
-			}
+		// Sweep the outer normal through the turn angle, with the same
+		// density as round caps.
+		turn := math.Atan2(cross, dot)
+		perHalfTurn := math.Max(8, math.Min(32, halfWidth*2))
+		steps := int(math.Ceil(perHalfTurn * math.Abs(turn) / math.Pi))
+		outer = outer[:1]
+		for i := 1; i < steps; i++ {
+			a := turn * float64(i) / float64(steps)
+			cos, sin := math.Cos(a), math.Sin(a)
+			outer = append(outer, quantizePt(geom.Pt{
+				X: joinPt.X + outerNormal.X*cos - outerNormal.Y*sin,
+				Y: joinPt.Y + outerNormal.X*sin + outerNormal.Y*cos,
+			}))
 		}
+		outer = append(outer, outerTo)
 
 	case render.JoinBevel:
 		// Already set to bevel above
 	}
 
-	return left, right
+	if leftInner {
+		return []geom.Pt{prevLeft, joinPt, currLeft}, outer
+	}
+	return outer, []geom.Pt{prevRight, joinPt, currRight}
+}
+
+// direction returns the unit direction of a segment, or false if it has no
+// length.
+func direction(seg segment) (geom.Pt, bool) {
+	dx := seg.End.X - seg.Start.X
+	dy := seg.End.Y - seg.Start.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return geom.Pt{}, false
+	}
+	return geom.Pt{X: dx / l, Y: dy / l}, true
 }
 
 // calculateCap generates the cap geometry for the start or end of a path.
@@ -395,21 +397,24 @@ func calculateCap(seg segment, isStart bool, halfWidth float64, capStyle render.
 		radius := halfWidth
 		numSegments := int(math.Max(8, math.Min(32, radius*2))) // Adaptive based on size
 		
+		// Start on the right side and sweep to the left so the cap winds
+		// the same way as the stroke body; opposite windings would cancel
+		// along the shared edge and leave an antialiasing seam. The normal
+		// is flipped for end caps, so the same sweep points away from the
+		// body at both ends.
+		back := geom.Pt{X: -normal.X, Y: -normal.Y}
 		result.C = append(result.C, geom.MoveTo)
-		result.V = append(result.V, geom.Pt{X: capPt.X + normal.X, Y: capPt.Y + normal.Y})
+		result.V = append(result.V, geom.Pt{X: capPt.X + back.X, Y: capPt.Y + back.Y})
 
 		for i := 1; i <= numSegments; i++ {
-			angle := math.Pi * float64(i) / float64(numSegments)
-			if !isStart {
-				angle = -angle
-			}
+			angle := -math.Pi * float64(i) / float64(numSegments)
 
 			cos := math.Cos(angle)
 			sin := math.Sin(angle)
 
 			// Rotate normal vector to create points on the semicircle
-			x := normal.X*cos - normal.Y*sin
-			y := normal.X*sin + normal.Y*cos
+			x := back.X*cos - back.Y*sin
+			y := back.X*sin + back.Y*cos
 
 			result.C = append(result.C, geom.LineTo)
 			result.V = append(result.V, quantizePt(geom.Pt{X: capPt.X + x, Y: capPt.Y + y}))
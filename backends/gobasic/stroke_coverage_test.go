@@ -0,0 +1,234 @@
+package gobasic
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Analytic stroke checks: black strokes of known geometry are rasterized on
+// white, and the antialiased coverage is compared with the exact area and
+// extent of the ideal stroke. This catches stroker regressions (wrong
+// width, shifted outlines, missing or oversized caps and joins) that
+// command-count tests cannot see.
+
+const coverageSize = 100
+
+// strokeCoverage rasterizes p as a black stroke and returns per-pixel
+// coverage in [0,1], indexed [y][x].
+func strokeCoverage(t *testing.T, p geom.Path, paint render.Paint) [][]float64 {
+	t.Helper()
+	r := New(coverageSize, coverageSize, render.Color{R: 1, G: 1, B: 1, A: 1})
+	viewport := geom.Rect{Max: geom.Pt{X: coverageSize, Y: coverageSize}}
+	if err := r.Begin(viewport); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	paint.Stroke = render.Color{R: 0, G: 0, B: 0, A: 1}
+	r.Path(p, &paint)
+	if err := r.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	img := r.GetImage()
+	cov := make([][]float64, coverageSize)
+	for y := range cov {
+		cov[y] = make([]float64, coverageSize)
+		for x := range cov[y] {
+			cov[y][x] = 1 - float64(img.RGBAAt(x, y).R)/255
+		}
+	}
+	return cov
+}
+
+func polyline(pts ...geom.Pt) geom.Path {
+	var p geom.Path
+	p.MoveTo(pts[0])
+	for _, pt := range pts[1:] {
+		p.LineTo(pt)
+	}
+	return p
+}
+
+func totalCoverage(cov [][]float64) float64 {
+	var sum float64
+	for _, row := range cov {
+		for _, c := range row {
+			sum += c
+		}
+	}
+	return sum
+}
+
+// columnProfile returns the coverage sum and the coverage-weighted center
+// (in pixel-center coordinates) of column x.
+func columnProfile(cov [][]float64, x int) (sum, center float64) {
+	var moment float64
+	for y := range cov {
+		sum += cov[y][x]
+		moment += cov[y][x] * (float64(y) + 0.5)
+	}
+	if sum > 0 {
+		center = moment / sum
+	}
+	return sum, center
+}
+
+// extent returns the first and last columns with any coverage in row y.
+func extent(cov [][]float64, y int) (first, last int) {
+	first, last = -1, -1
+	for x, c := range cov[y] {
+		if c > 0.01 {
+			if first < 0 {
+				first = x
+			}
+			last = x
+		}
+	}
+	return first, last
+}
+
+func TestStrokeCoverage_HorizontalWidth(t *testing.T) {
+	for _, w := range []float64{1, 1.5, 2, 3.5, 6, 10} {
+		// Offsets on and off the pixel grid.
+		for _, y := range []float64{50, 50.5, 50.25} {
+			p := polyline(geom.Pt{X: 10, Y: y}, geom.Pt{X: 90, Y: y})
+			cov := strokeCoverage(t, p, render.Paint{LineWidth: w, LineCap: render.CapButt})
+
+			for _, x := range []int{20, 50, 80} {
+				sum, center := columnProfile(cov, x)
+				if math.Abs(sum-w) > 0.5 {
+					t.Errorf("w=%v y=%v col %d: stroke width %.3f, want %v ±0.5", w, y, x, sum, w)
+				}
+				if math.Abs(center-y) > 0.1 {
+					t.Errorf("w=%v y=%v col %d: stroke centered at %.3f, want %v", w, y, x, center, y)
+				}
+			}
+		}
+	}
+}
+
+func TestStrokeCoverage_VerticalSymmetry(t *testing.T) {
+	// A vertical stroke centered on a pixel boundary must cover both
+	// neighbouring columns equally.
+	for _, w := range []float64{1, 2, 5} {
+		p := polyline(geom.Pt{X: 50, Y: 10}, geom.Pt{X: 50, Y: 90})
+		cov := strokeCoverage(t, p, render.Paint{LineWidth: w, LineCap: render.CapButt})
+		for d := 0; d < int(math.Ceil(w/2)); d++ {
+			left, right := cov[50][49-d], cov[50][50+d]
+			if math.Abs(left-right) > 2.0/255 {
+				t.Errorf("w=%v: column ±%d coverage %.3f vs %.3f, want symmetric", w, d, left, right)
+			}
+		}
+	}
+}
+
+func TestStrokeCoverage_Diagonal(t *testing.T) {
+	// A 45° stroke of width w has a vertical cross-section of w·√2 and an
+	// area of length·w.
+	for _, w := range []float64{1, 2, 4} {
+		p := polyline(geom.Pt{X: 20, Y: 20}, geom.Pt{X: 80, Y: 80})
+		cov := strokeCoverage(t, p, render.Paint{LineWidth: w, LineCap: render.CapButt})
+
+		for _, x := range []int{35, 50, 65} {
+			sum, center := columnProfile(cov, x)
+			if want := w * math.Sqrt2; math.Abs(sum-want) > 0.5 {
+				t.Errorf("w=%v col %d: cross-section %.3f, want %.3f ±0.5", w, x, sum, want)
+			}
+			if want := float64(x) + 0.5; math.Abs(center-want) > 0.1 {
+				t.Errorf("w=%v col %d: centered at %.3f, want %.3f", w, x, center, want)
+			}
+		}
+		if got, want := totalCoverage(cov), 60*math.Sqrt2*w; math.Abs(got-want) > 0.02*want {
+			t.Errorf("w=%v: area %.2f, want %.2f ±2%%", w, got, want)
+		}
+	}
+}
+
+func TestStrokeCoverage_Caps(t *testing.T) {
+	const w, x0, x1, y = 8.0, 30.0, 70.0, 50.0
+	length := x1 - x0
+
+	tests := []struct {
+		name  string
+		cap   render.LineCap
+		reach float64 // how far the stroke extends past each endpoint
+		area  float64
+	}{
+		{"butt", render.CapButt, 0, length * w},
+		{"square", render.CapSquare, w / 2, (length + w) * w},
+		{"round", render.CapRound, w / 2, length*w + math.Pi*w*w/4},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := polyline(geom.Pt{X: x0, Y: y}, geom.Pt{X: x1, Y: y})
+			cov := strokeCoverage(t, p, render.Paint{LineWidth: w, LineCap: tc.cap})
+
+			// Along the center line the stroke spans [x0-reach, x1+reach].
+			first, last := extent(cov, int(y))
+			if want := int(x0 - tc.reach); first != want {
+				t.Errorf("stroke starts at column %d, want %d", first, want)
+			}
+			if want := int(x1+tc.reach) - 1; last != want {
+				t.Errorf("stroke ends at column %d, want %d", last, want)
+			}
+
+			// Caps and body must merge without an antialiasing seam.
+			for x := first + 1; x < last; x++ {
+				if c := cov[int(y)][x]; c < 0.99 {
+					t.Errorf("seam at column %d: coverage %.3f", x, c)
+				}
+			}
+
+			if got := totalCoverage(cov); math.Abs(got-tc.area) > 0.01*tc.area {
+				t.Errorf("area %.2f, want %.2f ±1%%", got, tc.area)
+			}
+		})
+	}
+}
+
+func TestStrokeCoverage_RightAngleJoins(t *testing.T) {
+	// An L of two legs of length L and width w with butt caps. Relative to
+	// the miter join (a full corner square), a bevel cuts off a triangle
+	// with legs w/2; a round join cuts off the corner square's outer
+	// quarter and adds back a quarter disc.
+	const w, l = 10.0, 50.0
+	miter := 2 * l * w
+	tests := []struct {
+		name string
+		join render.LineJoin
+		area float64
+	}{
+		{"miter", render.JoinMiter, miter},
+		{"bevel", render.JoinBevel, miter - w*w/8},
+		{"round", render.JoinRound, miter - w*w/4 + math.Pi*w*w/16},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := polyline(geom.Pt{X: 20, Y: 20}, geom.Pt{X: 20 + l, Y: 20}, geom.Pt{X: 20 + l, Y: 20 + l})
+			cov := strokeCoverage(t, p, render.Paint{
+				LineWidth:  w,
+				LineCap:    render.CapButt,
+				LineJoin:   tc.join,
+				MiterLimit: 10,
+			})
+
+			if got := totalCoverage(cov); math.Abs(got-tc.area) > 2 {
+				t.Errorf("area %.2f, want %.2f ±2", got, tc.area)
+			}
+
+			// The legs keep their width right up to the corner.
+			if sum, _ := columnProfile(cov, 40); math.Abs(sum-w) > 0.5 {
+				t.Errorf("horizontal leg width %.3f, want %v", sum, w)
+			}
+			var rowSum float64
+			for _, c := range cov[50] {
+				rowSum += c
+			}
+			if math.Abs(rowSum-w) > 0.5 {
+				t.Errorf("vertical leg width %.3f, want %v", rowSum, w)
+			}
+		})
+	}
+}
@@ -111,6 +111,32 @@ func TestStrokeToPath_Dashes(t *testing.T) {
 	}
 }
 
+func TestStrokeToPath_DashCap(t *testing.T) {
+	path := geom.Path{
+		C: []geom.Cmd{geom.MoveTo, geom.LineTo},
+		V: []geom.Pt{{X: 0, Y: 0}, {X: 20, Y: 0}},
+	}
+	paint := render.Paint{
+		LineWidth: 2.0,
+		LineCap:   render.CapButt,
+		DashCap:   render.DashCapRound,
+		Stroke:    render.Color{A: 1},
+		Dashes:    []float64{4, 4}, // dashes 0-4, 8-12 and 16-20
+	}
+
+	minX, maxX, inGap := math.Inf(1), math.Inf(-1), false
+	for _, v := range strokeToPath(path, &paint).V {
+		minX, maxX = math.Min(minX, v.X), math.Max(maxX, v.X)
+		inGap = inGap || (v.X > 4 && v.X < 8)
+	}
+	if minX != 0 || maxX != 20 {
+		t.Errorf("line ends at x %v..%v, want butt caps at 0..20", minX, maxX)
+	}
+	if !inGap {
+		t.Error("expected round dash caps reaching into the gaps")
+	}
+}
+
 func TestSegmentNormal(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -328,20 +354,21 @@ func TestMiterJoinEdgeCases(t *testing.T) {
 
 			left, right := calculateJoin(tc.prev, tc.curr, halfWidth, render.JoinMiter, miterLimit)
 
-			// Verify that join points are reasonable (not NaN, not extremely far)
-			if math.IsNaN(left.X) || math.IsNaN(left.Y) || math.IsNaN(right.X) || math.IsNaN(right.Y) {
-				t.Error("Join calculation produced NaN values")
+			if len(left) == 0 || len(right) == 0 {
+				t.Fatal("Join calculation produced no points")
 			}
-
 			joinPt := tc.prev.End
-			leftDist := distance(joinPt, left)
-			rightDist := distance(joinPt, right)
-
-			// Miter points shouldn't be extremely far from the join point
 			maxReasonableDistance := miterLimit * halfWidth * 2
-			if leftDist > maxReasonableDistance || rightDist > maxReasonableDistance {
-				t.Errorf("Join points too far from join: left=%.2f, right=%.2f, max=%.2f",
-					leftDist, rightDist, maxReasonableDistance)
+			for _, pt := range append(left, right...) {
+				// Verify that join points are reasonable (not NaN, not extremely far)
+				if math.IsNaN(pt.X) || math.IsNaN(pt.Y) {
+					t.Error("Join calculation produced NaN values")
+				}
+
+				// Miter points shouldn't be extremely far from the join point
+				if d := distance(joinPt, pt); d > maxReasonableDistance {
+					t.Errorf("Join point too far from join: %.2f, max=%.2f", d, maxReasonableDistance)
+				}
 			}
 		})
 	}
@@ -0,0 +1,84 @@
+package gobasic
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// SetTextOptions sets the font family, hinting and glyph positioning of
+// DrawText and DrawTextRotated.
+func (r *Renderer) SetTextOptions(opts render.TextOptions) {
+	r.text = opts
+	switch opts.Hinting {
+	case render.HintingSlight:
+		r.fonts.Hinting = font.HintingVertical
+	case render.HintingFull:
+		r.fonts.Hinting = font.HintingFull
+	default:
+		r.fonts.Hinting = font.HintingNone
+	}
+}
+
+// lineExtent returns the ascent and descent of the text font at size in
+// whole pixels.
+func (r *Renderer) lineExtent(size float64) (ascent, descent int) {
+	m := r.fonts.Face(r.text.FontKey, size).Metrics()
+	return m.Ascent.Ceil(), m.Descent.Ceil()
+}
+
+// textWidth returns the advance of text in family at size in pixels, with
+// kerning and color glyphs.
+func (r *Renderer) textWidth(text string, size float64, family string) float64 {
+	height := lineHeight(r.fonts.Face(family, size))
+	run := r.fonts.Shape(text, geom.Pt{}, size, family)
+	w := 0.0
+	for i, ch := range []rune(text) {
+		if _, cw, ok := r.colorGlyph(ch, height); ok {
+			w += float64(cw)
+		} else {
+			w += run.Glyphs[i].Advance
+		}
+	}
+	return w
+}
+
+// drawTextLine draws text with its baseline origin at dot in the text font
+// at size, font glyphs in src and color glyphs in their own colors. Without
+// subpixel positioning each glyph starts on a whole pixel.
+func (r *Renderer) drawTextLine(dst draw.Image, text string, size float64, dot geom.Pt, src image.Image) {
+	face := r.fonts.Face(r.text.FontKey, size)
+	ascent, height := face.Metrics().Ascent.Ceil(), lineHeight(face)
+	run := r.fonts.Shape(text, dot, size, r.text.FontKey)
+	x, y := dot.X, fixed.Int26_6(math.Round(dot.Y*64))
+	for i, ch := range []rune(text) {
+		advance := run.Glyphs[i].Advance
+		if img, w, ok := r.colorGlyph(ch, height); ok {
+			gx, gy := int(math.Round(x)), y.Round()
+			xdraw.BiLinear.Scale(dst, image.Rect(gx, gy-ascent, gx+w, gy-ascent+height), img, img.Bounds(), xdraw.Over, nil)
+			x += float64(w)
+			continue
+		}
+		gx := x
+		if !r.text.Subpixel {
+			gx = math.Round(x)
+		}
+		dr, mask, maskp, _, ok := face.Glyph(fixed.Point26_6{X: fixed.Int26_6(math.Round(gx * 64)), Y: y}, ch)
+		if ok {
+			draw.DrawMask(dst, dr, src, image.Point{}, mask, maskp, draw.Over)
+		}
+		x += advance
+	}
+}
+
+// lineHeight returns the ascent plus descent of face in whole pixels.
+func lineHeight(face font.Face) int {
+	m := face.Metrics()
+	return m.Ascent.Ceil() + m.Descent.Ceil()
+}
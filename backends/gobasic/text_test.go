@@ -0,0 +1,103 @@
+package gobasic
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	mplfont "matplotlib-go/font"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// inkBounds returns the bounding box of the dark pixels of r.
+func inkBounds(r *Renderer) image.Rectangle {
+	img := r.GetImage()
+	ink := image.Rectangle{}
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			if img.RGBAAt(x, y).R < 128 {
+				ink = ink.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return ink
+}
+
+func TestGlyphRunOutlines(t *testing.T) {
+	r := New(200, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 200, Y: 100}})
+	defer r.End()
+
+	var fonts mplfont.Cache
+	run := fonts.Shape("HH", geom.Pt{X: 20, Y: 70}, 40, "")
+	r.GlyphRun(run, render.Color{A: 1})
+	ink := inkBounds(r)
+	if ink.Empty() {
+		t.Fatal("no glyphs drawn")
+	}
+	// Two capitals of a 40px font stand on the baseline.
+	if ink.Max.Y > 71 || ink.Dy() < 25 || ink.Min.X < 20 || ink.Dx() < 40 {
+		t.Errorf("ink bounds %v, want two 40px capitals on the baseline y=70", ink)
+	}
+}
+
+func TestDrawTextScales(t *testing.T) {
+	heights := map[float64]int{}
+	for _, size := range []float64{10, 30} {
+		r := New(200, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+		_ = r.Begin(geom.Rect{Max: geom.Pt{X: 200, Y: 100}})
+		r.DrawText("H", geom.Pt{X: 10, Y: 80}, size, render.Color{A: 1})
+		heights[size] = inkBounds(r).Dy()
+		_ = r.End()
+	}
+	if heights[10] == 0 || heights[30] < 2*heights[10] {
+		t.Errorf("cap heights %v, want text three times taller at 30px than at 10px", heights)
+	}
+}
+
+func TestSetTextOptions(t *testing.T) {
+	r := New(10, 10, render.Color{R: 1, G: 1, B: 1, A: 1})
+	unknown := r.MeasureText("iW", 20, "NoSuchFamily")
+	if def := r.MeasureText("iW", 20, mplfont.DefaultFamily); unknown != def {
+		t.Errorf("unregistered family measured %+v, want the default font's %+v", unknown, def)
+	}
+
+	r.SetTextOptions(render.TextOptions{Hinting: render.HintingFull})
+	for _, g := range r.fonts.Shape("abc", geom.Pt{}, 13, "").Glyphs {
+		if g.Advance != float64(int(g.Advance)) {
+			t.Errorf("advance %v with full hinting, want whole pixels", g.Advance)
+		}
+	}
+}
+
+func TestDrawTextRotatedOutlines(t *testing.T) {
+	r := New(200, 200, render.Color{R: 1, G: 1, B: 1, A: 1})
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 200, Y: 200}})
+	defer r.End()
+
+	origin, angle := geom.Pt{X: 40, Y: 160}, math.Pi/4
+	r.DrawTextRotated("Rotated", origin, 24, angle, render.Color{A: 1})
+	ink := inkBounds(r)
+	want := r.MeasureText("Rotated", 24, "").Bounds(origin, angle).Inflate(1, 1)
+	if ink.Empty() || float64(ink.Min.X) < want.Min.X || float64(ink.Min.Y) < want.Min.Y ||
+		float64(ink.Max.X) > want.Max.X || float64(ink.Max.Y) > want.Max.Y {
+		t.Errorf("ink bounds %v, want within the rotated text box %v", ink, want)
+	}
+	if ink.Dx() < 60 || ink.Dy() < 60 {
+		t.Errorf("ink bounds %v, want a diagonal run of 24px text", ink)
+	}
+
+	// Slanted edges are anti-aliased, not stair-stepped.
+	img, partial := r.GetImage(), 0
+	for y := ink.Min.Y; y < ink.Max.Y; y++ {
+		for x := ink.Min.X; x < ink.Max.X; x++ {
+			if c := img.RGBAAt(x, y); c.R > 30 && c.R < 225 {
+				partial++
+			}
+		}
+	}
+	if partial < 50 {
+		t.Errorf("%d partially covered pixels, want anti-aliased edges", partial)
+	}
+}
@@ -0,0 +1,11 @@
+// Package pgf provides a vector backend that emits PGF drawing commands.
+//
+// The output is a pgfpicture that can be included in a LaTeX document with
+// \input, so tick labels and titles are typeset in the document's own fonts.
+// The backend supports:
+//   - Fill and stroke operations with joins, caps, and dashes
+//   - Rectangular and path clipping via pgfscope
+//   - Text via \pgftext (typeset by LaTeX, metrics are estimates)
+//
+// Images and glyph runs are not supported yet.
+package pgf
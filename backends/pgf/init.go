@@ -0,0 +1,22 @@
+package pgf
+
+import (
+	"matplotlib-go/backends"
+	"matplotlib-go/render"
+)
+
+func init() {
+	// Register PGF backend with the global registry
+	backends.Register(backends.PGF, &backends.BackendInfo{
+		Name:        "PGF",
+		Description: "LaTeX PGF/TikZ code for inclusion in documents",
+		Capabilities: []backends.Capability{
+			backends.PathClip,
+			backends.VectorOutput,
+		},
+		Factory: func(config backends.Config) (render.Renderer, error) {
+			return New(config.Width, config.Height, config.Background, config.DPI), nil
+		},
+		Available: true, // Pure Go text output
+	})
+}
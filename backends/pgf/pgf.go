@@ -0,0 +1,384 @@
+package pgf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Renderer implements render.Renderer by emitting PGF drawing commands.
+//
+// Pixel coordinates (origin top-left, Y down) are converted to PostScript
+// points (origin bottom-left, Y up) using the configured DPI, so a figure
+// keeps its physical size when included in a document.
+type Renderer struct {
+	width  float64 // canvas width in pixels
+	height float64 // canvas height in pixels
+	dpi    float64
+	bg     render.Color
+
+	body   bytes.Buffer
+	began  bool
+	scopes int
+	done   []byte // output of the last completed session
+}
+
+var _ render.Renderer = (*Renderer)(nil)
+var _ render.TextDrawer = (*Renderer)(nil)
+var _ render.RotatedTextDrawer = (*Renderer)(nil)
+
+// New creates a PGF renderer for a w×h pixel canvas. A non-positive dpi
+// defaults to 96, matching style.Default.
+func New(w, h int, bg render.Color, dpi float64) *Renderer {
+	if dpi <= 0 {
+		dpi = 96
+	}
+	return &Renderer{
+		width:  float64(w),
+		height: float64(h),
+		dpi:    dpi,
+		bg:     bg,
+	}
+}
+
+// Begin starts a drawing session with the given viewport.
+func (r *Renderer) Begin(_ geom.Rect) error {
+	if r.began {
+		return errors.New("Begin called twice")
+	}
+	r.began = true
+	r.scopes = 0
+	r.body.Reset()
+
+	// Fix the bounding box to the full canvas and paint the background.
+	r.printf("\\pgfpathrectangle{\\pgfpointorigin}{%s}\n", r.extent(r.width, r.height))
+	r.printf("\\pgfusepath{use as bounding box, clip}\n")
+	if r.bg.A > 0 {
+		r.printf("\\begin{pgfscope}\n")
+		r.setFill(r.bg)
+		r.printf("\\pgfpathrectangle{\\pgfpointorigin}{%s}\n", r.extent(r.width, r.height))
+		r.printf("\\pgfusepath{fill}\n")
+		r.printf("\\end{pgfscope}\n")
+	}
+	return nil
+}
+
+// End finishes the drawing session, closing any scopes left open.
+func (r *Renderer) End() error {
+	if !r.began {
+		return errors.New("End called before Begin")
+	}
+	for r.scopes > 0 {
+		r.Restore()
+	}
+	r.began = false
+
+	var out bytes.Buffer
+	out.WriteString("%% Creator: matplotlib-go\n")
+	out.WriteString("%% Requires \\usepackage{pgf} in the document preamble.\n")
+	out.WriteString("\\begingroup%\n\\makeatletter%\n\\begin{pgfpicture}%\n")
+	out.Write(r.body.Bytes())
+	out.WriteString("\\end{pgfpicture}%\n\\makeatother%\n\\endgroup%\n")
+	r.done = out.Bytes()
+	return nil
+}
+
+// Save pushes the graphics state by opening a pgfscope.
+func (r *Renderer) Save() {
+	if !r.began {
+		return
+	}
+	r.printf("\\begin{pgfscope}\n")
+	r.scopes++
+}
+
+// Restore pops the graphics state; underflow is ignored.
+func (r *Renderer) Restore() {
+	if !r.began || r.scopes == 0 {
+		return
+	}
+	r.printf("\\end{pgfscope}\n")
+	r.scopes--
+}
+
+// ClipRect intersects the current clip with a rectangle.
+func (r *Renderer) ClipRect(rect geom.Rect) {
+	if !r.began {
+		return
+	}
+	r.printf("\\pgfpathrectangle{%s}{%s}\n",
+		r.point(geom.Pt{X: rect.Min.X, Y: rect.Max.Y}),
+		r.extent(rect.W(), rect.H()))
+	r.printf("\\pgfusepath{clip}\n")
+}
+
+// ClipPath intersects the current clip with an arbitrary path.
+func (r *Renderer) ClipPath(p geom.Path) {
+	if !r.began || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	r.writePath(p)
+	r.printf("\\pgfusepath{clip}\n")
+}
+
+// Path fills and/or strokes a path.
+func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
+	if !r.began || paint == nil || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	fill := paint.Fill.A > 0
+	stroke := paint.Stroke.A > 0 && paint.LineWidth > 0
+	if !fill && !stroke {
+		return
+	}
+
+	r.printf("\\begin{pgfscope}\n")
+	var use []string
+	if fill {
+		r.setFill(paint.Fill)
+		if paint.FillRule == render.FillEvenOdd {
+			r.printf("\\pgfseteorule\n")
+		}
+		use = append(use, "fill")
+	}
+	if stroke {
+		r.setStroke(paint)
+		use = append(use, "stroke")
+	}
+	r.writePath(p)
+	r.printf("\\pgfusepath{%s}\n", strings.Join(use, ","))
+	r.printf("\\end{pgfscope}\n")
+}
+
+// Image is not supported by the PGF backend yet; it is a no-op.
+func (r *Renderer) Image(_ render.Image, _ geom.Rect) {}
+
+// GlyphRun is a no-op: PGF output leaves typesetting to LaTeX, so text is
+// emitted as strings via DrawText instead of positioned glyphs.
+func (r *Renderer) GlyphRun(_ render.GlyphRun, _ render.Color) {}
+
+// MeasureText estimates text extents. The real metrics depend on the
+// document font and are only known when LaTeX compiles the output.
+func (r *Renderer) MeasureText(text string, size float64, _ string) render.TextMetrics {
+	if text == "" {
+		return render.TextMetrics{}
+	}
+	n := float64(len([]rune(text)))
+	return render.TextMetrics{
+		W:       n * size * 0.5,
+		H:       size * 1.2,
+		Ascent:  size * 0.8,
+		Descent: size * 0.2,
+	}
+}
+
+// DrawText emits text anchored at its left baseline. The text is typeset by
+// LaTeX in the document font at the requested size.
+func (r *Renderer) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
+	r.DrawTextRotated(text, origin, size, 0, textColor)
+}
+
+// DrawTextRotated emits text turned by angle radians counterclockwise
+// around its left baseline.
+func (r *Renderer) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	if !r.began || text == "" {
+		return
+	}
+	x, y := r.toPt(origin)
+	pt := size * 72 / r.dpi
+	r.printf("\\begin{pgfscope}\n")
+	r.printf("\\definecolor{textcolor}{rgb}{%s,%s,%s}\n", num(textColor.R), num(textColor.G), num(textColor.B))
+	r.printf("\\pgfsetstrokecolor{textcolor}\n\\pgfsetfillcolor{textcolor}\n")
+	if textColor.A < 1 {
+		r.printf("\\pgfsetfillopacity{%s}\n", num(textColor.A))
+	}
+	rotate := ""
+	if angle != 0 {
+		rotate = ",rotate=" + num(angle*180/math.Pi)
+	}
+	r.printf("\\pgftext[x=%sbp,y=%sbp,left,base%s]{\\color{textcolor}\\fontsize{%s}{%s}\\selectfont %s}\n",
+		num(x), num(y), rotate, num(pt), num(pt*1.2), escape(text))
+	r.printf("\\end{pgfscope}\n")
+}
+
+// Bytes returns the PGF code of the last completed session.
+func (r *Renderer) Bytes() []byte { return r.done }
+
+// WriteTo writes the PGF code of the last completed session to w.
+func (r *Renderer) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(r.done)
+	return int64(n), err
+}
+
+// SavePGF writes the PGF code of the last completed session to a file that
+// can be included in a LaTeX document with \input.
+func (r *Renderer) SavePGF(path string) error {
+	if r.done == nil {
+		return errors.New("nothing rendered: call End before SavePGF")
+	}
+	return os.WriteFile(path, r.done, 0o644)
+}
+
+func (r *Renderer) setFill(c render.Color) {
+	r.printf("\\definecolor{currentfill}{rgb}{%s,%s,%s}\n", num(c.R), num(c.G), num(c.B))
+	r.printf("\\pgfsetfillcolor{currentfill}\n")
+	if c.A < 1 {
+		r.printf("\\pgfsetfillopacity{%s}\n", num(c.A))
+	}
+}
+
+func (r *Renderer) setStroke(paint *render.Paint) {
+	c := paint.Stroke
+	r.printf("\\definecolor{currentstroke}{rgb}{%s,%s,%s}\n", num(c.R), num(c.G), num(c.B))
+	r.printf("\\pgfsetstrokecolor{currentstroke}\n")
+	if c.A < 1 {
+		r.printf("\\pgfsetstrokeopacity{%s}\n", num(c.A))
+	}
+	r.printf("\\pgfsetlinewidth{%sbp}\n", num(r.toPoints(paint.LineWidth)))
+
+	switch paint.LineJoin {
+	case render.JoinRound:
+		r.printf("\\pgfsetroundjoin\n")
+	case render.JoinBevel:
+		r.printf("\\pgfsetbeveljoin\n")
+	default:
+		r.printf("\\pgfsetmiterjoin\n")
+		if paint.MiterLimit > 0 {
+			r.printf("\\pgfsetmiterlimit{%s}\n", num(paint.MiterLimit))
+		}
+	}
+	switch paint.StrokeCap() {
+	case render.CapRound:
+		r.printf("\\pgfsetroundcap\n")
+	case render.CapSquare:
+		r.printf("\\pgfsetrectcap\n")
+	default:
+		r.printf("\\pgfsetbuttcap\n")
+	}
+
+	if len(paint.Dashes) > 0 {
+		var b strings.Builder
+		for _, d := range paint.Dashes {
+			fmt.Fprintf(&b, "{%sbp}", num(r.toPoints(d)))
+		}
+		// PGF requires an even number of entries; repeat odd patterns.
+		if len(paint.Dashes)%2 == 1 {
+			for _, d := range paint.Dashes {
+				fmt.Fprintf(&b, "{%sbp}", num(r.toPoints(d)))
+			}
+		}
+		r.printf("\\pgfsetdash{%s}{0bp}\n", b.String())
+	}
+}
+
+func (r *Renderer) writePath(p geom.Path) {
+	vi := 0
+	for _, cmd := range p.C {
+		switch cmd {
+		case geom.MoveTo:
+			r.printf("\\pgfpathmoveto{%s}\n", r.point(p.V[vi]))
+			vi++
+		case geom.LineTo:
+			r.printf("\\pgfpathlineto{%s}\n", r.point(p.V[vi]))
+			vi++
+		case geom.QuadTo:
+			r.printf("\\pgfpathquadraticcurveto{%s}{%s}\n", r.point(p.V[vi]), r.point(p.V[vi+1]))
+			vi += 2
+		case geom.CubicTo:
+			r.printf("\\pgfpathcurveto{%s}{%s}{%s}\n", r.point(p.V[vi]), r.point(p.V[vi+1]), r.point(p.V[vi+2]))
+			vi += 3
+		case geom.ClosePath:
+			r.printf("\\pgfpathclose\n")
+		}
+	}
+}
+
+// toPoints converts a pixel length to PostScript points (TeX "bp").
+func (r *Renderer) toPoints(px float64) float64 { return px * 72 / r.dpi }
+
+// toPt converts a pixel-space point to points with the Y axis flipped.
+func (r *Renderer) toPt(p geom.Pt) (float64, float64) {
+	return r.toPoints(p.X), r.toPoints(r.height - p.Y)
+}
+
+// point formats a pixel-space point as a \pgfqpoint.
+func (r *Renderer) point(p geom.Pt) string {
+	x, y := r.toPt(p)
+	return fmt.Sprintf("\\pgfqpoint{%sbp}{%sbp}", num(x), num(y))
+}
+
+// extent formats a pixel-space width and height as a \pgfqpoint.
+func (r *Renderer) extent(w, h float64) string {
+	return fmt.Sprintf("\\pgfqpoint{%sbp}{%sbp}", num(r.toPoints(w)), num(r.toPoints(h)))
+}
+
+func (r *Renderer) printf(format string, args ...any) {
+	fmt.Fprintf(&r.body, format, args...)
+}
+
+// num formats a float with fixed precision and trims trailing zeros so the
+// output is deterministic and compact.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimRight(s, ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// escape quotes characters that are special in LaTeX and spells the
+// Unicode typography of tick labels (minus, thin space, ×, superscripts)
+// in commands, as pdflatex rejects them by default.
+func escape(s string) string {
+	var b strings.Builder
+	var sup strings.Builder // pending run of superscript characters
+	for _, c := range s {
+		if r, ok := superscript[c]; ok {
+			sup.WriteString(r)
+			continue
+		}
+		if sup.Len() > 0 {
+			b.WriteString("\\textsuperscript{" + sup.String() + "}")
+			sup.Reset()
+		}
+		switch c {
+		case '\\':
+			b.WriteString("\\textbackslash{}")
+		case '{', '}', '$', '&', '#', '%', '_':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case '^':
+			b.WriteString("\\^{}")
+		case '~':
+			b.WriteString("\\~{}")
+		case '\u2212':
+			b.WriteString("\\ensuremath{-}")
+		case '\u2009':
+			b.WriteString("\\,")
+		case '×':
+			b.WriteString("\\ensuremath{\\times}")
+		default:
+			b.WriteRune(c)
+		}
+	}
+	if sup.Len() > 0 {
+		b.WriteString("\\textsuperscript{" + sup.String() + "}")
+	}
+	return b.String()
+}
+
+// superscript spells the superscript digits and minus of ×10ⁿ labels.
+var superscript = map[rune]string{
+	'⁰': "0", '¹': "1", '²': "2", '³': "3", '⁴': "4",
+	'⁵': "5", '⁶': "6", '⁷': "7", '⁸': "8", '⁹': "9", '⁻': "\\ensuremath{-}",
+}
@@ -0,0 +1,129 @@
+package pgf
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestLifecycle(t *testing.T) {
+	r := New(100, 50, render.Color{R: 1, G: 1, B: 1, A: 1}, 72)
+	vp := geom.Rect{Max: geom.Pt{X: 100, Y: 50}}
+	if err := r.Begin(vp); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := r.Begin(vp); err == nil {
+		t.Error("expected double Begin to fail")
+	}
+	r.Save()
+	r.Save()
+	if err := r.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if err := r.End(); err == nil {
+		t.Error("expected End without Begin to fail")
+	}
+
+	out := string(r.Bytes())
+	if strings.Count(out, "\\begin{pgfscope}") != strings.Count(out, "\\end{pgfscope}") {
+		t.Errorf("unbalanced scopes in output:\n%s", out)
+	}
+	if !strings.Contains(out, "\\begin{pgfpicture}") || !strings.Contains(out, "\\end{pgfpicture}") {
+		t.Errorf("missing pgfpicture environment:\n%s", out)
+	}
+}
+
+func TestPathOutput(t *testing.T) {
+	// At 72 DPI one pixel is one point, which keeps expected values readable.
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+
+	p := geom.Path{}
+	p.MoveTo(geom.Pt{X: 10, Y: 10})
+	p.LineTo(geom.Pt{X: 90, Y: 40})
+	r.Path(p, &render.Paint{
+		LineWidth: 2,
+		LineCap:   render.CapRound,
+		Stroke:    render.Color{R: 1, A: 0.5},
+		Dashes:    []float64{4, 2},
+	})
+	_ = r.End()
+
+	out := string(r.Bytes())
+	for _, want := range []string{
+		"\\pgfpathmoveto{\\pgfqpoint{10bp}{40bp}}", // Y flipped
+		"\\pgfpathlineto{\\pgfqpoint{90bp}{10bp}}",
+		"\\pgfsetlinewidth{2bp}",
+		"\\pgfsetroundcap",
+		"\\pgfsetstrokeopacity{0.5}",
+		"\\pgfsetdash{{4bp}{2bp}}{0bp}",
+		"\\pgfusepath{stroke}",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\\pgfusepath{fill}") {
+		t.Error("transparent background should not be filled")
+	}
+}
+
+func TestDrawTextEscapes(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	r.DrawText("50% of $x_1$", geom.Pt{X: 5, Y: 45}, 10, render.Color{A: 1})
+	_ = r.End()
+
+	out := string(r.Bytes())
+	if !strings.Contains(out, "50\\% of \\$x\\_1\\$") {
+		t.Errorf("text not escaped:\n%s", out)
+	}
+	if !strings.Contains(out, "x=5bp,y=5bp,left,base") {
+		t.Errorf("text not positioned at flipped baseline:\n%s", out)
+	}
+}
+
+func TestDrawTextTypography(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	// A tick label with Unicode minus, thin-space thousands and ×10ⁿ.
+	r.DrawText("\u22121\u2009250×10⁻¹²", geom.Pt{X: 5, Y: 45}, 10, render.Color{A: 1})
+	_ = r.End()
+
+	out := string(r.Bytes())
+	want := `\ensuremath{-}1\,250\ensuremath{\times}10\textsuperscript{\ensuremath{-}12}`
+	if !strings.Contains(out, want) {
+		t.Errorf("tick label not spelled in LaTeX, want %s in:\n%s", want, out)
+	}
+}
+
+func TestDrawTextRotated(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	r.DrawTextRotated("label", geom.Pt{X: 5, Y: 45}, 10, math.Pi/4, render.Color{A: 1})
+	_ = r.End()
+
+	if out := string(r.Bytes()); !strings.Contains(out, "left,base,rotate=45]") {
+		t.Errorf("text not rotated:\n%s", out)
+	}
+}
+
+func TestEvenOddFill(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	p := geom.Path{}
+	p.MoveTo(geom.Pt{X: 10, Y: 10})
+	p.LineTo(geom.Pt{X: 90, Y: 40})
+	p.LineTo(geom.Pt{X: 10, Y: 40})
+	p.Close()
+	r.Path(p, &render.Paint{Fill: render.Color{A: 1}})
+	r.Path(p, &render.Paint{Fill: render.Color{A: 1}, FillRule: render.FillEvenOdd})
+	_ = r.End()
+
+	if n := strings.Count(string(r.Bytes()), "\\pgfseteorule"); n != 1 {
+		t.Errorf("got %d even-odd rules, want 1 for the second path", n)
+	}
+}
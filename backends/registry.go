@@ -13,7 +13,10 @@ type Backend string
 const (
 	GoBasic Backend = "gobasic"
 	Skia    Backend = "skia"
-	// Future backends: AGG, PDF, SVG, etc.
+	PGF     Backend = "pgf"
+	EMF     Backend = "emf"
+	SVG     Backend = "svg"
+	// Future backends: AGG, PDF, etc.
 )
 
 // Capability represents a backend feature capability.
@@ -50,7 +53,7 @@ type Config struct {
 
 // GoBasicConfig holds GoBasic-specific options.
 type GoBasicConfig struct {
-	// No specific options yet
+	BitDepth int // bits per channel of the backing buffer: 8 (default) or 16
 }
 
 // SkiaConfig holds Skia-specific options.
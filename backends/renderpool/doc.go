@@ -0,0 +1,12 @@
+// Package renderpool keeps pre-allocated gobasic renderers for reuse.
+//
+// Services that render many figures per second otherwise pay for a fresh
+// pixel buffer, rasterizer, and background fill on every request. A Pool
+// hands out renderers keyed by size, background, and bit depth; returned
+// renderers are cleared and kept for the next Get with the same key.
+//
+//	pool := renderpool.New(8)
+//	r := pool.Get(renderpool.Key{W: 640, H: 480, Background: white})
+//	defer pool.Put(r)
+//	err := core.SavePNG(fig, r, "out.png")
+package renderpool
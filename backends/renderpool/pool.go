@@ -0,0 +1,114 @@
+package renderpool
+
+import (
+	"sync"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/render"
+)
+
+// DefaultMaxPerKey is the number of idle renderers kept per key when New is
+// given a non-positive limit.
+const DefaultMaxPerKey = 4
+
+// Key identifies interchangeable renderers.
+type Key struct {
+	W, H       int
+	Background render.Color
+	Depth      gobasic.Depth
+}
+
+// Stats reports pool activity since creation.
+type Stats struct {
+	Hits   int // Get calls served from the pool
+	Misses int // Get calls that allocated a new renderer
+	Idle   int // renderers currently held by the pool
+}
+
+// Pool is a set of idle gobasic renderers, safe for concurrent use.
+type Pool struct {
+	mu        sync.Mutex
+	maxPerKey int
+	idle      map[Key][]*gobasic.Renderer
+	stats     Stats
+}
+
+// New creates a pool that keeps at most maxPerKey idle renderers per key.
+func New(maxPerKey int) *Pool {
+	if maxPerKey <= 0 {
+		maxPerKey = DefaultMaxPerKey
+	}
+	return &Pool{
+		maxPerKey: maxPerKey,
+		idle:      make(map[Key][]*gobasic.Renderer),
+	}
+}
+
+// Get returns a renderer for k with a freshly filled background, reusing an
+// idle one when available.
+func (p *Pool) Get(k Key) *gobasic.Renderer {
+	p.mu.Lock()
+	if rs := p.idle[k]; len(rs) > 0 {
+		r := rs[len(rs)-1]
+		p.idle[k] = rs[:len(rs)-1]
+		p.stats.Hits++
+		p.stats.Idle--
+		p.mu.Unlock()
+		return r
+	}
+	p.stats.Misses++
+	p.mu.Unlock()
+	return gobasic.NewWithDepth(k.W, k.H, k.Background, k.Depth)
+}
+
+// Put clears r and returns it to the pool. The caller must not use r or any
+// image obtained from GetImage afterwards. If the pool already holds
+// maxPerKey renderers for r's key, r is dropped.
+func (p *Pool) Put(r *gobasic.Renderer) {
+	if r == nil {
+		return
+	}
+	k := keyOf(r)
+	p.mu.Lock()
+	full := len(p.idle[k]) >= p.maxPerKey
+	p.mu.Unlock()
+	if full {
+		return
+	}
+
+	// Clear outside the lock; it is the expensive part.
+	r.Clear()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.idle[k]) < p.maxPerKey {
+		p.idle[k] = append(p.idle[k], r)
+		p.stats.Idle++
+	}
+}
+
+// Prewarm allocates renderers for k until n are idle (capped at maxPerKey),
+// e.g. at service startup.
+func (p *Pool) Prewarm(k Key, n int) {
+	if n > p.maxPerKey {
+		n = p.maxPerKey
+	}
+	p.mu.Lock()
+	missing := n - len(p.idle[k])
+	p.mu.Unlock()
+	for i := 0; i < missing; i++ {
+		p.Put(gobasic.NewWithDepth(k.W, k.H, k.Background, k.Depth))
+	}
+}
+
+// Stats returns a snapshot of the pool counters.
+func (p *Pool) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+func keyOf(r *gobasic.Renderer) Key {
+	w, h := r.Size()
+	return Key{W: w, H: h, Background: r.Background(), Depth: r.Depth()}
+}
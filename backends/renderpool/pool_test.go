@@ -0,0 +1,85 @@
+package renderpool
+
+import (
+	"image/color"
+	"sync"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+var white = render.Color{R: 1, G: 1, B: 1, A: 1}
+
+func TestGetPutReuse(t *testing.T) {
+	p := New(2)
+	k := Key{W: 40, H: 30, Background: white}
+
+	r := p.Get(k)
+	if w, h := r.Size(); w != 40 || h != 30 {
+		t.Fatalf("got %dx%d renderer", w, h)
+	}
+
+	// Draw something, then return it; the next Get must see a clean buffer.
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 40, Y: 30}})
+	path := geom.Path{}
+	path.MoveTo(geom.Pt{X: 0, Y: 0})
+	path.LineTo(geom.Pt{X: 40, Y: 0})
+	path.LineTo(geom.Pt{X: 40, Y: 30})
+	path.Close()
+	r.Path(path, &render.Paint{Fill: render.Color{A: 1}})
+	_ = r.End()
+	p.Put(r)
+
+	r2 := p.Get(k)
+	if r2 != r {
+		t.Error("expected the idle renderer to be reused")
+	}
+	if c := r2.GetImage().RGBAAt(39, 1); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("reused renderer not cleared: %v", c)
+	}
+	if s := p.Stats(); s.Hits != 1 || s.Misses != 1 || s.Idle != 0 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+
+	// Different key must not share renderers.
+	if p.Get(Key{W: 40, H: 30}) == r2 {
+		t.Error("renderer shared across keys")
+	}
+}
+
+func TestMaxPerKeyAndPrewarm(t *testing.T) {
+	p := New(2)
+	k := Key{W: 8, H: 8, Background: white}
+	p.Prewarm(k, 5)
+	if s := p.Stats(); s.Idle != 2 {
+		t.Fatalf("expected prewarm capped at 2, got %+v", s)
+	}
+	p.Put(p.Get(k))
+	p.Put(New(1).Get(k))
+	if s := p.Stats(); s.Idle != 2 {
+		t.Errorf("pool exceeded limit: %+v", s)
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	p := New(4)
+	k := Key{W: 16, H: 16, Background: white}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				r := p.Get(k)
+				_ = r.Begin(geom.Rect{Max: geom.Pt{X: 16, Y: 16}})
+				_ = r.End()
+				p.Put(r)
+			}
+		}()
+	}
+	wg.Wait()
+	if s := p.Stats(); s.Hits+s.Misses != 160 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
@@ -0,0 +1,15 @@
+// Package svg provides a vector backend that writes SVG documents.
+//
+// SVG output scales without loss, embeds in web pages and opens in vector
+// editors such as Inkscape, which makes it a good fit for publications.
+// The backend supports:
+//   - Fill and stroke operations with joins, caps, dashes and fill rules
+//   - Rectangular and path clipping via clipPath definitions
+//   - Text as <text> elements (metrics are estimates), optionally rotated
+//   - Embedded PNG bitmaps, used for rasterized artists
+//   - Artist groups with stable ids and classes, and tooltips as <title>
+//   - ARIA roles, titles and descriptions of the figure, axes and series
+//     for screen readers (core.AltText)
+//
+// Glyph runs are not supported yet.
+package svg
@@ -0,0 +1,22 @@
+package svg
+
+import (
+	"matplotlib-go/backends"
+	"matplotlib-go/render"
+)
+
+func init() {
+	// Register SVG backend with the global registry
+	backends.Register(backends.SVG, &backends.BackendInfo{
+		Name:        "SVG",
+		Description: "Scalable Vector Graphics for the web and publications",
+		Capabilities: []backends.Capability{
+			backends.PathClip,
+			backends.VectorOutput,
+		},
+		Factory: func(config backends.Config) (render.Renderer, error) {
+			return New(config.Width, config.Height, config.Background, config.DPI), nil
+		},
+		Available: true, // Pure Go text output
+	})
+}
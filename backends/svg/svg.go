@@ -0,0 +1,471 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Renderer implements render.Renderer by writing SVG elements.
+//
+// Drawing happens in pixel coordinates (origin top-left, Y down) through
+// the document's viewBox, while the width and height are given in points
+// using the configured DPI, so a figure keeps its physical size when
+// placed in a document.
+type Renderer struct {
+	width  float64 // canvas width in pixels
+	height float64 // canvas height in pixels
+	dpi    float64
+	bg     render.Color
+
+	defs   bytes.Buffer // clip paths
+	body   bytes.Buffer
+	began  bool
+	clips  int   // clip paths defined so far, for unique ids
+	open   int   // <g> elements opened for clipping since the last Save
+	saved  []int // open counts of the enclosing Save levels
+	groups int   // <g> elements opened by BeginGroup
+	title  string
+	doc    group  // accessibility metadata of the document, see Describe
+	next   *group // group opened by BeginGroup whose start tag is not written yet
+	done   []byte // output of the last completed session
+}
+
+var _ render.Renderer = (*Renderer)(nil)
+var _ render.TextDrawer = (*Renderer)(nil)
+var _ render.RotatedTextDrawer = (*Renderer)(nil)
+var _ render.Grouper = (*Renderer)(nil)
+var _ render.Titler = (*Renderer)(nil)
+var _ render.Describer = (*Renderer)(nil)
+var _ render.VectorOutput = (*Renderer)(nil)
+
+// New creates an SVG renderer for a w×h pixel canvas. A non-positive dpi
+// defaults to 96, matching style.Default.
+func New(w, h int, bg render.Color, dpi float64) *Renderer {
+	if dpi <= 0 {
+		dpi = 96
+	}
+	return &Renderer{
+		width:  float64(w),
+		height: float64(h),
+		dpi:    dpi,
+		bg:     bg,
+	}
+}
+
+// Begin starts a drawing session with the given viewport.
+func (r *Renderer) Begin(_ geom.Rect) error {
+	if r.began {
+		return errors.New("Begin called twice")
+	}
+	r.began = true
+	r.defs.Reset()
+	r.body.Reset()
+	r.clips, r.open, r.saved, r.groups, r.title = 0, 0, nil, 0, ""
+	r.doc, r.next = group{}, nil
+
+	if r.bg.A > 0 {
+		r.printf("<rect width=\"%s\" height=\"%s\"%s/>\n", num(r.width), num(r.height), fillAttrs(r.bg))
+	}
+	return nil
+}
+
+// End finishes the drawing session, closing any groups left open.
+func (r *Renderer) End() error {
+	if !r.began {
+		return errors.New("End called before Begin")
+	}
+	for len(r.saved) > 0 {
+		r.Restore()
+	}
+	r.closeGroups(r.open)
+	for r.groups > 0 {
+		r.EndGroup()
+	}
+	r.began = false
+
+	var out bytes.Buffer
+	out.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	out.WriteString("<!-- Creator: matplotlib-go -->\n")
+	fmt.Fprintf(&out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%spt\" height=\"%spt\" viewBox=\"0 0 %s %s\"%s>\n",
+		num(r.toPoints(r.width)), num(r.toPoints(r.height)), num(r.width), num(r.height), r.doc.attrs("figure"))
+	r.doc.writeChildren(&out, "figure")
+	if r.defs.Len() > 0 {
+		out.WriteString("<defs>\n")
+		out.Write(r.defs.Bytes())
+		out.WriteString("</defs>\n")
+	}
+	out.Write(r.body.Bytes())
+	out.WriteString("</svg>\n")
+	r.done = out.Bytes()
+	return nil
+}
+
+// Save pushes the graphics state. SVG has no state stack, so it records
+// how many clip groups are open for Restore to close.
+func (r *Renderer) Save() {
+	if !r.began {
+		return
+	}
+	r.saved = append(r.saved, r.open)
+	r.open = 0
+}
+
+// Restore pops the graphics state, closing the clip groups opened since
+// the matching Save; underflow is ignored.
+func (r *Renderer) Restore() {
+	if !r.began || len(r.saved) == 0 {
+		return
+	}
+	r.closeGroups(r.open)
+	r.open = r.saved[len(r.saved)-1]
+	r.saved = r.saved[:len(r.saved)-1]
+}
+
+// ClipRect intersects the current clip with a rectangle.
+func (r *Renderer) ClipRect(rect geom.Rect) {
+	if !r.began {
+		return
+	}
+	r.clip(fmt.Sprintf("<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\"/>",
+		num(rect.Min.X), num(rect.Min.Y), num(rect.W()), num(rect.H())))
+}
+
+// ClipPath intersects the current clip with an arbitrary path.
+func (r *Renderer) ClipPath(p geom.Path) {
+	if !r.began || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	r.clip(fmt.Sprintf("<path d=\"%s\"/>", pathData(p)))
+}
+
+// clip defines a clipPath holding shape and opens a group clipped by it.
+// Nested groups intersect their clips.
+func (r *Renderer) clip(shape string) {
+	r.clips++
+	fmt.Fprintf(&r.defs, "<clipPath id=\"clip%d\">%s</clipPath>\n", r.clips, shape)
+	r.printf("<g clip-path=\"url(#clip%d)\">\n", r.clips)
+	r.open++
+}
+
+func (r *Renderer) closeGroups(n int) {
+	for range n {
+		r.printf("</g>\n")
+	}
+}
+
+// Path fills and/or strokes a path.
+func (r *Renderer) Path(p geom.Path, paint *render.Paint) {
+	title := r.title
+	r.title = ""
+	if !r.began || paint == nil || !p.Validate() || len(p.C) == 0 {
+		return
+	}
+	fill := paint.Fill.A > 0
+	stroke := paint.Stroke.A > 0 && paint.LineWidth > 0
+	if !fill && !stroke {
+		return
+	}
+
+	var attrs strings.Builder
+	if fill {
+		attrs.WriteString(fillAttrs(paint.Fill))
+		if paint.FillRule == render.FillEvenOdd {
+			attrs.WriteString(" fill-rule=\"evenodd\"")
+		}
+	} else {
+		attrs.WriteString(" fill=\"none\"")
+	}
+	if stroke {
+		attrs.WriteString(strokeAttrs(paint))
+	}
+	if paint.Aliased {
+		attrs.WriteString(" shape-rendering=\"crispEdges\"")
+	}
+	if title == "" {
+		r.printf("<path d=\"%s\"%s/>\n", pathData(p), attrs.String())
+		return
+	}
+	r.printf("<path d=\"%s\"%s><title>%s</title></path>\n", pathData(p), attrs.String(), html.EscapeString(title))
+}
+
+// Image embeds a bitmap scaled to dst as a PNG data URI. Only
+// render.RGBAImage is supported; other images are ignored.
+func (r *Renderer) Image(img render.Image, dst geom.Rect) {
+	src, ok := img.(render.RGBAImage)
+	if !r.began || !ok || src.RGBA == nil {
+		return
+	}
+	if w, h := src.Size(); w == 0 || h == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src.RGBA); err != nil {
+		return
+	}
+	r.printf("<image x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" preserveAspectRatio=\"none\" href=\"data:image/png;base64,%s\"/>\n",
+		num(dst.Min.X), num(dst.Min.Y), num(dst.W()), num(dst.H()), base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// VectorOutput reports that images passed to Image are embedded in the
+// document.
+func (r *Renderer) VectorOutput() bool { return true }
+
+// GlyphRun is a no-op: SVG output keeps text selectable, so strings are
+// emitted via DrawText instead of positioned glyphs.
+func (r *Renderer) GlyphRun(_ render.GlyphRun, _ render.Color) {}
+
+// MeasureText estimates text extents. The real metrics depend on the font
+// the viewer picks for the generic sans-serif family.
+func (r *Renderer) MeasureText(text string, size float64, _ string) render.TextMetrics {
+	if text == "" {
+		return render.TextMetrics{}
+	}
+	n := float64(len([]rune(text)))
+	return render.TextMetrics{
+		W:       n * size * 0.5,
+		H:       size * 1.2,
+		Ascent:  size * 0.8,
+		Descent: size * 0.2,
+	}
+}
+
+// DrawText emits text anchored at its left baseline.
+func (r *Renderer) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
+	r.DrawTextRotated(text, origin, size, 0, textColor)
+}
+
+// DrawTextRotated emits text turned by angle radians counterclockwise
+// around its left baseline.
+func (r *Renderer) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	if !r.began || text == "" {
+		return
+	}
+	x, y := num(origin.X), num(origin.Y)
+	rotate := ""
+	if angle != 0 {
+		// SVG angles turn clockwise on screen.
+		rotate = fmt.Sprintf(" transform=\"rotate(%s %s %s)\"", num(-angle*180/math.Pi), x, y)
+	}
+	r.printf("<text x=\"%s\" y=\"%s\" font-family=\"sans-serif\" font-size=\"%s\"%s%s>%s</text>\n",
+		x, y, num(size), fillAttrs(textColor), rotate, html.EscapeString(text))
+}
+
+// BeginGroup opens a <g> element with the given id and class.
+func (r *Renderer) BeginGroup(id, class string) {
+	if !r.began {
+		return
+	}
+	r.flushGroup()
+	r.next = &group{id: id, class: class}
+	r.groups++
+}
+
+// EndGroup closes the innermost group opened by BeginGroup.
+func (r *Renderer) EndGroup() {
+	if !r.began || r.groups == 0 {
+		return
+	}
+	r.printf("</g>\n")
+	r.groups--
+}
+
+// Describe sets the ARIA role, accessible name and description of the
+// group just opened by BeginGroup, or of the document outside groups. They
+// are written as role, aria-labelledby and aria-describedby attributes
+// pointing to <title> and <desc> children, which browsers also show as
+// tooltips. Groups that already have content are left unchanged.
+func (r *Renderer) Describe(role, title, desc string) {
+	switch {
+	case !r.began:
+	case r.groups == 0:
+		r.doc = group{role: role, title: title, desc: desc}
+	case r.next != nil:
+		r.next.role, r.next.title, r.next.desc = role, title, desc
+	}
+}
+
+// group is a <g> element opened by BeginGroup. Its start tag is written
+// with the first content, so that Describe can still annotate it.
+type group struct {
+	id, class         string
+	role, title, desc string
+}
+
+// flushGroup writes the start tag of the group opened last, if pending.
+func (r *Renderer) flushGroup() {
+	g := r.next
+	if g == nil {
+		return
+	}
+	r.next = nil
+	fmt.Fprintf(&r.body, "<g id=\"%s\" class=\"%s\"%s>\n", html.EscapeString(g.id), html.EscapeString(g.class), g.attrs(g.id))
+	g.writeChildren(&r.body, g.id)
+}
+
+// attrs returns the ARIA attributes of g, referring to the ids of its
+// title and description, which start with prefix.
+func (g *group) attrs(prefix string) string {
+	var b strings.Builder
+	if g.role != "" {
+		fmt.Fprintf(&b, " role=\"%s\"", html.EscapeString(g.role))
+	}
+	if g.title != "" {
+		fmt.Fprintf(&b, " aria-labelledby=\"%s-title\"", html.EscapeString(prefix))
+	}
+	if g.desc != "" {
+		fmt.Fprintf(&b, " aria-describedby=\"%s-desc\"", html.EscapeString(prefix))
+	}
+	return b.String()
+}
+
+// writeChildren writes the <title> and <desc> elements of g.
+func (g *group) writeChildren(w io.Writer, prefix string) {
+	id := html.EscapeString(prefix)
+	if g.title != "" {
+		fmt.Fprintf(w, "<title id=\"%s-title\">%s</title>\n", id, html.EscapeString(g.title))
+	}
+	if g.desc != "" {
+		fmt.Fprintf(w, "<desc id=\"%s-desc\">%s</desc>\n", id, html.EscapeString(g.desc))
+	}
+}
+
+// Title attaches a tooltip to the next path.
+func (r *Renderer) Title(text string) { r.title = text }
+
+// Bytes returns the SVG document of the last completed session.
+func (r *Renderer) Bytes() []byte { return r.done }
+
+// WriteTo writes the SVG document of the last completed session to w.
+func (r *Renderer) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(r.done)
+	return int64(n), err
+}
+
+// SaveSVG writes the SVG document of the last completed session to a file.
+func (r *Renderer) SaveSVG(path string) error {
+	if r.done == nil {
+		return errors.New("nothing rendered: call End before SaveSVG")
+	}
+	return os.WriteFile(path, r.done, 0o644)
+}
+
+// toPoints converts a pixel length to points.
+func (r *Renderer) toPoints(px float64) float64 { return px * 72 / r.dpi }
+
+func (r *Renderer) printf(format string, args ...any) {
+	r.flushGroup()
+	fmt.Fprintf(&r.body, format, args...)
+}
+
+// fillAttrs returns the fill color attributes of c.
+func fillAttrs(c render.Color) string {
+	s := " fill=\"" + hex(c) + "\""
+	if c.A < 1 {
+		s += " fill-opacity=\"" + num(c.A) + "\""
+	}
+	return s
+}
+
+// strokeAttrs returns the stroke attributes of paint. SVG defaults (butt
+// caps, miter joins with limit 4) are left out.
+func strokeAttrs(paint *render.Paint) string {
+	c := paint.Stroke
+	var b strings.Builder
+	fmt.Fprintf(&b, " stroke=\"%s\" stroke-width=\"%s\"", hex(c), num(paint.LineWidth))
+	if c.A < 1 {
+		fmt.Fprintf(&b, " stroke-opacity=\"%s\"", num(c.A))
+	}
+	switch paint.LineJoin {
+	case render.JoinRound:
+		b.WriteString(" stroke-linejoin=\"round\"")
+	case render.JoinBevel:
+		b.WriteString(" stroke-linejoin=\"bevel\"")
+	default:
+		if paint.MiterLimit > 0 && paint.MiterLimit != 4 {
+			fmt.Fprintf(&b, " stroke-miterlimit=\"%s\"", num(paint.MiterLimit))
+		}
+	}
+	switch paint.StrokeCap() {
+	case render.CapRound:
+		b.WriteString(" stroke-linecap=\"round\"")
+	case render.CapSquare:
+		b.WriteString(" stroke-linecap=\"square\"")
+	}
+	if len(paint.Dashes) > 0 {
+		dashes := make([]string, len(paint.Dashes))
+		for i, d := range paint.Dashes {
+			dashes[i] = num(d)
+		}
+		fmt.Fprintf(&b, " stroke-dasharray=\"%s\"", strings.Join(dashes, " "))
+	}
+	return b.String()
+}
+
+// pathData formats p as SVG path data.
+func pathData(p geom.Path) string {
+	var b strings.Builder
+	pt := func(v geom.Pt) { b.WriteString(num(v.X) + " " + num(v.Y)) }
+	vi := 0
+	for i, cmd := range p.C {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		switch cmd {
+		case geom.MoveTo:
+			b.WriteString("M")
+			pt(p.V[vi])
+			vi++
+		case geom.LineTo:
+			b.WriteString("L")
+			pt(p.V[vi])
+			vi++
+		case geom.QuadTo:
+			b.WriteString("Q")
+			pt(p.V[vi])
+			b.WriteByte(' ')
+			pt(p.V[vi+1])
+			vi += 2
+		case geom.CubicTo:
+			b.WriteString("C")
+			pt(p.V[vi])
+			b.WriteByte(' ')
+			pt(p.V[vi+1])
+			b.WriteByte(' ')
+			pt(p.V[vi+2])
+			vi += 3
+		case geom.ClosePath:
+			b.WriteString("Z")
+		}
+	}
+	return b.String()
+}
+
+// hex formats the RGB components of c as #rrggbb.
+func hex(c render.Color) string {
+	ch := func(v float64) int { return int(math.Round(math.Max(0, math.Min(1, v)) * 255)) }
+	return fmt.Sprintf("#%02x%02x%02x", ch(c.R), ch(c.G), ch(c.B))
+}
+
+// num formats a float with fixed precision and trims trailing zeros so the
+// output is deterministic and compact.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimRight(s, ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,164 @@
+package svg
+
+import (
+	"encoding/xml"
+	"image"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// wellFormed parses out as XML, failing on unbalanced elements.
+func wellFormed(t *testing.T, out []byte) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(string(out)))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %v\n%s", err, out)
+		}
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	r := New(100, 50, render.Color{R: 1, G: 1, B: 1, A: 1}, 72)
+	vp := geom.Rect{Max: geom.Pt{X: 100, Y: 50}}
+	if err := r.Begin(vp); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if err := r.Begin(vp); err == nil {
+		t.Error("expected double Begin to fail")
+	}
+	r.BeginGroup("axes-1", "axes")
+	r.Save()
+	r.ClipRect(geom.Rect{Min: geom.Pt{X: 10, Y: 10}, Max: geom.Pt{X: 50, Y: 40}})
+	r.Save()
+	r.ClipRect(geom.Rect{Max: geom.Pt{X: 20, Y: 20}})
+	if err := r.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	if err := r.End(); err == nil {
+		t.Error("expected End without Begin to fail")
+	}
+
+	out := r.Bytes()
+	wellFormed(t, out)
+	for _, want := range []string{
+		`width="100pt" height="50pt" viewBox="0 0 100 50"`,
+		`<rect width="100" height="50" fill="#ffffff"/>`,
+		`<clipPath id="clip1"><rect x="10" y="10" width="40" height="30"/></clipPath>`,
+		`<g clip-path="url(#clip2)">`,
+		`<g id="axes-1" class="axes">`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPathOutput(t *testing.T) {
+	r := New(100, 50, render.Color{}, 96)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+
+	p := geom.Path{}
+	p.MoveTo(geom.Pt{X: 10, Y: 10})
+	p.LineTo(geom.Pt{X: 90, Y: 40})
+	r.Title("a < b")
+	r.Path(p, &render.Paint{
+		LineWidth: 2,
+		LineCap:   render.CapRound,
+		Stroke:    render.Color{R: 1, A: 0.5},
+		Dashes:    []float64{4, 2},
+	})
+	p.Close()
+	r.Path(p, &render.Paint{Fill: render.Color{B: 1, A: 1}, FillRule: render.FillEvenOdd})
+	_ = r.End()
+
+	out := string(r.Bytes())
+	wellFormed(t, r.Bytes())
+	for _, want := range []string{
+		`width="75pt" height="37.5pt"`,
+		`<path d="M10 10 L90 40" fill="none" stroke="#ff0000" stroke-width="2" stroke-opacity="0.5" stroke-linecap="round" stroke-dasharray="4 2"><title>a &lt; b</title></path>`,
+		`<path d="M10 10 L90 40 Z" fill="#0000ff" fill-rule="evenodd"/>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<rect") {
+		t.Error("transparent background should not be filled")
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	r.DrawText("x & y", geom.Pt{X: 5, Y: 45}, 10, render.Color{A: 1})
+	r.DrawTextRotated("label", geom.Pt{X: 5, Y: 45}, 10, math.Pi/2, render.Color{A: 1})
+	_ = r.End()
+
+	out := string(r.Bytes())
+	if !strings.Contains(out, `<text x="5" y="45" font-family="sans-serif" font-size="10" fill="#000000">x &amp; y</text>`) {
+		t.Errorf("text not escaped or positioned:\n%s", out)
+	}
+	if !strings.Contains(out, `transform="rotate(-90 5 45)">label</text>`) {
+		t.Errorf("text not rotated counterclockwise:\n%s", out)
+	}
+}
+
+func TestImage(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	r.Image(render.RGBAImage{RGBA: img}, geom.Rect{Min: geom.Pt{X: 10, Y: 5}, Max: geom.Pt{X: 30, Y: 45}})
+	_ = r.End()
+
+	if out := string(r.Bytes()); !strings.Contains(out, `<image x="10" y="5" width="20" height="40" preserveAspectRatio="none" href="data:image/png;base64,`) {
+		t.Errorf("image not embedded:\n%s", out)
+	}
+	if !r.VectorOutput() {
+		t.Error("VectorOutput = false")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	r := New(100, 50, render.Color{}, 72)
+	_ = r.Begin(geom.Rect{Max: geom.Pt{X: 100, Y: 50}})
+	r.Describe("graphics-document", "Sales & costs", "")
+	r.BeginGroup("axes0", "axes")
+	r.Describe("graphics-object", "Revenue", "Grows by 5% a month")
+	var p geom.Path
+	p.MoveTo(geom.Pt{X: 10, Y: 10})
+	p.LineTo(geom.Pt{X: 20, Y: 20})
+	r.Path(p, &render.Paint{Stroke: render.Color{A: 1}, LineWidth: 1})
+	r.Describe("graphics-object", "too late", "")
+	r.EndGroup()
+	r.BeginGroup("axes1", "axes")
+	r.EndGroup()
+	_ = r.End()
+
+	wellFormed(t, r.Bytes())
+	out := string(r.Bytes())
+	for _, want := range []string{
+		`viewBox="0 0 100 50" role="graphics-document" aria-labelledby="figure-title">` + "\n" +
+			`<title id="figure-title">Sales &amp; costs</title>`,
+		`<g id="axes0" class="axes" role="graphics-object" aria-labelledby="axes0-title" aria-describedby="axes0-desc">` + "\n" +
+			`<title id="axes0-title">Revenue</title>` + "\n" +
+			`<desc id="axes0-desc">Grows by 5% a month</desc>` + "\n<path",
+		`<g id="axes1" class="axes">` + "\n</g>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "too late") {
+		t.Error("group described after drawing into it")
+	}
+}
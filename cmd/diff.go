@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"matplotlib-go/core"
+)
+
+var diffTol float64
+
+// errDiffer is returned by diff when the figures differ. Execute exits
+// with status 1 for it without printing it, as the changes are the output.
+var errDiffer = errors.New("figures differ")
+
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Compare the data and settings of two saved figures",
+	Long: `Diff compares two figures saved as FigureSpec JSON (core.SaveFigureData)
+or as PNGs with embedded data (core.SavePNGWithData) and prints the changed
+axes, series and properties, one per line. It exits with status 1 if the
+figures differ, like diff(1), so it can gate reviews next to pixel diffs:
+
+  matplotlib-go diff testdata/figure.golden.json figure.png`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		before, err := core.LoadFigureData(args[0])
+		if err != nil {
+			return err
+		}
+		after, err := core.LoadFigureData(args[1])
+		if err != nil {
+			return err
+		}
+		changes := core.DiffSpecs(before, after, diffTol)
+		for _, c := range changes {
+			fmt.Fprintln(cmd.OutOrStdout(), c)
+		}
+		if len(changes) > 0 {
+			return errDiffer
+		}
+		return nil
+	},
+}
+
+func init() {
+	diffCmd.Flags().Float64Var(&diffTol, "tol", 1e-9, "relative tolerance for numbers")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,169 @@
+// Command gen-goldens renders every golden scene with every available backend.
+//
+// Output is written to <out>/<backend>/<scene>.<ext> together with a
+// SHA256SUMS manifest in sha256sum(1) format, so a rendering change shows up
+// as a diff of one text file and the affected outputs can be inspected side
+// by side:
+//
+//	go run ./cmd/gen-goldens -out _goldens
+//	go run ./cmd/gen-goldens -golden testdata/golden   # refresh the PNG goldens
+//
+// Backends that are registered but unavailable (such as skia without cgo)
+// are skipped.
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"matplotlib-go/backends"
+	_ "matplotlib-go/backends/emf"
+	_ "matplotlib-go/backends/gobasic"
+	_ "matplotlib-go/backends/pgf"
+	_ "matplotlib-go/backends/svg"
+	"matplotlib-go/core"
+	"matplotlib-go/test/scenes"
+)
+
+// extensions maps backends to the file extension of their output.
+var extensions = map[backends.Backend]string{
+	backends.GoBasic: "png",
+	backends.Skia:    "png",
+	backends.PGF:     "pgf",
+	backends.EMF:     "emf",
+	backends.SVG:     "svg",
+}
+
+func main() {
+	out := flag.String("out", "_goldens", "output directory")
+	only := flag.String("backends", "", "comma-separated backends to render (default: all available)")
+	names := flag.String("scenes", "", "comma-separated scenes to render (default: all)")
+	golden := flag.String("golden", "", "also write the gobasic PNGs to this golden directory")
+	flag.Parse()
+
+	if err := run(*out, split(*only), split(*names), *golden); err != nil {
+		fmt.Fprintln(os.Stderr, "gen-goldens:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, only, names []string, golden string) error {
+	list, err := selectBackends(only)
+	if err != nil {
+		return err
+	}
+	sc, err := selectScenes(names)
+	if err != nil {
+		return err
+	}
+
+	var sums []string
+	for _, b := range list {
+		dir := filepath.Join(out, string(b))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		for _, s := range sc {
+			data, err := render(b, s)
+			if err != nil {
+				return fmt.Errorf("%s/%s: %w", b, s.Name, err)
+			}
+			name := s.Name + "." + ext(b)
+			if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+				return err
+			}
+			if golden != "" && b == backends.GoBasic {
+				if err := os.WriteFile(filepath.Join(golden, name), data, 0o644); err != nil {
+					return err
+				}
+			}
+			sums = append(sums, fmt.Sprintf("%x  %s/%s", sha256.Sum256(data), b, name))
+		}
+		fmt.Printf("%s: %d scenes\n", b, len(sc))
+	}
+
+	manifest := strings.Join(sums, "\n") + "\n"
+	return os.WriteFile(filepath.Join(out, "SHA256SUMS"), []byte(manifest), 0o644)
+}
+
+// render draws a scene with backend b and returns the encoded output.
+func render(b backends.Backend, s scenes.Scene) ([]byte, error) {
+	fig := s.Build()
+	cfg := backends.SimpleConfig(int(fig.SizePx.X), int(fig.SizePx.Y), scenes.Background)
+	cfg.DPI = fig.RC.DPI
+	r, err := backends.Create(b, cfg)
+	if err != nil {
+		return nil, err
+	}
+	core.DrawFigure(fig, r)
+
+	switch out := r.(type) {
+	case interface{ GetImage() *image.RGBA }:
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, out.GetImage()); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case interface{ Bytes() []byte }:
+		return out.Bytes(), nil
+	}
+	return nil, errors.New("renderer output cannot be captured")
+}
+
+// selectBackends returns the requested backends in a stable order, or all
+// available ones.
+func selectBackends(only []string) ([]backends.Backend, error) {
+	available := backends.Available()
+	sort.Slice(available, func(i, j int) bool { return available[i] < available[j] })
+	if len(only) == 0 {
+		return available, nil
+	}
+	var list []backends.Backend
+	for _, name := range only {
+		b := backends.Backend(name)
+		if _, ok := backends.DefaultRegistry.Get(b); !ok {
+			return nil, fmt.Errorf("unknown backend %q", name)
+		}
+		list = append(list, b)
+	}
+	return list, nil
+}
+
+// selectScenes returns the named scenes, or all of them.
+func selectScenes(names []string) ([]scenes.Scene, error) {
+	if len(names) == 0 {
+		return scenes.All(), nil
+	}
+	var list []scenes.Scene
+	for _, name := range names {
+		s, ok := scenes.Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown scene %q", name)
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func ext(b backends.Backend) string {
+	if e, ok := extensions[b]; ok {
+		return e
+	}
+	return "out"
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
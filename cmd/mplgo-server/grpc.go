@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"matplotlib-go/core"
+)
+
+// grpcRenderPath is the HTTP/2 path of the Render method in render.proto.
+const grpcRenderPath = "/mplgo.v1.Renderer/Render"
+
+// gRPC status codes used by the server.
+const (
+	grpcOK              = 0
+	grpcInvalidArgument = 3
+	grpcUnimplemented   = 12
+	grpcInternal        = 13
+	grpcUnavailable     = 14
+)
+
+// grpcRender serves Render calls. The messages are decoded by hand, as
+// they are two fields each, so the server needs no gRPC dependency; the
+// status goes into the Grpc-Status and Grpc-Message trailers.
+func (s *server) grpcRender(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "want content type application/grpc", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+
+	msg, err := readGRPCMessage(http.MaxBytesReader(w, r.Body, s.maxBody))
+	if err != nil {
+		code := grpcInvalidArgument
+		if errors.Is(err, errCompressed) {
+			code = grpcUnimplemented
+		}
+		grpcStatus(w, code, err.Error())
+		return
+	}
+	var req renderRequest
+	if err := req.unmarshal(msg); err != nil {
+		grpcStatus(w, grpcInvalidArgument, err.Error())
+		return
+	}
+	var spec core.FigureSpec
+	if err := json.Unmarshal(req.spec, &spec); err != nil {
+		grpcStatus(w, grpcInvalidArgument, "figure spec: "+err.Error())
+		return
+	}
+	data, contentType, err := s.renderSpec(r.Context(), spec, req.format)
+	switch {
+	case errors.As(err, &badRequest{}):
+		grpcStatus(w, grpcInvalidArgument, err.Error())
+		return
+	case errors.Is(err, errBusy):
+		grpcStatus(w, grpcUnavailable, err.Error())
+		return
+	case err != nil:
+		grpcStatus(w, grpcInternal, err.Error())
+		return
+	}
+	writeGRPCMessage(w, renderReply{data: data, contentType: contentType}.marshal())
+	grpcStatus(w, grpcOK, "")
+}
+
+// errCompressed reports a message compressed with an encoding the server
+// never offered.
+var errCompressed = errors.New("compressed messages are not supported")
+
+// readGRPCMessage reads one length-prefixed message from a request body.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var head [5]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, fmt.Errorf("message header: %w", err)
+	}
+	if head[0] != 0 {
+		return nil, errCompressed
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(head[1:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("message: %w", err)
+	}
+	return msg, nil
+}
+
+// writeGRPCMessage writes msg length-prefixed and uncompressed.
+func writeGRPCMessage(w io.Writer, msg []byte) {
+	head := [5]byte{}
+	binary.BigEndian.PutUint32(head[1:], uint32(len(msg)))
+	w.Write(head[:])
+	w.Write(msg)
+}
+
+// grpcStatus sets the status trailers; msg is percent-encoded as gRPC
+// requires.
+func grpcStatus(w http.ResponseWriter, code int, msg string) {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		if c := msg[i]; c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	w.Header().Set("Grpc-Status", strconv.Itoa(code))
+	w.Header().Set("Grpc-Message", b.String())
+}
+
+// renderRequest is the RenderRequest message of render.proto.
+type renderRequest struct {
+	spec   []byte
+	format string
+}
+
+// unmarshal decodes the protobuf encoding of the message. Unknown fields
+// are skipped.
+func (m *renderRequest) unmarshal(b []byte) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("render request: bad field key")
+		}
+		b = b[n:]
+		var val []byte
+		switch key & 7 {
+		case 0: // varint
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return errors.New("render request: bad varint")
+			}
+		case 1: // 64-bit
+			n = 8
+		case 2: // length-delimited
+			size, k := binary.Uvarint(b)
+			if k <= 0 || size > uint64(len(b)-k) {
+				return errors.New("render request: bad length")
+			}
+			val, n = b[k:k+int(size)], k+int(size)
+		case 5: // 32-bit
+			n = 4
+		default:
+			return fmt.Errorf("render request: unsupported wire type %d", key&7)
+		}
+		if n > len(b) {
+			return errors.New("render request: truncated")
+		}
+		b = b[n:]
+		switch key {
+		case 1<<3 | 2:
+			m.spec = val
+		case 2<<3 | 2:
+			m.format = string(val)
+		}
+	}
+	return nil
+}
+
+// renderReply is the RenderReply message of render.proto.
+type renderReply struct {
+	data        []byte
+	contentType string
+}
+
+// marshal returns the protobuf encoding of the message.
+func (m renderReply) marshal() []byte {
+	var b []byte
+	field := func(num uint64, val []byte) {
+		if len(val) > 0 {
+			b = binary.AppendUvarint(b, num<<3|2)
+			b = binary.AppendUvarint(b, uint64(len(val)))
+			b = append(b, val...)
+		}
+	}
+	field(1, m.data)
+	field(2, []byte(m.contentType))
+	return b
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// callRender calls Render over HTTP/2 without TLS and returns the reply
+// message, if any, and the gRPC status.
+func callRender(t *testing.T, url string, req []byte) (reply []byte, status string) {
+	t.Helper()
+	var body bytes.Buffer
+	writeGRPCMessage(&body, req)
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+	hreq, err := http.NewRequest(http.MethodPost, url+grpcRenderPath, &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hreq.Header.Set("Content-Type", "application/grpc")
+	hreq.Header.Set("TE", "trailers")
+	resp, err := client.Do(hreq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("served over %s, want HTTP/2", resp.Proto)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status = resp.Trailer.Get("Grpc-Status")
+	if status == "" {
+		status = resp.Header.Get("Grpc-Status") // trailers-only response
+	}
+	if len(data) > 0 {
+		if reply, err = readGRPCMessage(bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return reply, status
+}
+
+// requestMessage encodes a RenderRequest.
+func requestMessage(spec, format string) []byte {
+	var b []byte
+	for i, v := range []string{spec, format} {
+		b = binary.AppendUvarint(b, uint64(i+1)<<3|2)
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		b = append(b, v...)
+	}
+	return b
+}
+
+func TestGRPCRender(t *testing.T) {
+	ts := httptest.NewUnstartedServer(newServer(1, 0))
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	reply, status := callRender(t, ts.URL, requestMessage(lineSpec, "pdf"))
+	if status != "0" {
+		t.Fatalf("status %q, want 0", status)
+	}
+	// RenderReply: field 1 holds the PDF, field 2 the content type.
+	if !bytes.Contains(reply, []byte("%PDF-")) || !bytes.HasSuffix(reply, []byte("\x12\x0fapplication/pdf")) {
+		t.Errorf("unexpected reply %.32q...", reply)
+	}
+
+	for name, req := range map[string][]byte{
+		"format": requestMessage(lineSpec, "gif"),
+		"spec":   requestMessage("{", ""),
+		"wire":   {0x0f},
+	} {
+		if _, status := callRender(t, ts.URL, req); status != "3" {
+			t.Errorf("%s: status %q, want 3 (invalid argument)", name, status)
+		}
+	}
+}
+
+func TestRenderRequestUnmarshal(t *testing.T) {
+	// An unknown varint field before the known ones is skipped.
+	msg := append([]byte{3 << 3, 0x96, 0x01}, requestMessage("{}", "svg")...)
+	var req renderRequest
+	if err := req.unmarshal(msg); err != nil {
+		t.Fatal(err)
+	}
+	if string(req.spec) != "{}" || req.format != "svg" {
+		t.Errorf("decoded %+v", req)
+	}
+	if err := req.unmarshal([]byte{1<<3 | 2, 10, 'x'}); err == nil {
+		t.Error("truncated field accepted")
+	}
+}
@@ -0,0 +1,67 @@
+// Command mplgo-server renders figures for other programs over HTTP and
+// gRPC.
+//
+// Clients POST a core.FigureSpec as JSON to /render and receive the
+// rendered figure; the format query parameter selects png (default), pdf,
+// pgf, emf or svg. PDFs hold the PNG raster as a page of the figure's size
+// at its DPI:
+//
+//	go run ./cmd/mplgo-server -addr :8080
+//	curl --data @figure.json 'localhost:8080/render?format=png' > figure.png
+//
+// gRPC clients generated from render.proto call mplgo.v1.Renderer/Render
+// on the same address over HTTP/2 without TLS, passing the spec and format
+// in the request message.
+//
+// At most -max-concurrent figures are rendered at once; further requests
+// wait for a slot until the client gives up. Raster renderers are kept in a
+// renderpool.Pool for reuse. GET /healthz reports readiness. Slow clients
+// are cut off by the -read-timeout, -write-timeout and -idle-timeout limits.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	_ "matplotlib-go/backends/emf"
+	_ "matplotlib-go/backends/pgf"
+	_ "matplotlib-go/backends/svg"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	concurrent := flag.Int("max-concurrent", runtime.GOMAXPROCS(0), "figures rendered at once")
+	pooled := flag.Int("pool", 0, "idle PNG renderers kept per size (default renderpool.DefaultMaxPerKey)")
+	maxBody := flag.Int64("max-body", 8<<20, "maximum request size in bytes")
+	maxPixels := flag.Int("max-pixels", 4096*4096, "maximum figure size in pixels")
+	readTimeout := flag.Duration("read-timeout", 30*time.Second, "time allowed to read a request")
+	writeTimeout := flag.Duration("write-timeout", 2*time.Minute, "time allowed to render and write a response, including the wait for a slot")
+	idleTimeout := flag.Duration("idle-timeout", 2*time.Minute, "time an idle keep-alive connection is kept open")
+	flag.Parse()
+
+	s := newServer(*concurrent, *pooled)
+	s.maxBody = *maxBody
+	s.maxPixels = *maxPixels
+	// gRPC clients connect with HTTP/2 without TLS.
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      s,
+		ReadTimeout:  *readTimeout,
+		WriteTimeout: *writeTimeout,
+		IdleTimeout:  *idleTimeout,
+		Protocols:    &protocols,
+	}
+	log.Printf("mplgo-server listening on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Fprintln(os.Stderr, "mplgo-server:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+)
+
+// encodePDF writes img as a one-page PDF holding it as an image, sized for
+// dpi pixels per inch. Transparent pixels get a soft mask, so the page
+// shows through where the figure has no background.
+func encodePDF(img *image.RGBA, dpi float64) ([]byte, error) {
+	if dpi <= 0 {
+		dpi = 72
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	rgb := make([]byte, 0, 3*w*h)
+	alpha := make([]byte, 0, w*h)
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(b.Min.X, y):]
+		for x := 0; x < w; x++ {
+			p := row[4*x : 4*x+4]
+			a := p[3]
+			for _, c := range p[:3] {
+				if a > 0 && a < 255 {
+					c = byte((int(c)*255 + int(a)/2) / int(a)) // RGBA is premultiplied
+				}
+				rgb = append(rgb, c)
+			}
+			alpha = append(alpha, a)
+			opaque = opaque && a == 255
+		}
+	}
+	pixels, err := deflate(rgb)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string, stream []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s", len(offsets), body)
+		if stream != nil {
+			out.WriteString("\nstream\n")
+			out.Write(stream)
+			out.WriteString("\nendstream")
+		}
+		out.WriteString("\nendobj\n")
+	}
+	pw, ph := float64(w)*72/dpi, float64(h)*72/dpi
+	content := fmt.Sprintf("q %.4f 0 0 %.4f 0 0 cm /Im0 Do Q", pw, ph)
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	obj("<< /Type /Pages /Kids [3 0 R] /Count 1 >>", nil)
+	obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.4f %.4f] /Resources << /XObject << /Im0 5 0 R >> >> /Contents 4 0 R >>", pw, ph), nil)
+	obj(fmt.Sprintf("<< /Length %d >>", len(content)), []byte(content))
+	smask := ""
+	if !opaque {
+		smask = " /SMask 6 0 R"
+	}
+	obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode%s /Length %d >>", w, h, smask, len(pixels)), pixels)
+	if !opaque {
+		mask, err := deflate(alpha)
+		if err != nil {
+			return nil, err
+		}
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>", w, h, len(mask)), mask)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
+
+// deflate compresses data for a FlateDecode stream.
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncodePDF(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	data, err := encodePDF(img, 144)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("/MediaBox [0 0 2.0000 1.0000]")) {
+		t.Error("page not sized for 144 dpi")
+	}
+	if bytes.Contains(data, []byte("/SMask")) {
+		t.Error("opaque image got a soft mask")
+	}
+	checkXref(t, data, 5)
+
+	img.Pix[3] = 0 // one transparent pixel
+	if data, err = encodePDF(img, 72); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("/SMask 6 0 R")) {
+		t.Error("transparent image has no soft mask")
+	}
+	checkXref(t, data, 6)
+}
+
+// checkXref checks that the cross-reference table lists n objects at the
+// offsets where they start.
+func checkXref(t *testing.T, data []byte, n int) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte(fmt.Sprintf("xref\n0 %d\n", n+1))) {
+		t.Fatalf("startxref %d does not point at a table of %d objects", xref, n)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != n {
+		t.Fatalf("%d xref entries, want %d", len(entries), n)
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if !bytes.HasPrefix(data[off:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))) {
+			t.Errorf("object %d not at offset %d", i+1, off)
+		}
+	}
+}
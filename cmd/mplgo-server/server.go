@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+
+	"matplotlib-go/backends"
+	"matplotlib-go/backends/renderpool"
+	"matplotlib-go/core"
+)
+
+// format is an output format of the server.
+type format struct {
+	backend     backends.Backend
+	contentType string
+	// encode encodes the image of a raster backend at the figure's DPI,
+	// nil for backends writing their own output.
+	encode func(img *image.RGBA, dpi float64) ([]byte, error)
+}
+
+// formats maps the format query parameter to its format.
+var formats = map[string]format{
+	"png": {backends.GoBasic, "image/png", encodePNG},
+	"pdf": {backends.GoBasic, "application/pdf", encodePDF},
+	"pgf": {backends.PGF, "text/x-tex; charset=utf-8", nil},
+	"emf": {backends.EMF, "image/emf", nil},
+	"svg": {backends.SVG, "image/svg+xml", nil},
+}
+
+// server is the HTTP handler of mplgo-server.
+type server struct {
+	mux       *http.ServeMux
+	slots     chan struct{} // one token per figure being rendered
+	pool      *renderpool.Pool
+	maxBody   int64 // request size limit in bytes
+	maxPixels int   // figure size limit in pixels
+}
+
+func newServer(concurrent, pooled int) *server {
+	s := &server{
+		mux:       http.NewServeMux(),
+		slots:     make(chan struct{}, max(concurrent, 1)),
+		pool:      renderpool.New(pooled),
+		maxBody:   8 << 20,
+		maxPixels: 4096 * 4096,
+	}
+	s.mux.HandleFunc("POST /render", s.render)
+	s.mux.HandleFunc("POST "+grpcRenderPath, s.grpcRender)
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	return s
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// errBusy reports that no render slot became free before the client gave up.
+var errBusy = errors.New("server busy")
+
+// badRequest marks errors caused by the request rather than the server.
+type badRequest struct{ error }
+
+// render builds the posted figure spec and writes the rendered figure.
+func (s *server) render(w http.ResponseWriter, r *http.Request) {
+	var spec core.FigureSpec
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBody)).Decode(&spec); err != nil {
+		http.Error(w, "figure spec: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, contentType, err := s.renderSpec(r.Context(), spec, r.URL.Query().Get("format"))
+	switch {
+	case errors.As(err, &badRequest{}):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, errBusy):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Write(data)
+}
+
+// renderSpec renders spec in the named format, png if empty, once a slot
+// is free, and returns the output and its content type. Errors are
+// badRequest, errBusy if ctx ends first, or failures of the renderer.
+func (s *server) renderSpec(ctx context.Context, spec core.FigureSpec, name string) ([]byte, string, error) {
+	if name == "" {
+		name = "png"
+	}
+	format, ok := formats[name]
+	if !ok {
+		return nil, "", badRequest{fmt.Errorf("unknown format %q", name)}
+	}
+	if spec.Width > s.maxPixels || spec.Height > s.maxPixels || spec.Width*spec.Height > s.maxPixels {
+		return nil, "", badRequest{fmt.Errorf("figure spec: %dx%d exceeds %d pixels", spec.Width, spec.Height, s.maxPixels)}
+	}
+	fig, err := core.BuildFigure(spec)
+	if err != nil {
+		return nil, "", badRequest{err}
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		return nil, "", errBusy
+	}
+	data, err := s.draw(fig, format)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, format.contentType, nil
+}
+
+// draw renders fig in format f and returns the encoded output. Raster
+// formats are drawn with pooled renderers.
+func (s *server) draw(fig *core.Figure, f format) ([]byte, error) {
+	cfg := fig.RendererConfig()
+	if f.encode != nil {
+		r := s.pool.Get(renderpool.Key{W: cfg.Width, H: cfg.Height, Background: cfg.Background})
+		defer s.pool.Put(r)
+		core.DrawFigure(fig, r)
+		return f.encode(r.GetImage(), cfg.DPI)
+	}
+
+	r, err := backends.Create(f.backend, cfg)
+	if err != nil {
+		return nil, err
+	}
+	core.DrawFigure(fig, r)
+	if out, ok := r.(interface{ Bytes() []byte }); ok {
+		return out.Bytes(), nil
+	}
+	return nil, errors.New("renderer output cannot be captured")
+}
+
+// encodePNG encodes img as PNG.
+func encodePNG(img *image.RGBA, _ float64) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
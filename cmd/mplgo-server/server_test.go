@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const lineSpec = `{"width": 120, "height": 80, "axes": [{
+	"rect": [0.1, 0.1, 0.9, 0.9], "xlim": [0, 2], "ylim": [0, 2],
+	"series": [{"kind": "line", "x": [0, 1, 2], "y": [0, 2, 1]}]}]}`
+
+func post(s *server, ctx context.Context, query, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/render"+query, strings.NewReader(body)).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRender_Formats(t *testing.T) {
+	s := newServer(2, 0)
+	for _, tc := range []struct{ query, contentType, prefix string }{
+		{"", "image/png", "\x89PNG"},
+		{"?format=pdf", "application/pdf", "%PDF-"},
+		{"?format=pgf", "text/x-tex; charset=utf-8", ""},
+		{"?format=emf", "image/emf", "\x01\x00\x00\x00"},
+		{"?format=svg", "image/svg+xml", "<?xml"},
+	} {
+		rec := post(s, context.Background(), tc.query, lineSpec)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%q: status %d: %s", tc.query, rec.Code, rec.Body)
+		}
+		if got := rec.Header().Get("Content-Type"); got != tc.contentType {
+			t.Errorf("%q: content type %q, want %q", tc.query, got, tc.contentType)
+		}
+		if rec.Body.Len() == 0 || !bytes.HasPrefix(rec.Body.Bytes(), []byte(tc.prefix)) {
+			t.Errorf("%q: unexpected output %.16q", tc.query, rec.Body.Bytes())
+		}
+	}
+
+	// The PDF and the second PNG of the same size reuse the pooled renderer.
+	post(s, context.Background(), "", lineSpec)
+	if st := s.pool.Stats(); st.Hits != 2 || st.Misses != 1 {
+		t.Errorf("pool stats %+v, want 2 hits and 1 miss", st)
+	}
+}
+
+func TestRender_BadRequests(t *testing.T) {
+	s := newServer(1, 0)
+	s.maxPixels = 100 * 100
+	for name, tc := range map[string]struct{ query, body string }{
+		"format": {"?format=gif", lineSpec},
+		"json":   {"", "{"},
+		"size":   {"", `{"width": 200, "height": 200}`},
+		"series": {"", `{"width": 10, "height": 10, "axes": [{"series": [{"kind": "pie", "x": [], "y": []}]}]}`},
+	} {
+		if rec := post(s, context.Background(), tc.query, tc.body); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, rec.Code)
+		}
+	}
+}
+
+func TestRender_Busy(t *testing.T) {
+	s := newServer(1, 0)
+	s.slots <- struct{}{} // occupy the only slot
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if rec := post(s, ctx, "", lineSpec); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d, want 503", rec.Code)
+	}
+	<-s.slots
+	if rec := post(s, context.Background(), "", lineSpec); rec.Code != http.StatusOK {
+		t.Fatalf("status %d after release, want 200", rec.Code)
+	}
+}
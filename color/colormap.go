@@ -0,0 +1,61 @@
+package color
+
+import (
+	"math"
+
+	"matplotlib-go/render"
+)
+
+// Colormap maps values in [0, 1] to colors by interpolating linearly
+// between evenly spaced stops, the first at 0 and the last at 1.
+type Colormap []render.Color
+
+// Viridis is matplotlib's default perceptually uniform colormap, sampled
+// at nine stops.
+var Viridis = Colormap{
+	{R: 0.267004, G: 0.004874, B: 0.329415, A: 1},
+	{R: 0.282623, G: 0.140926, B: 0.457517, A: 1},
+	{R: 0.253935, G: 0.265254, B: 0.529983, A: 1},
+	{R: 0.206756, G: 0.371758, B: 0.553117, A: 1},
+	{R: 0.163625, G: 0.471133, B: 0.558148, A: 1},
+	{R: 0.127568, G: 0.566949, B: 0.550556, A: 1},
+	{R: 0.134692, G: 0.658636, B: 0.517649, A: 1},
+	{R: 0.266941, G: 0.748751, B: 0.440573, A: 1},
+	{R: 0.993248, G: 0.906157, B: 0.143936, A: 1},
+}
+
+// Gray runs from black to white.
+var Gray = Colormap{{A: 1}, {R: 1, G: 1, B: 1, A: 1}}
+
+// Reversed returns the colormap running from its last stop to its first,
+// as matplotlib's "_r" maps do.
+func (c Colormap) Reversed() Colormap {
+	r := make(Colormap, len(c))
+	for i, s := range c {
+		r[len(c)-1-i] = s
+	}
+	return r
+}
+
+// At returns the color at t, clamped to [0, 1]. An empty colormap yields
+// black.
+func (c Colormap) At(t float64) render.Color {
+	switch {
+	case len(c) == 0:
+		return render.Color{A: 1}
+	case len(c) == 1 || math.IsNaN(t) || t <= 0:
+		return c[0]
+	case t >= 1:
+		return c[len(c)-1]
+	}
+	pos := t * float64(len(c)-1)
+	i := int(pos)
+	f := pos - float64(i)
+	a, b := c[i], c[i+1]
+	return render.Color{
+		R: a.R + (b.R-a.R)*f,
+		G: a.G + (b.G-a.G)*f,
+		B: a.B + (b.B-a.B)*f,
+		A: a.A + (b.A-a.A)*f,
+	}
+}
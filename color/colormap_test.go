@@ -0,0 +1,38 @@
+package color
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/render"
+)
+
+func TestColormapAt(t *testing.T) {
+	c := Colormap{{A: 1}, {R: 1, G: 0.5, A: 1}}
+	tests := map[float64]render.Color{
+		-1:   {A: 1},
+		0:    {A: 1},
+		0.5:  {R: 0.5, G: 0.25, A: 1},
+		1:    {R: 1, G: 0.5, A: 1},
+		2:    {R: 1, G: 0.5, A: 1},
+		0.25: {R: 0.25, G: 0.125, A: 1},
+	}
+	for v, want := range tests {
+		if got := c.At(v); got != want {
+			t.Errorf("At(%v) = %v, want %v", v, got, want)
+		}
+	}
+	if got := Viridis.At(math.NaN()); got != Viridis[0] {
+		t.Errorf("At(NaN) = %v, want the first stop", got)
+	}
+}
+
+func TestColormapReversed(t *testing.T) {
+	r := Viridis.Reversed()
+	if r.At(0) != Viridis.At(1) || r.At(1) != Viridis.At(0) {
+		t.Errorf("reversed ends %v, %v", r.At(0), r.At(1))
+	}
+	if Viridis[0] != (render.Color{R: 0.267004, G: 0.004874, B: 0.329415, A: 1}) {
+		t.Error("Reversed changed the original")
+	}
+}
@@ -0,0 +1,208 @@
+package color
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"matplotlib-go/render"
+)
+
+// CVD identifies a type of color-vision deficiency.
+type CVD uint8
+
+const (
+	CVDNone      CVD = iota // normal trichromatic vision
+	Deuteranopia            // missing M (green) cones
+	Protanopia              // missing L (red) cones
+	Tritanopia              // missing S (blue) cones
+)
+
+// String returns the lower-case name of the deficiency.
+func (d CVD) String() string {
+	switch d {
+	case CVDNone:
+		return "none"
+	case Deuteranopia:
+		return "deuteranopia"
+	case Protanopia:
+		return "protanopia"
+	case Tritanopia:
+		return "tritanopia"
+	}
+	return fmt.Sprintf("CVD(%d)", uint8(d))
+}
+
+// cvdMatrices holds the Machado et al. (2009) simulation matrices for
+// severity 1.0, applied to linear RGB.
+var cvdMatrices = map[CVD][3][3]float64{
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// SimulateColor returns how c appears to a viewer with deficiency d.
+// Components are interpreted as sRGB-encoded, matching PNG output; alpha is
+// passed through unchanged.
+func SimulateColor(c render.Color, d CVD) render.Color {
+	m, ok := cvdMatrices[d]
+	if !ok {
+		return c
+	}
+	r, g, b := srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+	return render.Color{
+		R: linearToSRGB(m[0][0]*r + m[0][1]*g + m[0][2]*b),
+		G: linearToSRGB(m[1][0]*r + m[1][1]*g + m[1][2]*b),
+		B: linearToSRGB(m[2][0]*r + m[2][1]*g + m[2][2]*b),
+		A: c.A,
+	}
+}
+
+// SimulateImage returns a copy of a rendered figure as seen with deficiency d.
+// The source buffer is treated as premultiplied RGBA, as produced by the
+// gobasic renderer, and is left untouched.
+func SimulateImage(src *image.RGBA, d CVD) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(b)
+	// Copy row by row: src may be a sub-image with a wider stride.
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		i := src.PixOffset(b.Min.X, y)
+		copy(dst.Pix[dst.PixOffset(b.Min.X, y):], src.Pix[i:i+4*b.Dx()])
+	}
+	m, ok := cvdMatrices[d]
+	if !ok {
+		return dst
+	}
+
+	// Precompute the 8-bit sRGB to linear table once per call.
+	var lut [256]float64
+	for i := range lut {
+		lut[i] = srgbToLinear(float64(i) / 255)
+	}
+
+	for i := 0; i+3 < len(dst.Pix); i += 4 {
+		a := dst.Pix[i+3]
+		if a == 0 {
+			continue
+		}
+		// Un-premultiply before moving to linear space.
+		r := lut[unpremul(dst.Pix[i], a)]
+		g := lut[unpremul(dst.Pix[i+1], a)]
+		b := lut[unpremul(dst.Pix[i+2], a)]
+
+		alpha := float64(a) / 255
+		dst.Pix[i] = to8(linearToSRGB(m[0][0]*r+m[0][1]*g+m[0][2]*b) * alpha)
+		dst.Pix[i+1] = to8(linearToSRGB(m[1][0]*r+m[1][1]*g+m[1][2]*b) * alpha)
+		dst.Pix[i+2] = to8(linearToSRGB(m[2][0]*r+m[2][1]*g+m[2][2]*b) * alpha)
+	}
+	return dst
+}
+
+// DefaultMinDeltaE is the CIE76 distance below which two palette entries are
+// reported as hard to tell apart.
+const DefaultMinDeltaE = 10.0
+
+// PaletteConflict reports a pair of palette entries that become too similar
+// under a given vision type.
+type PaletteConflict struct {
+	I, J   int     // palette indices, I < J
+	Vision CVD     // vision type under which the pair collides
+	DeltaE float64 // CIE76 distance between the simulated colors
+}
+
+// CheckPalette flags pairs of colors in p whose simulated appearance differs
+// by less than minDeltaE. If no vision types are given, all three
+// deficiencies are checked. A non-positive minDeltaE uses DefaultMinDeltaE.
+func CheckPalette(p Palette, minDeltaE float64, visions ...CVD) []PaletteConflict {
+	if minDeltaE <= 0 {
+		minDeltaE = DefaultMinDeltaE
+	}
+	if len(visions) == 0 {
+		visions = []CVD{Deuteranopia, Protanopia, Tritanopia}
+	}
+
+	var conflicts []PaletteConflict
+	for _, v := range visions {
+		labs := make([][3]float64, len(p))
+		for i, c := range p {
+			labs[i] = toLab(SimulateColor(c, v))
+		}
+		for i := 0; i < len(p); i++ {
+			for j := i + 1; j < len(p); j++ {
+				if d := deltaE(labs[i], labs[j]); d < minDeltaE {
+					conflicts = append(conflicts, PaletteConflict{I: i, J: j, Vision: v, DeltaE: d})
+				}
+			}
+		}
+	}
+	return conflicts
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+	if v >= 1 {
+		return 1
+	}
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+func unpremul(v, a uint8) uint8 {
+	if a == 255 {
+		return v
+	}
+	u := (uint32(v)*255 + uint32(a)/2) / uint32(a)
+	if u > 255 {
+		u = 255
+	}
+	return uint8(u)
+}
+
+func to8(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, v*255+0.5)))
+}
+
+// toLab converts an sRGB color to CIE L*a*b* under a D65 white point.
+func toLab(c render.Color) [3]float64 {
+	r, g, b := srgbToLinear(c.R), srgbToLinear(c.G), srgbToLinear(c.B)
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+func deltaE(a, b [3]float64) float64 {
+	dl, da, db := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
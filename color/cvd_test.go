@@ -0,0 +1,83 @@
+package color
+
+import (
+	"image"
+	stdcolor "image/color"
+	"math"
+	"testing"
+
+	"matplotlib-go/render"
+)
+
+func TestSimulateColor_GraysUnchanged(t *testing.T) {
+	for _, d := range []CVD{Deuteranopia, Protanopia, Tritanopia} {
+		for _, v := range []float64{0, 0.25, 0.5, 1} {
+			got := SimulateColor(render.Color{R: v, G: v, B: v, A: 1}, d)
+			for _, ch := range []float64{got.R, got.G, got.B} {
+				if math.Abs(ch-v) > 0.01 {
+					t.Fatalf("%v: gray %v became %+v", d, v, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSimulateColor_RedGreenCollapse(t *testing.T) {
+	red := render.Color{R: 1, G: 0, B: 0, A: 1}
+	green := render.Color{R: 0, G: 0.5, B: 0, A: 1}
+	normal := deltaE(toLab(red), toLab(green))
+	deut := deltaE(toLab(SimulateColor(red, Deuteranopia)), toLab(SimulateColor(green, Deuteranopia)))
+	if deut >= normal/2 {
+		t.Fatalf("expected red/green to converge under deuteranopia: normal=%.1f deut=%.1f", normal, deut)
+	}
+}
+
+func TestSimulateImage_PreservesAlphaAndSource(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.SetRGBA(0, 0, stdcolor.RGBA{R: 255, A: 255})
+	src.SetRGBA(1, 0, stdcolor.RGBA{R: 64, A: 128})
+
+	out := SimulateImage(src, Protanopia)
+	if src.RGBAAt(0, 0) != (stdcolor.RGBA{R: 255, A: 255}) {
+		t.Fatal("source image was modified")
+	}
+	if out.RGBAAt(1, 0).A != 128 {
+		t.Fatalf("alpha changed: %v", out.RGBAAt(1, 0))
+	}
+	if c := out.RGBAAt(0, 0); c.R == 255 && c.G == 0 {
+		t.Fatalf("pure red not transformed: %v", c)
+	}
+}
+
+func TestSimulateImage_SubImage(t *testing.T) {
+	full := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	full.SetRGBA(2, 2, stdcolor.RGBA{B: 255, A: 255})
+	sub := full.SubImage(image.Rect(1, 1, 3, 3)).(*image.RGBA)
+
+	out := SimulateImage(sub, CVDNone)
+	if out.Bounds() != sub.Bounds() {
+		t.Fatalf("bounds %v, want %v", out.Bounds(), sub.Bounds())
+	}
+	for y := 1; y < 3; y++ {
+		for x := 1; x < 3; x++ {
+			if got, want := out.RGBAAt(x, y), sub.RGBAAt(x, y); got != want {
+				t.Errorf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestCheckPalette(t *testing.T) {
+	p := Palette{
+		{R: 1, G: 0, B: 0, A: 1},
+		{R: 0, G: 0.6, B: 0, A: 1},
+		{R: 0, G: 0, B: 1, A: 1},
+	}
+	conflicts := CheckPalette(p, 20, Deuteranopia)
+	if len(conflicts) != 1 || conflicts[0].I != 0 || conflicts[0].J != 1 {
+		t.Fatalf("expected red/green conflict, got %+v", conflicts)
+	}
+	if got := CheckPalette(p, 20, CVDNone); len(got) != 0 {
+		t.Fatalf("expected no conflicts under normal vision, got %+v", got)
+	}
+}
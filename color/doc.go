@@ -1,4 +1,3 @@
-// Package color contains color utilities and colormaps.
-//
-// Phase A: scaffold only. Details come in later milestones.
+// Package color contains color utilities and colormaps: the Tab10 color
+// cycle, the Viridis colormap and color vision deficiency simulation.
 package color
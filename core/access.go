@@ -0,0 +1,100 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"matplotlib-go/render"
+)
+
+// AltText is the text alternative of a figure, axes or artist for screen
+// readers, written to document output such as SVG as ARIA metadata (see
+// render.Describer). Empty fields fall back to text derived from the
+// figure: axes titles and labels, and legend labels.
+type AltText struct {
+	Title       string // short accessible name, e.g. "Monthly revenue"
+	Description string // longer description, e.g. the trend the chart shows
+}
+
+// SetAltText sets the text alternative of an artist of the axes. By
+// default artists with a legend label are named by it and others are not
+// described. Artists that cannot be map keys, such as ArtistFunc, are left
+// out.
+func (a *Axes) SetAltText(art Artist, alt AltText) {
+	if !trackable(art) {
+		return
+	}
+	if alt == (AltText{}) {
+		delete(a.alts, art)
+		return
+	}
+	if a.alts == nil {
+		a.alts = make(map[Artist]AltText)
+	}
+	a.alts[art] = alt
+}
+
+// AltText returns the text alternative of art, set by SetAltText or
+// derived from its legend label.
+func (a *Axes) AltText(art Artist) AltText {
+	var alt AltText
+	if trackable(art) {
+		alt = a.alts[art]
+	}
+	if alt.Title == "" {
+		if e, ok := a.legendEntryFor(art); ok {
+			alt.Title = e.label
+		}
+	}
+	return alt
+}
+
+// describe gives the document the figure's text alternative, by default
+// the titles of its axes.
+func (f *Figure) describe(d render.Describer) {
+	alt := f.Alt
+	if alt.Title == "" {
+		var titles []string
+		for _, ax := range f.Children {
+			if ax.Title != "" {
+				titles = append(titles, ax.Title)
+			}
+		}
+		alt.Title = strings.Join(titles, "; ")
+	}
+	if alt.Title == "" {
+		alt.Title = "Figure"
+	}
+	d.Describe("graphics-document", alt.Title, alt.Description)
+}
+
+// describe gives the group of the axes, the i-th of its figure, its text
+// alternative: by default its title and a description of the axis labels.
+func (a *Axes) describe(d render.Describer, i int) {
+	alt := a.Alt
+	if alt.Title == "" {
+		alt.Title = a.Title
+	}
+	if alt.Title == "" {
+		alt.Title = fmt.Sprintf("Axes %d", i+1)
+	}
+	if alt.Description == "" {
+		var labels []string
+		if a.XLabel != "" {
+			labels = append(labels, "x axis: "+a.XLabel)
+		}
+		if a.YLabel != "" {
+			labels = append(labels, "y axis: "+a.YLabel)
+		}
+		alt.Description = strings.Join(labels, ", ")
+	}
+	d.Describe("graphics-object", alt.Title, alt.Description)
+}
+
+// describeArtist gives the group of a described artist its text
+// alternative; other artists are left alone.
+func (a *Axes) describeArtist(d render.Describer, art Artist) {
+	if alt := a.AltText(art); alt != (AltText{}) {
+		d.Describe("graphics-object", alt.Title, alt.Description)
+	}
+}
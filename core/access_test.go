@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+// describeRecorder records groups with the metadata Describe gives them;
+// "" stands for the document.
+type describeRecorder struct {
+	groupRecorder
+	described map[string][3]string
+}
+
+func (d *describeRecorder) Describe(role, title, desc string) {
+	group := ""
+	if d.depth > 0 {
+		group = d.opened[len(d.opened)-1]
+	}
+	d.described[group] = [3]string{role, title, desc}
+}
+
+func TestDrawFigureDescribe(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Title, ax.XLabel = "Revenue", "Month"
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1, Label: "2024"})
+	s := &Scatter2D{XY: []geom.Pt{{X: 0.5, Y: 0.5}}, Size: 2}
+	ax.Add(s)
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 1}, {X: 1, Y: 0}}, W: 1})
+
+	r := describeRecorder{described: map[string][3]string{}}
+	DrawFigure(fig, &r)
+	want := map[string][3]string{
+		"":                     {"graphics-document", "Revenue", ""},
+		"axes0|axes":           {"graphics-object", "Revenue", "x axis: Month"},
+		"axes0-line2d0|line2d": {"graphics-object", "2024", ""},
+	}
+	if len(r.described) != len(want) {
+		t.Errorf("described %v, want %v", r.described, want)
+	}
+	for g, w := range want {
+		if got := r.described[g]; got != w {
+			t.Errorf("group %q described as %q, want %q", g, got, w)
+		}
+	}
+
+	// Configured alt text replaces the derived one.
+	fig.Alt = AltText{Title: "Sales report", Description: "Revenue grows"}
+	ax.Alt.Title = "Revenue by month"
+	ax.SetAltText(s, AltText{Title: "Outlier"})
+	r = describeRecorder{described: map[string][3]string{}}
+	DrawFigure(fig, &r)
+	if got := r.described[""]; got != [3]string{"graphics-document", "Sales report", "Revenue grows"} {
+		t.Errorf("figure described as %q", got)
+	}
+	if got := r.described["axes0|axes"]; got[1] != "Revenue by month" || got[2] != "x axis: Month" {
+		t.Errorf("axes described as %q", got)
+	}
+	if got := r.described["axes0-scatter2d0|scatter2d"]; got[1] != "Outlier" {
+		t.Errorf("scatter described as %q", got)
+	}
+}
@@ -0,0 +1,276 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Coords selects the coordinate system of a point.
+type Coords uint8
+
+const (
+	CoordsData   Coords = iota // data coordinates of the axes
+	CoordsAxes                 // fractions of the axes: (0,0) lower left, (1,1) upper right
+	CoordsPixel                // figure pixels from the top left
+	CoordsFigure               // fractions of the figure: (0,0) lower left, (1,1) upper right
+)
+
+// ArrowStyle selects the shape of an Arrow.
+type ArrowStyle uint8
+
+const (
+	ArrowSimple ArrowStyle = iota // stroked shaft with a filled triangular head
+	ArrowFancy                    // filled shaft widening from the tail into a swept-back head
+	ArrowWedge                    // filled wedge tapering from HeadWidth at the tail to the tip
+)
+
+// arrowCurveSteps is the number of segments curved arrows are sampled with.
+const arrowCurveSteps = 32
+
+// Arrow is an arrow patch from From to To, the equivalent of matplotlib's
+// FancyArrowPatch. Endpoints can be given in data or axes coordinates; the
+// shape and curvature are computed in pixels, so heads keep their size
+// under zoom.
+type Arrow struct {
+	From, To   geom.Pt      // tail and tip
+	FromCoords Coords       // coordinate system of From
+	ToCoords   Coords       // coordinate system of To
+	Style      ArrowStyle   // head style
+	Rad        float64      // arc3 curvature: control point offset as a fraction of the length, 0 is straight
+	HeadLength float64      // head length in pixels, 0 draws ArrowSimple as a plain line
+	HeadWidth  float64      // head width in pixels
+	TailWidth  float64      // ArrowFancy shaft width at the head in pixels
+	ShrinkA    float64      // pixels left free at the tail
+	ShrinkB    float64      // pixels left free at the tip
+	Color      render.Color // fill and stroke color
+	LineWidth  float64      // ArrowSimple shaft width in pixels
+	z          float64      // z-order
+}
+
+// AddArrow adds a straight ArrowSimple from from to to in data coordinates.
+// Set Rad for a curved connection and FromCoords/ToCoords to anchor an end
+// relative to the axes instead.
+func (a *Axes) AddArrow(from, to geom.Pt) *Arrow {
+	arr := &Arrow{
+		From:       from,
+		To:         to,
+		HeadLength: 10,
+		HeadWidth:  7,
+		TailWidth:  3,
+		ShrinkA:    2,
+		ShrinkB:    2,
+		Color:      render.Color{R: 0, G: 0, B: 0, A: 1},
+		LineWidth:  1.25,
+		z:          100, // above data, below legends
+	}
+	a.Add(arr)
+	return arr
+}
+
+// Draw renders the arrow.
+func (ar *Arrow) Draw(r render.Renderer, ctx *DrawContext) {
+	pts := ar.centerline(ctx)
+	if len(pts) < 2 {
+		return
+	}
+	switch ar.Style {
+	case ArrowFancy:
+		r.Path(ar.fancyPath(pts), &render.Paint{Fill: ar.Color})
+	case ArrowWedge:
+		r.Path(taperPath(pts, func(t float64) float64 { return ar.HeadWidth * (1 - t) }), &render.Paint{Fill: ar.Color})
+	default:
+		ar.drawSimple(r, pts)
+	}
+}
+
+// centerline returns the arrow's path in pixels from tail to tip, sampled
+// into a polyline and shortened by ShrinkA and ShrinkB.
+func (ar *Arrow) centerline(ctx *DrawContext) []geom.Pt {
+	p0 := arrowPoint(ctx, ar.From, ar.FromCoords)
+	p1 := arrowPoint(ctx, ar.To, ar.ToCoords)
+	pts := []geom.Pt{p0, p1}
+	if ar.Rad != 0 {
+		// arc3: the control point lies off the chord's midpoint by Rad
+		// times its length; positive Rad bows the arrow to the right of its
+		// direction, as in matplotlib.
+		dx, dy := p1.X-p0.X, p1.Y-p0.Y
+		c := geom.Pt{X: (p0.X+p1.X)/2 - ar.Rad*dy, Y: (p0.Y+p1.Y)/2 + ar.Rad*dx}
+		pts = make([]geom.Pt, arrowCurveSteps+1)
+		for i := range pts {
+			t := float64(i) / arrowCurveSteps
+			u := 1 - t
+			pts[i] = geom.Pt{
+				X: u*u*p0.X + 2*u*t*c.X + t*t*p1.X,
+				Y: u*u*p0.Y + 2*u*t*c.Y + t*t*p1.Y,
+			}
+		}
+	}
+	pts = trimPolyline(pts, ar.ShrinkA)
+	reverse(pts)
+	pts = trimPolyline(pts, ar.ShrinkB)
+	reverse(pts)
+	return pts
+}
+
+func arrowPoint(ctx *DrawContext, p geom.Pt, c Coords) geom.Pt {
+	t := ctx.coords(c)
+	return t.Apply(p)
+}
+
+// drawSimple strokes the shaft up to the base of the head and fills the head.
+func (ar *Arrow) drawSimple(r render.Renderer, pts []geom.Pt) {
+	tip := pts[len(pts)-1]
+	shaft := append([]geom.Pt(nil), pts...)
+	reverse(shaft)
+	shaft = trimPolyline(shaft, ar.HeadLength)
+	reverse(shaft)
+
+	base := pts[0]
+	if len(shaft) >= 2 {
+		base = shaft[len(shaft)-1]
+		var p geom.Path
+		p.MoveTo(shaft[0])
+		for _, v := range shaft[1:] {
+			p.LineTo(v)
+		}
+		r.Path(p, &render.Paint{
+			LineWidth: ar.LineWidth,
+			LineJoin:  render.JoinRound,
+			LineCap:   render.CapButt,
+			Stroke:    ar.Color,
+		})
+	}
+
+	dir, ok := direction(base, tip)
+	if !ok || ar.HeadLength <= 0 {
+		return
+	}
+	hw := ar.HeadWidth / 2
+	var head geom.Path
+	head.MoveTo(tip)
+	head.LineTo(geom.Pt{X: base.X - dir.Y*hw, Y: base.Y + dir.X*hw})
+	head.LineTo(geom.Pt{X: base.X + dir.Y*hw, Y: base.Y - dir.X*hw})
+	head.Close()
+	r.Path(head, &render.Paint{Fill: ar.Color})
+}
+
+// fancyPath outlines a shaft widening from a point at the tail to TailWidth
+// at the head, followed by a head whose barbs sweep back past its base.
+func (ar *Arrow) fancyPath(pts []geom.Pt) geom.Path {
+	tip := pts[len(pts)-1]
+	shaft := append([]geom.Pt(nil), pts...)
+	reverse(shaft)
+	shaft = trimPolyline(shaft, ar.HeadLength)
+	reverse(shaft)
+	if len(shaft) < 2 {
+		shaft = []geom.Pt{pts[0], pts[0]}
+	}
+	base := shaft[len(shaft)-1]
+	dir, ok := direction(base, tip)
+	if !ok {
+		return geom.Path{}
+	}
+
+	left, right := offsets(shaft, func(t float64) float64 { return ar.TailWidth * t })
+	hw, back := ar.HeadWidth/2, ar.HeadLength/4
+	barb := geom.Pt{X: base.X - dir.X*back, Y: base.Y - dir.Y*back}
+
+	var p geom.Path
+	p.MoveTo(left[0])
+	for _, v := range left[1:] {
+		p.LineTo(v)
+	}
+	p.LineTo(geom.Pt{X: barb.X - dir.Y*hw, Y: barb.Y + dir.X*hw})
+	p.LineTo(tip)
+	p.LineTo(geom.Pt{X: barb.X + dir.Y*hw, Y: barb.Y - dir.X*hw})
+	for i := len(right) - 1; i >= 0; i-- {
+		p.LineTo(right[i])
+	}
+	p.Close()
+	return p
+}
+
+// taperPath outlines pts with the width given at each fraction t of the
+// length.
+func taperPath(pts []geom.Pt, width func(t float64) float64) geom.Path {
+	left, right := offsets(pts, width)
+	var p geom.Path
+	p.MoveTo(left[0])
+	for _, v := range left[1:] {
+		p.LineTo(v)
+	}
+	for i := len(right) - 1; i >= 0; i-- {
+		p.LineTo(right[i])
+	}
+	p.Close()
+	return p
+}
+
+// offsets returns the two sides of pts at half the given width from it.
+func offsets(pts []geom.Pt, width func(t float64) float64) (left, right []geom.Pt) {
+	lengths := make([]float64, len(pts))
+	for i := 1; i < len(pts); i++ {
+		lengths[i] = lengths[i-1] + math.Hypot(pts[i].X-pts[i-1].X, pts[i].Y-pts[i-1].Y)
+	}
+	total := lengths[len(pts)-1]
+
+	left = make([]geom.Pt, len(pts))
+	right = make([]geom.Pt, len(pts))
+	for i, v := range pts {
+		dir, _ := direction(pts[max(i-1, 0)], pts[min(i+1, len(pts)-1)])
+		t := 0.0
+		if total > 0 {
+			t = lengths[i] / total
+		}
+		hw := width(t) / 2
+		left[i] = geom.Pt{X: v.X - dir.Y*hw, Y: v.Y + dir.X*hw}
+		right[i] = geom.Pt{X: v.X + dir.Y*hw, Y: v.Y - dir.X*hw}
+	}
+	return left, right
+}
+
+// trimPolyline removes the first d pixels of length from pts. It returns
+// nil if the polyline is shorter than d.
+func trimPolyline(pts []geom.Pt, d float64) []geom.Pt {
+	if d <= 0 {
+		return pts
+	}
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		seg := math.Hypot(b.X-a.X, b.Y-a.Y)
+		if seg > d {
+			t := d / seg
+			return append([]geom.Pt{{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}}, pts[i:]...)
+		}
+		d -= seg
+	}
+	return nil
+}
+
+func reverse(pts []geom.Pt) {
+	for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+		pts[i], pts[j] = pts[j], pts[i]
+	}
+}
+
+// direction returns the unit vector from a to b, or false if they coincide.
+func direction(a, b geom.Pt) (geom.Pt, bool) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return geom.Pt{}, false
+	}
+	return geom.Pt{X: dx / l, Y: dy / l}, true
+}
+
+// Z returns the z-order for sorting.
+func (ar *Arrow) Z() float64 {
+	return ar.z
+}
+
+// Bounds returns an empty rect for now.
+func (ar *Arrow) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func near(a, b geom.Pt) bool {
+	return math.Abs(a.X-b.X) < 1e-6 && math.Abs(a.Y-b.Y) < 1e-6
+}
+
+func TestArrow_Simple(t *testing.T) {
+	ar := &Arrow{
+		From:       geom.Pt{X: 10, Y: 10},
+		To:         geom.Pt{X: 0.5, Y: 0},
+		ToCoords:   CoordsAxes,
+		HeadLength: 10,
+		HeadWidth:  6,
+		ShrinkA:    2,
+		ShrinkB:    2,
+		LineWidth:  1,
+		Color:      render.Color{A: 1},
+	}
+	r := &segmentRecorder{}
+	ar.Draw(r, createTestDrawContext())
+
+	// Data (10,10) is pixel (150,350); axes (0.5,0) is pixel (100,450).
+	if len(r.paths) != 2 {
+		t.Fatalf("got %d paths, want shaft and head", len(r.paths))
+	}
+	shaft, head := r.paths[0], r.paths[1]
+	if r.paints[0].LineWidth != 1 || r.paints[1].Fill.A != 1 {
+		t.Errorf("paints %+v", r.paints)
+	}
+
+	length := math.Hypot(50, 100)
+	at := func(d float64) geom.Pt { return geom.Pt{X: 150 - 50*d/length, Y: 350 + 100*d/length} }
+	if !near(shaft.V[0], at(2)) {
+		t.Errorf("shaft starts at %v, want %v (ShrinkA)", shaft.V[0], at(2))
+	}
+	if !near(shaft.V[1], at(length-2-10)) {
+		t.Errorf("shaft ends at %v, want the head's base %v", shaft.V[1], at(length-12))
+	}
+	if !near(head.V[0], at(length-2)) {
+		t.Errorf("tip at %v, want %v (ShrinkB)", head.V[0], at(length-2))
+	}
+	if w := math.Hypot(head.V[1].X-head.V[2].X, head.V[1].Y-head.V[2].Y); math.Abs(w-6) > 1e-9 {
+		t.Errorf("head width %v, want 6", w)
+	}
+}
+
+func TestArrow_Arc3(t *testing.T) {
+	ar := &Arrow{From: geom.Pt{X: 0, Y: 5}, To: geom.Pt{X: 40, Y: 5}, Rad: 0.25, Style: ArrowWedge, HeadWidth: 4}
+	pts := ar.centerline(createTestDrawContext())
+
+	// From pixel (50,400) to (450,400): the curve's midpoint lies Rad/2 of
+	// the length off the chord, to the right of the direction (down).
+	if len(pts) != arrowCurveSteps+1 {
+		t.Fatalf("got %d points, want %d", len(pts), arrowCurveSteps+1)
+	}
+	if mid := pts[arrowCurveSteps/2]; !near(mid, geom.Pt{X: 250, Y: 450}) {
+		t.Errorf("midpoint %v, want (250, 450)", mid)
+	}
+
+	r := &segmentRecorder{}
+	ar.Draw(r, createTestDrawContext())
+	if len(r.paths) != 1 || r.paints[0].Stroke.A != 0 {
+		t.Fatalf("wedge must be a single filled path, got %d", len(r.paths))
+	}
+	// The wedge is HeadWidth wide at the tail and closes at the tip.
+	p := r.paths[0]
+	n := len(pts)
+	if w := math.Hypot(p.V[0].X-p.V[2*n-1].X, p.V[0].Y-p.V[2*n-1].Y); math.Abs(w-4) > 1e-9 {
+		t.Errorf("tail width %v, want 4", w)
+	}
+	if !near(p.V[n-1], p.V[n]) {
+		t.Errorf("wedge tip is open: %v vs %v", p.V[n-1], p.V[n])
+	}
+}
+
+func TestArrow_TooShort(t *testing.T) {
+	ar := &Arrow{From: geom.Pt{X: 1, Y: 1}, To: geom.Pt{X: 1.01, Y: 1}, ShrinkA: 2, ShrinkB: 2, HeadLength: 10}
+	r := &segmentRecorder{}
+	ar.Draw(r, createTestDrawContext())
+	if len(r.paths) != 0 {
+		t.Errorf("arrow shorter than its shrink drew %d paths", len(r.paths))
+	}
+}
@@ -1,7 +1,12 @@
 package core
 
 import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
 	"sort"
+	"strconv"
 
 	"matplotlib-go/color"
 	"matplotlib-go/internal/geom"
@@ -32,6 +37,34 @@ type DrawContext struct {
 	RC style.RC
 	// Clip is the axes pixel rectangle.
 	Clip geom.Rect
+	// Figure is the figure pixel rectangle, for CoordsFigure.
+	Figure geom.Rect
+}
+
+// Least pixel distances between major ticks that TickTarget aims for: three
+// label heights along x, where labels run side by side, and two along y, as
+// in matplotlib, at the default 12 px tick labels.
+const (
+	tickSpacingX = 36
+	tickSpacingY = 24
+)
+
+// TickTarget returns the number of major ticks that locators aim for along
+// the x or y axis, from the axis' length in pixels: up to 8 on full-size
+// axes and down to 2 on small ones such as sparklines. Axes without a
+// pixel extent get 8.
+func (ctx *DrawContext) TickTarget(isX bool) int {
+	t := ctx.DataToPixel.AxesToPixel
+	o := t.Apply(geom.Pt{})
+	end, spacing := t.Apply(geom.Pt{X: 1}), tickSpacingX
+	if !isX {
+		end, spacing = t.Apply(geom.Pt{Y: 1}), tickSpacingY
+	}
+	length := math.Hypot(end.X-o.X, end.Y-o.Y)
+	if length == 0 || math.IsNaN(length) {
+		return 8
+	}
+	return min(max(int(length/float64(spacing)), 2), 8)
 }
 
 // Transform2D wires x/y scales with an axes->pixel affine transform.
@@ -39,20 +72,89 @@ type Transform2D struct {
 	XScale      transform.Scale
 	YScale      transform.Scale
 	AxesToPixel transform.AffineT
+	Polar       *Polar      // non-nil for polar axes, see Polar
+	Post        transform.T // applied to the pixels afterwards, e.g. a rotation; nil for none
 }
 
 // Apply transforms a data-space point to pixel coordinates.
 func (t *Transform2D) Apply(p geom.Pt) geom.Pt {
-	u := t.XScale.Fwd(p.X)
-	v := t.YScale.Fwd(p.Y)
-	return t.AxesToPixel.Apply(geom.Pt{X: u, Y: v})
+	var q geom.Pt
+	if t.Polar != nil {
+		q = t.Polar.apply(t, p)
+	} else {
+		u := t.XScale.Fwd(p.X)
+		v := t.YScale.Fwd(p.Y)
+		q = t.AxesToPixel.Apply(geom.Pt{X: u, Y: v})
+	}
+	if t.Post != nil {
+		q = t.Post.Apply(q)
+	}
+	return q
+}
+
+// Invert maps a pixel back to data coordinates.
+func (t *Transform2D) Invert(p geom.Pt) (geom.Pt, bool) {
+	if t.Post != nil {
+		var ok bool
+		if p, ok = t.Post.Invert(p); !ok {
+			return geom.Pt{}, false
+		}
+	}
+	if t.Polar != nil {
+		return t.Polar.invert(t, p)
+	}
+	return transform.NewAxes2D(t.XScale, t.YScale, t.AxesToPixel).Invert(p)
+}
+
+// coords returns the transform from c to pixels.
+func (ctx *DrawContext) coords(c Coords) Transform2D {
+	unit := transform.NewLinear(0, 1)
+	switch c {
+	case CoordsAxes:
+		return Transform2D{XScale: unit, YScale: unit, AxesToPixel: ctx.DataToPixel.AxesToPixel}
+	case CoordsFigure:
+		return Transform2D{XScale: unit, YScale: unit, AxesToPixel: transform.NewAffine(axesToPixel(ctx.Figure))}
+	case CoordsPixel:
+		return Transform2D{XScale: unit, YScale: unit, AxesToPixel: transform.NewAffine(geom.Identity())}
+	}
+	return ctx.DataToPixel
+}
+
+// XAxisTransform maps x in data coordinates and y in axes fractions to
+// pixels, e.g. for marks spanning the height of the axes at a data x.
+func (ctx *DrawContext) XAxisTransform() transform.T {
+	data := ctx.DataToPixel
+	return transform.Blended{X: &data, Y: data.AxesToPixel}
+}
+
+// YAxisTransform maps x in axes fractions and y in data coordinates to
+// pixels, e.g. for labels at the right edge of the axes at a data y.
+func (ctx *DrawContext) YAxisTransform() transform.T {
+	data := ctx.DataToPixel
+	return transform.Blended{X: data.AxesToPixel, Y: &data}
 }
 
 // Figure is the root of the Artist tree. It contains Axes children.
 type Figure struct {
-	SizePx   geom.Pt
-	RC       style.RC
-	Children []*Axes
+	SizePx     geom.Pt
+	RC         style.RC
+	Children   []*Axes
+	Artists    []Artist // drawn above all axes, unclipped, see Figure.Add
+	Watermarks []*Watermark
+	Alt        AltText   // accessible name and description in document output such as SVG
+	hooks      drawHooks // callbacks, see OnPreDraw
+
+	legendHandlers map[reflect.Type]legendEntryFunc // legend samples of artist types, see RegisterLegendHandler
+
+	// Strict makes plot constructors and limit setters reject invalid
+	// input, such as x and y of different lengths, empty data, NaN limits or
+	// colors outside [0, 1], instead of truncating, skipping or ignoring it:
+	// the call returns nil or leaves the axes unchanged and the error is
+	// recorded for Err. The save functions then fail with it.
+	Strict bool
+	errs   []error               `spec:"-"` // validation errors, see Err
+	rngs   map[string]*rand.Rand `spec:"-"` // random streams, see Rand
+	design *figureDesign         `spec:"-"` // size and fonts before the first Resize
 }
 
 // NewFigure creates a new figure with pixel dimensions and optional style overrides.
@@ -72,12 +174,30 @@ type Axes struct {
 	XScale       transform.Scale
 	YScale       transform.Scale
 	Artists      []Artist
-	zsorted      bool
+	zsorted      bool                       `spec:"-"` // draw-time cache, not part of the figure content
+	hidden       map[Artist]bool            // artists skipped when drawing, see SetVisible
+	clips        map[Artist]*Patch          // per-artist clip shapes, see SetClipPath
+	transforms   map[Artist]ArtistTransform // per-artist coordinates, see SetTransform
+	alts         map[Artist]AltText         // per-artist accessible names, see SetAltText
+	hooks        drawHooks                  // callbacks, see OnPreDraw
+	fig          *Figure                    `spec:"-"` // owner, for its Strict setting
+	rngs         map[string]*rand.Rand      `spec:"-"` // random streams outside a figure, see Axes.rand
+
+	// Text around the axes (empty => not drawn)
+	Title  string  // above the axes
+	XLabel string  // below the x-axis
+	YLabel string  // left of the y-axis
+	Panel  string  // panel letter above the top-left corner, see Figure.LabelPanels
+	Alt    AltText // accessible name and description in document output such as SVG
+
+	// Projection (nil => cartesian)
+	Polar *Polar
 
 	// Axis control
-	XAxis *Axis // bottom x-axis
-	YAxis *Axis // left y-axis
-	
+	XAxis     *Axis   // bottom x-axis
+	YAxis     *Axis   // left y-axis
+	OtherAxes []*Axis // further axes, e.g. along the top or right (see AddAxis)
+
 	// Color cycling for multiple series
 	ColorCycle *color.ColorCycle
 }
@@ -98,45 +218,191 @@ func (f *Figure) AddAxes(r geom.Rect, opts ...style.Option) *Axes {
 		XAxis:        NewXAxis(),
 		YAxis:        NewYAxis(),
 		ColorCycle:   color.NewDefaultColorCycle(),
+		fig:          f,
 	}
 	f.Children = append(f.Children, ax)
+	if ax.rc().Grid {
+		ax.AddXGrid()
+		ax.AddYGrid()
+	}
 	return ax
 }
 
+// Add registers a figure-level Artist. It is drawn after all axes without
+// their clipping, in a DrawContext whose data coordinates are figure
+// fractions: (0,0) lower left, (1,1) upper right.
+func (f *Figure) Add(art Artist) { f.Artists = append(f.Artists, art) }
+
 // Add registers an Artist with the Axes.
 func (a *Axes) Add(art Artist) { a.Artists = append(a.Artists, art); a.zsorted = false }
 
+// SetVisible shows or hides an artist of the axes without removing it.
+// Hidden artists keep their legend entry. Artists that cannot be map keys,
+// such as ArtistFunc, are always visible.
+func (a *Axes) SetVisible(art Artist, visible bool) {
+	if !trackable(art) {
+		return
+	}
+	if visible {
+		delete(a.hidden, art)
+		return
+	}
+	if a.hidden == nil {
+		a.hidden = make(map[Artist]bool)
+	}
+	a.hidden[art] = true
+}
+
+// Visible reports whether art is drawn, see SetVisible.
+func (a *Axes) Visible(art Artist) bool {
+	return !trackable(art) || !a.hidden[art]
+}
+
+// SetClipPath clips art to the shape of clip in addition to the axes
+// rectangle, e.g. a fill to a circle or a country outline. The patch is not
+// drawn unless it is also added to the axes. A nil clip removes the clip
+// path; artists that cannot be map keys, such as ArtistFunc, are not
+// clipped. Renderers whose ClipPath is a no-op draw the artist unclipped.
+func (a *Axes) SetClipPath(art Artist, clip *Patch) {
+	if !trackable(art) {
+		return
+	}
+	if clip == nil {
+		delete(a.clips, art)
+		return
+	}
+	if a.clips == nil {
+		a.clips = make(map[Artist]*Patch)
+	}
+	a.clips[art] = clip
+}
+
+// ClipPath returns the clip shape of art set by SetClipPath, or nil.
+func (a *Axes) ClipPath(art Artist) *Patch {
+	if !trackable(art) {
+		return nil
+	}
+	return a.clips[art]
+}
+
+// ArtistTransform replaces the data coordinates of an artist, e.g. for
+// decorations that should not move with the data limits.
+type ArtistTransform struct {
+	Coords Coords      // coordinate system of the artist's points
+	Post   transform.T // applied to the pixels afterwards, e.g. transform.Rotation; nil for none
+}
+
+func (tr ArtistTransform) isDefault() bool { return tr.Coords == CoordsData && tr.Post == nil }
+
+// SetTransform makes art interpret its points in tr.Coords and applies
+// tr.Post to the result, instead of plain data coordinates. The zero
+// ArtistTransform restores the default. Artists that cannot be map keys,
+// such as ArtistFunc, always use data coordinates.
+//
+//	ax.SetTransform(badge, core.ArtistTransform{Coords: core.CoordsAxes})
+func (a *Axes) SetTransform(art Artist, tr ArtistTransform) {
+	if !trackable(art) {
+		return
+	}
+	if tr.isDefault() {
+		delete(a.transforms, art)
+		return
+	}
+	if a.transforms == nil {
+		a.transforms = make(map[Artist]ArtistTransform)
+	}
+	a.transforms[art] = tr
+}
+
+// Transform returns the transform of art set by SetTransform.
+func (a *Axes) Transform(art Artist) ArtistTransform {
+	if !trackable(art) {
+		return ArtistTransform{}
+	}
+	return a.transforms[art]
+}
+
+// artistContext returns the DrawContext art is drawn in: ctx, or a copy
+// with the artist's own transform.
+func (a *Axes) artistContext(ctx *DrawContext, art Artist) *DrawContext {
+	tr := a.Transform(art)
+	if tr.isDefault() {
+		return ctx
+	}
+	c := *ctx
+	c.DataToPixel = ctx.coords(tr.Coords)
+	c.DataToPixel.Post = tr.Post
+	return &c
+}
+
 // SetXLim sets the x-axis limits.
 func (a *Axes) SetXLim(min, max float64) {
+	if !a.validate(checkLimits("SetXLim", min, max, false)) {
+		return
+	}
 	a.XScale = transform.NewLinear(min, max)
 }
 
 // SetYLim sets the y-axis limits.
 func (a *Axes) SetYLim(min, max float64) {
+	if !a.validate(checkLimits("SetYLim", min, max, false)) {
+		return
+	}
 	a.YScale = transform.NewLinear(min, max)
 }
 
 // SetXLimLog sets the x-axis to logarithmic scale with given limits.
 func (a *Axes) SetXLimLog(min, max, base float64) {
+	if !a.validate(checkLimits("SetXLimLog", min, max, true)) {
+		return
+	}
 	a.XScale = transform.NewLog(min, max, base)
 	if a.XAxis != nil {
 		a.XAxis.Locator = LogLocator{Base: base, Minor: false}
 		a.XAxis.Formatter = LogFormatter{Base: base}
+		if a.XAxis.MinorLocator != nil {
+			a.XAxis.setMinor(a.XScale, true)
+		}
 	}
 }
 
 // SetYLimLog sets the y-axis to logarithmic scale with given limits.
 func (a *Axes) SetYLimLog(min, max, base float64) {
+	if !a.validate(checkLimits("SetYLimLog", min, max, true)) {
+		return
+	}
 	a.YScale = transform.NewLog(min, max, base)
 	if a.YAxis != nil {
 		a.YAxis.Locator = LogLocator{Base: base, Minor: false}
 		a.YAxis.Formatter = LogFormatter{Base: base}
+		if a.YAxis.MinorLocator != nil {
+			a.YAxis.setMinor(a.YScale, true)
+		}
 	}
 }
 
-// AddGrid adds grid lines for the specified axis.
+// MinorTicksOn adds minor ticks to the x and y axes, like matplotlib's
+// minorticks_on: at 2, 3, ..., 9 times each power on log scales and by
+// AutoMinorLocator on others. Switching between linear and log limits
+// later keeps them. Use Grid.Minor for minor grid lines.
+func (a *Axes) MinorTicksOn() {
+	a.XAxis.setMinor(a.XScale, true)
+	a.YAxis.setMinor(a.YScale, true)
+}
+
+// MinorTicksOff removes the minor ticks of the x and y axes.
+func (a *Axes) MinorTicksOff() {
+	a.XAxis.setMinor(a.XScale, false)
+	a.YAxis.setMinor(a.YScale, false)
+}
+
+// AddGrid adds grid lines for the specified axis, styled by the RC.
 func (a *Axes) AddGrid(axis AxisSide) *Grid {
 	grid := NewGrid(axis)
+	grid.axes = a
+	if rc := a.rc(); rc.GridLineWidth > 0 {
+		grid.Color, grid.LineWidth = rcColor(rc.GridColor), rc.GridLineWidth
+	}
 	a.Add(grid)
 	return grid
 }
@@ -190,52 +456,232 @@ func (a *Axes) effectiveRC(f *Figure) style.RC {
 	return f.RC
 }
 
+// rc returns the RC that artist defaults are taken from when they are
+// created: the axes' own, its figure's, or style.Default outside a figure.
+func (a *Axes) rc() style.RC {
+	if a.RC != nil {
+		return *a.RC
+	}
+	if a.fig != nil {
+		return a.fig.RC
+	}
+	return style.Default
+}
+
+func rcColor(c [4]float64) render.Color { return render.Color{R: c[0], G: c[1], B: c[2], A: c[3]} }
+
+// drawContext builds the DrawContext for this Axes inside the Figure.
+func (a *Axes) drawContext(f *Figure) *DrawContext {
+	px := a.layout(f)
+	return &DrawContext{
+		DataToPixel: Transform2D{
+			XScale:      a.XScale,
+			YScale:      a.YScale,
+			AxesToPixel: transform.NewAffine(axesToPixel(px)),
+			Polar:       a.Polar,
+		},
+		RC:     a.effectiveRC(f),
+		Clip:   px,
+		Figure: geom.Rect{Max: f.SizePx},
+	}
+}
+
+// drawContext builds the DrawContext of figure-level artists.
+func (f *Figure) drawContext() *DrawContext {
+	px := geom.Rect{Max: f.SizePx}
+	return &DrawContext{
+		DataToPixel: Transform2D{
+			XScale:      transform.NewLinear(0, 1),
+			YScale:      transform.NewLinear(0, 1),
+			AxesToPixel: transform.NewAffine(axesToPixel(px)),
+		},
+		RC:     f.RC,
+		Clip:   px,
+		Figure: px,
+	}
+}
+
+// sortArtists orders the artists by z, keeping insertion order for ties.
+func (a *Axes) sortArtists() {
+	if a.zsorted {
+		return
+	}
+	sort.SliceStable(a.Artists, func(i, j int) bool {
+		zi, zj := a.Artists[i].Z(), a.Artists[j].Z()
+		if zi == zj {
+			return i < j
+		}
+		return zi < zj
+	})
+	a.zsorted = true
+}
+
+// drawOrder returns the artists merged with the axes by z. Axes come after
+// artists of the same z.
+func (a *Axes) drawOrder() []Artist {
+	axes := a.AxisList()
+	if len(axes) == 0 {
+		return a.Artists
+	}
+	out := make([]Artist, 0, len(a.Artists)+len(axes))
+	out = append(out, a.Artists...)
+	for _, ax := range axes {
+		out = append(out, ax)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Z() < out[j].Z() })
+	return out
+}
+
+// axisIDs returns the group id and class of each axis: "xaxis" and "yaxis"
+// for XAxis and YAxis, numbered ones such as "xaxis1" for OtherAxes.
+func (a *Axes) axisIDs(prefix string) map[Artist][2]string {
+	ids := make(map[Artist][2]string)
+	counts := map[string]int{}
+	for _, ax := range a.AxisList() {
+		kind := "yaxis"
+		if ax.isX() {
+			kind = "xaxis"
+		}
+		id := prefix + "-" + kind
+		if n := counts[kind]; n > 0 {
+			id += strconv.Itoa(n)
+		}
+		counts[kind]++
+		ids[ax] = [2]string{id, "axis " + kind}
+	}
+	return ids
+}
+
 // DrawFigure performs a traversal and draws the figure into the renderer.
 func DrawFigure(fig *Figure, r render.Renderer) {
+	drawFigure(fig, r, nil, nil)
+}
+
+// drawFigure draws fig into r. If region is non-nil, drawing is clipped to
+// it and artists for which include returns false are skipped.
+func drawFigure(fig *Figure, r render.Renderer, region *geom.Rect, include func(Artist) bool) {
 	vp := geom.Rect{Min: geom.Pt{X: 0, Y: 0}, Max: geom.Pt{X: fig.SizePx.X, Y: fig.SizePx.Y}}
 	_ = r.Begin(vp)
 	defer r.End()
+	if region != nil {
+		r.ClipRect(*region)
+	}
+
+	if tc, ok := r.(render.TextConfigurer); ok {
+		tc.SetTextOptions(textOptions(fig.RC))
+	}
+	grouper, _ := r.(render.Grouper)
+	describer, _ := r.(render.Describer)
+	if describer != nil {
+		fig.describe(describer)
+	}
+	vector := false
+	if v, ok := r.(render.VectorOutput); ok {
+		vector = v.VectorOutput()
+	}
 
-	for _, ax := range fig.Children {
+	figCtx := fig.drawContext()
+	runHooks(fig.hooks.pre, r, figCtx)
+	drawWatermarks(fig, r, false)
+	for i, ax := range fig.Children {
 		px := ax.layout(fig)
+		axesID := fmt.Sprintf("axes%d", i)
+		if grouper != nil {
+			grouper.BeginGroup(axesID, "axes")
+			if describer != nil {
+				ax.describe(describer, i)
+			}
+		}
 		r.Save()
 		r.ClipRect(px)
 
 		// Build DrawContext with composed transform
-		ctx := &DrawContext{
-			DataToPixel: Transform2D{
-				XScale:      ax.XScale,
-				YScale:      ax.YScale,
-				AxesToPixel: transform.NewAffine(axesToPixel(px)),
-			},
-			RC:   ax.effectiveRC(fig),
-			Clip: px,
+		ctx := ax.drawContext(fig)
+
+		runHooks(ax.hooks.pre, r, ctx)
+		ax.sortArtists()
+		// Draw the artists and axes by z-order; axes default to above data
+		names := groupNamer{prefix: axesID}
+		axisIDs := ax.axisIDs(axesID)
+		for _, art := range ax.drawOrder() {
+			if !ax.Visible(art) || include != nil && !include(art) {
+				continue
+			}
+			if grouper != nil {
+				if id, ok := axisIDs[art]; ok {
+					grouper.BeginGroup(id[0], id[1])
+				} else {
+					grouper.BeginGroup(names.next(art))
+				}
+				if describer != nil {
+					ax.describeArtist(describer, art)
+				}
+			}
+			clip := ax.ClipPath(art)
+			if clip != nil {
+				r.Save()
+				r.ClipPath(clip.pixelPath(ctx))
+			}
+			actx := ax.artistContext(ctx, art)
+			if vector && isRasterized(art) {
+				drawRasterized(r, art, actx)
+			} else {
+				art.Draw(r, actx)
+			}
+			if clip != nil {
+				r.Restore()
+			}
+			if grouper != nil {
+				grouper.EndGroup()
+			}
 		}
 
-		if !ax.zsorted {
-			sort.SliceStable(ax.Artists, func(i, j int) bool {
-				zi, zj := ax.Artists[i].Z(), ax.Artists[j].Z()
-				if zi == zj {
-					return i < j
-				}
-				return zi < zj
-			})
-			ax.zsorted = true
+		runHooks(ax.hooks.post, r, ctx)
+		r.Restore()
+
+		// Title and axis labels sit outside the axes clip
+		if ax.hasLabels() {
+			if grouper != nil {
+				grouper.BeginGroup(axesID+"-labels", "labels")
+			}
+			ax.drawLabels(r, ctx)
+			if grouper != nil {
+				grouper.EndGroup()
+			}
 		}
-		// Draw all artists (data) first
-		for _, art := range ax.Artists {
-			art.Draw(r, ctx)
+		if grouper != nil {
+			grouper.EndGroup()
 		}
+	}
 
-		// Draw axes on top of data
-		if ax.XAxis != nil {
-			ax.XAxis.Draw(r, ctx)
+	// Figure-level artists, e.g. connectors between axes
+	sort.SliceStable(fig.Artists, func(i, j int) bool { return fig.Artists[i].Z() < fig.Artists[j].Z() })
+	names := groupNamer{prefix: "figure"}
+	for _, art := range fig.Artists {
+		if include != nil && !include(art) {
+			continue
 		}
-		if ax.YAxis != nil {
-			ax.YAxis.Draw(r, ctx)
+		if grouper != nil {
+			grouper.BeginGroup(names.next(art))
+		}
+		if vector && isRasterized(art) {
+			drawRasterized(r, art, figCtx)
+		} else {
+			art.Draw(r, figCtx)
+		}
+		if grouper != nil {
+			grouper.EndGroup()
 		}
-		r.Restore()
 	}
+	drawWatermarks(fig, r, true)
+	runHooks(fig.hooks.post, r, figCtx)
+}
+
+// textOptions returns the text font and rasterization settings of rc; unknown
+// hinting names leave the choice to the backend.
+func textOptions(rc style.RC) render.TextOptions {
+	hinting, _ := render.ParseHinting(rc.TextHinting)
+	return render.TextOptions{FontKey: rc.FontKey, Hinting: hinting, Subpixel: rc.TextSubpixel}
 }
 
 // axesToPixel returns an affine mapping [0..1]^2 (axes space) -> pixel rect.
@@ -1,11 +1,13 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
 	"matplotlib-go/style"
+	"matplotlib-go/transform"
 )
 
 func TestRCEffectivePrecedence(t *testing.T) {
@@ -31,6 +33,42 @@ func (a zArtist) Draw(_ render.Renderer, _ *DrawContext) { *a.hit = append(*a.hi
 func (a zArtist) Z() float64                             { return a.z }
 func (a zArtist) Bounds(*DrawContext) geom.Rect          { return geom.Rect{} }
 
+func TestRCArtistDefaults(t *testing.T) {
+	fig := NewFigure(400, 300,
+		style.WithLineWidth(3), style.WithMarkerSize(5), style.WithGrid(true),
+		style.WithGridStyle(0.1, 0.2, 0.3, 1, 2), style.WithLegendFrame(false, 0),
+		style.WithSaveDPI(300), style.WithSaveFacecolor(0, 0, 0, 1))
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	if len(ax.Artists) != 2 {
+		t.Fatalf("got %d artists, want x and y grids", len(ax.Artists))
+	}
+	if g := ax.Artists[0].(*Grid); g.Color != (render.Color{R: 0.1, G: 0.2, B: 0.3, A: 1}) || g.LineWidth != 2 {
+		t.Errorf("grid style %v %v", g.Color, g.LineWidth)
+	}
+	if l := ax.Plot([]float64{0, 1}, []float64{0, 1}); l.W != 3 {
+		t.Errorf("line width %v, want 3", l.W)
+	}
+	if s := ax.Scatter([]float64{0}, []float64{0}); s.Size != 5 {
+		t.Errorf("marker size %v, want 5", s.Size)
+	}
+	if l := ax.AddLegend(); l.Background.A != 0 || l.EdgeColor.A != 0 || l.FontSize != 12 {
+		t.Errorf("legend frame %v %v size %v", l.Background, l.EdgeColor, l.FontSize)
+	}
+	cfg := fig.RendererConfig()
+	if cfg.Width != 400 || cfg.DPI != 300 || cfg.Background != (render.Color{A: 1}) {
+		t.Errorf("renderer config %+v", cfg)
+	}
+
+	// Axes options override the figure's; unset save settings fall back.
+	own := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}}, style.WithGrid(false), style.WithLineWidth(1))
+	if len(own.Artists) != 0 || own.Plot([]float64{0, 1}, []float64{0, 1}).W != 1 {
+		t.Error("axes RC not used for defaults")
+	}
+	if cfg := NewFigure(10, 10).RendererConfig(); cfg.DPI != style.Default.DPI || cfg.Background != (render.Color{R: 1, G: 1, B: 1, A: 1}) {
+		t.Errorf("default renderer config %+v", cfg)
+	}
+}
+
 func TestZOrderStableSortAndTraversal(t *testing.T) {
 	fig := NewFigure(100, 100)
 	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0, Y: 0}, Max: geom.Pt{X: 1, Y: 1}})
@@ -56,3 +94,80 @@ func TestZOrderStableSortAndTraversal(t *testing.T) {
 		}
 	}
 }
+
+func TestDrawContextBlendedTransforms(t *testing.T) {
+	ctx := createTestDrawContext() // data 0..10 -> pixels 50..150 (x), 450..350 (y)
+
+	// x at data 4, y at the top of the axes
+	if got := ctx.XAxisTransform().Apply(geom.Pt{X: 4, Y: 1}); got != (geom.Pt{X: 90, Y: 350}) {
+		t.Errorf("XAxisTransform = %v, want (90, 350)", got)
+	}
+	// x at the right edge of the axes, y at data 5
+	if got := ctx.YAxisTransform().Apply(geom.Pt{X: 1, Y: 5}); got != (geom.Pt{X: 150, Y: 400}) {
+		t.Errorf("YAxisTransform = %v, want (150, 400)", got)
+	}
+	if back, ok := ctx.DataToPixel.Invert(geom.Pt{X: 90, Y: 400}); !ok || back != (geom.Pt{X: 4, Y: 5}) {
+		t.Errorf("Invert = %v, %v; want (4, 5)", back, ok)
+	}
+}
+
+func TestAxesSetTransform(t *testing.T) {
+	fig := NewFigure(200, 100)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.5}, Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.SetXLim(-50, 50)
+	badge := &Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1, Col: render.Color{A: 1}}
+	ax.Add(badge)
+
+	draw := func() geom.Path {
+		r := &segmentRecorder{}
+		DrawFigure(fig, r)
+		return r.paths[0]
+	}
+
+	ax.SetTransform(badge, ArtistTransform{Coords: CoordsAxes})
+	if p := draw(); p.V[0] != (geom.Pt{X: 100, Y: 100}) || p.V[1] != (geom.Pt{X: 200, Y: 0}) {
+		t.Errorf("axes coordinates drawn at %v, want the axes corners", p.V)
+	}
+
+	ax.SetTransform(badge, ArtistTransform{Coords: CoordsFigure})
+	if p := draw(); p.V[0] != (geom.Pt{X: 0, Y: 100}) || p.V[1] != (geom.Pt{X: 200, Y: 0}) {
+		t.Errorf("figure coordinates drawn at %v, want the figure corners", p.V)
+	}
+
+	// Half a turn about the figure center swaps the corners.
+	ax.SetTransform(badge, ArtistTransform{Coords: CoordsFigure, Post: transform.Rotation(math.Pi, geom.Pt{X: 100, Y: 50})})
+	if p := draw(); math.Abs(p.V[0].X-200) > 1e-9 || math.Abs(p.V[0].Y) > 1e-9 {
+		t.Errorf("rotated start at %v, want (200, 0)", p.V[0])
+	}
+
+	ax.SetTransform(badge, ArtistTransform{})
+	if got := ax.Transform(badge); got.Coords != CoordsData || got.Post != nil {
+		t.Errorf("zero transform not restored: %+v", got)
+	}
+	if p := draw(); p.V[0] != (geom.Pt{X: 150, Y: 100}) {
+		t.Errorf("data (0,0) drawn at %v, want (150, 100)", p.V[0])
+	}
+}
+
+type textOptionsRecorder struct {
+	render.NullRenderer
+	opts []render.TextOptions
+}
+
+func (r *textOptionsRecorder) SetTextOptions(opts render.TextOptions) { r.opts = append(r.opts, opts) }
+
+func TestDrawFigureTextOptions(t *testing.T) {
+	var r textOptionsRecorder
+	DrawFigure(NewFigure(100, 100, style.WithTextRendering("slight", true)), &r)
+	want := render.TextOptions{FontKey: style.Default.FontKey, Hinting: render.HintingSlight, Subpixel: true}
+	if len(r.opts) != 1 || r.opts[0] != want {
+		t.Errorf("text options %v, want one call with %v", r.opts, want)
+	}
+
+	r.opts = nil
+	DrawFigure(NewFigure(100, 100, style.WithTextRendering("wobbly", false)), &r)
+	if len(r.opts) != 1 || r.opts[0] != (render.TextOptions{FontKey: style.Default.FontKey}) {
+		t.Errorf("unknown hinting gave %v, want the backend default", r.opts)
+	}
+}
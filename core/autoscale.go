@@ -0,0 +1,46 @@
+package core
+
+import (
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+// AutoScale sets the limits of linear axes to the data of the axes' lines,
+// scatters, bars, fills and heatmaps, widened by margin times the span on
+// each side (0.05 as in matplotlib). Bars and fills keep their baseline
+// in view. Axes without data keep their limits; log and polar axes are
+// left unchanged.
+func (a *Axes) AutoScale(margin float64) {
+	if a.Polar != nil {
+		return
+	}
+	xr, yr := emptyRange(), emptyRange()
+	for _, art := range a.Artists {
+		switch art := art.(type) {
+		case *Line2D:
+			xr, yr = addPoints(xr, yr, art.points())
+		case *Scatter2D:
+			xr, yr = addPoints(xr, yr, art.points())
+		case *Bar2D, *Hist, *Fill2D, *Heatmap:
+			b := art.Bounds(nil)
+			if b != (geom.Rect{}) {
+				xr, yr = xr.add(b.Min.X, b.Max.X), yr.add(b.Min.Y, b.Max.Y)
+			}
+		}
+	}
+	if _, linear := a.XScale.(transform.Linear); linear && xr.lo <= xr.hi {
+		a.SetXLim(xr.margin(margin))
+	}
+	if _, linear := a.YScale.(transform.Linear); linear && yr.lo <= yr.hi {
+		a.SetYLim(yr.margin(margin))
+	}
+}
+
+// addPoints widens the x and y ranges by the finite coordinates of pts.
+func addPoints(xr, yr valueRange, pts Points) (valueRange, valueRange) {
+	for i := range pts.Len() {
+		p := pts.At(i)
+		xr, yr = xr.add(p.X), yr.add(p.Y)
+	}
+	return xr, yr
+}
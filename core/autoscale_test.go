@@ -0,0 +1,36 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestAxesAutoScale(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Plot([]float64{0, 10}, []float64{2, 4})
+	ax.Bar([]float64{5}, []float64{6}, WithWidth(2))
+	ax.Scatter([]float64{12}, []float64{math.NaN()})
+	ax.AutoScale(0.1)
+
+	// x: 0..12 from the line and scatter; y: 0..6 from the bar baseline
+	// and top.
+	xmin, xmax := ax.XScale.Domain()
+	ymin, ymax := ax.YScale.Domain()
+	if math.Abs(xmin+1.2) > 1e-9 || math.Abs(xmax-13.2) > 1e-9 || math.Abs(ymin+0.6) > 1e-9 || math.Abs(ymax-6.6) > 1e-9 {
+		t.Errorf("limits x %v..%v y %v..%v", xmin, xmax, ymin, ymax)
+	}
+
+	logAx := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	logAx.SetYLimLog(1, 1000, 10)
+	logAx.Plot([]float64{0, 1}, []float64{5, 50})
+	logAx.AutoScale(0.05)
+	if ymin, ymax := logAx.YScale.Domain(); ymin != 1 || ymax != 1000 {
+		t.Errorf("log limits changed to %v..%v", ymin, ymax)
+	}
+	if xmin, xmax := logAx.XScale.Domain(); xmin != -0.05 || xmax != 1.05 {
+		t.Errorf("linear x limits %v..%v", xmin, xmax)
+	}
+}
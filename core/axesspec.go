@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"matplotlib-go/transform"
+)
+
+// AxesSpec collects common Axes settings for Set. Zero fields are left
+// unchanged, so only the settings of interest need to be given:
+//
+//	ax.Set(core.AxesSpec{
+//		Title:  "Response",
+//		XLabel: "time [s]",
+//		XLim:   [2]float64{0, 10},
+//		YScale: "log",
+//		Grid:   true,
+//		Legend: true,
+//	})
+type AxesSpec struct {
+	Title  string
+	XLabel string
+	YLabel string
+
+	// XLim and YLim set the view limits; {0, 0} leaves them unchanged.
+	XLim [2]float64
+	YLim [2]float64
+
+	// XScale and YScale select "linear", "log", "symlog", "power" or
+	// "time"; "" leaves the scale type unchanged. A scale change keeps the
+	// current limits unless new ones are given.
+	XScale string
+	YScale string
+
+	// XScaleParams and YScaleParams set the parameters of the scale; zero
+	// fields keep those of the current scale if it is of the same type.
+	XScaleParams ScaleParams
+	YScaleParams ScaleParams
+
+	Grid   bool // add x and y grid lines if the axes has none
+	Legend bool // add a legend if the axes has none
+}
+
+// ScaleParams are the parameters of the non-linear scales of AxesSpec and
+// AxesData. Zero fields take the defaults of matplotlib.
+type ScaleParams struct {
+	Base      float64 `json:"base,omitempty"`      // log and symlog base, 0 for 10
+	LinThresh float64 `json:"linthresh,omitempty"` // half width of the linear range of symlog, 0 for 2
+	Exp       float64 `json:"exp,omitempty"`       // power exponent, 0 for 0.5
+}
+
+// or returns p with its zero fields taken from q.
+func (p ScaleParams) or(q ScaleParams) ScaleParams {
+	if p.Base == 0 {
+		p.Base = q.Base
+	}
+	if p.LinThresh == 0 {
+		p.LinThresh = q.LinThresh
+	}
+	if p.Exp == 0 {
+		p.Exp = q.Exp
+	}
+	return p
+}
+
+// Set applies the non-zero fields of spec, mirroring matplotlib's Axes.set.
+// Invalid settings return an error and leave the axes unchanged.
+func (a *Axes) Set(spec AxesSpec) error {
+	xs, err := specScale("x", a.XScale, spec.XScale, spec.XScaleParams, spec.XLim)
+	if err != nil {
+		return err
+	}
+	ys, err := specScale("y", a.YScale, spec.YScale, spec.YScaleParams, spec.YLim)
+	if err != nil {
+		return err
+	}
+
+	if spec.Title != "" {
+		a.Title = spec.Title
+	}
+	if spec.XLabel != "" {
+		a.XLabel = spec.XLabel
+	}
+	if spec.YLabel != "" {
+		a.YLabel = spec.YLabel
+	}
+
+	a.setScale(a.XAxis, &a.XScale, xs)
+	a.setScale(a.YAxis, &a.YScale, ys)
+
+	if spec.Grid && !a.hasArtist(func(art Artist) bool { _, ok := art.(*Grid); return ok }) {
+		a.AddXGrid()
+		a.AddYGrid()
+	}
+	if spec.Legend && !a.hasArtist(func(art Artist) bool { _, ok := art.(*Legend); return ok }) {
+		a.AddLegend()
+	}
+	return nil
+}
+
+// specScale returns the scale that results from applying a scale name,
+// parameters and limits to cur. Without a name, cur keeps its type.
+func specScale(axis string, cur transform.Scale, name string, p ScaleParams, lim [2]float64) (transform.Scale, error) {
+	if name == "" && p == (ScaleParams{}) && lim == [2]float64{} {
+		return cur, nil
+	}
+	min, max := cur.Domain()
+	if lim != [2]float64{} {
+		min, max = lim[0], lim[1]
+		if min == max || math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+			return nil, fmt.Errorf("invalid %slim %v", axis, lim)
+		}
+	}
+
+	curName, curParams := scaleKind(cur)
+	if name == "" {
+		name = curName
+	}
+	if name == curName {
+		p = p.or(curParams)
+	}
+	p = p.or(ScaleParams{Base: 10, LinThresh: 2, Exp: 0.5})
+	switch name {
+	case "linear":
+		return transform.NewLinear(min, max), nil
+	case "log":
+		if min <= 0 || max <= 0 {
+			return nil, fmt.Errorf("%sscale log needs positive limits, got [%v, %v]", axis, min, max)
+		}
+		if !(p.Base > 1) || math.IsInf(p.Base, 0) {
+			return nil, fmt.Errorf("%sscale log needs a finite base above 1, got %v", axis, p.Base)
+		}
+		return transform.NewLog(min, max, p.Base), nil
+	case "symlog":
+		if !(p.Base > 1) || math.IsInf(p.Base, 0) || !(p.LinThresh > 0) || math.IsInf(p.LinThresh, 0) {
+			return nil, fmt.Errorf("%sscale symlog needs a finite base above 1 and a positive linthresh, got %v and %v", axis, p.Base, p.LinThresh)
+		}
+		return transform.NewSymLog(min, max, p.LinThresh, p.Base), nil
+	case "power":
+		if !(p.Exp > 0) || math.IsInf(p.Exp, 0) {
+			return nil, fmt.Errorf("%sscale power needs a positive exponent, got %v", axis, p.Exp)
+		}
+		return transform.NewPower(min, max, p.Exp), nil
+	case "time":
+		return transform.Time{Min: min, Max: max}, nil
+	}
+	return nil, fmt.Errorf("unknown %sscale %q", axis, name)
+}
+
+// scaleKind returns the AxesSpec name and parameters of s, "linear" for
+// scales of other types.
+func scaleKind(s transform.Scale) (string, ScaleParams) {
+	switch s := s.(type) {
+	case transform.Log:
+		return "log", ScaleParams{Base: s.Base}
+	case transform.SymLog:
+		return "symlog", ScaleParams{Base: s.Base, LinThresh: s.LinThresh}
+	case transform.Power:
+		return "power", ScaleParams{Exp: s.Exp}
+	case transform.Time:
+		return "time", ScaleParams{}
+	}
+	return "linear", ScaleParams{}
+}
+
+// setScale installs s and switches the axis ticks to those of its type
+// when the scale type or parameters change.
+func (a *Axes) setScale(axis *Axis, dst *transform.Scale, s transform.Scale) {
+	oldName, oldParams := scaleKind(*dst)
+	*dst = s
+	if axis == nil {
+		return
+	}
+	name, p := scaleKind(s)
+	if name == oldName && p == oldParams {
+		return
+	}
+	switch name {
+	case "log":
+		axis.Locator = LogLocator{Base: p.Base, Minor: false}
+		axis.Formatter = LogFormatter{Base: p.Base}
+	case "symlog":
+		axis.Locator = SymLogLocator{Base: p.Base, LinThresh: p.LinThresh}
+		axis.Formatter = SymLogFormatter{Base: p.Base}
+	case "time":
+		axis.Locator = DateLocator{}
+		axis.Formatter = DateFormatter{}
+	default:
+		axis.Locator = LinearLocator{}
+		axis.Formatter = ScalarFormatter{Prec: 3}
+	}
+	if axis.MinorLocator != nil {
+		axis.setMinor(s, true)
+	}
+}
+
+// hasArtist reports whether any artist of the axes matches.
+func (a *Axes) hasArtist(match func(Artist) bool) bool {
+	for _, art := range a.Artists {
+		if match(art) {
+			return true
+		}
+	}
+	return false
+}
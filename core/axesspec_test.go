@@ -0,0 +1,132 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestAxesSet(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+
+	err := ax.Set(AxesSpec{
+		Title:  "T",
+		XLabel: "x",
+		YLabel: "y",
+		XLim:   [2]float64{-1, 1},
+		YLim:   [2]float64{1, 1000},
+		YScale: "log",
+		Grid:   true,
+		Legend: true,
+	})
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ax.Title != "T" || ax.XLabel != "x" || ax.YLabel != "y" {
+		t.Errorf("labels = %q %q %q", ax.Title, ax.XLabel, ax.YLabel)
+	}
+	if got := ax.XScale; got != transform.NewLinear(-1, 1) {
+		t.Errorf("XScale = %#v", got)
+	}
+	if got := ax.YScale; got != transform.NewLog(1, 1000, 10) {
+		t.Errorf("YScale = %#v", got)
+	}
+	if _, ok := ax.YAxis.Locator.(LogLocator); !ok {
+		t.Errorf("y locator = %T, want LogLocator", ax.YAxis.Locator)
+	}
+
+	// Zero fields leave settings alone; Grid and Legend are not duplicated.
+	if err := ax.Set(AxesSpec{Grid: true, Legend: true, XLim: [2]float64{0, 5}}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ax.Title != "T" || ax.YScale != transform.NewLog(1, 1000, 10) {
+		t.Errorf("unset fields changed: title %q, YScale %#v", ax.Title, ax.YScale)
+	}
+	if ax.XScale != transform.NewLinear(0, 5) {
+		t.Errorf("XScale = %#v", ax.XScale)
+	}
+	if n := len(ax.Artists); n != 3 {
+		t.Errorf("got %d artists, want 2 grids and a legend", n)
+	}
+
+	// Switching back to linear keeps the limits and restores linear ticks.
+	if err := ax.Set(AxesSpec{YScale: "linear"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ax.YScale != transform.NewLinear(1, 1000) {
+		t.Errorf("YScale = %#v", ax.YScale)
+	}
+	if _, ok := ax.YAxis.Locator.(LinearLocator); !ok {
+		t.Errorf("y locator = %T, want LinearLocator", ax.YAxis.Locator)
+	}
+}
+
+func TestAxesSet_ScaleKinds(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+
+	err := ax.Set(AxesSpec{
+		XScale: "symlog", XScaleParams: ScaleParams{LinThresh: 0.5}, XLim: [2]float64{-100, 100},
+		YScale: "time", YLim: [2]float64{0, 86400},
+	})
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ax.XScale != transform.NewSymLog(-100, 100, 0.5, 10) {
+		t.Errorf("XScale = %#v", ax.XScale)
+	}
+	if _, ok := ax.XAxis.Locator.(SymLogLocator); !ok {
+		t.Errorf("x locator = %T, want SymLogLocator", ax.XAxis.Locator)
+	}
+	if _, ok := ax.YAxis.Locator.(DateLocator); !ok {
+		t.Errorf("y locator = %T, want DateLocator", ax.YAxis.Locator)
+	}
+
+	// Limits alone keep the scale type, its parameters and its ticks.
+	ax.SetYLimPower(0, 100, 2)
+	if err := ax.Set(AxesSpec{XLim: [2]float64{-10, 10}, YLim: [2]float64{0, 50}}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ax.XScale != transform.NewSymLog(-10, 10, 0.5, 10) || ax.YScale != transform.NewPower(0, 50, 2) {
+		t.Errorf("limits changed the scales to %#v, %#v", ax.XScale, ax.YScale)
+	}
+	if _, ok := ax.XAxis.Locator.(SymLogLocator); !ok {
+		t.Errorf("x locator = %T, want SymLogLocator", ax.XAxis.Locator)
+	}
+
+	// A new base switches the ticks of a log axis.
+	if err := ax.Set(AxesSpec{XScale: "log", XLim: [2]float64{1, 64}}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := ax.Set(AxesSpec{XScaleParams: ScaleParams{Base: 2}}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if l, ok := ax.XAxis.Locator.(LogLocator); !ok || l.Base != 2 || ax.XScale != transform.NewLog(1, 64, 2) {
+		t.Errorf("x locator %#v, scale %#v, want base 2", ax.XAxis.Locator, ax.XScale)
+	}
+}
+
+func TestAxesSetErrors(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+
+	for _, spec := range []AxesSpec{
+		{XScale: "logit"},
+		{XScale: "symlog", XScaleParams: ScaleParams{Base: 1}},
+		{XScale: "symlog", XScaleParams: ScaleParams{LinThresh: -1}},
+		{XScale: "power", XScaleParams: ScaleParams{Exp: math.Inf(1)}},
+		{XLim: [2]float64{2, 2}},
+		{YScale: "log", YLim: [2]float64{-1, 10}},
+		{YScale: "log"}, // current limits [0, 1] include zero
+	} {
+		if err := ax.Set(spec); err == nil {
+			t.Errorf("Set(%+v) succeeded, want error", spec)
+		}
+	}
+	if ax.XScale != transform.NewLinear(0, 1) || ax.YScale != transform.NewLinear(0, 1) {
+		t.Errorf("failed Set changed scales: %#v %#v", ax.XScale, ax.YScale)
+	}
+}
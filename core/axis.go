@@ -1,8 +1,11 @@
 package core
 
 import (
+	"math"
+
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
+	"matplotlib-go/transform"
 )
 
 // AxisSide specifies which side of the plot area an axis is on.
@@ -15,6 +18,28 @@ const (
 	AxisRight                  // y-axis at right
 )
 
+// LabelAnchor selects which point of a tick label lines up with its tick.
+// Setting one, or a LabelRotation, centers labels on the tick across the
+// text direction.
+type LabelAnchor uint8
+
+const (
+	AnchorAuto   LabelAnchor = iota // upright labels as usual; rotated ones point away from the axis
+	AnchorStart                     // start of the text at the tick
+	AnchorCenter                    // middle of the text at the tick
+	AnchorEnd                       // end of the text at the tick
+)
+
+// LabelOverlap decides what happens to tick labels that would collide,
+// e.g. long dates or category names on a narrow axis.
+type LabelOverlap uint8
+
+const (
+	OverlapThin   LabelOverlap = iota // draw every n-th label, with the smallest n that leaves them apart
+	OverlapAllow                      // draw all labels, even overlapping
+	OverlapRotate                     // slant colliding upright x labels by 45°, like matplotlib's autofmt_xdate, then thin them if needed
+)
+
 // Axis renders axis spines, ticks, and labels for a single dimension.
 type Axis struct {
 	Side       AxisSide     // which side of the plot
@@ -26,37 +51,131 @@ type Axis struct {
 	ShowSpine  bool         // whether to draw the axis line
 	ShowTicks  bool         // whether to draw tick marks
 	ShowLabels bool         // whether to draw tick labels (stub for now)
-	z          float64      // z-order
+	FontSize   float64      // tick label size in pixels, 0 for 12
+	LabelColor render.Color // tick label color, 0 alpha for Color
+
+	// LabelRotation turns the tick labels counterclockwise by this many
+	// degrees, e.g. 45 for long category names. Renderers without
+	// render.RotatedTextDrawer draw them upright. LabelAnchor picks the
+	// point of a label that sits at its tick.
+	LabelRotation float64
+	LabelAnchor   LabelAnchor
+	LabelOverlap  LabelOverlap // handling of colliding labels
+	Snap          SnapMode     // align the spine and ticks with the pixel grid, SnapAuto follows the RC
+	z             float64      // z-order, see SetZ
+
+	// Minor ticks are shorter and unlabeled. A LogLocator with Minor set
+	// yields them by itself; other locators need MinorLocator.
+	MinorLocator  Locator // minor tick positions, nil for none
+	MinorTickSize float64 // length of minor tick marks (in pixels), 0 for TickSize/2
+
+	// Mirror makes the axis take its locators and formatter from another
+	// axis, e.g. a top axis repeating the ticks of the bottom one. Changes to
+	// the mirrored axis carry over; nil uses the axis' own.
+	Mirror *Axis
+
+	unlog *unlogged `spec:"-"` // scale before ToggleLogX or ToggleLogY, to restore
 }
 
+// axisZ is the default z-order of axes: above data and annotations, below
+// legends.
+const axisZ = 500
+
 // NewXAxis creates an axis for the bottom (x-axis).
 func NewXAxis() *Axis {
 	return &Axis{
-		Side:       AxisBottom,
-		Locator:    LinearLocator{},
-		Formatter:  ScalarFormatter{Prec: 3},
-		Color:      render.Color{R: 0, G: 0, B: 0, A: 1}, // black
-		LineWidth:  1.0,
-		TickSize:   5.0,
-		ShowSpine:  true,
-		ShowTicks:  true,
-		ShowLabels: true,
+		Side:          AxisBottom,
+		Locator:       LinearLocator{},
+		Formatter:     ScalarFormatter{Prec: 3},
+		Color:         render.Color{R: 0, G: 0, B: 0, A: 1}, // black
+		LineWidth:     1.0,
+		TickSize:      5.0,
+		MinorTickSize: 2.5,
+		ShowSpine:     true,
+		ShowTicks:     true,
+		ShowLabels:    true,
+		z:             axisZ,
 	}
 }
 
 // NewYAxis creates an axis for the left (y-axis).
 func NewYAxis() *Axis {
 	return &Axis{
-		Side:       AxisLeft,
-		Locator:    LinearLocator{},
-		Formatter:  ScalarFormatter{Prec: 3},
-		Color:      render.Color{R: 0, G: 0, B: 0, A: 1}, // black
-		LineWidth:  1.0,
-		TickSize:   5.0,
-		ShowSpine:  true,
-		ShowTicks:  true,
-		ShowLabels: true,
+		Side:          AxisLeft,
+		Locator:       LinearLocator{},
+		Formatter:     ScalarFormatter{Prec: 3},
+		Color:         render.Color{R: 0, G: 0, B: 0, A: 1}, // black
+		LineWidth:     1.0,
+		TickSize:      5.0,
+		MinorTickSize: 2.5,
+		ShowSpine:     true,
+		ShowTicks:     true,
+		ShowLabels:    true,
+		z:             axisZ,
+	}
+}
+
+// AddAxis adds a further axis on the given side, e.g. AxisTop for ticks
+// along the top edge. It starts with the locator and formatter of XAxis or
+// YAxis, if set, and can be styled independently.
+func (a *Axes) AddAxis(side AxisSide) *Axis {
+	var ax *Axis
+	var like *Axis
+	switch side {
+	case AxisBottom, AxisTop:
+		ax, like = NewXAxis(), a.XAxis
+	default:
+		ax, like = NewYAxis(), a.YAxis
+	}
+	ax.Side = side
+	if like != nil {
+		ax.Locator, ax.Formatter, ax.MinorLocator = like.Locator, like.Formatter, like.MinorLocator
+	}
+	a.OtherAxes = append(a.OtherAxes, ax)
+	return ax
+}
+
+// MirrorAxis adds an axis on the given side that repeats the ticks of XAxis
+// (AxisTop) or YAxis (AxisRight), as matplotlib's ticks on all four sides.
+// Its tick labels are off; set ShowLabels to repeat them as well.
+func (a *Axes) MirrorAxis(side AxisSide) *Axis {
+	ax := a.AddAxis(side)
+	if ax.isX() {
+		ax.Mirror = a.XAxis
+	} else {
+		ax.Mirror = a.YAxis
+	}
+	ax.ShowLabels = false
+	return ax
+}
+
+// AxisList returns all axes of a in draw order before z-sorting: XAxis,
+// YAxis and OtherAxes, leaving out nil ones.
+func (a *Axes) AxisList() []*Axis {
+	var out []*Axis
+	for _, ax := range append([]*Axis{a.XAxis, a.YAxis}, a.OtherAxes...) {
+		if ax != nil {
+			out = append(out, ax)
+		}
 	}
+	return out
+}
+
+// SetZ sets the z-order of the axis among the artists of its Axes. Axes
+// default to 500, above data and below legends; a negative value puts them
+// below the data.
+func (a *Axis) SetZ(z float64) { a.z = z }
+
+// isX reports whether a is horizontal, i.e. an x-axis.
+func (a *Axis) isX() bool { return a.Side == AxisBottom || a.Side == AxisTop }
+
+// tickSource returns the axis whose locators and formatter a uses. Only
+// one level of Mirror is followed.
+func (a *Axis) tickSource() *Axis {
+	if a.Mirror != nil {
+		return a.Mirror
+	}
+	return a
 }
 
 // Draw renders the axis spine and ticks.
@@ -75,7 +194,7 @@ func (a *Axis) Draw(r render.Renderer, ctx *DrawContext) {
 	}
 
 	// Calculate tick positions
-	ticks := a.Locator.Ticks(min, max, 8) // aim for ~8 ticks
+	ticks, minor := a.ticks(min, max, ctx.TickTarget(isXAxis))
 
 	// Draw spine (axis line)
 	if a.ShowSpine {
@@ -84,7 +203,14 @@ func (a *Axis) Draw(r render.Renderer, ctx *DrawContext) {
 
 	// Draw tick marks
 	if a.ShowTicks && len(ticks) > 0 {
-		a.drawTicks(r, ctx, ticks, isXAxis)
+		a.drawTicks(r, ctx, ticks, isXAxis, a.TickSize)
+	}
+	if a.ShowTicks && len(minor) > 0 {
+		size := a.MinorTickSize
+		if size <= 0 {
+			size = a.TickSize / 2
+		}
+		a.drawTicks(r, ctx, minor, isXAxis, size)
 	}
 
 	// Draw tick labels if supported by the renderer
@@ -93,6 +219,59 @@ func (a *Axis) Draw(r render.Renderer, ctx *DrawContext) {
 	}
 }
 
+// setMinor sets the minor locator for the scale s, or removes it. Nil axes
+// are ignored.
+func (a *Axis) setMinor(s transform.Scale, on bool) {
+	if a == nil {
+		return
+	}
+	a.MinorLocator = nil
+	if !on {
+		return
+	}
+	if l, ok := s.(transform.Log); ok {
+		a.MinorLocator = LogLocator{Base: l.Base, Minor: true, Subs: LogSubsAll()}
+	} else {
+		a.MinorLocator = AutoMinorLocator{}
+	}
+}
+
+// ticks returns the major and minor tick positions in [min,max] for about
+// target major ticks, see DrawContext.TickTarget. Minor positions that
+// coincide with a major tick are dropped.
+func (a *Axis) ticks(min, max float64, target int) (major, minor []float64) {
+	src := a.tickSource()
+	if lg, ok := src.Locator.(LogLocator); ok && lg.Minor {
+		return LogLocator{Base: lg.Base}.Ticks(min, max, target), lg.MinorTicks(min, max)
+	}
+	major = src.Locator.Ticks(min, max, target)
+	if src.MinorLocator == nil {
+		return major, nil
+	}
+	for _, v := range src.MinorLocator.Ticks(min, max, target) {
+		isMajor := false
+		for _, m := range major {
+			if approx(v, m, 1e-9*math.Max(math.Abs(m), 1e-300)) {
+				isMajor = true
+				break
+			}
+		}
+		if !isMajor {
+			minor = append(minor, v)
+		}
+	}
+	return major, minor
+}
+
+// hasMinor reports whether the axis places minor ticks.
+func (a *Axis) hasMinor() bool {
+	src := a.tickSource()
+	if lg, ok := src.Locator.(LogLocator); ok && lg.Minor {
+		return true
+	}
+	return src.MinorLocator != nil
+}
+
 // drawSpine draws the main axis line.
 func (a *Axis) drawSpine(r render.Renderer, ctx *DrawContext, isXAxis bool) {
 	var p1, p2 geom.Pt
@@ -113,6 +292,10 @@ func (a *Axis) drawSpine(r render.Renderer, ctx *DrawContext, isXAxis bool) {
 		p2 = ctx.DataToPixel.Apply(geom.Pt{X: x, Y: max})
 	}
 
+	if a.Snap.enabled(ctx) {
+		p1, p2 = snapLine(p1, p2, a.LineWidth)
+	}
+
 	// Create line path
 	path := geom.Path{}
 	path.C = append(path.C, geom.MoveTo)
@@ -131,14 +314,14 @@ func (a *Axis) drawSpine(r render.Renderer, ctx *DrawContext, isXAxis bool) {
 }
 
 // drawTicks draws tick marks at the specified positions.
-func (a *Axis) drawTicks(r render.Renderer, ctx *DrawContext, ticks []float64, isXAxis bool) {
+func (a *Axis) drawTicks(r render.Renderer, ctx *DrawContext, ticks []float64, isXAxis bool, size float64) {
 	for _, tickValue := range ticks {
-		a.drawSingleTick(r, ctx, tickValue, isXAxis)
+		a.drawSingleTick(r, ctx, tickValue, isXAxis, size)
 	}
 }
 
-// drawSingleTick draws a single tick mark.
-func (a *Axis) drawSingleTick(r render.Renderer, ctx *DrawContext, tickValue float64, isXAxis bool) {
+// drawSingleTick draws a single tick mark of the given length.
+func (a *Axis) drawSingleTick(r render.Renderer, ctx *DrawContext, tickValue float64, isXAxis bool, size float64) {
 	var p1, p2 geom.Pt
 
 	if isXAxis {
@@ -154,10 +337,10 @@ func (a *Axis) drawSingleTick(r render.Renderer, ctx *DrawContext, tickValue flo
 		switch a.Side {
 		case AxisBottom:
 			p1 = spinePixel
-			p2 = geom.Pt{X: spinePixel.X, Y: spinePixel.Y - a.TickSize} // Ticks point down (more positive Y in screen coords)
+			p2 = geom.Pt{X: spinePixel.X, Y: spinePixel.Y - size} // Ticks point down (more positive Y in screen coords)
 		case AxisTop:
 			p1 = spinePixel
-			p2 = geom.Pt{X: spinePixel.X, Y: spinePixel.Y + a.TickSize} // Ticks point up (less Y in screen coords)
+			p2 = geom.Pt{X: spinePixel.X, Y: spinePixel.Y + size} // Ticks point up (less Y in screen coords)
 		}
 	} else {
 		// Horizontal tick mark
@@ -171,13 +354,17 @@ func (a *Axis) drawSingleTick(r render.Renderer, ctx *DrawContext, tickValue flo
 		switch a.Side {
 		case AxisLeft:
 			p1 = spinePixel
-			p2 = geom.Pt{X: spinePixel.X - a.TickSize, Y: spinePixel.Y}
+			p2 = geom.Pt{X: spinePixel.X - size, Y: spinePixel.Y}
 		case AxisRight:
 			p1 = spinePixel
-			p2 = geom.Pt{X: spinePixel.X + a.TickSize, Y: spinePixel.Y}
+			p2 = geom.Pt{X: spinePixel.X + size, Y: spinePixel.Y}
 		}
 	}
 
+	if a.Snap.enabled(ctx) {
+		p1, p2 = snapLine(p1, p2, a.LineWidth)
+	}
+
 	// Create tick path
 	path := geom.Path{}
 	path.C = append(path.C, geom.MoveTo)
@@ -226,35 +413,85 @@ func (a *Axis) Bounds(*DrawContext) geom.Rect {
 	return geom.Rect{}
 }
 
+// tickLabel is a tick label placed in pixels.
+type tickLabel struct {
+	text    string
+	origin  geom.Pt // left end of the baseline
+	angle   float64 // radians, counterclockwise
+	metrics render.TextMetrics
+}
+
 // drawTickLabels draws text labels for the ticks if the renderer supports text.
 func (a *Axis) drawTickLabels(r render.Renderer, ctx *DrawContext, ticks []float64, isXAxis bool) {
-	// Check if renderer supports text drawing (gobasic.Renderer has DrawText method)
-	type textRenderer interface {
-		DrawText(text string, origin geom.Pt, size float64, textColor render.Color)
-	}
-	
-	textRen, ok := r.(textRenderer)
+	// Check if renderer supports direct text drawing
+	textRen, ok := r.(render.TextDrawer)
 	if !ok {
 		return // Renderer doesn't support text
 	}
-	
-	fontSize := 12.0 // Default font size
-	
+	rotRen, rotates := r.(render.RotatedTextDrawer)
+
+	textColor := a.LabelColor
+	if textColor.A == 0 {
+		textColor = a.Color
+	}
+	for _, l := range a.placeTickLabels(r, ctx, ticks, isXAxis, rotates) {
+		if l.angle != 0 {
+			rotRen.DrawTextRotated(l.text, l.origin, a.fontSize(), l.angle, textColor)
+		} else {
+			textRen.DrawText(l.text, l.origin, a.fontSize(), textColor)
+		}
+	}
+}
+
+func (a *Axis) fontSize() float64 {
+	if a.FontSize <= 0 {
+		return 12
+	}
+	return a.FontSize
+}
+
+// placeTickLabels positions the labels of ticks and drops or slants them
+// as LabelOverlap says. Without rotate, labels are laid out upright even if
+// LabelRotation or OverlapRotate is set.
+func (a *Axis) placeTickLabels(r render.Renderer, ctx *DrawContext, ticks []float64, isXAxis, rotate bool) []tickLabel {
+	angle := 0.0
+	if rotate {
+		angle = a.LabelRotation * math.Pi / 180
+	}
+	labels := a.layoutTickLabels(r, ctx, ticks, isXAxis, angle)
+	switch a.LabelOverlap {
+	case OverlapAllow:
+		return labels
+	case OverlapRotate:
+		if rotate && isXAxis && angle == 0 && labelsCollide(labels, 1) {
+			labels = a.layoutTickLabels(r, ctx, ticks, isXAxis, math.Pi/4)
+		}
+	}
+	return thinLabels(labels)
+}
+
+// layoutTickLabels positions the labels of ticks turned by angle.
+func (a *Axis) layoutTickLabels(r render.Renderer, ctx *DrawContext, ticks []float64, isXAxis bool, angle float64) []tickLabel {
+	fontSize := a.fontSize()
+	formatter := withRCTypography(a.tickSource().Formatter, ctx.RC)
+	var out []tickLabel
+
 	for _, tickValue := range ticks {
 		// Format the tick value using the formatter
-		label := a.Formatter.Format(tickValue)
+		label := formatter.Format(tickValue)
 		if label == "" {
 			continue
 		}
-		
+		m := r.MeasureText(label, fontSize, ctx.RC.FontKey)
+
 		// Calculate label position
-		var labelPos geom.Pt
-		
+		var labelPos, tickPos geom.Pt
+
 		if isXAxis {
 			// X-axis labels go below the ticks
 			spineY := getSpinePosition(a.Side, ctx)
-			tickPos := ctx.DataToPixel.Apply(geom.Pt{X: tickValue, Y: spineY})
-			
+			tickPos = ctx.DataToPixel.Apply(geom.Pt{X: tickValue, Y: spineY})
+
 			switch a.Side {
 			case AxisBottom:
 				labelPos = geom.Pt{X: tickPos.X, Y: tickPos.Y - a.TickSize - 5} // Below tick
@@ -264,8 +501,8 @@ func (a *Axis) drawTickLabels(r render.Renderer, ctx *DrawContext, ticks []float
 		} else {
 			// Y-axis labels go to the left of the ticks
 			spineX := getSpinePosition(a.Side, ctx)
-			tickPos := ctx.DataToPixel.Apply(geom.Pt{X: spineX, Y: tickValue})
-			
+			tickPos = ctx.DataToPixel.Apply(geom.Pt{X: spineX, Y: tickValue})
+
 			switch a.Side {
 			case AxisLeft:
 				labelPos = geom.Pt{X: tickPos.X - a.TickSize - 50, Y: tickPos.Y + fontSize/2} // Left of tick
@@ -273,8 +510,127 @@ func (a *Axis) drawTickLabels(r render.Renderer, ctx *DrawContext, ticks []float
 				labelPos = geom.Pt{X: tickPos.X + a.TickSize + 5, Y: tickPos.Y + fontSize/2} // Right of tick
 			}
 		}
-		
-		// Draw the label
-		textRen.DrawText(label, labelPos, fontSize, a.Color)
+		if angle != 0 || a.LabelAnchor != AnchorAuto {
+			labelPos = a.anchorLabel(tickPos, angle, m)
+		}
+		out = append(out, tickLabel{text: label, origin: labelPos, angle: angle, metrics: m})
+	}
+	return out
+}
+
+// labelGap is the least distance in pixels between tick labels kept by
+// OverlapThin.
+const labelGap = 2
+
+// thinLabels keeps every n-th label, starting at the first, for the
+// smallest n at which no two kept neighbours collide.
+func thinLabels(labels []tickLabel) []tickLabel {
+	n := 1
+	for n < len(labels) && labelsCollide(labels, n) {
+		n++
+	}
+	if n == 1 {
+		return labels
+	}
+	out := make([]tickLabel, 0, len(labels)/n+1)
+	for i := 0; i < len(labels); i += n {
+		out = append(out, labels[i])
+	}
+	return out
+}
+
+// labelsCollide reports whether any two labels n apart come closer than
+// labelGap. All labels share one angle, so their boxes are compared along
+// and across the text direction.
+func labelsCollide(labels []tickLabel, n int) bool {
+	for i := n; i < len(labels); i += n {
+		p, q := labels[i-n], labels[i]
+		sin, cos := math.Sincos(p.angle)
+		along := func(o geom.Pt) float64 { return o.X*cos - o.Y*sin }
+		across := func(o geom.Pt) float64 { return o.X*sin + o.Y*cos }
+		ps, qs := along(p.origin), along(q.origin)
+		pt, qt := across(p.origin), across(q.origin)
+		if ps < qs+q.metrics.W+labelGap && qs < ps+p.metrics.W+labelGap &&
+			pt-p.metrics.Ascent < qt+q.metrics.Descent+labelGap && qt-q.metrics.Ascent < pt+p.metrics.Descent+labelGap {
+			return true
+		}
+	}
+	return false
+}
+
+// anchorLabel returns the origin of a label rotated by angle whose anchor
+// point, on its middle line, lies just past the tick at tickPos.
+func (a *Axis) anchorLabel(tickPos geom.Pt, angle float64, m render.TextMetrics) geom.Pt {
+	var normal geom.Pt // direction from the tick to its label
+	switch a.Side {
+	case AxisBottom:
+		normal = geom.Pt{Y: -1}
+	case AxisTop:
+		normal = geom.Pt{Y: 1}
+	case AxisLeft:
+		normal = geom.Pt{X: -1}
+	case AxisRight:
+		normal = geom.Pt{X: 1}
+	}
+	gap := a.TickSize + 5
+	anchor := geom.Pt{X: tickPos.X + normal.X*gap, Y: tickPos.Y + normal.Y*gap}
+
+	sin, cos := math.Sincos(angle)
+	dir := geom.Pt{X: cos, Y: -sin} // along the baseline
+	up := geom.Pt{X: -sin, Y: -cos} // towards the top of the glyphs
+
+	var f float64 // fraction of the width at the anchor
+	switch a.LabelAnchor {
+	case AnchorStart:
+		f = 0
+	case AnchorCenter:
+		f = 0.5
+	case AnchorEnd:
+		f = 1
+	default:
+		// Let the label point away from the axis.
+		switch d := dir.X*normal.X + dir.Y*normal.Y; {
+		case d > 1e-9:
+			f = 0
+		case d < -1e-9:
+			f = 1
+		default:
+			f = 0.5
+		}
+	}
+	mid := (m.Ascent - m.Descent) / 2
+	return geom.Pt{
+		X: anchor.X - f*m.W*dir.X - mid*up.X,
+		Y: anchor.Y - f*m.W*dir.Y - mid*up.Y,
+	}
+}
+
+// TickLabelBounds returns the pixel bounds of the tick labels as Draw would
+// place them with r, e.g. to reserve a margin for long rotated labels. It
+// is empty if labels are off or r cannot draw text.
+func (a *Axis) TickLabelBounds(r render.Renderer, ctx *DrawContext) geom.Rect {
+	if _, ok := r.(render.TextDrawer); !ok || !a.ShowLabels {
+		return geom.Rect{}
+	}
+	_, rotates := r.(render.RotatedTextDrawer)
+	var min, max float64
+	if a.isX() {
+		min, max = ctx.DataToPixel.XScale.Domain()
+	} else {
+		min, max = ctx.DataToPixel.YScale.Domain()
+	}
+	ticks, _ := a.ticks(min, max, ctx.TickTarget(a.isX()))
+
+	var b geom.Rect
+	first := true
+	for _, l := range a.placeTickLabels(r, ctx, ticks, a.isX(), rotates) {
+		lb := l.metrics.Bounds(l.origin, l.angle)
+		if first {
+			b, first = lb, false
+			continue
+		}
+		b.Min.X, b.Min.Y = math.Min(b.Min.X, lb.Min.X), math.Min(b.Min.Y, lb.Min.Y)
+		b.Max.X, b.Max.Y = math.Max(b.Max.X, lb.Max.X), math.Max(b.Max.Y, lb.Max.Y)
 	}
+	return b
 }
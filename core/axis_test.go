@@ -1,10 +1,13 @@
 package core
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
+	"matplotlib-go/transform"
 )
 
 func TestAxis_Draw(t *testing.T) {
@@ -211,3 +214,295 @@ func TestAxes_AddGrid(t *testing.T) {
 		t.Errorf("AddYGrid should create grid for AxisLeft, got %v", yGrid.Axis)
 	}
 }
+
+func TestAxis_LogMinorTicks(t *testing.T) {
+	ctx := createTestDrawContext()
+	ctx.DataToPixel.XScale = transform.NewLog(1, 1000, 10)
+	axis := NewXAxis()
+	axis.Locator = LogLocator{Base: 10, Minor: true}
+	axis.Formatter = LogFormatter{Base: 10}
+	axis.ShowSpine = false
+
+	r := &tickRecorder{}
+	axis.Draw(r, ctx)
+
+	// Majors at 1, 10, 100, 1000 keep the full length; minors at 2, 5, ...
+	// are half as long and unlabeled.
+	var major, minor int
+	for _, l := range r.lengths {
+		switch l {
+		case axis.TickSize:
+			major++
+		case axis.MinorTickSize:
+			minor++
+		default:
+			t.Errorf("tick of length %v", l)
+		}
+	}
+	if major != 4 || minor != 6 {
+		t.Errorf("got %d major and %d minor ticks, want 4 and 6", major, minor)
+	}
+	if len(r.labels) != 4 {
+		t.Errorf("labels %q, want one per major tick", r.labels)
+	}
+}
+
+func TestAxis_MinorLocator(t *testing.T) {
+	axis := NewYAxis()
+	axis.MinorLocator = fixedLocator{0.5, 1, 1.5}
+	major, minor := axis.ticks(0, 2, 8)
+	if len(major) == 0 || len(minor) != 2 || minor[0] != 0.5 || minor[1] != 1.5 {
+		t.Errorf("major %v, minor %v; want minors 0.5 and 1.5 without the major 1", major, minor)
+	}
+}
+
+func TestAxes_MinorTicksOn(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(0, 4)
+	ax.SetYLimLog(1, 100, 10)
+	ax.MinorTicksOn()
+	if _, ok := ax.XAxis.MinorLocator.(AutoMinorLocator); !ok {
+		t.Errorf("x minor locator %T, want AutoMinorLocator", ax.XAxis.MinorLocator)
+	}
+	if _, minor := ax.YAxis.ticks(1, 100, 8); len(minor) != 16 {
+		t.Errorf("log minor ticks %v, want 2..9 in two decades", minor)
+	}
+
+	// Switching the scale keeps minor ticks fitting the new scale.
+	ax.SetXLimLog(1, 10, 10)
+	if l, ok := ax.XAxis.MinorLocator.(LogLocator); !ok || len(l.Subs) != 8 {
+		t.Errorf("x minor locator %#v after SetXLimLog, want LogLocator with LogSubsAll", ax.XAxis.MinorLocator)
+	}
+	ax.setScale(ax.YAxis, &ax.YScale, transform.NewLinear(0, 10))
+	if _, ok := ax.YAxis.MinorLocator.(AutoMinorLocator); !ok {
+		t.Errorf("y minor locator %T after linear scale, want AutoMinorLocator", ax.YAxis.MinorLocator)
+	}
+
+	ax.MinorTicksOff()
+	if ax.XAxis.MinorLocator != nil || ax.YAxis.MinorLocator != nil {
+		t.Error("minor locators left after MinorTicksOff")
+	}
+}
+
+func TestAxis_LabelOverlap(t *testing.T) {
+	ctx := createTestDrawContext() // 0..10 over 100 px
+	labels := make([]string, 11)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("Label %d", i)
+	}
+	draw := func(overlap LabelOverlap) *labelRecorder {
+		axis := NewXAxis()
+		axis.Locator = fixedLocator{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		axis.Formatter = CategoryFormatter{Labels: labels}
+		axis.LabelOverlap = overlap
+		r := &labelRecorder{}
+		axis.Draw(r, ctx)
+		return r
+	}
+
+	// 42 px wide labels 10 px apart: every fifth one fits.
+	if r := draw(OverlapThin); len(r.texts) != 3 || r.texts[1] != "Label 5" {
+		t.Errorf("thinned labels %q, want Label 0, 5 and 10", r.texts)
+	}
+	if r := draw(OverlapAllow); len(r.texts) != 11 {
+		t.Errorf("%d labels with OverlapAllow, want 11", len(r.texts))
+	}
+	// Slanted by 45°, 10 px tall labels are 7 px apart across the text:
+	// every second one fits.
+	r := draw(OverlapRotate)
+	if len(r.texts) != 6 || r.angles[0] != math.Pi/4 {
+		t.Errorf("rotated labels %q at %v, want 6 at 45°", r.texts, r.angles)
+	}
+}
+
+func TestDrawContext_TickTarget(t *testing.T) {
+	ctx := createTestDrawContext() // 100 px axes
+	if x, y := ctx.TickTarget(true), ctx.TickTarget(false); x != 2 || y != 4 {
+		t.Errorf("targets %d, %d on 100 px, want 2, 4", x, y)
+	}
+	ctx.DataToPixel.AxesToPixel = transform.NewAffine(geom.Affine{A: 600, D: -150})
+	if x, y := ctx.TickTarget(true), ctx.TickTarget(false); x != 8 || y != 6 {
+		t.Errorf("targets %d, %d on 600x150 px, want 8, 6", x, y)
+	}
+	ctx.DataToPixel.AxesToPixel = transform.NewAffine(geom.Affine{})
+	if got := ctx.TickTarget(true); got != 8 {
+		t.Errorf("target %d without pixel extent, want 8", got)
+	}
+
+	// A small axis draws fewer labels than a large one.
+	small, large := &labelRecorder{}, &labelRecorder{}
+	NewXAxis().Draw(small, createTestDrawContext())
+	ctx.DataToPixel.AxesToPixel = transform.NewAffine(geom.Affine{A: 400, D: -400, E: 50, F: 450})
+	NewXAxis().Draw(large, ctx)
+	if len(small.texts) >= len(large.texts) {
+		t.Errorf("labels %q on 100 px, %q on 400 px", small.texts, large.texts)
+	}
+}
+
+// labelRecorder records tick labels and their angles, measuring every
+// character as 6 px wide.
+type labelRecorder struct {
+	render.NullRenderer
+	texts  []string
+	angles []float64
+}
+
+func (r *labelRecorder) MeasureText(text string, _ float64, _ string) render.TextMetrics {
+	return render.TextMetrics{W: 6 * float64(len(text)), Ascent: 8, Descent: 2}
+}
+
+func (r *labelRecorder) DrawText(text string, _ geom.Pt, _ float64, _ render.Color) {
+	r.texts, r.angles = append(r.texts, text), append(r.angles, 0)
+}
+
+func (r *labelRecorder) DrawTextRotated(text string, _ geom.Pt, _, angle float64, _ render.Color) {
+	r.texts, r.angles = append(r.texts, text), append(r.angles, angle)
+}
+
+// fixedLocator returns its values regardless of the domain.
+type fixedLocator []float64
+
+func (f fixedLocator) Ticks(float64, float64, int) []float64 { return f }
+
+// tickRecorder records the length of each drawn path and each text.
+type tickRecorder struct {
+	render.NullRenderer
+	lengths []float64
+	labels  []string
+}
+
+func (r *tickRecorder) Path(p geom.Path, _ *render.Paint) {
+	a, b := p.V[0], p.V[len(p.V)-1]
+	r.lengths = append(r.lengths, math.Hypot(b.X-a.X, b.Y-a.Y))
+}
+
+func (r *tickRecorder) DrawText(text string, _ geom.Pt, _ float64, _ render.Color) {
+	r.labels = append(r.labels, text)
+}
+
+func TestAxes_AddAxis(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis.Locator = fixedLocator{0.5}
+	top := ax.AddAxis(AxisTop)
+	if top.Side != AxisTop || !top.isX() {
+		t.Fatalf("side = %v, want AxisTop", top.Side)
+	}
+	if _, ok := top.Locator.(fixedLocator); !ok {
+		t.Errorf("top axis locator = %T, want the x-axis locator", top.Locator)
+	}
+	if got := ax.AxisList(); len(got) != 3 || got[2] != top {
+		t.Errorf("AxisList = %v", got)
+	}
+
+	ax.XAxis = nil
+	if got := ax.AxisList(); len(got) != 2 || got[0] != ax.YAxis {
+		t.Errorf("AxisList without XAxis = %v", got)
+	}
+
+	var r tickRecorder
+	top.ShowSpine = false
+	top.Draw(&r, ax.drawContext(fig))
+	if len(r.labels) != 1 || r.labels[0] != "0.5" {
+		t.Errorf("top labels = %v, want [0.5]", r.labels)
+	}
+}
+
+func TestAxes_MirrorAxis(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	right := ax.MirrorAxis(AxisRight)
+	if right.Mirror != ax.YAxis || right.ShowLabels {
+		t.Fatalf("mirror = %p labels %v, want YAxis without labels", right.Mirror, right.ShowLabels)
+	}
+
+	// Later changes to the mirrored axis carry over.
+	ax.YAxis.Locator = fixedLocator{0.25, 0.75}
+	ax.YAxis.Formatter = ScalarFormatter{Prec: 2}
+	right.ShowSpine = false
+	right.ShowLabels = true
+
+	var r tickRecorder
+	right.Draw(&r, ax.drawContext(fig))
+	if len(r.lengths) != 2 {
+		t.Errorf("drew %d ticks, want 2", len(r.lengths))
+	}
+	if len(r.labels) != 2 || r.labels[0] != "0.25" {
+		t.Errorf("labels = %v, want the y-axis ones", r.labels)
+	}
+
+	// An independent axis keeps its own locator.
+	top := ax.AddAxis(AxisTop)
+	top.Locator = fixedLocator{0.5}
+	if major, _ := top.ticks(0, 1, 8); len(major) != 1 {
+		t.Errorf("top ticks = %v, want [0.5]", major)
+	}
+}
+
+// rotatedTextRecorder records text draws with their angle.
+type rotatedTextRecorder struct {
+	render.NullRenderer
+	origins []geom.Pt
+	angles  []float64
+}
+
+func (r *rotatedTextRecorder) MeasureText(string, float64, string) render.TextMetrics {
+	return render.TextMetrics{W: 30, H: 10, Ascent: 8, Descent: 2}
+}
+
+func (r *rotatedTextRecorder) DrawText(text string, origin geom.Pt, size float64, c render.Color) {
+	r.DrawTextRotated(text, origin, size, 0, c)
+}
+
+func (r *rotatedTextRecorder) DrawTextRotated(_ string, origin geom.Pt, _, angle float64, _ render.Color) {
+	r.origins = append(r.origins, origin)
+	r.angles = append(r.angles, angle)
+}
+
+func TestAxis_LabelRotation(t *testing.T) {
+	axis := NewXAxis()
+	axis.Locator = fixedLocator{5} // pixel (100, 450)
+	axis.LabelRotation = 90
+	ctx := createTestDrawContext()
+
+	var r rotatedTextRecorder
+	axis.Draw(&r, ctx)
+	if len(r.angles) != 1 || math.Abs(r.angles[0]-math.Pi/2) > 1e-9 {
+		t.Fatalf("angles = %v, want [π/2]", r.angles)
+	}
+	// The label starts 10px past the tick and runs up, its middle line
+	// through the tick.
+	if o := r.origins[0]; !near(o, geom.Pt{X: 103, Y: 440}) {
+		t.Errorf("origin = %v, want (103, 440)", o)
+	}
+	b := axis.TickLabelBounds(&r, ctx)
+	if !near(b.Min, geom.Pt{X: 95, Y: 410}) || !near(b.Max, geom.Pt{X: 105, Y: 440}) {
+		t.Errorf("bounds = %v, want (95,410)-(105,440)", b)
+	}
+
+	axis.LabelAnchor = AnchorEnd
+	r = rotatedTextRecorder{}
+	axis.Draw(&r, ctx)
+	if o := r.origins[0]; !near(o, geom.Pt{X: 103, Y: 470}) {
+		t.Errorf("end-anchored origin = %v, want (103, 470)", o)
+	}
+
+	// Renderers that cannot rotate text get upright labels at the usual spot.
+	axis.LabelAnchor = AnchorAuto
+	var plain textPosRecorder
+	axis.Draw(&plain, ctx)
+	if len(plain.origins) != 1 || !near(plain.origins[0], geom.Pt{X: 100, Y: 440}) {
+		t.Errorf("upright origins = %v, want [(100, 440)]", plain.origins)
+	}
+}
+
+// textPosRecorder records upright text origins.
+type textPosRecorder struct {
+	render.NullRenderer
+	origins []geom.Pt
+}
+
+func (r *textPosRecorder) DrawText(_ string, origin geom.Pt, _ float64, _ render.Color) {
+	r.origins = append(r.origins, origin)
+}
@@ -1,8 +1,11 @@
 package core
 
 import (
+	"math"
+
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
+	"matplotlib-go/transform"
 )
 
 // BarOrientation specifies the direction of bars.
@@ -13,11 +16,19 @@ const (
 	BarHorizontal                       // bars extend rightward from baseline
 )
 
-// Bar2D renders bar charts using filled rectangles.
+// Bar2D renders bar charts using filled rectangles. On a log position axis
+// a bar of width w centered at x spans [x/k, x*k] with x*k - x/k = w, so
+// bars keep their data width but look symmetric and never cross zero. A
+// zero baseline on a log value axis is moved to the bottom of the axis.
+//
+// On polar axes, X is the angle in radians and the bars become annular
+// sectors: vertical bars extend outwards from their baseline radius,
+// horizontal ones sweep from their baseline angle at radius X.
 type Bar2D struct {
 	X           []float64      // x positions (centers of bars for vertical, positions for horizontal)
 	Heights     []float64      // heights/lengths of bars (Y values for vertical, X values for horizontal)
 	Widths      []float64      // bar widths, if nil uses Width
+	Edges       []float64      // bin edges: if set, bar i spans Edges[i] to Edges[i+1] and X, Widths and Width only serve tooltips
 	Colors      []render.Color // bar fill colors, if nil uses Color
 	EdgeColors  []render.Color // edge colors for bar outlines, if nil uses EdgeColor
 	Width       float64        // default bar width in data units
@@ -26,8 +37,13 @@ type Bar2D struct {
 	EdgeWidth   float64        // edge width in pixels (0 means no edge)
 	Alpha       float64        // alpha transparency (0-1), applied to both fill and edge
 	Baseline    float64        // baseline value (0 for most cases)
+	Bottoms     []float64      // per-bar baselines, e.g. for stacking, if nil uses Baseline
 	Orientation BarOrientation // vertical or horizontal bars
 	Label       string         // series label for legend
+	Tooltip     TooltipFunc    // per-bar tooltip text (called with position, height), if nil none
+	Rasterize   bool           // draw as an embedded bitmap in vector output
+	Aliased     bool           // draw without anti-aliasing, with hard pixel edges, e.g. for pixel-exact bars
+	Snap        SnapMode       // align bar edges with the pixel grid, SnapAuto follows the RC
 	z           float64        // z-order
 }
 
@@ -42,6 +58,7 @@ func (b *Bar2D) Draw(r render.Renderer, ctx *DrawContext) {
 	if len(b.Heights) < numBars {
 		numBars = len(b.Heights)
 	}
+	titler, _ := r.(render.Titler)
 
 	for i := 0; i < numBars; i++ {
 		x := b.X[i]
@@ -85,19 +102,31 @@ func (b *Bar2D) Draw(r render.Renderer, ctx *DrawContext) {
 
 		// Create rectangle path based on orientation
 		var rectPath geom.Path
-		if b.Orientation == BarVertical {
-			rectPath = b.createVerticalBarPath(x, height, width, ctx)
+		if ctx.DataToPixel.Polar != nil {
+			rectPath = b.polarBarPath(i, x, width, height, ctx)
+		} else if b.Orientation == BarVertical {
+			lo, hi := b.span(i, x, width, ctx.DataToPixel.XScale)
+			rectPath = b.createVerticalBarPath(lo, hi, b.bottom(i), height, ctx)
 		} else {
-			rectPath = b.createHorizontalBarPath(x, height, width, ctx)
+			lo, hi := b.span(i, x, width, ctx.DataToPixel.YScale)
+			rectPath = b.createHorizontalBarPath(lo, hi, b.bottom(i), height, ctx)
 		}
 
 		if len(rectPath.C) == 0 {
 			continue // skip invalid bars
 		}
+		if ctx.DataToPixel.Polar == nil && b.Snap.enabled(ctx) {
+			snapWidth := 0.0
+			if b.EdgeWidth > 0 && edgeColor.A > 0 {
+				snapWidth = b.EdgeWidth
+			}
+			snapPath(rectPath, snapWidth)
+		}
 
 		// Create paint for bar
 		paint := render.Paint{
-			Fill: fillColor,
+			Fill:    fillColor,
+			Aliased: b.Aliased,
 		}
 
 		// Add stroke if edge width is specified
@@ -109,25 +138,68 @@ func (b *Bar2D) Draw(r render.Renderer, ctx *DrawContext) {
 		}
 
 		// Draw bar
+		if titler != nil && b.Tooltip != nil {
+			titler.Title(b.Tooltip(i, geom.Pt{X: x, Y: height}))
+		}
 		r.Path(rectPath, &paint)
 	}
 }
 
-// createVerticalBarPath creates a rectangle for a vertical bar.
-func (b *Bar2D) createVerticalBarPath(x, height, width float64, ctx *DrawContext) geom.Path {
+// span returns the extent of bar i along the position axis, see Bar2D.
+func (b *Bar2D) span(i int, x, width float64, scale transform.Scale) (lo, hi float64) {
+	if i+1 < len(b.Edges) {
+		return b.Edges[i], b.Edges[i+1]
+	}
+	if _, ok := scale.(transform.Log); ok && x > 0 {
+		r := width / x
+		k := (r + math.Sqrt(r*r+4)) / 2
+		return x / k, x * k
+	}
+	return x - width/2, x + width/2
+}
+
+// bottom returns the baseline of bar i.
+func (b *Bar2D) bottom(i int) float64 {
+	if i < len(b.Bottoms) {
+		return b.Bottoms[i]
+	}
+	return b.Baseline
+}
+
+// logFloor moves a baseline outside the domain of a log value axis to the
+// bottom of the axis.
+func logFloor(base float64, scale transform.Scale) float64 {
+	if s, ok := scale.(transform.Log); ok && base <= 0 {
+		return math.Min(s.Min, s.Max)
+	}
+	return base
+}
+
+// polarBarPath creates the annular sector of bar i on polar axes.
+func (b *Bar2D) polarBarPath(i int, x, width, height float64, ctx *DrawContext) geom.Path {
+	base := b.bottom(i)
+	if b.Orientation == BarVertical {
+		t0, t1 := b.span(i, x, width, ctx.DataToPixel.XScale)
+		rlo, rhi := ctx.DataToPixel.YScale.Domain()
+		return wedgePath(&ctx.DataToPixel, t0, t1, math.Max(base, math.Min(rlo, rhi)), base+height)
+	}
+	r0, r1 := b.span(i, x, width, ctx.DataToPixel.YScale)
+	return wedgePath(&ctx.DataToPixel, base, base+height, r0, r1)
+}
+
+// createVerticalBarPath creates a rectangle for a vertical bar from left to
+// right.
+func (b *Bar2D) createVerticalBarPath(left, right, base, height float64, ctx *DrawContext) geom.Path {
 	path := geom.Path{}
 
 	// Calculate rectangle corners in data space
-	halfWidth := width / 2
-	left := x - halfWidth
-	right := x + halfWidth
-	bottom := b.Baseline
-	top := b.Baseline + height
+	bottom := logFloor(base, ctx.DataToPixel.YScale)
+	top := base + height
 
 	// Handle negative heights (bars extending below baseline)
 	if height < 0 {
-		bottom = b.Baseline + height
-		top = b.Baseline
+		bottom = base + height
+		top = base
 	}
 
 	// Define rectangle corners
@@ -153,24 +225,19 @@ func (b *Bar2D) createVerticalBarPath(x, height, width float64, ctx *DrawContext
 	return path
 }
 
-// createHorizontalBarPath creates a rectangle for a horizontal bar.
-func (b *Bar2D) createHorizontalBarPath(y, height, width float64, ctx *DrawContext) geom.Path {
+// createHorizontalBarPath creates a rectangle for a horizontal bar from
+// bottom to top.
+func (b *Bar2D) createHorizontalBarPath(bottom, top, base, height float64, ctx *DrawContext) geom.Path {
 	path := geom.Path{}
 
-	// For horizontal bars:
-	// y is the y-position (center)
-	// height is the length (width) of the bar
-	// width is the thickness (height) of the bar
-	halfWidth := width / 2
-	left := b.Baseline
-	right := b.Baseline + height
-	bottom := y - halfWidth
-	top := y + halfWidth
+	// For horizontal bars height is the length (width) of the bar
+	left := logFloor(base, ctx.DataToPixel.XScale)
+	right := base + height
 
 	// Handle negative heights (bars extending left from baseline)
 	if height < 0 {
-		left = b.Baseline + height
-		right = b.Baseline
+		left = base + height
+		right = base
 	}
 
 	// Define rectangle corners
@@ -201,6 +268,9 @@ func (b *Bar2D) Z() float64 {
 	return b.z
 }
 
+// Rasterized reports whether the Bar2D should be embedded as a bitmap in vector output.
+func (b *Bar2D) Rasterized() bool { return b.Rasterize }
+
 // Bounds returns the bounding box of all bars.
 func (b *Bar2D) Bounds(*DrawContext) geom.Rect {
 	if len(b.X) == 0 || len(b.Heights) == 0 {
@@ -219,9 +289,17 @@ func (b *Bar2D) Bounds(*DrawContext) geom.Rect {
 
 	// Calculate bounds based on orientation
 	if b.Orientation == BarVertical {
-		return b.verticalBounds(numBars)
+		bounds := b.verticalBounds(numBars)
+		if len(b.Edges) > numBars {
+			bounds.Min.X, bounds.Max.X = b.Edges[0], b.Edges[numBars]
+		}
+		return bounds
 	} else {
-		return b.horizontalBounds(numBars)
+		bounds := b.horizontalBounds(numBars)
+		if len(b.Edges) > numBars {
+			bounds.Min.Y, bounds.Max.Y = b.Edges[0], b.Edges[numBars]
+		}
+		return bounds
 	}
 }
 
@@ -243,12 +321,12 @@ func (b *Bar2D) verticalBounds(numBars int) geom.Rect {
 	height0 := b.Heights[0]
 	minX := x0 - halfMaxWidth
 	maxX := x0 + halfMaxWidth
-	minY := b.Baseline
-	maxY := b.Baseline + height0
+	minY := b.bottom(0)
+	maxY := b.bottom(0) + height0
 
 	if height0 < 0 {
-		minY = b.Baseline + height0
-		maxY = b.Baseline
+		minY = b.bottom(0) + height0
+		maxY = b.bottom(0)
 	}
 
 	// Expand bounds to include all bars
@@ -268,8 +346,8 @@ func (b *Bar2D) verticalBounds(numBars int) geom.Rect {
 
 		// Y bounds (bar heights)
 		if height >= 0 {
-			bottom := b.Baseline
-			top := b.Baseline + height
+			bottom := b.bottom(i)
+			top := b.bottom(i) + height
 			if bottom < minY {
 				minY = bottom
 			}
@@ -277,8 +355,8 @@ func (b *Bar2D) verticalBounds(numBars int) geom.Rect {
 				maxY = top
 			}
 		} else {
-			bottom := b.Baseline + height
-			top := b.Baseline
+			bottom := b.bottom(i) + height
+			top := b.bottom(i)
 			if bottom < minY {
 				minY = bottom
 			}
@@ -310,14 +388,14 @@ func (b *Bar2D) horizontalBounds(numBars int) geom.Rect {
 	// Initialize bounds with first bar
 	y0 := b.X[0] // In horizontal bars, X represents Y positions
 	height0 := b.Heights[0]
-	minX := b.Baseline
-	maxX := b.Baseline + height0
+	minX := b.bottom(0)
+	maxX := b.bottom(0) + height0
 	minY := y0 - halfMaxWidth
 	maxY := y0 + halfMaxWidth
 
 	if height0 < 0 {
-		minX = b.Baseline + height0
-		maxX = b.Baseline
+		minX = b.bottom(0) + height0
+		maxX = b.bottom(0)
 	}
 
 	// Expand bounds to include all bars
@@ -327,8 +405,8 @@ func (b *Bar2D) horizontalBounds(numBars int) geom.Rect {
 
 		// X bounds (bar lengths)
 		if height >= 0 {
-			left := b.Baseline
-			right := b.Baseline + height
+			left := b.bottom(i)
+			right := b.bottom(i) + height
 			if left < minX {
 				minX = left
 			}
@@ -336,8 +414,8 @@ func (b *Bar2D) horizontalBounds(numBars int) geom.Rect {
 				maxX = right
 			}
 		} else {
-			left := b.Baseline + height
-			right := b.Baseline
+			left := b.bottom(i) + height
+			right := b.bottom(i)
 			if left < minX {
 				minX = left
 			}
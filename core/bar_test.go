@@ -1,10 +1,12 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
+	"matplotlib-go/transform"
 )
 
 func TestBar2D_Draw_Vertical(t *testing.T) {
@@ -289,3 +291,115 @@ func TestBar2D_Bounds_Vertical(t *testing.T) {
 		t.Errorf("Expected MaxY = %v, got %v", expectedMaxY, bounds.Max.Y)
 	}
 }
+
+// logBarContext maps x in [1, 1000] on a log scale to pixels 50..350, 100
+// pixels per decade, and y in [1, 100] on a log scale to pixels 450..250.
+func logBarContext() *DrawContext {
+	ctx := createTestDrawContext()
+	ctx.DataToPixel.XScale = transform.NewLog(1, 1000, 10)
+	ctx.DataToPixel.YScale = transform.NewLog(1, 100, 10)
+	ctx.DataToPixel.AxesToPixel = transform.NewAffine(geom.Affine{A: 300, D: -200, E: 50, F: 450})
+	return ctx
+}
+
+func TestBar2D_LogScale(t *testing.T) {
+	// Widths proportional to the position give equal widths on screen,
+	// centered on the position.
+	bar := &Bar2D{
+		X:           []float64{10, 100},
+		Heights:     []float64{10, 10},
+		Widths:      []float64{5, 50},
+		Color:       render.Color{A: 1},
+		Orientation: BarVertical,
+	}
+	r := &segmentRecorder{}
+	bar.Draw(r, logBarContext())
+	if len(r.paths) != 2 {
+		t.Fatalf("drew %d bars, want 2", len(r.paths))
+	}
+	width := func(p geom.Path) float64 { return p.V[1].X - p.V[0].X }
+	center := func(p geom.Path) float64 { return (p.V[0].X + p.V[1].X) / 2 }
+	if w0, w1 := width(r.paths[0]), width(r.paths[1]); w0 <= 0 || math.Abs(w0-w1) > 1e-9 {
+		t.Errorf("pixel widths = %v, %v, want equal and positive", w0, w1)
+	}
+	if c := center(r.paths[0]); math.Abs(c-150) > 1e-9 {
+		t.Errorf("bar at 10 centered at %v, want 150", c)
+	}
+	// The zero baseline is moved to the bottom of the log y axis.
+	if y := r.paths[0].V[0].Y; math.Abs(y-450) > 1e-9 {
+		t.Errorf("bar bottom = %v, want 450", y)
+	}
+
+	// A bar wider than twice its position still stays positive.
+	lo, hi := bar.span(-1, 1, 10, transform.NewLog(1, 1000, 10))
+	if lo <= 0 || math.Abs(hi-lo-10) > 1e-9 {
+		t.Errorf("span = [%v, %v], want positive with width 10", lo, hi)
+	}
+}
+
+func TestBar2D_Edges(t *testing.T) {
+	bar := &Bar2D{
+		X:           []float64{3, 30},
+		Heights:     []float64{10, 10},
+		Edges:       []float64{1, 10, 100},
+		Color:       render.Color{A: 1},
+		Orientation: BarVertical,
+	}
+	r := &segmentRecorder{}
+	bar.Draw(r, logBarContext())
+	if len(r.paths) != 2 {
+		t.Fatalf("drew %d bars, want 2", len(r.paths))
+	}
+	for i, want := range [][2]float64{{50, 150}, {150, 250}} {
+		v := r.paths[i].V
+		if math.Abs(v[0].X-want[0]) > 1e-9 || math.Abs(v[1].X-want[1]) > 1e-9 {
+			t.Errorf("bar %d spans %v..%v, want %v", i, v[0].X, v[1].X, want)
+		}
+	}
+	b := bar.Bounds(nil)
+	if b.Min.X != 1 || b.Max.X != 100 {
+		t.Errorf("Bounds x = %v..%v, want 1..100", b.Min.X, b.Max.X)
+	}
+}
+
+func TestBar2D_Polar(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddPolarAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Polar.Margin = 0
+	ax.SetYLim(0, 2)
+	ctx := ax.drawContext(fig)
+	center := geom.Pt{X: 100, Y: 100}
+	dist := func(p geom.Pt) float64 { return math.Hypot(p.X-center.X, p.Y-center.Y) }
+
+	// A vertical bar is a sector from the center out to its height.
+	bar := &Bar2D{X: []float64{math.Pi / 2}, Heights: []float64{1}, Width: math.Pi / 2, Color: render.Color{A: 1}}
+	r := &segmentRecorder{}
+	bar.Draw(r, ctx)
+	if len(r.paths) != 1 {
+		t.Fatalf("drew %d bars, want 1", len(r.paths))
+	}
+	maxR, minY := 0.0, math.Inf(1)
+	for _, v := range r.paths[0].V {
+		maxR = math.Max(maxR, dist(v))
+		minY = math.Min(minY, v.Y)
+	}
+	if math.Abs(maxR-50) > 1e-9 || len(r.paths[0].V) <= 4 {
+		t.Errorf("sector reaches radius %v with %d vertices, want 50 and an arc", maxR, len(r.paths[0].V))
+	}
+	if math.Abs(minY-50) > 1e-9 {
+		t.Errorf("sector centered at θ=π/2 reaches y=%v, want 50", minY)
+	}
+
+	// A horizontal bar is an arc band at its radius.
+	bar.Orientation = BarHorizontal
+	bar.X = []float64{1.5}
+	bar.Widths = []float64{1}
+	bar.Heights = []float64{math.Pi}
+	r = &segmentRecorder{}
+	bar.Draw(r, ctx)
+	for _, v := range r.paths[0].V {
+		if d := dist(v); d < 50-1e-9 || d > 100+1e-9 {
+			t.Fatalf("band vertex at radius %v, want within [50, 100]", d)
+		}
+	}
+}
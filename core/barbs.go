@@ -0,0 +1,182 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Barbs draws meteorological wind barbs. Each staff points from its
+// station into the direction the wind comes from; speeds, rounded to the
+// nearest 5 units (knots by convention), are shown by flags of 50, full
+// barbs of 10 and a half barb of 5 at the staff's outer end. Calm winds
+// below 2.5 are drawn as circles. Feathers sit on the clockwise side of
+// the staff, as used in the northern hemisphere; Flip moves them to the
+// other side.
+type Barbs struct {
+	XY         []geom.Pt    // data space station positions
+	Speeds     []float64    // wind speeds
+	Directions []float64    // directions the wind comes from, in degrees clockwise from north (up)
+	Length     float64      // staff length in pixels
+	Color      render.Color // staff, barb and flag color
+	W          float64      // stroke width in pixels
+	Flip       bool         // feathers on the counterclockwise side, for the southern hemisphere
+	Label      string       // series label for legend
+	z          float64      // z-order
+}
+
+// Proportions of a barb relative to the staff length, as in matplotlib.
+const (
+	barbSpacing = 0.125 // distance between feathers along the staff
+	barbHeight  = 0.4   // feather length
+	barbWidth   = 0.25  // flag base along the staff, and feather slant
+	barbCalm    = 0.15  // radius of the calm circle
+)
+
+// Barbs draws wind barbs at the points (x, y) for the given speeds and
+// directions, in the next color of the cycle unless WithColor is given.
+// WithLineWidth sets the stroke width.
+func (a *Axes) Barbs(x, y, speed, direction []float64, opts ...PlotOption) *Barbs {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(checkLengths("Barbs", "x,y,speed,direction", x, y, speed, direction), opt.check("Barbs")) {
+		return nil
+	}
+	n := min(len(x), len(y), len(speed), len(direction))
+	if n == 0 {
+		return nil
+	}
+
+	color := a.NextColor()
+	if opt.Color != nil {
+		color = *opt.Color
+	}
+	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
+		color.A = *opt.Alpha
+	}
+	lineWidth := 1.0
+	if opt.LineWidth != nil {
+		lineWidth = *opt.LineWidth
+	}
+
+	b := &Barbs{
+		XY:         make([]geom.Pt, n),
+		Speeds:     speed[:n:n],
+		Directions: direction[:n:n],
+		Length:     28,
+		Color:      color,
+		W:          lineWidth,
+		Label:      opt.Label,
+	}
+	for i := range n {
+		b.XY[i] = geom.Pt{X: x[i], Y: y[i]}
+	}
+	a.Add(b)
+	return b
+}
+
+// barbCounts splits speed, rounded to the nearest 5, into flags, full
+// barbs and half barbs.
+func barbCounts(speed float64) (flags, full, half int) {
+	n := int(math.Round(math.Abs(speed) / 5)) // half barbs in total
+	flags, n = n/10, n%10
+	return flags, n / 2, n % 2
+}
+
+// Draw renders the barbs: staffs and feathers as one stroked path, flags
+// as one filled path.
+func (b *Barbs) Draw(r render.Renderer, ctx *DrawContext) {
+	var lines, flags geom.Path
+	for i, pt := range b.XY {
+		if i >= len(b.Speeds) || i >= len(b.Directions) {
+			break
+		}
+		p := ctx.DataToPixel.Apply(pt)
+		if !finitePt(p) || math.IsNaN(b.Speeds[i]) || math.IsNaN(b.Directions[i]) {
+			continue
+		}
+		l, f := b.glyph(p, b.Speeds[i])
+		rotateMarker(&l, p, -b.Directions[i])
+		rotateMarker(&f, p, -b.Directions[i])
+		appendPath(&lines, l)
+		appendPath(&flags, f)
+	}
+	if len(lines.C) > 0 {
+		r.Path(lines, &render.Paint{
+			Stroke:    b.Color,
+			LineWidth: b.W,
+			LineJoin:  render.JoinRound,
+			LineCap:   render.CapRound,
+		})
+	}
+	if len(flags.C) > 0 {
+		r.Path(flags, &render.Paint{Fill: b.Color})
+	}
+}
+
+// glyph returns the stroked and filled parts of the barb for speed at p,
+// with the staff pointing up.
+func (b *Barbs) glyph(p geom.Pt, speed float64) (lines, flags geom.Path) {
+	L := b.Length
+	nFlags, nFull, nHalf := barbCounts(speed)
+	if nFlags+nFull+nHalf == 0 {
+		addRing(&lines, circleRing(p, barbCalm*L))
+		return lines, flags
+	}
+	side := 1.0
+	if b.Flip {
+		side = -1
+	}
+	h, w, sp := barbHeight*L*side, barbWidth*L, barbSpacing*L
+	tip := p.Y - L
+
+	lines.MoveTo(p)
+	lines.LineTo(geom.Pt{X: p.X, Y: tip})
+	pos := 0.0 // distance of the next feather from the tip
+	for range nFlags {
+		flags.MoveTo(geom.Pt{X: p.X, Y: tip + pos})
+		flags.LineTo(geom.Pt{X: p.X + h, Y: tip + pos + w/2})
+		flags.LineTo(geom.Pt{X: p.X, Y: tip + pos + w})
+		flags.Close()
+		pos += w + sp/2
+	}
+	for range nFull {
+		lines.MoveTo(geom.Pt{X: p.X, Y: tip + pos})
+		lines.LineTo(geom.Pt{X: p.X + h, Y: tip + pos - w/2})
+		pos += sp
+	}
+	if nHalf > 0 {
+		if nFlags+nFull == 0 {
+			pos += sp // a lone half barb is set off from the tip
+		}
+		lines.MoveTo(geom.Pt{X: p.X, Y: tip + pos})
+		lines.LineTo(geom.Pt{X: p.X + h/2, Y: tip + pos - w/4})
+	}
+	return lines, flags
+}
+
+// appendPath appends the subpaths of src to dst.
+func appendPath(dst *geom.Path, src geom.Path) {
+	dst.C = append(dst.C, src.C...)
+	dst.V = append(dst.V, src.V...)
+}
+
+// Z returns the z-order for sorting.
+func (b *Barbs) Z() float64 {
+	return b.z
+}
+
+// Bounds returns the bounding box of the stations.
+func (b *Barbs) Bounds(*DrawContext) geom.Rect {
+	if len(b.XY) == 0 {
+		return geom.Rect{}
+	}
+	bounds := geom.Rect{Min: b.XY[0], Max: b.XY[0]}
+	for _, p := range b.XY[1:] {
+		bounds.Min.X = math.Min(bounds.Min.X, p.X)
+		bounds.Min.Y = math.Min(bounds.Min.Y, p.Y)
+		bounds.Max.X = math.Max(bounds.Max.X, p.X)
+		bounds.Max.Y = math.Max(bounds.Max.Y, p.Y)
+	}
+	return bounds
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestBarbCounts(t *testing.T) {
+	tests := []struct {
+		speed             float64
+		flags, full, half int
+	}{
+		{0, 0, 0, 0},
+		{2.4, 0, 0, 0},
+		{5, 0, 0, 1},
+		{12, 0, 1, 0},
+		{13, 0, 1, 1},
+		{65, 1, 1, 1},
+		{-20, 0, 2, 0},
+		{142, 2, 4, 0},
+		{148, 3, 0, 0},
+	}
+	for _, tt := range tests {
+		flags, full, half := barbCounts(tt.speed)
+		if flags != tt.flags || full != tt.full || half != tt.half {
+			t.Errorf("barbCounts(%v) = %d, %d, %d, want %d, %d, %d",
+				tt.speed, flags, full, half, tt.flags, tt.full, tt.half)
+		}
+	}
+}
+
+func TestBarbs_Draw(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	b := ax.Barbs([]float64{5, 5}, []float64{5, 5}, []float64{65, 0}, []float64{90, 0})
+	if b == nil || b.Length != 28 {
+		t.Fatalf("barbs %+v", b)
+	}
+
+	var r segmentRecorder
+	b.Draw(&r, createTestDrawContext()) // station at pixel (100, 400)
+	if len(r.paths) != 2 || r.paints[0].Fill.A != 0 || r.paints[1].Stroke.A != 0 {
+		t.Fatalf("want one stroked and one filled path, got %d paints %v", len(r.paths), r.paints)
+	}
+	lines, flags := r.paths[0], r.paths[1]
+
+	// Wind from the east: the staff points right, feathers turned to the
+	// clockwise side, below it on screen.
+	if !near(lines.V[0], geom.Pt{X: 100, Y: 400}) || !near(lines.V[1], geom.Pt{X: 128, Y: 400}) {
+		t.Errorf("staff %v..%v, want (100,400)..(128,400)", lines.V[0], lines.V[1])
+	}
+	if len(flags.V) != 3 || !near(flags.V[0], geom.Pt{X: 128, Y: 400}) || !(flags.V[1].Y > 400) {
+		t.Errorf("flag %v", flags.V)
+	}
+	// The staff, one full and one half barb; the calm station is a circle.
+	if moves := countCmd(lines, geom.MoveTo); moves != 4 {
+		t.Errorf("%d subpaths, want staff, two barbs and the calm circle", moves)
+	}
+
+	fig.Strict = true
+	if ax.Barbs([]float64{1}, []float64{1}, []float64{10, 20}, []float64{0}) != nil {
+		t.Error("strict Barbs accepted mismatched lengths")
+	}
+}
+
+func countCmd(p geom.Path, cmd geom.Cmd) int {
+	n := 0
+	for _, c := range p.C {
+		if c == cmd {
+			n++
+		}
+	}
+	return n
+}
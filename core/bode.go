@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+
+	"matplotlib-go/internal/geom"
+)
+
+// Bode is a frequency-response plot: magnitude in dB above phase in
+// degrees, sharing a logarithmic frequency axis.
+type Bode struct {
+	Mag   *Axes // magnitude axes, top
+	Phase *Axes // phase axes, bottom
+}
+
+// bodeGap is the gap between the two axes as a fraction of the rectangle
+// height.
+const bodeGap = 0.06
+
+// AddBode adds a Bode plot of the complex response resp sampled at the
+// positive frequencies freq (Hz) into the figure rectangle r. The phase is
+// unwrapped so it does not jump at ±180°.
+func (f *Figure) AddBode(r geom.Rect, freq []float64, resp []complex128) (*Bode, error) {
+	if len(freq) != len(resp) {
+		return nil, errors.New("bode: freq and resp differ in length")
+	}
+	if len(freq) < 2 {
+		return nil, errors.New("bode: need at least two samples")
+	}
+	fmin, fmax := math.Inf(1), math.Inf(-1)
+	for _, v := range freq {
+		if !(v > 0) || math.IsInf(v, 0) {
+			return nil, errors.New("bode: frequencies must be positive and finite")
+		}
+		fmin, fmax = math.Min(fmin, v), math.Max(fmax, v)
+	}
+	if fmin == fmax {
+		return nil, errors.New("bode: frequencies span no range")
+	}
+
+	mag := make([]float64, len(resp))
+	phase := make([]float64, len(resp))
+	for i, h := range resp {
+		mag[i] = 20 * math.Log10(cmplx.Abs(h))
+		phase[i] = cmplx.Phase(h) * 180 / math.Pi
+	}
+	unwrapDegrees(phase)
+
+	mid := (r.Min.Y + r.Max.Y) / 2
+	gap := bodeGap * (r.Max.Y - r.Min.Y) / 2
+	b := &Bode{
+		Mag:   f.AddAxes(geom.Rect{Min: r.Min, Max: geom.Pt{X: r.Max.X, Y: mid - gap}}),
+		Phase: f.AddAxes(geom.Rect{Min: geom.Pt{X: r.Min.X, Y: mid + gap}, Max: r.Max}),
+	}
+	b.SetFreqLim(fmin, fmax)
+
+	b.Mag.SetYLim(niceBounds(mag, 10))
+	b.Mag.YAxis.Formatter = DBFormatter{Prec: 1}
+	b.Mag.XAxis.ShowLabels = false // shared with the phase axes
+	b.Mag.YLabel = "Magnitude"
+
+	b.Phase.SetYLim(niceBounds(phase, 45))
+	b.Phase.XLabel = "Frequency"
+	b.Phase.YLabel = "Phase (deg)"
+
+	for _, ax := range []*Axes{b.Mag, b.Phase} {
+		g := ax.AddXGrid()
+		g.Both, g.Minor = true, true
+	}
+	b.Mag.Plot(freq, mag)
+	b.Phase.Plot(freq, phase)
+	return b, nil
+}
+
+// SetFreqLim sets the frequency range of both axes.
+func (b *Bode) SetFreqLim(min, max float64) {
+	for _, ax := range []*Axes{b.Mag, b.Phase} {
+		ax.SetXLimLog(min, max, 10)
+		if ax.XAxis != nil {
+			ax.XAxis.Locator = LogLocator{Base: 10, Minor: true}
+			ax.XAxis.Formatter = EngFormatter{Unit: "Hz", Prec: 1}
+		}
+	}
+}
+
+// unwrapDegrees removes jumps of more than 180° between neighbours in place.
+func unwrapDegrees(deg []float64) {
+	offset := 0.0
+	for i := 1; i < len(deg); i++ {
+		d := deg[i] + offset - deg[i-1]
+		offset -= 360 * math.Round(d/360)
+		deg[i] += offset
+	}
+}
+
+// niceBounds returns the range of the finite values in vs widened to
+// multiples of step, or [-step, step] if there are none.
+func niceBounds(vs []float64, step float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range vs {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	if lo > hi {
+		return -step, step
+	}
+	lo, hi = math.Floor(lo/step)*step+0, math.Ceil(hi/step)*step+0 // +0 clears -0
+	if lo == hi {
+		lo, hi = lo-step, hi+step
+	}
+	return lo, hi
+}
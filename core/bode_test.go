@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestAddBode(t *testing.T) {
+	// First-order low-pass with a 100 Hz corner.
+	var freq []float64
+	var resp []complex128
+	for i := 0; i <= 40; i++ {
+		f := math.Pow(10, float64(i)/10)
+		freq = append(freq, f)
+		resp = append(resp, 1/complex(1, f/100))
+	}
+
+	fig := NewFigure(400, 300)
+	b, err := fig.AddBode(geom.Rect{Max: geom.Pt{X: 1, Y: 1}}, freq, resp)
+	if err != nil {
+		t.Fatalf("AddBode: %v", err)
+	}
+	if len(fig.Children) != 2 || b.Mag.RectFraction.Max.Y >= b.Phase.RectFraction.Min.Y {
+		t.Fatalf("want magnitude axes stacked above phase axes")
+	}
+	for _, ax := range []*Axes{b.Mag, b.Phase} {
+		if ax.XScale != transform.NewLog(1, 1e4, 10) {
+			t.Errorf("XScale = %#v, want log 1..1e4", ax.XScale)
+		}
+	}
+	if lo, hi := b.Mag.YScale.Domain(); lo != -50 || hi != 0 || math.Signbit(hi) {
+		t.Errorf("magnitude limits %v..%v, want -50..0", lo, hi)
+	}
+	if lo, hi := b.Phase.YScale.Domain(); lo != -90 || hi != 0 {
+		t.Errorf("phase limits %v..%v, want -90..0", lo, hi)
+	}
+
+	b.SetFreqLim(10, 1000)
+	if b.Mag.XScale != b.Phase.XScale || b.Phase.XScale != transform.NewLog(10, 1000, 10) {
+		t.Errorf("SetFreqLim did not update both axes")
+	}
+}
+
+func TestAddBodeErrors(t *testing.T) {
+	fig := NewFigure(100, 100)
+	r := geom.Rect{Max: geom.Pt{X: 1, Y: 1}}
+	tests := []struct {
+		name string
+		freq []float64
+		resp []complex128
+	}{
+		{"length mismatch", []float64{1, 2}, []complex128{1}},
+		{"too short", []float64{1}, []complex128{1}},
+		{"zero frequency", []float64{0, 1}, []complex128{1, 1}},
+		{"no range", []float64{5, 5}, []complex128{1, 1}},
+	}
+	for _, tc := range tests {
+		if _, err := fig.AddBode(r, tc.freq, tc.resp); err == nil {
+			t.Errorf("%s: no error", tc.name)
+		}
+	}
+	if len(fig.Children) != 0 {
+		t.Errorf("failed calls added %d axes", len(fig.Children))
+	}
+}
+
+func TestUnwrapDegrees(t *testing.T) {
+	deg := []float64{170, -170, -150, 170, 10}
+	unwrapDegrees(deg)
+	want := []float64{170, 190, 210, 170, 10}
+	for i := range want {
+		if math.Abs(deg[i]-want[i]) > 1e-9 {
+			t.Fatalf("unwrapped %v, want %v", deg, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"errors"
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/stats"
+)
+
+// BrokenAxes is a y-axis broken into two panels sharing the x-axis: the
+// bulk of the data in Bottom and outliers far above it in Top, with
+// diagonal marks at the break. Plot through its methods to draw into both
+// panels, so that lines crossing the break continue in the other panel.
+// It is a figure-level artist drawing the marks.
+type BrokenAxes struct {
+	Top       *Axes        // panel above the break, for the outliers
+	Bottom    *Axes        // panel below the break
+	Threshold float64      // largest value shown in Bottom
+	MarkSize  float64      // length of the break marks in pixels, 0 for none
+	Color     render.Color // color of the break marks
+	fig       *Figure
+	z         float64 // z-order among figure-level artists
+}
+
+const (
+	brokenTop = 0.3  // fraction of the rectangle height taken by the top panel
+	brokenGap = 0.04 // gap between the panels as a fraction of the rectangle height
+)
+
+// OutlierThreshold returns a break threshold for AddBrokenAxes: Tukey's
+// far-out fence Q3 + 3·IQR of all values of ys.
+func OutlierThreshold(ys ...[]float64) float64 {
+	var all []float64
+	for _, y := range ys {
+		all = append(all, y...)
+	}
+	return stats.UpperFence(all, 3)
+}
+
+// AddBrokenAxes adds a broken y-axis into the figure rectangle r for the
+// data series ys, which it does not plot. Values up to threshold set the
+// limits of the bottom panel and larger ones those of the top panel, each
+// with a 5% margin; pass OutlierThreshold(ys...) to detect outliers. The
+// top panel takes 30% of the height and hides its x-axis. It fails if
+// either panel would be empty.
+func (f *Figure) AddBrokenAxes(r geom.Rect, ys [][]float64, threshold float64) (*BrokenAxes, error) {
+	if math.IsNaN(threshold) {
+		return nil, errors.New("broken axes: threshold is NaN")
+	}
+	low, high := emptyRange(), emptyRange()
+	for _, y := range ys {
+		for _, v := range y {
+			if v <= threshold {
+				low = low.add(v)
+			} else {
+				high = high.add(v)
+			}
+		}
+	}
+	if low.lo > low.hi || high.lo > high.hi {
+		return nil, errors.New("broken axes: no values on one side of the threshold")
+	}
+
+	h := r.Max.Y - r.Min.Y
+	split := r.Min.Y + brokenTop*h
+	gap := brokenGap * h / 2
+	b := &BrokenAxes{
+		Top:       f.AddAxes(geom.Rect{Min: r.Min, Max: geom.Pt{X: r.Max.X, Y: split - gap}}),
+		Bottom:    f.AddAxes(geom.Rect{Min: geom.Pt{X: r.Min.X, Y: split + gap}, Max: r.Max}),
+		Threshold: threshold,
+		MarkSize:  8,
+		Color:     render.Color{A: 1},
+		fig:       f,
+	}
+	b.Top.SetYLim(high.margin(0.05))
+	b.Bottom.SetYLim(low.margin(0.05))
+	if ax := b.Top.XAxis; ax != nil {
+		ax.ShowSpine, ax.ShowTicks, ax.ShowLabels = false, false, false
+	}
+	f.Add(b)
+	return b, nil
+}
+
+// SetXLim sets the shared x limits of both panels.
+func (b *BrokenAxes) SetXLim(min, max float64) {
+	b.Top.SetXLim(min, max)
+	b.Bottom.SetXLim(min, max)
+}
+
+// Plot draws a line into both panels.
+func (b *BrokenAxes) Plot(x, y []float64, opts ...PlotOption) [2]*Line2D {
+	return [2]*Line2D{b.Top.Plot(x, y, opts...), b.Bottom.Plot(x, y, opts...)}
+}
+
+// Scatter draws points into both panels.
+func (b *BrokenAxes) Scatter(x, y []float64, opts ...ScatterOption) [2]*Scatter2D {
+	return [2]*Scatter2D{b.Top.Scatter(x, y, opts...), b.Bottom.Scatter(x, y, opts...)}
+}
+
+// Bar draws bars into both panels; tall bars continue above the break.
+func (b *BrokenAxes) Bar(x, heights []float64, opts ...BarOption) [2]*Bar2D {
+	return [2]*Bar2D{b.Top.Bar(x, heights, opts...), b.Bottom.Bar(x, heights, opts...)}
+}
+
+// Draw renders the break marks at the facing corners of the panels.
+func (b *BrokenAxes) Draw(r render.Renderer, _ *DrawContext) {
+	if b.MarkSize <= 0 || b.fig == nil {
+		return
+	}
+	top, bottom := b.Top.layout(b.fig), b.Bottom.layout(b.fig)
+	d := b.MarkSize / 2 / math.Sqrt2
+	var p geom.Path
+	for _, c := range []geom.Pt{
+		{X: top.Min.X, Y: top.Max.Y}, {X: top.Max.X, Y: top.Max.Y},
+		{X: bottom.Min.X, Y: bottom.Min.Y}, {X: bottom.Max.X, Y: bottom.Min.Y},
+	} {
+		p.C = append(p.C, geom.MoveTo, geom.LineTo)
+		p.V = append(p.V, geom.Pt{X: c.X - d, Y: c.Y + d}, geom.Pt{X: c.X + d, Y: c.Y - d})
+	}
+	r.Path(p, &render.Paint{LineWidth: 1, LineCap: render.CapButt, Stroke: b.Color})
+}
+
+// Z returns the z-order for sorting.
+func (b *BrokenAxes) Z() float64 {
+	return b.z
+}
+
+// Bounds returns an empty rect for now.
+func (b *BrokenAxes) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestBrokenAxes(t *testing.T) {
+	y := []float64{1, 2, 3, 2, 1, 2, 3, 100, 2}
+	threshold := OutlierThreshold(y)
+	if !(threshold > 3 && threshold < 100) {
+		t.Fatalf("threshold %v does not separate the outlier", threshold)
+	}
+
+	fig := NewFigure(400, 300)
+	b, err := fig.AddBrokenAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}}, [][]float64{y}, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		ax     *Axes
+		lo, hi float64
+	}{{b.Top, 99, 101}, {b.Bottom, 0.9, 3.1}} {
+		min, max := tc.ax.YScale.Domain()
+		if math.Abs(min-tc.lo) > 1e-9 || math.Abs(max-tc.hi) > 1e-9 {
+			t.Errorf("limits %v..%v, want %v..%v", min, max, tc.lo, tc.hi)
+		}
+	}
+	if top, bottom := b.Top.RectFraction, b.Bottom.RectFraction; top.Max.Y >= bottom.Min.Y || top.Min.Y != 0.1 || bottom.Max.Y != 0.9 {
+		t.Errorf("panels %v and %v", top, bottom)
+	}
+	if b.Top.XAxis.ShowSpine || !b.Bottom.XAxis.ShowSpine {
+		t.Error("only the bottom panel should show the x-axis")
+	}
+
+	x := make([]float64, len(y))
+	for i := range x {
+		x[i] = float64(i)
+	}
+	lines := b.Plot(x, y)
+	b.SetXLim(0, 8)
+	if lines[0] == nil || lines[1] == nil || lines[0].Col != lines[1].Col {
+		t.Errorf("lines %v", lines)
+	}
+	if min, max := b.Top.XScale.Domain(); min != 0 || max != 8 {
+		t.Errorf("top x limits %v..%v", min, max)
+	}
+
+	var r segmentRecorder
+	b.Draw(&r, fig.drawContext())
+	if len(r.paths) != 1 || len(r.paths[0].V) != 8 {
+		t.Fatalf("break marks %v", r.paths)
+	}
+	// The first mark crosses the lower left corner of the top panel.
+	corner := geom.Pt{X: 40, Y: 0.1*300 + (0.3*0.8-0.02*0.8)*300}
+	mid := geom.Pt{X: (r.paths[0].V[0].X + r.paths[0].V[1].X) / 2, Y: (r.paths[0].V[0].Y + r.paths[0].V[1].Y) / 2}
+	if !near(mid, corner) {
+		t.Errorf("mark centered at %v, want %v", mid, corner)
+	}
+
+	if _, err := fig.AddBrokenAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}}, [][]float64{{1, 2}}, 10); err == nil {
+		t.Error("no error without values above the threshold")
+	}
+}
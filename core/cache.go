@@ -0,0 +1,153 @@
+package core
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// DefaultCacheEntries is the capacity of a RenderCache created with a
+// non-positive size.
+const DefaultCacheEntries = 128
+
+// CacheStats reports RenderCache activity.
+type CacheStats struct {
+	Hits, Misses int
+	Uncacheable  int // renders of figures without a fingerprint
+	Entries      int
+}
+
+// RenderCache keeps rendered output keyed by figure fingerprint, so identical
+// requests (e.g. in an HTTP handler) skip drawing and encoding. It is safe
+// for concurrent use.
+//
+// Because keys are content hashes, a mutated figure never hits a stale
+// entry. For figures that are expensive to fingerprint, set AssumeUnchanged
+// and call Invalidate after mutating their artists.
+type RenderCache struct {
+	// AssumeUnchanged reuses a figure's fingerprint across calls until
+	// Invalidate is called for it, instead of rehashing every time.
+	AssumeUnchanged bool
+
+	mu      sync.Mutex
+	max     int
+	lru     *list.List               // of *cacheEntry, most recent first
+	entries map[string]*list.Element // by key
+	prints  map[*Figure]string       // memoized fingerprints
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewRenderCache creates a cache holding up to maxEntries rendered outputs,
+// evicting the least recently used.
+func NewRenderCache(maxEntries int) *RenderCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+	return &RenderCache{
+		max:     maxEntries,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		prints:  make(map[*Figure]string),
+	}
+}
+
+// Render returns the bytes for fig, calling render on a miss and storing its
+// result. variant distinguishes outputs of the same figure, such as "png" and
+// "svg" or different sizes. Figures that cannot be fingerprinted are always
+// rendered and never stored. The returned slice must not be modified.
+func (c *RenderCache) Render(fig *Figure, variant string, render func() ([]byte, error)) ([]byte, error) {
+	fp, err := c.fingerprint(fig)
+	if errors.Is(err, ErrUnhashable) {
+		c.mu.Lock()
+		c.stats.Uncacheable++
+		c.mu.Unlock()
+		return render()
+	}
+	if err != nil {
+		return nil, err
+	}
+	key := fp + "/" + variant
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		c.stats.Hits++
+		data := el.Value.(*cacheEntry).data
+		c.mu.Unlock()
+		return data, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	data, err := render()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok { // stored concurrently
+		c.lru.MoveToFront(el)
+		return el.Value.(*cacheEntry).data, nil
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, data: data})
+	for c.lru.Len() > c.max {
+		old := c.lru.Back()
+		c.lru.Remove(old)
+		delete(c.entries, old.Value.(*cacheEntry).key)
+	}
+	return data, nil
+}
+
+func (c *RenderCache) fingerprint(fig *Figure) (string, error) {
+	if c.AssumeUnchanged {
+		c.mu.Lock()
+		fp, ok := c.prints[fig]
+		c.mu.Unlock()
+		if ok {
+			return fp, nil
+		}
+	}
+	fp, err := Fingerprint(fig)
+	if err != nil {
+		return "", err
+	}
+	if c.AssumeUnchanged {
+		c.mu.Lock()
+		c.prints[fig] = fp
+		c.mu.Unlock()
+	}
+	return fp, nil
+}
+
+// Invalidate forgets the memoized fingerprint of fig. Call it after mutating
+// a figure's artists when AssumeUnchanged is set. Entries for its previous
+// content stay cached, since an identical figure may still request them.
+func (c *RenderCache) Invalidate(fig *Figure) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.prints, fig)
+}
+
+// Purge drops all cached output and memoized fingerprints.
+func (c *RenderCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.prints = make(map[*Figure]string)
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *RenderCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.lru.Len()
+	return s
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"matplotlib-go/font"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func cacheFigure(y float64) *Figure {
+	fig := NewFigure(100, 80)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: y}}, W: 1})
+	ax.Add(&Scatter2D{XY: []geom.Pt{{X: 0.5, Y: 0.5}}, Size: 3, z: -1})
+	return fig
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := Fingerprint(cacheFigure(1))
+	if err != nil {
+		t.Fatalf("Fingerprint failed: %v", err)
+	}
+	b, _ := Fingerprint(cacheFigure(1))
+	if a != b {
+		t.Error("identical figures should have equal fingerprints")
+	}
+	c, _ := Fingerprint(cacheFigure(0.5))
+	if a == c {
+		t.Error("different data should change the fingerprint")
+	}
+
+	// Drawing sorts artists by z; that must not change the fingerprint.
+	fig := cacheFigure(1)
+	DrawFigure(fig, &render.NullRenderer{})
+	if d, _ := Fingerprint(fig); d != a {
+		t.Error("drawing changed the fingerprint")
+	}
+
+	fig.Children[0].Add(ArtistFunc(func(render.Renderer, *DrawContext) {}))
+	if _, err := Fingerprint(fig); !errors.Is(err, ErrUnhashable) {
+		t.Errorf("expected ErrUnhashable, got %v", err)
+	}
+}
+
+func TestFingerprint_RegisteredFont(t *testing.T) {
+	if err := font.Register("FingerprintFont", gomono.TTF); err != nil {
+		t.Fatal(err)
+	}
+	fig := cacheFigure(1)
+	fig.RC.FontKey = "FingerprintFont"
+	a, _ := Fingerprint(fig)
+	if err := font.Register("FingerprintFont", goregular.TTF); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := Fingerprint(fig); a == b {
+		t.Error("re-registering the figure's font should change the fingerprint")
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	c := NewRenderCache(2)
+	calls := 0
+	render := func() ([]byte, error) { calls++; return []byte{byte(calls)}, nil }
+
+	fig := cacheFigure(1)
+	first, _ := c.Render(fig, "png", render)
+	again, _ := c.Render(cacheFigure(1), "png", render)
+	if calls != 1 || again[0] != first[0] {
+		t.Fatalf("expected a hit for identical content, calls=%d", calls)
+	}
+	c.Render(fig, "svg", render)
+	if calls != 2 {
+		t.Fatalf("variants must be cached separately, calls=%d", calls)
+	}
+
+	// Mutation is detected through the content hash.
+	fig.Children[0].Artists[1].(*Line2D).XY[1].Y = 2
+	c.Render(fig, "png", render)
+	if calls != 3 {
+		t.Fatalf("mutated figure should miss, calls=%d", calls)
+	}
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 3 || s.Entries != 2 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestRenderCacheHooks(t *testing.T) {
+	c := NewRenderCache(0)
+	calls := 0
+	draw := func() ([]byte, error) { calls++; return nil, nil }
+
+	c.Render(cacheFigure(1), "", draw)
+	fig := cacheFigure(1)
+	fig.OnPostDraw(func(render.Renderer, *DrawContext) {})
+	c.Render(fig, "", draw)
+	if calls != 2 {
+		t.Fatalf("figure with a hook served from the cache, calls=%d", calls)
+	}
+	if s := c.Stats(); s.Uncacheable != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestRenderCacheAssumeUnchanged(t *testing.T) {
+	c := NewRenderCache(0)
+	c.AssumeUnchanged = true
+	calls := 0
+	render := func() ([]byte, error) { calls++; return nil, nil }
+
+	fig := cacheFigure(1)
+	c.Render(fig, "", render)
+	fig.Children[0].Artists[1].(*Line2D).XY[1].Y = 2
+	c.Render(fig, "", render)
+	if calls != 1 {
+		t.Fatalf("expected memoized fingerprint to hit, calls=%d", calls)
+	}
+	c.Invalidate(fig)
+	c.Render(fig, "", render)
+	if calls != 2 {
+		t.Fatalf("expected miss after Invalidate, calls=%d", calls)
+	}
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// LineCollection draws many independent polylines sharing one style, such
+// as event ticks or contour segments. Consecutive segments of the same
+// color are batched into a single path, so large collections cost few
+// renderer calls.
+type LineCollection struct {
+	Segments  [][]geom.Pt    // data space polylines
+	Colors    []render.Color // per-segment stroke colors, cycled; nil uses Color
+	Color     render.Color   // stroke color
+	W         float64        // stroke width in pixels
+	Dashes    []float64      // dash pattern (on/off pairs)
+	DashCap   render.DashCap // cap of dash ends; the segment ends stay butt
+	Label     string         // series label for legend
+	Rasterize bool           // draw as an embedded bitmap in vector output
+	z         float64        // z-order
+}
+
+// Draw renders the segments, one path per run of equal colors.
+func (c *LineCollection) Draw(r render.Renderer, ctx *DrawContext) {
+	paint := render.Paint{
+		LineWidth:  c.W,
+		LineJoin:   render.JoinRound,
+		LineCap:    render.CapButt,
+		MiterLimit: 10.0,
+		Dashes:     c.Dashes,
+		DashCap:    c.DashCap,
+	}
+	var p geom.Path
+	flush := func() {
+		if len(p.C) > 0 {
+			r.Path(p, &paint)
+			p = geom.Path{}
+		}
+	}
+	for i, seg := range c.Segments {
+		if len(seg) < 2 {
+			continue
+		}
+		col := c.segmentColor(i)
+		if col != paint.Stroke {
+			flush()
+			paint.Stroke = col
+		}
+		p.MoveTo(ctx.DataToPixel.Apply(seg[0]))
+		for _, v := range seg[1:] {
+			p.LineTo(ctx.DataToPixel.Apply(v))
+		}
+	}
+	flush()
+}
+
+func (c *LineCollection) segmentColor(i int) render.Color {
+	if len(c.Colors) == 0 {
+		return c.Color
+	}
+	return c.Colors[i%len(c.Colors)]
+}
+
+// Z returns the z-order for sorting.
+func (c *LineCollection) Z() float64 {
+	return c.z
+}
+
+// Rasterized reports whether the collection should be embedded as a bitmap in vector output.
+func (c *LineCollection) Rasterized() bool { return c.Rasterize }
+
+// Bounds returns an empty rect for now.
+func (c *LineCollection) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
+
+func (c *LineCollection) legendHandle() (string, LegendHandler) {
+	return c.Label, func(r render.Renderer, box geom.Rect) {
+		y := (box.Min.Y + box.Max.Y) / 2
+		var p geom.Path
+		p.MoveTo(geom.Pt{X: box.Min.X, Y: y})
+		p.LineTo(geom.Pt{X: box.Max.X, Y: y})
+		r.Path(p, &render.Paint{
+			LineWidth: math.Min(c.W, box.H()),
+			LineCap:   render.CapButt,
+			Stroke:    c.segmentColor(0),
+			Dashes:    c.Dashes,
+			DashCap:   c.DashCap,
+		})
+	}
+}
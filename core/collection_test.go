@@ -0,0 +1,80 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// segmentRecorder records every Path call with its subpath count.
+type segmentRecorder struct {
+	render.NullRenderer
+	paths  []geom.Path
+	paints []render.Paint
+}
+
+func (s *segmentRecorder) Path(p geom.Path, paint *render.Paint) {
+	s.paths = append(s.paths, p)
+	s.paints = append(s.paints, *paint)
+}
+
+func moveCount(p geom.Path) int {
+	var n int
+	for _, c := range p.C {
+		if c == geom.MoveTo {
+			n++
+		}
+	}
+	return n
+}
+
+func TestEventPlot_Batched(t *testing.T) {
+	fig := NewFigure(500, 500)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	lc := ax.EventPlot([][]float64{{1, 2, 3}, {}, {4.5, 6}}, WithColor(render.Color{B: 1, A: 1}), WithLineWidth(2))
+
+	if len(lc.Segments) != 5 {
+		t.Fatalf("got %d segments, want 5", len(lc.Segments))
+	}
+	// Row 2 is centered on y=2.
+	if seg := lc.Segments[3]; seg[0].X != 4.5 || math.Abs(seg[0].Y-1.6) > 1e-12 || math.Abs(seg[1].Y-2.4) > 1e-12 {
+		t.Errorf("segment 3 = %v, want x=4.5 from y=1.6 to 2.4", seg)
+	}
+
+	r := &segmentRecorder{}
+	lc.Draw(r, createTestDrawContext())
+	if len(r.paths) != 1 {
+		t.Fatalf("got %d Path calls, want 1", len(r.paths))
+	}
+	if n := moveCount(r.paths[0]); n != 5 {
+		t.Errorf("path has %d subpaths, want 5", n)
+	}
+	if p := r.paints[0]; p.LineWidth != 2 || p.Stroke != (render.Color{B: 1, A: 1}) || p.Fill.A != 0 {
+		t.Errorf("paint = %+v", p)
+	}
+}
+
+func TestLineCollection_ColorRuns(t *testing.T) {
+	red, blue := render.Color{R: 1, A: 1}, render.Color{B: 1, A: 1}
+	seg := []geom.Pt{{X: 1, Y: 1}, {X: 2, Y: 2}}
+	lc := &LineCollection{
+		Segments: [][]geom.Pt{seg, seg, seg, {{X: 1, Y: 1}}, seg},
+		Colors:   []render.Color{red, red, blue, blue, blue},
+		W:        1,
+	}
+	r := &segmentRecorder{}
+	lc.Draw(r, createTestDrawContext())
+
+	// red×2, then blue×2 (the single-point segment is skipped).
+	if len(r.paths) != 2 {
+		t.Fatalf("got %d Path calls, want 2", len(r.paths))
+	}
+	if r.paints[0].Stroke != red || moveCount(r.paths[0]) != 2 {
+		t.Errorf("first run: %+v with %d subpaths", r.paints[0].Stroke, moveCount(r.paths[0]))
+	}
+	if r.paints[1].Stroke != blue || moveCount(r.paths[1]) != 2 {
+		t.Errorf("second run: %+v with %d subpaths", r.paints[1].Stroke, moveCount(r.paths[1]))
+	}
+}
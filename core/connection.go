@@ -0,0 +1,65 @@
+package core
+
+import (
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// ConnectionPatch is a line or arrow between points of two different Axes,
+// e.g. linking an inset zoom to the region of its parent it shows. It is a
+// figure-level artist, so it crosses axes boundaries without being clipped.
+type ConnectionPatch struct {
+	XYA, XYB         geom.Pt // start and end point
+	CoordsA, CoordsB Coords  // coordinate systems of XYA and XYB
+	AxesA, AxesB     *Axes   // axes the points belong to; nil means figure fractions
+	Arrow            Arrow   // connector shape and style; its endpoints are ignored
+	fig              *Figure
+	z                float64 // z-order among figure-level artists
+}
+
+// AddConnection connects xyA in the data coordinates of axA to xyB in those
+// of axB with a plain line. Set Arrow.HeadLength for an arrow head at xyB.
+func (f *Figure) AddConnection(axA *Axes, xyA geom.Pt, axB *Axes, xyB geom.Pt) *ConnectionPatch {
+	c := &ConnectionPatch{
+		XYA:   xyA,
+		XYB:   xyB,
+		AxesA: axA,
+		AxesB: axB,
+		Arrow: Arrow{
+			HeadWidth: 7,
+			TailWidth: 3,
+			Color:     render.Color{R: 0, G: 0, B: 0, A: 1},
+			LineWidth: 1,
+		},
+		fig: f,
+	}
+	f.Add(c)
+	return c
+}
+
+// Draw renders the connector from XYA to XYB.
+func (c *ConnectionPatch) Draw(r render.Renderer, ctx *DrawContext) {
+	ar := c.Arrow
+	ar.From = c.endpoint(ctx, c.AxesA, c.XYA, c.CoordsA)
+	ar.To = c.endpoint(ctx, c.AxesB, c.XYB, c.CoordsB)
+	ar.FromCoords, ar.ToCoords = CoordsPixel, CoordsPixel
+	ar.Draw(r, ctx)
+}
+
+// endpoint maps p to pixels in the coordinates of ax, or of ctx if ax is nil.
+func (c *ConnectionPatch) endpoint(ctx *DrawContext, ax *Axes, p geom.Pt, coords Coords) geom.Pt {
+	if ax != nil && c.fig != nil {
+		ctx = ax.drawContext(c.fig)
+	}
+	return arrowPoint(ctx, p, coords)
+}
+
+// Z returns the z-order for sorting.
+func (c *ConnectionPatch) Z() float64 {
+	return c.z
+}
+
+// Bounds returns an empty rect for now.
+func (c *ConnectionPatch) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
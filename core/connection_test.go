@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestConnectionPatch_AcrossAxes(t *testing.T) {
+	fig := NewFigure(400, 200)
+	left := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.4, Y: 0.9}})
+	right := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.6, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	left.SetXLim(0, 10)
+	left.SetYLim(0, 10)
+	c := fig.AddConnection(left, geom.Pt{X: 10, Y: 10}, right, geom.Pt{X: 0, Y: 0})
+	c.CoordsB = CoordsAxes
+	c.Arrow.ShrinkA, c.Arrow.ShrinkB = 0, 0
+
+	r := &segmentRecorder{}
+	DrawFigure(fig, r)
+
+	// Drawn last, above both axes, from the upper right of the left axes to
+	// the lower left of the right one.
+	p := r.paths[len(r.paths)-1]
+	if len(r.paths) < 2 || len(p.V) != 2 {
+		t.Fatalf("connector path %+v", p)
+	}
+	if want := (geom.Pt{X: 160, Y: 20}); !near(p.V[0], want) {
+		t.Errorf("starts at %v, want %v", p.V[0], want)
+	}
+	if want := (geom.Pt{X: 240, Y: 180}); !near(p.V[1], want) {
+		t.Errorf("ends at %v, want %v", p.V[1], want)
+	}
+	if paint := r.paints[len(r.paints)-1]; paint.Stroke != (render.Color{A: 1}) || paint.LineWidth != 1 {
+		t.Errorf("paint %+v", paint)
+	}
+}
+
+func TestFigureArtists_FigureCoords(t *testing.T) {
+	fig := NewFigure(400, 200)
+	var got geom.Pt
+	fig.Add(ArtistFunc(func(_ render.Renderer, ctx *DrawContext) {
+		got = ctx.DataToPixel.Apply(geom.Pt{X: 0.25, Y: 0.75})
+	}))
+	DrawFigure(fig, &render.NullRenderer{})
+	if want := (geom.Pt{X: 100, Y: 50}); !near(got, want) {
+		t.Errorf("figure fraction (0.25, 0.75) at %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,322 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// contourLevels is the number of levels Contour picks by itself.
+const contourLevels = 7
+
+// Contour draws the lines where z crosses each of levels, e.g. for a
+// density grid from stats.KDE2D. z[j][i] is the value at (x[i], y[j]);
+// cells with a NaN corner are skipped. Without levels, seven are spread
+// evenly between the extremes of z. All lines form one LineCollection in a
+// single color (automatic cycling if not specified); LineWidth (default 1),
+// Dashes, Alpha and Label apply as in Plot.
+func (a *Axes) Contour(x, y []float64, z [][]float64, levels []float64, opts ...PlotOption) *LineCollection {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(checkGrid("Contour", x, y, z), opt.check("Contour")) {
+		return nil
+	}
+	if len(levels) == 0 {
+		levels = autoLevels(z)
+	}
+
+	var segs [][]geom.Pt
+	for _, level := range levels {
+		segs = append(segs, contourSegments(x, y, z, level)...)
+	}
+
+	color := a.NextColor()
+	if opt.Color != nil {
+		color = *opt.Color
+	}
+	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
+		color.A = *opt.Alpha
+	}
+	lineWidth := 1.0
+	if opt.LineWidth != nil {
+		lineWidth = *opt.LineWidth
+	}
+	lc := &LineCollection{
+		Segments: segs,
+		Color:    color,
+		W:        lineWidth,
+		Dashes:   opt.dashes(lineWidth),
+		DashCap:  opt.DashCap,
+		Label:    opt.Label,
+	}
+	a.Add(lc)
+	return lc
+}
+
+// Contourf fills the bands between consecutive levels of z, like
+// matplotlib's contourf: band k covers levels[k] <= z < levels[k+1], the
+// last band including its upper level. Grids and NaN cells are as in
+// Contour. Without levels, nine are spread evenly from the minimum to the
+// maximum of z. Each band is one Patch with holes where higher or lower
+// values rise through it, colored from cmap (nil for color.Viridis) from
+// the first band to the last; the patches are returned in band order.
+func (a *Axes) Contourf(x, y []float64, z [][]float64, levels []float64, cmap color.Colormap) []*Patch {
+	if !a.validate(checkGrid("Contourf", x, y, z), checkLevels("Contourf", levels)) {
+		return nil
+	}
+	if len(levels) == 0 {
+		levels = autoLevels(z)
+		if len(levels) == 0 {
+			return nil
+		}
+		r := emptyRange()
+		for _, row := range z {
+			r = r.add(row...)
+		}
+		levels = append(append([]float64{r.lo}, levels...), r.hi)
+	}
+	if cmap == nil {
+		cmap = color.Viridis
+	}
+
+	var bands []*Patch
+	for k := 0; k+1 < len(levels); k++ {
+		t := 0.5
+		if len(levels) > 2 {
+			t = float64(k) / float64(len(levels)-2)
+		}
+		p := &Patch{
+			Path:     contourBand(x, y, z, levels[k], levels[k+1], k+2 == len(levels)),
+			FillRule: render.FillEvenOdd,
+			Color:    cmap.At(t),
+		}
+		a.Add(p)
+		bands = append(bands, p)
+	}
+	return bands
+}
+
+// checkLevels requires increasing levels, if any.
+func checkLevels(op string, levels []float64) error {
+	if len(levels) == 1 {
+		return invalid(op, "one level bounds no band")
+	}
+	for k := 1; k < len(levels); k++ {
+		if !(levels[k] > levels[k-1]) {
+			return invalid(op, "levels %v are not increasing", levels)
+		}
+	}
+	return nil
+}
+
+// contourBand returns the outline of the region where lo <= z < hi, or
+// z <= hi if top is set, as rings that are holes by the even-odd rule.
+// Each cell is split into four triangles at its center, whose value is
+// the mean of the corners, and z is linear on each triangle, so that
+// saddles resolve as in Contour. The band's polygon in each triangle is
+// clipped exactly; the edges shared by neighbouring polygons cancel, and
+// the rest are joined into the rings.
+func contourBand(x, y []float64, z [][]float64, lo, hi float64, top bool) geom.Path {
+	below := func(v float64) bool { return v < hi || top && v == hi }
+	in := func(v float64) bool { return v >= lo && below(v) }
+
+	var edges contourEdges
+	var ring []geom.Pt
+	for j := 0; j+1 < len(y) && j+1 < len(z); j++ {
+		for i := 0; i+1 < len(x) && i+1 < len(z[j]) && i+1 < len(z[j+1]); i++ {
+			c := [4]geom.Pt{{X: x[i], Y: y[j]}, {X: x[i+1], Y: y[j]}, {X: x[i+1], Y: y[j+1]}, {X: x[i], Y: y[j+1]}}
+			v := [4]float64{z[j][i], z[j][i+1], z[j+1][i+1], z[j+1][i]}
+			if math.IsNaN(v[0]) || math.IsNaN(v[1]) || math.IsNaN(v[2]) || math.IsNaN(v[3]) {
+				continue
+			}
+			m := geom.Pt{X: (c[0].X + c[2].X) / 2, Y: (c[0].Y + c[2].Y) / 2}
+			vm := (v[0] + v[1] + v[2] + v[3]) / 4
+			for e := 0; e < 4; e++ {
+				tri := [3]geom.Pt{c[e], c[(e+1)%4], m}
+				tv := [3]float64{v[e], v[(e+1)%4], vm}
+				// Walk the triangle: its corners inside the band and the
+				// points where an edge enters or leaves it.
+				ring = ring[:0]
+				for k := 0; k < 3; k++ {
+					p, q, vp, vq := tri[k], tri[(k+1)%3], tv[k], tv[(k+1)%3]
+					if in(vp) {
+						ring = append(ring, p)
+					}
+					var cross [2]geom.Pt
+					n := 0
+					if (vp >= lo) != (vq >= lo) {
+						cross[n] = edgeCrossing(p, q, vp, vq, lo)
+						n++
+					}
+					if below(vp) != below(vq) {
+						cross[n] = edgeCrossing(p, q, vp, vq, hi)
+						n++
+					}
+					if n == 2 && dist2(p, cross[1]) < dist2(p, cross[0]) {
+						cross[0], cross[1] = cross[1], cross[0]
+					}
+					ring = append(ring, cross[:n]...)
+				}
+				for k := range ring {
+					edges.add(ring[k], ring[(k+1)%len(ring)])
+				}
+			}
+		}
+	}
+	return edges.rings()
+}
+
+// edgeCrossing returns the point where z, linear from vp at p to vq at q,
+// equals level. It interpolates from the lesser endpoint, so that
+// neighbouring cells get the same point for their shared edge.
+func edgeCrossing(p, q geom.Pt, vp, vq, level float64) geom.Pt {
+	if q.X < p.X || q.X == p.X && q.Y < p.Y {
+		p, q, vp, vq = q, p, vq, vp
+	}
+	t := (level - vp) / (vq - vp)
+	return geom.Pt{X: p.X + t*(q.X-p.X), Y: p.Y + t*(q.Y-p.Y)}
+}
+
+func dist2(a, b geom.Pt) float64 {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+// contourEdges collects directed polygon edges; an edge and its reverse
+// cancel, so that only the outline of the union remains.
+type contourEdges struct {
+	list  [][2]geom.Pt       // in insertion order, for a stable outline
+	count map[[2]geom.Pt]int // uncancelled copies of each edge
+}
+
+func (c *contourEdges) add(a, b geom.Pt) {
+	if a == b {
+		return
+	}
+	if c.count == nil {
+		c.count = map[[2]geom.Pt]int{}
+	}
+	if rev := [2]geom.Pt{b, a}; c.count[rev] > 0 {
+		c.count[rev]--
+		return
+	}
+	c.count[[2]geom.Pt{a, b}]++
+	c.list = append(c.list, [2]geom.Pt{a, b})
+}
+
+// rings joins the remaining edges into closed subpaths.
+func (c *contourEdges) rings() geom.Path {
+	next := map[geom.Pt][]geom.Pt{}
+	var starts []geom.Pt
+	for _, e := range c.list {
+		if c.count[e] > 0 {
+			c.count[e]--
+			next[e[0]] = append(next[e[0]], e[1])
+			starts = append(starts, e[0])
+		}
+	}
+	var path geom.Path
+	for _, s := range starts {
+		if len(next[s]) == 0 {
+			continue
+		}
+		path.MoveTo(s)
+		for p := s; len(next[p]) > 0; {
+			q := next[p][0]
+			next[p] = next[p][1:]
+			if q == s {
+				break
+			}
+			path.LineTo(q)
+			p = q
+		}
+		path.Close()
+	}
+	return path
+}
+
+// checkGrid requires z to have len(y) rows of len(x) values.
+func checkGrid(op string, x, y []float64, z [][]float64) error {
+	if len(x) < 2 || len(y) < 2 {
+		return invalid(op, "grid of %dx%d points is too small", len(x), len(y))
+	}
+	if len(z) != len(y) {
+		return invalid(op, "z has %d rows, y has %d values", len(z), len(y))
+	}
+	for j, row := range z {
+		if len(row) != len(x) {
+			return invalid(op, "z row %d has %d values, x has %d", j, len(row), len(x))
+		}
+	}
+	return nil
+}
+
+// autoLevels spreads contourLevels levels evenly inside the range of z.
+func autoLevels(z [][]float64) []float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, row := range z {
+		for _, v := range row {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if !(hi > lo) {
+		return nil
+	}
+	levels := make([]float64, contourLevels)
+	for i := range levels {
+		levels[i] = lo + (hi-lo)*float64(i+1)/(contourLevels+1)
+	}
+	return levels
+}
+
+// contourSegments returns the line pieces where z crosses level, found by
+// marching squares with one or two pieces per grid cell.
+func contourSegments(x, y []float64, z [][]float64, level float64) [][]geom.Pt {
+	var segs [][]geom.Pt
+	for j := 0; j+1 < len(y) && j+1 < len(z); j++ {
+		for i := 0; i+1 < len(x) && i+1 < len(z[j]) && i+1 < len(z[j+1]); i++ {
+			// Corners counterclockwise from the bottom left.
+			c := [4]geom.Pt{{X: x[i], Y: y[j]}, {X: x[i+1], Y: y[j]}, {X: x[i+1], Y: y[j+1]}, {X: x[i], Y: y[j+1]}}
+			v := [4]float64{z[j][i], z[j][i+1], z[j+1][i+1], z[j+1][i]}
+			if math.IsNaN(v[0]) || math.IsNaN(v[1]) || math.IsNaN(v[2]) || math.IsNaN(v[3]) {
+				continue
+			}
+
+			// Crossings on the bottom, right, top and left edges.
+			var cross [4]geom.Pt
+			var has [4]bool
+			n := 0
+			for e := 0; e < 4; e++ {
+				a, b := e, (e+1)%4
+				if (v[a] > level) != (v[b] > level) {
+					t := (level - v[a]) / (v[b] - v[a])
+					cross[e] = geom.Pt{X: c[a].X + t*(c[b].X-c[a].X), Y: c[a].Y + t*(c[b].Y-c[a].Y)}
+					has[e] = true
+					n++
+				}
+			}
+			switch n {
+			case 2:
+				var pts []geom.Pt
+				for e := 0; e < 4; e++ {
+					if has[e] {
+						pts = append(pts, cross[e])
+					}
+				}
+				segs = append(segs, pts)
+			case 4:
+				// Saddle: the cell center decides which corners connect.
+				center := (v[0] + v[1] + v[2] + v[3]) / 4
+				if (center > level) == (v[0] > level) {
+					segs = append(segs, []geom.Pt{cross[0], cross[1]}, []geom.Pt{cross[2], cross[3]})
+				} else {
+					segs = append(segs, []geom.Pt{cross[3], cross[0]}, []geom.Pt{cross[1], cross[2]})
+				}
+			}
+		}
+	}
+	return segs
+}
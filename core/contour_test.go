@@ -0,0 +1,164 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestContour_Circle(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+
+	var xs []float64
+	for i := 0; i <= 40; i++ {
+		xs = append(xs, -2+float64(i)*0.1)
+	}
+	z := make([][]float64, len(xs))
+	for j, y := range xs {
+		z[j] = make([]float64, len(xs))
+		for i, x := range xs {
+			z[j][i] = x*x + y*y
+		}
+	}
+
+	lc := ax.Contour(xs, xs, z, []float64{1})
+	if lc == nil || len(lc.Segments) < 20 {
+		t.Fatalf("got %v, want a closed ring of segments", lc)
+	}
+	for _, seg := range lc.Segments {
+		for _, p := range seg {
+			if r := math.Hypot(p.X, p.Y); math.Abs(r-1) > 0.02 {
+				t.Fatalf("point %v at radius %v, want 1", p, r)
+			}
+		}
+	}
+
+	if auto := ax.Contour(xs, xs, z, nil); auto == nil || len(auto.Segments) <= len(lc.Segments) {
+		t.Error("automatic levels drew fewer lines than one level")
+	}
+}
+
+func TestContour_Saddle(t *testing.T) {
+	x := []float64{0, 1}
+	z := [][]float64{{1, 0}, {0, 1}} // high corners bottom left and top right
+	segs := contourSegments(x, x, z, 0.4)
+	if len(segs) != 2 {
+		t.Fatalf("got %d segments, want 2", len(segs))
+	}
+	// The center (0.5) is above the level, so the high corners connect and
+	// the lines cut off the low ones.
+	for _, s := range segs {
+		mid := geom.Pt{X: (s[0].X + s[1].X) / 2, Y: (s[0].Y + s[1].Y) / 2}
+		if math.Abs(mid.X-mid.Y) < 0.1 {
+			t.Errorf("segment %v crosses the high diagonal", s)
+		}
+	}
+}
+
+// ringGrid returns a grid over [-2, 2]² and z = x²+y² on it.
+func ringGrid() ([]float64, [][]float64) {
+	var xs []float64
+	for i := 0; i <= 40; i++ {
+		xs = append(xs, -2+float64(i)*0.1)
+	}
+	z := make([][]float64, len(xs))
+	for j, y := range xs {
+		z[j] = make([]float64, len(xs))
+		for i, x := range xs {
+			z[j][i] = x*x + y*y
+		}
+	}
+	return xs, z
+}
+
+// signedArea returns the area enclosed by the subpaths of p, holes
+// counting negative when they run the other way.
+func signedArea(p geom.Path) float64 {
+	var area float64
+	var ring []geom.Pt
+	flush := func() {
+		for i := range ring {
+			a, b := ring[i], ring[(i+1)%len(ring)]
+			area += a.X*b.Y - b.X*a.Y
+		}
+		ring = ring[:0]
+	}
+	v := 0
+	for _, c := range p.C {
+		switch c {
+		case geom.MoveTo:
+			flush()
+			ring = append(ring, p.V[v])
+			v++
+		case geom.LineTo:
+			ring = append(ring, p.V[v])
+			v++
+		}
+	}
+	flush()
+	return area / 2
+}
+
+func TestContourf_Annulus(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	xs, z := ringGrid()
+
+	bands := ax.Contourf(xs, xs, z, []float64{1, 4}, nil)
+	if len(bands) != 1 {
+		t.Fatalf("got %d bands, want 1", len(bands))
+	}
+	b := bands[0]
+	if b.FillRule != render.FillEvenOdd || moveCount(b.Path) != 2 {
+		t.Fatalf("band has %d rings, rule %v; want an outer ring and a hole", moveCount(b.Path), b.FillRule)
+	}
+	for _, v := range b.Path.V {
+		if r := math.Hypot(v.X, v.Y); math.Abs(r-1) > 0.02 && math.Abs(r-2) > 0.02 {
+			t.Fatalf("outline point %v at radius %v, want 1 or 2", v, r)
+		}
+	}
+	if a := math.Abs(signedArea(b.Path)); math.Abs(a-3*math.Pi) > 0.05 {
+		t.Errorf("band area %v, want 3π", a)
+	}
+}
+
+func TestContourf_BandsTile(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	xs, z := ringGrid()
+	z[3][5] = math.NaN() // leaves four cells of 0.01 empty
+
+	bands := ax.Contourf(xs, xs, z, nil, color.Gray)
+	if len(bands) != contourLevels+1 {
+		t.Fatalf("got %d bands, want %d", len(bands), contourLevels+1)
+	}
+	var total float64
+	for _, b := range bands {
+		total += math.Abs(signedArea(b.Path))
+	}
+	if math.Abs(total-(16-0.04)) > 1e-9 {
+		t.Errorf("bands cover %v, want the grid's 15.96", total)
+	}
+	if bands[0].Color != color.Gray.At(0) || bands[len(bands)-1].Color != color.Gray.At(1) {
+		t.Error("bands not colored from the ends of the colormap")
+	}
+}
+
+func TestContour_StrictGrid(t *testing.T) {
+	fig := NewFigure(100, 100)
+	fig.Strict = true
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	if ax.Contour([]float64{0, 1}, []float64{0, 1}, [][]float64{{0, 1}}, nil) != nil {
+		t.Error("strict Contour accepted a short grid")
+	}
+	if ax.Contourf([]float64{0, 1}, []float64{0, 1}, [][]float64{{0, 1}, {1, 0}}, []float64{1, 0}, nil) != nil {
+		t.Error("strict Contourf accepted decreasing levels")
+	}
+	if fig.Err() == nil {
+		t.Error("no error recorded")
+	}
+}
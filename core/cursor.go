@@ -0,0 +1,114 @@
+package core
+
+import (
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// LinkedCursor is a crosshair shared by axes with a common x range, such as
+// the stacked panels of a multi-panel time series: while the pointer is
+// over one of them, a vertical line marks the same data x in all of them,
+// and a horizontal line the data y in the axes under the pointer.
+// Interactive frontends call Move from their pointer handler and redraw the
+// cursor's artists when it reports a change:
+//
+//	cursor := fig.AddLinkedCursor(top, bottom)
+//	onMouseMove := func(p geom.Pt) {
+//		if cursor.Move(p) {
+//			redrawer.Invalidate(cursor.Artists()...)
+//			redrawer.Render()
+//		}
+//	}
+type LinkedCursor struct {
+	Color render.Color // line color
+	Width float64      // line width in pixels
+	X, Y  float64      // data position of the pointer, valid while Active is non-nil
+	// Active is the axes under the pointer, nil while the cursor is hidden.
+	Active *Axes
+	fig    *Figure
+	lines  []*cursorLine
+}
+
+// AddLinkedCursor adds a hidden cursor linking the given axes of the
+// figure, drawn above their artists.
+func (f *Figure) AddLinkedCursor(axes ...*Axes) *LinkedCursor {
+	c := &LinkedCursor{
+		Color: render.Color{R: 0.4, G: 0.4, B: 0.4, A: 1},
+		Width: 1,
+		fig:   f,
+	}
+	for _, ax := range axes {
+		l := &cursorLine{cursor: c, axes: ax}
+		ax.Add(l)
+		c.lines = append(c.lines, l)
+	}
+	return c
+}
+
+// Move places the cursor at pixel p. Over one of the linked axes it shows
+// the cursor at the data position under p; elsewhere it hides it. It
+// reports whether the cursor changed.
+func (c *LinkedCursor) Move(p geom.Pt) bool {
+	for _, l := range c.lines {
+		ctx := l.axes.drawContext(c.fig)
+		if !ctx.Clip.Contains(p) {
+			continue
+		}
+		d, ok := ctx.DataToPixel.Invert(p)
+		if !ok {
+			break
+		}
+		changed := c.Active != l.axes || c.X != d.X || c.Y != d.Y
+		c.Active, c.X, c.Y = l.axes, d.X, d.Y
+		return changed
+	}
+	return c.Hide()
+}
+
+// Hide hides the cursor, e.g. when the pointer leaves the figure, and
+// reports whether it was shown.
+func (c *LinkedCursor) Hide() bool {
+	shown := c.Active != nil
+	c.Active = nil
+	return shown
+}
+
+// Artists returns the cursor's artists, one per linked axes, e.g. for
+// Redrawer.Invalidate.
+func (c *LinkedCursor) Artists() []Artist {
+	arts := make([]Artist, len(c.lines))
+	for i, l := range c.lines {
+		arts[i] = l
+	}
+	return arts
+}
+
+// cursorLine draws a LinkedCursor in one of its axes.
+type cursorLine struct {
+	cursor *LinkedCursor
+	axes   *Axes
+}
+
+func (l *cursorLine) Draw(r render.Renderer, ctx *DrawContext) {
+	c := l.cursor
+	if c.Active == nil || c.Width <= 0 {
+		return
+	}
+	paint := &render.Paint{Stroke: c.Color, LineWidth: c.Width, LineJoin: render.JoinMiter, LineCap: render.CapButt}
+	line := func(tr transform.T, a, b geom.Pt) {
+		var p geom.Path
+		p.MoveTo(tr.Apply(a))
+		p.LineTo(tr.Apply(b))
+		r.Path(p, paint)
+	}
+	line(ctx.XAxisTransform(), geom.Pt{X: c.X}, geom.Pt{X: c.X, Y: 1})
+	if c.Active == l.axes {
+		line(ctx.YAxisTransform(), geom.Pt{Y: c.Y}, geom.Pt{X: 1, Y: c.Y})
+	}
+}
+
+// Z places the cursor above data, axes and legends.
+func (l *cursorLine) Z() float64 { return 2000 }
+
+func (l *cursorLine) Bounds(*DrawContext) geom.Rect { return geom.Rect{} }
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestLinkedCursor(t *testing.T) {
+	fig := NewFigure(200, 200)
+	axes := fig.Subplots(2, 1, SubplotOptions{Left: 0.1, Right: 0.1, Top: 0.1, Bottom: 0.1})
+	top, bottom := axes[0][0], axes[1][0]
+	top.SetXLim(0, 16)
+	bottom.SetXLim(0, 16)
+	bottom.SetYLim(0, 10)
+	c := fig.AddLinkedCursor(top, bottom)
+
+	draw := func(ax *Axes) []geom.Path {
+		var r segmentRecorder
+		for _, art := range c.Artists() {
+			if art.(*cursorLine).axes == ax {
+				art.Draw(&r, ax.drawContext(fig))
+			}
+		}
+		return r.paths
+	}
+	if len(draw(top)) != 0 {
+		t.Error("hidden cursor drawn")
+	}
+
+	// The axes span x 20..180; the top one y 20..20+160/2.2 in pixels.
+	p := geom.Pt{X: 60, Y: 150}
+	if !c.Move(p) {
+		t.Fatal("move into the axes reported no change")
+	}
+	if d, ok := bottom.drawContext(fig).DataToPixel.Invert(p); c.Active != bottom || !ok || c.X != d.X || !near(geom.Pt{X: c.X}, geom.Pt{X: 4}) {
+		t.Errorf("cursor at x %v in %p, want 4 in the bottom axes", c.X, c.Active)
+	}
+	if c.Move(p) {
+		t.Error("move to the same pixel reported a change")
+	}
+	vertical := draw(top)
+	if len(vertical) != 1 || !near(vertical[0].V[0], geom.Pt{X: 60, Y: 20 + 160/2.2}) || !near(vertical[0].V[1], geom.Pt{X: 60, Y: 20}) {
+		t.Errorf("top axes cursor %v, want a vertical line at x 60", vertical)
+	}
+	if lines := draw(bottom); len(lines) != 2 || !near(lines[1].V[0], geom.Pt{X: 20, Y: 150}) || !near(lines[1].V[1], geom.Pt{X: 180, Y: 150}) {
+		t.Errorf("bottom axes cursor %v, want a vertical and a horizontal line at y 150", lines)
+	}
+
+	if !c.Move(geom.Pt{X: 5, Y: 5}) || c.Active != nil {
+		t.Error("cursor not hidden outside the axes")
+	}
+	if c.Hide() {
+		t.Error("hiding a hidden cursor reported a change")
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// DataLabels styles the text labels drawn next to the points of a Line2D
+// or Scatter2D. Labels sit above and to the right of their point; points
+// outside the clip get none.
+type DataLabels struct {
+	Formatter    Formatter    // formats the y value of points without a text label, nil for ScalarFormatter{Prec: 2}
+	Every        int          // label every Nth point, 0 or 1 for all
+	Size         float64      // font size, 0 for 10
+	Color        render.Color // text color, 0 alpha for black
+	Offset       float64      // gap between point and label in pixels, 0 for 4
+	AvoidOverlap bool         // move labels apart with PlaceLabels, hiding those without room
+}
+
+// drawDataLabels draws labels for the points xy: texts[i] if given, else
+// the formatted y value when style is non-nil. gap is the extra distance
+// in pixels kept from the points, e.g. for markers. It draws nothing
+// without texts and style, or on renderers without text support.
+func drawDataLabels(r render.Renderer, ctx *DrawContext, xy Points, texts []string, style *DataLabels, gap float64) {
+	td, ok := r.(render.TextDrawer)
+	if !ok || (texts == nil && style == nil) {
+		return
+	}
+	var s DataLabels
+	if style != nil {
+		s = *style
+	}
+	if s.Formatter == nil {
+		s.Formatter = ScalarFormatter{Prec: 2}
+	}
+	if s.Size <= 0 {
+		s.Size = 10
+	}
+	if s.Color.A == 0 {
+		s.Color = render.Color{A: 1}
+	}
+	if s.Offset <= 0 {
+		s.Offset = 4
+	}
+	s.Offset += gap
+	every := max(s.Every, 1)
+
+	var text []string
+	var boxes []LabelBox
+	var ascents []float64
+	for i := 0; i < xy.Len(); i += every {
+		pt := xy.At(i)
+		var t string
+		switch {
+		case i < len(texts):
+			t = texts[i]
+		case style != nil && texts == nil:
+			t = s.Formatter.Format(pt.Y)
+		}
+		p := ctx.DataToPixel.Apply(pt)
+		if t == "" || math.IsNaN(p.X) || math.IsNaN(p.Y) || !ctx.Clip.Contains(p) {
+			continue
+		}
+		m := r.MeasureText(t, s.Size, ctx.RC.FontKey)
+		text = append(text, t)
+		boxes = append(boxes, LabelBox{Anchor: p, W: m.W, H: m.Ascent + m.Descent})
+		ascents = append(ascents, m.Ascent)
+	}
+
+	placed := make([]Placement, len(boxes))
+	if s.AvoidOverlap {
+		placed = PlaceLabels(boxes, PlaceOptions{Bounds: ctx.Clip, Distance: s.Offset, Drop: true})
+	} else {
+		for i, b := range boxes {
+			placed[i] = Placement{Rect: labelRect(b, labelDirections[0], s.Offset), Visible: true}
+		}
+	}
+	for i, p := range placed {
+		if p.Visible {
+			td.DrawText(text[i], geom.Pt{X: p.Rect.Min.X, Y: p.Rect.Min.Y + ascents[i]}, s.Size, s.Color)
+		}
+	}
+}
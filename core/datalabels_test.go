@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestDataLabels(t *testing.T) {
+	ctx := createTestDrawContext()
+	newRecorder := func() *textRecorder {
+		return &textRecorder{Renderer: gobasic.New(500, 500, render.Color{A: 1}), texts: map[string]geom.Pt{}}
+	}
+
+	// Explicit labels sit above and to the right of their points.
+	line := &Line2D{
+		XY:     []geom.Pt{{X: 1, Y: 1}, {X: 2, Y: 4}, {X: 3, Y: 9}},
+		W:      2,
+		Labels: []string{"a", "", "c"},
+	}
+	r := newRecorder()
+	line.Draw(r, ctx)
+	if len(r.texts) != 2 {
+		t.Fatalf("drew labels %v, want a and c", r.texts)
+	}
+	if p := r.texts["a"]; p.X <= 60 || p.Y >= 440 {
+		t.Errorf("label a at %+v, want above right of (60, 440)", p)
+	}
+
+	// Without texts the y values are formatted, every Nth point.
+	sc := &Scatter2D{
+		XY:         []geom.Pt{{X: 1, Y: 1}, {X: 2, Y: 2.5}, {X: 3, Y: 3}, {X: 4, Y: 20}},
+		Size:       3,
+		DataLabels: &DataLabels{Every: 2, Formatter: ScalarFormatter{Prec: 1}},
+	}
+	r = newRecorder()
+	sc.Draw(r, ctx)
+	if _, ok := r.texts["1"]; !ok || len(r.texts) != 2 {
+		t.Errorf("drew labels %v, want 1 and 3", r.texts)
+	}
+
+	// Overlapping labels are spread apart.
+	sc = &Scatter2D{
+		XY:         []geom.Pt{{X: 5, Y: 5}, {X: 5.05, Y: 5}},
+		Labels:     []string{"first", "second"},
+		DataLabels: &DataLabels{AvoidOverlap: true},
+	}
+	r = newRecorder()
+	sc.Draw(r, ctx)
+	a, b := r.texts["first"], r.texts["second"]
+	wa, wb := r.MeasureText("first", 10, "").W, r.MeasureText("second", 10, "").W
+	if a.Y == b.Y && a.X < b.X+wb && b.X < a.X+wa {
+		t.Errorf("labels at %+v and %+v overlap", a, b)
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"matplotlib-go/transform"
+)
+
+// DateLocator places ticks of axes holding Unix seconds (see
+// transform.Time) on calendar boundaries: whole seconds, minutes, hours,
+// days, months or years, in steps such as 15 minutes, 6 hours or 3 months
+// chosen for the span. Day steps restart at the first of each month, month
+// steps at January, year steps at multiples of {1,2,5}×10^k. Spans below a few seconds fall back to LinearLocator.
+type DateLocator struct {
+	Location *time.Location // time zone of the boundaries, nil for UTC
+}
+
+// dateUnit is a calendar field that DateLocator steps.
+type dateUnit int
+
+const (
+	dateSecond dateUnit = iota
+	dateMinute
+	dateHour
+	dateDay
+	dateMonth
+	dateYear
+)
+
+// dateUnitSeconds is the (average) length of each dateUnit.
+var dateUnitSeconds = [...]float64{1, 60, 3600, 86400, 30.44 * 86400, 365.25 * 86400}
+
+// dateSteps are the steps below a year, from the finest.
+var dateSteps = []struct {
+	unit dateUnit
+	n    int
+}{
+	{dateSecond, 1}, {dateSecond, 2}, {dateSecond, 5}, {dateSecond, 10}, {dateSecond, 15}, {dateSecond, 30},
+	{dateMinute, 1}, {dateMinute, 2}, {dateMinute, 5}, {dateMinute, 10}, {dateMinute, 15}, {dateMinute, 30},
+	{dateHour, 1}, {dateHour, 2}, {dateHour, 3}, {dateHour, 6}, {dateHour, 12},
+	{dateDay, 1}, {dateDay, 2}, {dateDay, 7}, {dateDay, 14},
+	{dateMonth, 1}, {dateMonth, 2}, {dateMonth, 3}, {dateMonth, 6},
+}
+
+// Ticks returns the boundaries within [min,max] of the finest step that
+// divides the span into at most targetCount intervals.
+func (l DateLocator) Ticks(min, max float64, targetCount int) []float64 {
+	if targetCount <= 0 {
+		targetCount = 1
+	}
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil
+	}
+	if min > max {
+		min, max = max, min
+	}
+	span := max - min
+	if span < float64(targetCount) {
+		return LinearLocator{}.Ticks(min, max, targetCount)
+	}
+	unit, n := dateYear, 0
+	for _, s := range dateSteps {
+		if span/(float64(s.n)*dateUnitSeconds[s.unit]) <= float64(targetCount) {
+			unit, n = s.unit, s.n
+			break
+		}
+	}
+	if n == 0 { // years in steps of {1,2,5}×10^k
+		years := span / dateUnitSeconds[dateYear] / float64(targetCount)
+		pow := math.Pow(10, math.Floor(math.Log10(years)))
+		n = int(pow)
+		for _, m := range []float64{1, 2, 5, 10} {
+			if m*pow >= years {
+				n = int(m * pow)
+				break
+			}
+		}
+		if n < 1 {
+			n = 1
+		}
+	}
+
+	loc := l.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := dateFloor(transform.TimeAt(min).In(loc), unit, n)
+	var ticks []float64
+	for i := 0; i < 4*targetCount+20; i++ {
+		s := transform.Seconds(t)
+		if s > max {
+			break
+		}
+		if s >= min {
+			ticks = append(ticks, s)
+		}
+		t = dateNext(t, unit, n)
+	}
+	return ticks
+}
+
+// dateFloor returns the last boundary of n units at or before t.
+func dateFloor(t time.Time, unit dateUnit, n int) time.Time {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	align := func(v, from int) int { return v - (v-from)%n }
+	switch unit {
+	case dateSecond:
+		return time.Date(y, mo, d, h, mi, align(s, 0), 0, t.Location())
+	case dateMinute:
+		return time.Date(y, mo, d, h, align(mi, 0), 0, 0, t.Location())
+	case dateHour:
+		return time.Date(y, mo, d, align(h, 0), 0, 0, 0, t.Location())
+	case dateDay:
+		return time.Date(y, mo, align(d, 1), 0, 0, 0, 0, t.Location())
+	case dateMonth:
+		return time.Date(y, time.Month(align(int(mo), 1)), 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(align(y, 0), time.January, 1, 0, 0, 0, 0, t.Location())
+}
+
+// dateNext returns the boundary n units after the boundary t.
+func dateNext(t time.Time, unit dateUnit, n int) time.Time {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	loc := t.Location()
+	switch unit {
+	case dateSecond:
+		return time.Date(y, mo, d, h, mi, s+n, 0, loc)
+	case dateMinute:
+		return time.Date(y, mo, d, h, mi+n, 0, 0, loc)
+	case dateHour:
+		return time.Date(y, mo, d, h+n, 0, 0, 0, loc)
+	case dateDay:
+		// Restart at the first of the next month, skipping a last tick
+		// less than half a step before it.
+		days := time.Date(y, mo+1, 0, 0, 0, 0, 0, loc).Day()
+		if d+n > days || 2*(days+1-d-n) < n {
+			return time.Date(y, mo+1, 1, 0, 0, 0, 0, loc)
+		}
+		return time.Date(y, mo, d+n, 0, 0, 0, 0, loc)
+	case dateMonth:
+		return time.Date(y, mo+time.Month(n), 1, 0, 0, 0, 0, loc)
+	}
+	return time.Date(y+n, time.January, 1, 0, 0, 0, 0, loc)
+}
+
+// DateFormatter formats Unix seconds as dates and times. With an empty
+// Layout it shows only the finest calendar field the tick is not at the
+// boundary of: "15:04:05" for seconds, "15:04" within a day, "Jan 2" at
+// midnight, "Jan 2006" on the first of a month and "2006" on New Year,
+// which labels the ticks of DateLocator concisely.
+type DateFormatter struct {
+	Layout   string         // time.Format layout, "" for automatic
+	Location *time.Location // time zone of the labels, nil for UTC
+}
+
+func (f DateFormatter) Format(x float64) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ""
+	}
+	loc := f.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t := transform.TimeAt(x).In(loc)
+	layout := f.Layout
+	if layout == "" {
+		switch {
+		case t.Nanosecond() != 0:
+			layout = "15:04:05.000"
+		case t.Second() != 0:
+			layout = "15:04:05"
+		case t.Hour() != 0 || t.Minute() != 0:
+			layout = "15:04"
+		case t.Day() != 1:
+			layout = "Jan 2"
+		case t.Month() != time.January:
+			layout = "Jan 2006"
+		default:
+			layout = "2006"
+		}
+	}
+	return t.Format(layout)
+}
+
+// SetXTime makes the x-axis a time axis from min to max: data x values are
+// Unix seconds (transform.SecondsOf), ticks fall on calendar boundaries and
+// are labeled as dates, both in the time zone of min.
+func (a *Axes) SetXTime(min, max time.Time) {
+	s := transform.NewTime(min, max)
+	if !a.validate(checkLimits("SetXTime", s.Min, s.Max, false)) {
+		return
+	}
+	a.XScale = s
+	if a.XAxis != nil {
+		a.XAxis.Locator = DateLocator{Location: min.Location()}
+		a.XAxis.Formatter = DateFormatter{Location: min.Location()}
+	}
+}
+
+// SetYTime makes the y-axis a time axis from min to max, see SetXTime.
+func (a *Axes) SetYTime(min, max time.Time) {
+	s := transform.NewTime(min, max)
+	if !a.validate(checkLimits("SetYTime", s.Min, s.Max, false)) {
+		return
+	}
+	a.YScale = s
+	if a.YAxis != nil {
+		a.YAxis.Locator = DateLocator{Location: min.Location()}
+		a.YAxis.Formatter = DateFormatter{Location: min.Location()}
+	}
+}
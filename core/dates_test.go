@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestDateLocator(t *testing.T) {
+	date := func(y int, mo time.Month, d, h int) time.Time { return time.Date(y, mo, d, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name     string
+		min, max time.Time
+		want     []string
+	}{
+		{"hours", date(2024, 3, 10, 0), date(2024, 3, 11, 0),
+			[]string{"Mar 10", "03:00", "06:00", "09:00", "12:00", "15:00", "18:00", "21:00", "Mar 11"}},
+		{"minutes", date(2024, 3, 10, 9).Add(7 * time.Minute), date(2024, 3, 10, 10),
+			[]string{"09:10", "09:20", "09:30", "09:40", "09:50", "10:00"}},
+		{"weeks", date(2024, 1, 1, 0), date(2024, 2, 20, 0),
+			[]string{"2024", "Jan 8", "Jan 15", "Jan 22", "Feb 2024", "Feb 8", "Feb 15"}},
+		{"half months", date(2024, 1, 1, 0), date(2024, 3, 1, 0),
+			[]string{"2024", "Jan 15", "Feb 2024", "Feb 15", "Mar 2024"}},
+		{"months", date(2023, 11, 15, 0), date(2024, 6, 1, 0),
+			[]string{"Dec 2023", "2024", "Feb 2024", "Mar 2024", "Apr 2024", "May 2024", "Jun 2024"}},
+		{"decades", date(1900, 6, 1, 0), date(2024, 1, 1, 0),
+			[]string{"1920", "1940", "1960", "1980", "2000", "2020"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticks := DateLocator{}.Ticks(transform.Seconds(tt.min), transform.Seconds(tt.max), 8)
+			var got []string
+			for _, v := range ticks {
+				got = append(got, DateFormatter{}.Format(v))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// Boundaries follow the location: midnight in New York is 05:00 UTC.
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database")
+	}
+	min := time.Date(2024, 1, 1, 0, 0, 0, 0, ny)
+	ticks := DateLocator{Location: ny}.Ticks(transform.Seconds(min), transform.Seconds(min.AddDate(0, 0, 3)), 4)
+	if len(ticks) != 4 || transform.TimeAt(ticks[1]).UTC().Hour() != 5 {
+		t.Errorf("New York day ticks %v", ticks)
+	}
+	if got := (DateFormatter{Location: ny}).Format(ticks[1]); got != "Jan 2" {
+		t.Errorf("New York label %q, want Jan 2", got)
+	}
+}
+
+func TestSetXTime(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ax.SetXTime(start, start.Add(time.Hour))
+	if _, ok := ax.XScale.(transform.Time); !ok {
+		t.Fatalf("x scale %T, want transform.Time", ax.XScale)
+	}
+	if u := ax.XScale.Fwd(transform.Seconds(start.Add(15 * time.Minute))); u != 0.25 {
+		t.Errorf("quarter hour at %v, want 0.25", u)
+	}
+	if _, ok := ax.XAxis.Locator.(DateLocator); !ok {
+		t.Errorf("x locator %T, want DateLocator", ax.XAxis.Locator)
+	}
+	if got := ax.XAxis.Formatter.Format(transform.Seconds(start)); got != "12:00" {
+		t.Errorf("start labeled %q", got)
+	}
+}
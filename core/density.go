@@ -0,0 +1,155 @@
+package core
+
+import (
+	"image"
+	"math"
+	"runtime"
+	"sync"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// DensityScatter draws a scatter of very many points, up to tens of
+// millions, as a density map: each draw counts the points falling into
+// every cell of a pixel grid over the axes, in parallel on all CPUs, and
+// colors the counts with a colormap, as datashader does. Cells without
+// points stay transparent. The result is embedded as one image, so vector
+// output stays small.
+type DensityScatter struct {
+	X, Y    []float64      // point coordinates; extra values of the longer slice are ignored
+	Cell    float64        // cell size in pixels, 0 for 1
+	Cmap    color.Colormap // colormap, nil for color.Viridis
+	Log     bool           // color by log(1+count), so sparse regions stay visible next to dense ones
+	Workers int            // goroutines counting points, 0 for runtime.GOMAXPROCS
+	Label   string         // series label for legend
+	z       float64        // z-order
+}
+
+// DensityScatter draws the points (x, y) as a density map. In strict mode,
+// x and y of different lengths are rejected.
+func (a *Axes) DensityScatter(x, y []float64) *DensityScatter {
+	if !a.validate(checkLengths("DensityScatter", "x,y", x, y)) {
+		return nil
+	}
+	d := &DensityScatter{X: x, Y: y}
+	a.Add(d)
+	return d
+}
+
+// densityChunk is the least number of points a counting goroutine gets.
+const densityChunk = 1 << 16
+
+// Counts returns the point counts of the cells covering clip, row by row
+// from the top, and the grid's width and height in cells. Points outside
+// clip or with NaN coordinates are not counted.
+func (d *DensityScatter) Counts(ctx *DrawContext) (counts []uint32, w, h int) {
+	cell := d.Cell
+	if cell <= 0 {
+		cell = 1
+	}
+	clip := ctx.Clip
+	w, h = int(math.Ceil(clip.W()/cell)), int(math.Ceil(clip.H()/cell))
+	n := min(len(d.X), len(d.Y))
+	if w <= 0 || h <= 0 || n == 0 {
+		return nil, 0, 0
+	}
+	workers := d.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = max(min(workers, (n+densityChunk-1)/densityChunk), 1)
+
+	// Each goroutine counts a contiguous slice of the points into its own
+	// grid; the grids are summed at the end.
+	grids := make([][]uint32, workers)
+	var wg sync.WaitGroup
+	for k := range workers {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			grid := make([]uint32, w*h)
+			for i := k * n / workers; i < (k+1)*n/workers; i++ {
+				p := ctx.DataToPixel.Apply(geom.Pt{X: d.X[i], Y: d.Y[i]})
+				cx, cy := (p.X-clip.Min.X)/cell, (p.Y-clip.Min.Y)/cell
+				if !(cx >= 0 && cy >= 0 && cx < float64(w) && cy < float64(h)) {
+					continue // outside, or NaN
+				}
+				grid[int(cy)*w+int(cx)]++
+			}
+			grids[k] = grid
+		}(k)
+	}
+	wg.Wait()
+	counts = grids[0]
+	for _, g := range grids[1:] {
+		for i, c := range g {
+			counts[i] += c
+		}
+	}
+	return counts, w, h
+}
+
+// Draw counts the points and draws the colored grid over the axes.
+func (d *DensityScatter) Draw(r render.Renderer, ctx *DrawContext) {
+	counts, w, h := d.Counts(ctx)
+	if counts == nil {
+		return
+	}
+	var most uint32
+	for _, c := range counts {
+		most = max(most, c)
+	}
+	if most == 0 {
+		return
+	}
+	cmap := d.Cmap
+	if cmap == nil {
+		cmap = color.Viridis
+	}
+	level := func(c uint32) float64 {
+		if d.Log {
+			return math.Log1p(float64(c)) / math.Log1p(float64(most))
+		}
+		return float64(c) / float64(most)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i, c := range counts {
+		if c == 0 {
+			continue
+		}
+		col := cmap.At(level(c))
+		// Premultiplied, as render.RGBAImage expects.
+		img.Pix[4*i] = uint8(math.Round(255 * col.R * col.A))
+		img.Pix[4*i+1] = uint8(math.Round(255 * col.G * col.A))
+		img.Pix[4*i+2] = uint8(math.Round(255 * col.B * col.A))
+		img.Pix[4*i+3] = uint8(math.Round(255 * col.A))
+	}
+	cell := d.Cell
+	if cell <= 0 {
+		cell = 1
+	}
+	o := ctx.Clip.Min
+	r.Image(render.RGBAImage{RGBA: img}, geom.Rect{
+		Min: o,
+		Max: geom.Pt{X: o.X + float64(w)*cell, Y: o.Y + float64(h)*cell},
+	})
+}
+
+// Z returns the z-order for sorting.
+func (d *DensityScatter) Z() float64 {
+	return d.z
+}
+
+// Bounds returns the data extent of the points, skipping NaNs.
+func (d *DensityScatter) Bounds(*DrawContext) geom.Rect {
+	xs, ys := emptyRange(), emptyRange()
+	n := min(len(d.X), len(d.Y))
+	xs, ys = xs.add(d.X[:n]...), ys.add(d.Y[:n]...)
+	if xs.lo > xs.hi || ys.lo > ys.hi {
+		return geom.Rect{}
+	}
+	return geom.Rect{Min: geom.Pt{X: xs.lo, Y: ys.lo}, Max: geom.Pt{X: xs.hi, Y: ys.hi}}
+}
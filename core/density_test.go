@@ -0,0 +1,102 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/style"
+	"matplotlib-go/transform"
+)
+
+// densityContext maps data 0..10 onto a 100×100 px axes at the origin.
+func densityContext() *DrawContext {
+	return &DrawContext{
+		DataToPixel: Transform2D{
+			XScale:      transform.NewLinear(0, 10),
+			YScale:      transform.NewLinear(0, 10),
+			AxesToPixel: transform.NewAffine(geom.Affine{A: 100, D: -100, F: 100}),
+		},
+		RC:   style.Default,
+		Clip: geom.Rect{Max: geom.Pt{X: 100, Y: 100}},
+	}
+}
+
+func TestDensityScatter_Counts(t *testing.T) {
+	d := &DensityScatter{
+		X:    []float64{0.05, 0.06, 9.95, 5, math.NaN(), 11},
+		Y:    []float64{9.95, 9.96, 0.05, 5, 1, 1},
+		Cell: 10,
+	}
+	counts, w, h := d.Counts(densityContext())
+	if w != 10 || h != 10 {
+		t.Fatalf("grid %dx%d, want 10x10", w, h)
+	}
+	// Top left, bottom right and the middle; NaN and outside are dropped.
+	want := map[int]uint32{0: 2, 9*10 + 9: 1, 5*10 + 5: 1}
+	for i, c := range counts {
+		if c != want[i] {
+			t.Errorf("cell %d = %d, want %d", i, c, want[i])
+		}
+	}
+
+	// Splitting the points across goroutines gives the same counts.
+	n := 4*densityChunk + 123
+	big := &DensityScatter{X: make([]float64, n), Y: make([]float64, n), Workers: 1}
+	for i := range n {
+		big.X[i], big.Y[i] = float64(i%997)/100, float64(i%991)/100
+	}
+	one, _, _ := big.Counts(densityContext())
+	big.Workers = 8
+	many, _, _ := big.Counts(densityContext())
+	if !slices.Equal(one, many) {
+		t.Error("counts differ between 1 and 8 workers")
+	}
+}
+
+func TestDensityScatter_Draw(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	d := ax.DensityScatter([]float64{0.05, 0.05, 9.95}, []float64{9.95, 9.95, 0.05})
+	d.Cmap = color.Gray
+	r := &imageRecorder{}
+	d.Draw(r, densityContext())
+	if r.img == nil || r.dst != (geom.Rect{Max: geom.Pt{X: 100, Y: 100}}) {
+		t.Fatalf("image %v at %v", r.img, r.dst)
+	}
+	img := r.img.(render.RGBAImage).RGBA
+	if c := img.RGBAAt(0, 0); c.R != 255 || c.A != 255 {
+		t.Errorf("densest cell %v, want white", c)
+	}
+	if c := img.RGBAAt(99, 99); c.R != 128 {
+		t.Errorf("half as dense cell %v, want mid gray", c)
+	}
+	if c := img.RGBAAt(50, 50); c.A != 0 {
+		t.Errorf("empty cell %v, want transparent", c)
+	}
+	d.Log = true
+	d.Draw(r, densityContext())
+	if c := r.img.(render.RGBAImage).RGBAAt(99, 99); c.R != uint8(math.Round(255*math.Log(2)/math.Log(3))) {
+		t.Errorf("log-scaled cell %v", c)
+	}
+
+	if b := d.Bounds(nil); b != (geom.Rect{Min: geom.Pt{X: 0.05, Y: 0.05}, Max: geom.Pt{X: 9.95, Y: 9.95}}) {
+		t.Errorf("bounds %v", b)
+	}
+	fig.Strict = true
+	if ax.DensityScatter([]float64{1}, nil) != nil || fig.Err() == nil {
+		t.Error("mismatched lengths accepted in strict mode")
+	}
+}
+
+// imageRecorder keeps the last image drawn.
+type imageRecorder struct {
+	render.NullRenderer
+	img render.Image
+	dst geom.Rect
+}
+
+func (r *imageRecorder) Image(img render.Image, dst geom.Rect) { r.img, r.dst = img, dst }
@@ -2,14 +2,88 @@
 //
 // Core types:
 //   - Artist: Interface for drawable elements with z-order and bounds
-//   - Figure: Root container with pixel dimensions and styling
+//   - Figure: Root container with pixel dimensions, styling and figure-level artists
 //   - Axes: Plot region with coordinate transforms and child artists
 //   - DrawContext: Per-draw state including transforms and styling
 //
 // Artists:
-//   - Line2D: Polyline artist for stroke-only line plots
+//   - Line2D: Polyline artist for stroke-only line plots, from slices or any Points source (Axes.PlotData, Axes.ScatterData, PackedXY)
+//   - DataSource, Axes.PlotStream: Lines streamed in chunks at draw time and decimated per pixel column, for data larger than memory
+//   - LineCollection: Many polylines batched into few paths (Axes.EventPlot, Axes.Contour)
+//   - Text: Aligned, rotatable or vertical text in data, axes or figure coordinates (Axes.AddText, Figure.AddText)
+//   - Arrow: Simple, fancy and wedge arrows with arc3 curvature (Axes.AddArrow)
+//   - ConnectionPatch: Line or arrow between points of two axes (Figure.AddConnection)
+//   - Patch: Filled shapes in data coordinates, optionally with holes (NewCircle, NewEllipse, NewPolygon, NewPolygonWithHoles, NewAnnulus, Axes.Contourf)
+//   - Legend: Labeled samples of an Axes' artists
+//   - LineEndLabels: Line labels at their right ends instead of a legend (Figure.AddLineEndLabels)
+//   - PolarGrid: Circles, spokes and angle labels of polar axes (Figure.AddPolarAxes)
+//   - PolarBars: Annular wedges on polar axes, e.g. for windroses (Figure.AddWindrose); Axes.Bar draws sectors there too
+//   - Watermark: Text or image stamped across a figure (Figure.AddWatermark, AddStamp)
+//   - Heatmap: Matrix of cells colored by a color.Colormap (Axes.Heatmap)
+//   - DensityScatter: Millions of points counted per pixel in parallel and colormapped (Axes.DensityScatter)
+//   - Barbs: Meteorological wind barbs from speeds and directions (Axes.Barbs)
+//   - Hist: Histogram of raw samples with density and cumulative modes (Axes.Hist)
 //
 // Helpers:
 //   - DrawFigure: Traverses and renders a figure with proper z-ordering
+//   - WithColor, WithLineWidth, ...: Functional options for Plot, Scatter, Bar and fills
+//   - WithAngles, WithDataSize: Rotated scatter markers and markers sized in data units
+//   - WithLineStyle, LineStyleDashes, WithDashCap: Named dash patterns ("--", ":", "-.") scaled by the line width, with their own dash caps
+//   - DegreeFormatter, RadianFormatter, CompassFormatter: Angle tick labels for polar axes
+//   - EngFormatter, DBFormatter, CurrencyFormatter, PercentFormatter: SI-prefixed, decibel, money and percent tick labels
+//   - Axes.SetXTime, DateLocator, DateFormatter: Time axes in Unix seconds with calendar ticks (transform.Time)
+//   - Axes.SetXLimSymLog, Axes.SetXLimPower: Symmetric log axes for data spanning negative and positive decades, and power axes (SymLogLocator, SymLogFormatter)
+//   - Typography: Unicode minus, thin-space thousands and ×10ⁿ tick labels (style.WithTypography)
+//   - Axes.PlotWithBand: Line with a shaded confidence band
+//   - Axes.RegPlot: Scatter with an OLS fit and confidence band, or a LOWESS curve
+//   - Axes.ConfidenceEllipse, NewCovarianceEllipse: n-sigma covariance ellipses of 2D point clouds
+//   - Axes.StackPlot, Axes.BarStacked: Stacked areas and bars, weighted or normalized to 100%
+//   - Axes.StripPlot, Axes.SwarmPlot: Seeded jitter and non-overlapping swarms of grouped values (FixedLocator, CategoryFormatter)
+//   - Figure.Rand: Named random streams seeded by RC.Seed, so randomized helpers render reproducibly (style.WithSeed)
+//   - Figure.AddBode: Linked magnitude and phase axes for frequency responses
+//   - Figure.AddBrokenAxes: Y-axis broken into outlier and bulk panels with break marks (OutlierThreshold)
+//   - NewSparkline: Tiny axis-less line figures with optional min/max markers for dashboards
+//   - Figure.Subplots: Grids of axes with shared outer margins and matplotlib-style wspace and hspace
+//   - Figure.SmallMultiples: Grids of titled panels with shared limits, one per data set
+//   - Figure.LabelPanels: Bold panel letters (a, b, ...) for multi-panel figures
+//   - RegisterLegendHandler: Legend samples for user-defined artist types
+//   - Axes.SetTransform: Axes, figure or pixel coordinates and extra transforms per artist
+//   - Axes.SetClipPath: Clipping artists to a Patch, e.g. a circle or an outline
+//   - Figure.OnPreDraw, Axes.OnPostDraw, ...: Custom drawing before or after the artists
+//   - Axes.AddAxis, Axes.MirrorAxis, Axis.SetZ: Axes on any side, independent or mirrored, drawn by z-order
+//   - DrawContext.TickTarget: Tick counts scaled to the axis length in pixels, fewer on small axes
+//   - Axes.MinorTicksOn, AutoMinorLocator, LogSubsAll: Minor ticks and grid lines, on log scales at 2–9 per decade
+//   - Axis.LabelRotation, Axis.LabelAnchor, Axis.TickLabelBounds: Slanted tick labels, their alignment and the space they take
+//   - Axis.LabelOverlap: Thinning or slanting tick labels that would collide (OverlapThin, OverlapRotate)
+//   - SnapMode: Pixel-crisp axis lines, grid lines and bar edges (style.WithPixelSnap)
+//   - PlaceLabels: Greedy placement of annotations and data labels without overlap
+//   - DataLabels: Text labels at the points of lines and scatters (Line2D.Labels, Scatter2D.Labels)
+//   - Axes.SetVisible, Legend.Toggle: Hiding artists, e.g. by clicking legend entries
+//   - Figure.AddLinkedCursor: Crosshair at the same x across axes, moved by interactive frontends
+//   - Navigator: Pan, zoom, the home/back/forward view history and saving the current view, as in a navigation toolbar
+//   - KeyMap: Configurable keyboard shortcuts for grid, log scale, colormap, save and view history
+//   - Axes.AutoScale: Limits fitted to the plotted data with a margin
+//   - Axes.Set: Bulk configuration of title, labels, limits, scales, grid and legend
+//   - Axes.Template, Axes.ApplyTemplate, Figure.AddAxesFrom: Reusable axes styles (RC, axes, grids, colors)
+//   - Figure.WithStyle, Axes.WithStyle: RC overrides scoped to a block of plotting calls, like matplotlib's rc_context
+//   - Figure.Strict, Figure.Err: Rejecting mismatched, empty or NaN plot input with errors
 //   - SavePNG: Placeholder for PNG export (requires backend renderer)
+//   - SavePGF: PGF export for LaTeX documents (requires the pgf backend)
+//   - SaveEMF: EMF export for Office documents (requires the emf backend)
+//   - SaveSVG: SVG export for the web and publications (requires the svg backend)
+//   - AltText, Axes.SetAltText: Text alternatives of figures, axes and series, written as ARIA metadata to SVG
+//   - Sonify: Series points as normalized (time, value) events for audio tooling
+//   - SavePNGWithData, LoadFigureData: PNG with the figure's data embedded as JSON (FigureSpec, BuildFigure)
+//   - DiffSpecs: Structural diff of two FigureSpecs by artist and property (matplotlib-go diff)
+//   - ImportMatplotlib: Figures exported from matplotlib with tools/mpl_export.py
+//   - Figure.Resize: Relayout for a new output size, keeping margins in pixels, with fixed or scaled fonts (ResizeOptions)
+//   - SaveMulti: Renders one figure at several pixel sizes (thumbnails, srcsets)
+//   - Redrawer: Retained gobasic rendering that redraws only dirty regions
+//   - Fingerprint, RenderCache: Content-hash caching of rendered output
+//   - Profiler: Per-artist and per-call render timings as a flame graph
+//   - SyncFigure: Mutex-guarded figure for concurrent update and drawing
+//
+// Concurrency: a Figure and its artists are not safe for concurrent use,
+// since DrawFigure reorders artist lists and reads artist data. Mutate and
+// draw from one goroutine, or wrap the figure in a SyncFigure.
 package core
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/stats"
+)
+
+// NewEllipse returns an ellipse patch in data coordinates with the given
+// full width and height, turned by angle degrees counterclockwise. It has
+// no fill or outline until Color or EdgeColor is set.
+func NewEllipse(center geom.Pt, width, height, angle float64) *Patch {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	xy := circleRing(geom.Pt{}, 1)
+	for i, v := range xy {
+		x, y := v.X*width/2, v.Y*height/2
+		xy[i] = geom.Pt{X: center.X + x*cos - y*sin, Y: center.Y + x*sin + y*cos}
+	}
+	return NewPolygon(xy)
+}
+
+// NewCovarianceEllipse returns the ellipse at nSigma standard deviations
+// of a 2D normal distribution with the given mean and covariance matrix:
+// its axes follow the eigenvectors of cov, with semi-axes nSigma times the
+// square roots of the eigenvalues. For 2D data nSigma = 1, 2, 3 enclose
+// about 39%, 86% and 99% of the distribution.
+func NewCovarianceEllipse(mean geom.Pt, cov [2][2]float64, nSigma float64) *Patch {
+	a, b, c := cov[0][0], (cov[0][1]+cov[1][0])/2, cov[1][1]
+	mid, d := (a+c)/2, math.Hypot((a-c)/2, b)
+	major, minor := math.Max(mid+d, 0), math.Max(mid-d, 0)
+	angle := 0.5 * math.Atan2(2*b, a-c) * 180 / math.Pi
+	return NewEllipse(mean, 2*nSigma*math.Sqrt(major), 2*nSigma*math.Sqrt(minor), angle)
+}
+
+// ConfidenceEllipse draws the nSigma covariance ellipse of the points
+// (x, y), see NewCovarianceEllipse, e.g. to outline a cluster or the
+// uncertainty of repeated measurements. It is filled with the next color
+// of the cycle at alpha 0.25 and outlined in the same color; points with
+// a NaN or infinite coordinate are ignored. It returns nil for fewer than
+// two points.
+func (a *Axes) ConfidenceEllipse(x, y []float64, nSigma float64, opts ...FillOption) *Patch {
+	opt := resolveFillOptions(opts)
+	if !a.validate(checkLengths("ConfidenceEllipse", "x,y", x, y), opt.check("ConfidenceEllipse")) {
+		return nil
+	}
+	mean, cov, err := stats.Covariance2D(x, y)
+	if err != nil {
+		a.validate(invalid("ConfidenceEllipse", "%v", err))
+		return nil
+	}
+
+	color := a.NextColor()
+	if opt.Color != nil {
+		color = *opt.Color
+	}
+	edgeColor := color
+	if opt.EdgeColor != nil {
+		edgeColor = *opt.EdgeColor
+	}
+	edgeWidth := 1.5
+	if opt.EdgeWidth != nil {
+		edgeWidth = *opt.EdgeWidth
+	}
+	fill := color
+	fill.A = 0.25
+	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
+		fill.A = *opt.Alpha
+	}
+
+	p := NewCovarianceEllipse(geom.Pt{X: mean[0], Y: mean[1]}, cov, nSigma)
+	p.Color, p.EdgeColor, p.EdgeWidth, p.Label = fill, edgeColor, edgeWidth, opt.Label
+	a.Add(p)
+	return p
+}
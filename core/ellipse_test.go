@@ -0,0 +1,44 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestNewCovarianceEllipse(t *testing.T) {
+	// Eigenvalues 4 and 1 with the major axis at 45°.
+	p := NewCovarianceEllipse(geom.Pt{X: 1, Y: 2}, [2][2]float64{{2.5, 1.5}, {1.5, 2.5}}, 2)
+	// The first vertex lies on the major axis, 2σ = 4 from the mean.
+	want := geom.Pt{X: 1 + 4/math.Sqrt2, Y: 2 + 4/math.Sqrt2}
+	if v := p.Path.V[0]; math.Abs(v.X-want.X) > 1e-9 || math.Abs(v.Y-want.Y) > 1e-9 {
+		t.Errorf("major vertex %v, want %v", v, want)
+	}
+	// A quarter turn later, on the minor axis 2σ = 2 away.
+	want = geom.Pt{X: 1 - 2/math.Sqrt2, Y: 2 + 2/math.Sqrt2}
+	if v := p.Path.V[circleSegments/4]; math.Abs(v.X-want.X) > 1e-9 || math.Abs(v.Y-want.Y) > 1e-9 {
+		t.Errorf("minor vertex %v, want %v", v, want)
+	}
+}
+
+func TestAxesConfidenceEllipse(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	p := ax.ConfidenceEllipse([]float64{-1, 1, 1, -1, math.NaN()}, []float64{-1, -1, 1, 1, 0}, 1)
+	if p == nil {
+		t.Fatal("no ellipse")
+	}
+	// Variance 4/3 along both axes: a circle of radius sqrt(4/3).
+	if r := math.Hypot(p.Path.V[5].X, p.Path.V[5].Y); math.Abs(r-math.Sqrt(4.0/3)) > 1e-9 {
+		t.Errorf("radius %v", r)
+	}
+	if p.Color.A != 0.25 || p.EdgeColor.A != 1 || p.EdgeWidth != 1.5 {
+		t.Errorf("style fill %v edge %v width %v", p.Color, p.EdgeColor, p.EdgeWidth)
+	}
+
+	fig.Strict = true
+	if ax.ConfidenceEllipse([]float64{1, math.NaN()}, []float64{1, 2}, 1) != nil || fig.Err() == nil {
+		t.Error("strict ConfidenceEllipse accepted a single finite point")
+	}
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"matplotlib-go/render"
+)
+
+// FigureDataKey is the keyword of the PNG text chunk SavePNGWithData
+// stores the figure's FigureSpec in.
+const FigureDataKey = "matplotlib-go:figure"
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// SavePNGWithData saves fig as PNG like SavePNG and embeds its FigureSpec
+// as JSON in a compressed iTXt chunk, so that the data can be read back
+// from the image with LoadFigureData. Image viewers ignore the chunk.
+func SavePNGWithData(fig *Figure, r render.Renderer, path string) error {
+	data, err := json.Marshal(ExportSpec(fig))
+	if err != nil {
+		return err
+	}
+	if err := SavePNG(fig, r, path); err != nil {
+		return err
+	}
+	img, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	img, err = insertPNGText(img, FigureDataKey, data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return os.WriteFile(path, img, 0o644)
+}
+
+// SaveFigureData writes the FigureSpec of fig as indented JSON, e.g. as a
+// sidecar next to an exported image.
+func SaveFigureData(fig *Figure, path string) error {
+	data, err := json.MarshalIndent(ExportSpec(fig), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadFigureData reads the FigureSpec saved by SavePNGWithData or
+// SaveFigureData. Pass it to BuildFigure to recreate the figure.
+func LoadFigureData(path string) (FigureSpec, error) {
+	var spec FigureSpec
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return spec, err
+	}
+	if bytes.HasPrefix(data, pngSignature) {
+		if data, err = pngText(data, FigureDataKey); err != nil {
+			return spec, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// insertPNGText returns img with a zlib-compressed iTXt chunk holding text
+// under key, placed before the IEND chunk.
+func insertPNGText(img []byte, key string, text []byte) ([]byte, error) {
+	const iendLen = 12 // length, type and CRC of the empty IEND chunk
+	if !bytes.HasPrefix(img, pngSignature) || len(img) < len(pngSignature)+iendLen ||
+		string(img[len(img)-8:len(img)-4]) != "IEND" {
+		return nil, errors.New("not a PNG file")
+	}
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write(text)
+	zw.Close()
+
+	// keyword, compression flag and method, empty language tag and
+	// translated keyword, text
+	chunk := append([]byte(key), 0, 1, 0, 0, 0)
+	chunk = append(chunk, z.Bytes()...)
+
+	out := make([]byte, 0, len(img)+len(chunk)+12)
+	out = append(out, img[:len(img)-iendLen]...)
+	out = appendPNGChunk(out, "iTXt", chunk)
+	return append(out, img[len(img)-iendLen:]...), nil
+}
+
+func appendPNGChunk(out []byte, typ string, data []byte) []byte {
+	out = binary.BigEndian.AppendUint32(out, uint32(len(data)))
+	start := len(out)
+	out = append(out, typ...)
+	out = append(out, data...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out[start:]))
+}
+
+// pngText returns the text of the iTXt chunk with keyword key.
+func pngText(img []byte, key string) ([]byte, error) {
+	p := img[len(pngSignature):]
+	for len(p) >= 12 {
+		n := binary.BigEndian.Uint32(p)
+		if uint64(n)+12 > uint64(len(p)) {
+			break
+		}
+		typ, data := string(p[4:8]), p[8:8+n]
+		p = p[12+n:]
+		if typ != "iTXt" || !bytes.HasPrefix(data, append([]byte(key), 0)) {
+			continue
+		}
+		data = data[len(key)+1:]
+		if len(data) < 2 {
+			break
+		}
+		compressed := data[0] == 1
+		data = data[2:]
+		for range 2 { // skip language tag and translated keyword
+			i := bytes.IndexByte(data, 0)
+			if i < 0 {
+				return nil, errors.New("malformed iTXt chunk")
+			}
+			data = data[i+1:]
+		}
+		if !compressed {
+			return data, nil
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(zr)
+	}
+	return nil, errors.New("no embedded figure data")
+}
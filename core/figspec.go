@@ -0,0 +1,340 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// FigureSpec is a portable description of a figure's data and main
+// settings, meant to be stored as JSON next to a rendered chart so that
+// its data can be recovered (see SavePNGWithData) or sent to another
+// program. It covers lines, scatters, bars and fills; other artists are
+// left out, except that grids and legends are noted per axes.
+type FigureSpec struct {
+	Width  int        `json:"width"`  // pixels
+	Height int        `json:"height"` // pixels
+	Axes   []AxesData `json:"axes"`
+}
+
+// AxesData describes one Axes of a FigureSpec.
+type AxesData struct {
+	Rect         [4]float64   `json:"rect"` // min x, min y, max x, max y in figure fractions
+	Title        string       `json:"title,omitempty"`
+	XLabel       string       `json:"xlabel,omitempty"`
+	YLabel       string       `json:"ylabel,omitempty"`
+	XLim         [2]float64   `json:"xlim"`
+	YLim         [2]float64   `json:"ylim"`
+	XScale       string       `json:"xscale,omitempty"` // as AxesSpec.XScale, "" for linear
+	YScale       string       `json:"yscale,omitempty"`
+	XScaleParams ScaleParams  `json:"xscaleparams,omitzero"` // parameters of XScale, zero for the defaults
+	YScaleParams ScaleParams  `json:"yscaleparams,omitzero"`
+	Grid         bool         `json:"grid,omitempty"`
+	Legend       bool         `json:"legend,omitempty"`
+	Series       []SeriesData `json:"series"`
+}
+
+// SeriesData is one plotted series of an AxesData.
+type SeriesData struct {
+	Kind     string    `json:"kind"` // "line", "scatter", "bar", "barh" or "fill"
+	Label    string    `json:"label,omitempty"`
+	X        Values    `json:"x"`                  // x values, bar positions for bars
+	Y        Values    `json:"y"`                  // y values, bar heights or the upper fill edge
+	Y2       Values    `json:"y2,omitempty"`       // lower fill edge, nil for Baseline
+	Baseline float64   `json:"baseline,omitempty"` // bar and fill baseline
+	Bottoms  Values    `json:"bottoms,omitempty"`  // per-bar baselines, e.g. of stacked bars, nil for Baseline
+	Edges    Values    `json:"edges,omitempty"`    // bar bin edges, one more than bars, nil to span x and width
+	Color    []float64 `json:"color,omitempty"`    // r, g, b[, a] in [0, 1], nil for the color cycle
+	Width    float64   `json:"width,omitempty"`    // line width, marker radius or bar width, 0 for the default
+	Dashes   []float64 `json:"dashes,omitempty"`   // line dash pattern
+	Marker   string    `json:"marker,omitempty"`   // scatter marker: "circle", "square", "triangle", "diamond", "plus" or "cross"
+}
+
+// Values are the data of a series. JSON has no NaN or infinities, so NaN,
+// e.g. a gap in a line, is written as null and infinities as the strings
+// "Infinity" and "-Infinity"; reading also accepts "NaN".
+type Values []float64
+
+// MarshalJSON writes v as an array of numbers, null and strings.
+func (v Values) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+	b := []byte{'['}
+	for i, x := range v {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		switch {
+		case math.IsNaN(x):
+			b = append(b, "null"...)
+		case math.IsInf(x, 1):
+			b = append(b, `"Infinity"`...)
+		case math.IsInf(x, -1):
+			b = append(b, `"-Infinity"`...)
+		default:
+			b = strconv.AppendFloat(b, x, 'g', -1, 64)
+		}
+	}
+	return append(b, ']'), nil
+}
+
+// UnmarshalJSON reads the array written by MarshalJSON.
+func (v *Values) UnmarshalJSON(data []byte) error {
+	var raw []any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*v = nil
+		return nil
+	}
+	out := make(Values, len(raw))
+	for i, e := range raw {
+		switch e := e.(type) {
+		case nil:
+			out[i] = math.NaN()
+		case float64:
+			out[i] = e
+		case string:
+			x, err := strconv.ParseFloat(e, 64)
+			if err != nil || !math.IsNaN(x) && !math.IsInf(x, 0) {
+				return fmt.Errorf("values: %q is neither NaN nor an infinity", e)
+			}
+			out[i] = x
+		default:
+			return fmt.Errorf("values: unexpected %T", e)
+		}
+	}
+	*v = out
+	return nil
+}
+
+var markerNames = []string{
+	MarkerCircle:   "circle",
+	MarkerSquare:   "square",
+	MarkerTriangle: "triangle",
+	MarkerDiamond:  "diamond",
+	MarkerPlus:     "plus",
+	MarkerCross:    "cross",
+}
+
+// ExportSpec returns the FigureSpec of fig.
+func ExportSpec(fig *Figure) FigureSpec {
+	s := FigureSpec{Width: int(fig.SizePx.X), Height: int(fig.SizePx.Y)}
+	for _, ax := range fig.Children {
+		r := ax.RectFraction
+		d := AxesData{
+			Rect:   [4]float64{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y},
+			Title:  ax.Title,
+			XLabel: ax.XLabel,
+			YLabel: ax.YLabel,
+			Series: []SeriesData{},
+		}
+		d.XLim, d.XScale, d.XScaleParams = scaleSpec(ax.XScale)
+		d.YLim, d.YScale, d.YScaleParams = scaleSpec(ax.YScale)
+		for _, art := range ax.Artists {
+			switch art := art.(type) {
+			case *Grid:
+				d.Grid = true
+			case *Legend:
+				d.Legend = true
+			default:
+				if sd, ok := seriesSpec(art); ok {
+					d.Series = append(d.Series, sd)
+				}
+			}
+		}
+		s.Axes = append(s.Axes, d)
+	}
+	return s
+}
+
+func scaleSpec(s transform.Scale) ([2]float64, string, ScaleParams) {
+	min, max := s.Domain()
+	name, p := scaleKind(s)
+	return [2]float64{min, max}, name, p
+}
+
+// splitPoints returns the x and y coordinates of pts, nil if it is empty.
+func splitPoints(pts Points) (x, y []float64) {
+	for i := range pts.Len() {
+		p := pts.At(i)
+		x, y = append(x, p.X), append(y, p.Y)
+	}
+	return x, y
+}
+
+func seriesSpec(art Artist) (SeriesData, bool) {
+	switch a := art.(type) {
+	case *Line2D:
+		sd := SeriesData{Kind: "line", Label: a.Label, Color: colorSpec(a.Col), Width: a.W, Dashes: a.Dashes}
+		sd.X, sd.Y = splitPoints(a.points())
+		return sd, true
+	case *Scatter2D:
+		sd := SeriesData{Kind: "scatter", Label: a.Label, Color: colorSpec(a.Color), Width: a.Size}
+		if int(a.Marker) < len(markerNames) {
+			sd.Marker = markerNames[a.Marker]
+		}
+		sd.X, sd.Y = splitPoints(a.points())
+		return sd, true
+	case *Bar2D:
+		kind := "bar"
+		if a.Orientation == BarHorizontal {
+			kind = "barh"
+		}
+		return SeriesData{
+			Kind: kind, Label: a.Label, X: a.X, Y: a.Heights, Baseline: a.Baseline,
+			Bottoms: a.Bottoms, Edges: a.Edges, Color: colorSpec(a.Color), Width: a.Width,
+		}, true
+	case *Fill2D:
+		c := a.Color
+		if a.Alpha > 0 {
+			c.A = a.Alpha
+		}
+		return SeriesData{
+			Kind: "fill", Label: a.Label, X: a.X, Y: a.Y1, Y2: a.Y2, Baseline: a.Baseline,
+			Color: colorSpec(c),
+		}, true
+	}
+	return SeriesData{}, false
+}
+
+func colorSpec(c render.Color) []float64 { return []float64{c.R, c.G, c.B, c.A} }
+
+// BuildFigure creates the figure described by s, plotting every series
+// with the Axes methods of its kind.
+func BuildFigure(s FigureSpec) (*Figure, error) {
+	if s.Width <= 0 || s.Height <= 0 {
+		return nil, fmt.Errorf("figure spec: invalid size %dx%d", s.Width, s.Height)
+	}
+	fig := NewFigure(s.Width, s.Height)
+	for i, d := range s.Axes {
+		ax := fig.AddAxes(geom.Rect{
+			Min: geom.Pt{X: d.Rect[0], Y: d.Rect[1]},
+			Max: geom.Pt{X: d.Rect[2], Y: d.Rect[3]},
+		})
+		for j, sd := range d.Series {
+			if err := buildSeries(ax, sd); err != nil {
+				return nil, fmt.Errorf("figure spec: axes %d series %d: %w", i, j, err)
+			}
+		}
+		// Settings last, so that the legend lists the series.
+		err := ax.Set(AxesSpec{
+			Title: d.Title, XLabel: d.XLabel, YLabel: d.YLabel,
+			XLim: d.XLim, YLim: d.YLim, XScale: d.XScale, YScale: d.YScale,
+			XScaleParams: d.XScaleParams, YScaleParams: d.YScaleParams,
+			Grid: d.Grid, Legend: d.Legend,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("figure spec: axes %d: %w", i, err)
+		}
+	}
+	return fig, nil
+}
+
+func buildSeries(ax *Axes, sd SeriesData) error {
+	var opts []Option
+	if sd.Label != "" {
+		opts = append(opts, WithLabel(sd.Label))
+	}
+	color, hasColor, err := parseColorSpec(sd.Color)
+	if err != nil {
+		return err
+	}
+	if hasColor {
+		opts = append(opts, WithColor(color))
+	}
+	if len(sd.X) != len(sd.Y) || (sd.Y2 != nil && len(sd.Y2) != len(sd.X)) {
+		return fmt.Errorf("%s: x, y and y2 differ in length", sd.Kind)
+	}
+
+	switch sd.Kind {
+	case "line":
+		if sd.Width > 0 {
+			opts = append(opts, WithLineWidth(sd.Width))
+		}
+		if sd.Dashes != nil {
+			opts = append(opts, WithDashes(sd.Dashes...))
+		}
+		ax.Plot(sd.X, sd.Y, optionList[PlotOption](opts)...)
+	case "scatter":
+		if sd.Width > 0 {
+			opts = append(opts, WithSize(sd.Width))
+		}
+		if sd.Marker != "" {
+			m, ok := markerByName(sd.Marker)
+			if !ok {
+				return fmt.Errorf("scatter: unknown marker %q", sd.Marker)
+			}
+			opts = append(opts, WithMarker(m))
+		}
+		ax.Scatter(sd.X, sd.Y, optionList[ScatterOption](opts)...)
+	case "bar", "barh":
+		if sd.Width > 0 {
+			opts = append(opts, WithWidth(sd.Width))
+		}
+		if sd.Kind == "barh" {
+			opts = append(opts, WithOrientation(BarHorizontal))
+		}
+		if sd.Bottoms != nil && len(sd.Bottoms) != len(sd.X) {
+			return fmt.Errorf("%s: x and bottoms differ in length", sd.Kind)
+		}
+		if sd.Edges != nil && len(sd.Edges) != len(sd.X)+1 {
+			return fmt.Errorf("%s: %d edges for %d bars", sd.Kind, len(sd.Edges), len(sd.X))
+		}
+		opts = append(opts, WithBaseline(sd.Baseline))
+		if b := ax.Bar(sd.X, sd.Y, optionList[BarOption](opts)...); b != nil {
+			b.Bottoms, b.Edges = sd.Bottoms, sd.Edges
+		}
+	case "fill":
+		if hasColor {
+			opts = append(opts, WithAlpha(color.A))
+		}
+		if sd.Y2 != nil {
+			ax.FillBetweenPlot(sd.X, sd.Y, sd.Y2, optionList[FillOption](opts)...)
+		} else {
+			opts = append(opts, WithBaseline(sd.Baseline))
+			ax.FillToBaselinePlot(sd.X, sd.Y, optionList[FillOption](opts)...)
+		}
+	default:
+		return fmt.Errorf("unknown series kind %q", sd.Kind)
+	}
+	return nil
+}
+
+// optionList converts opts for an Axes method taking option interface T.
+func optionList[T any](opts []Option) []T {
+	out := make([]T, len(opts))
+	for i, o := range opts {
+		out[i] = any(o).(T)
+	}
+	return out
+}
+
+func markerByName(name string) (MarkerType, bool) {
+	for m, n := range markerNames {
+		if n == name {
+			return MarkerType(m), true
+		}
+	}
+	return 0, false
+}
+
+// parseColorSpec reads an r, g, b[, a] color; it reports false for nil.
+func parseColorSpec(c []float64) (render.Color, bool, error) {
+	switch len(c) {
+	case 0:
+		return render.Color{}, false, nil
+	case 3:
+		return render.Color{R: c[0], G: c[1], B: c[2], A: 1}, true, nil
+	case 4:
+		return render.Color{R: c[0], G: c[1], B: c[2], A: c[3]}, true, nil
+	}
+	return render.Color{}, false, fmt.Errorf("color %v needs 3 or 4 components", c)
+}
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"encoding/json"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func specTestFigure() *Figure {
+	fig := NewFigure(200, 150)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.Plot([]float64{1, 2, 3}, []float64{1, 4, 9}, WithLabel("squares"), WithDashes(4, 2))
+	ax.Scatter([]float64{1, 2}, []float64{2, 3}, WithMarker(MarkerDiamond), WithSize(4))
+	ax.Bar([]float64{1, 2}, []float64{3, 5}, WithWidth(0.5), WithOrientation(BarHorizontal))
+	ax.BarStacked([]float64{1, 2}, [][]float64{{1, 2}, {3, 4}}, StackOptions{})
+	ax.Bar([]float64{1.5, 2.5}, []float64{2, 1}).Edges = []float64{1, 2, 3}
+	ax.FillBetweenPlot([]float64{1, 2}, []float64{5, 6}, []float64{1, 2}, WithAlpha(0.3))
+	ax.FillToBaselinePlot([]float64{1, 2}, []float64{5, 6}, WithBaseline(1))
+	ax.Set(AxesSpec{Title: "t", XLabel: "x", YLim: [2]float64{1, 100}, YScale: "log", Grid: true, Legend: true})
+
+	ax = fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.5, Y: 0.5}})
+	ax.SetXLimSymLog(-10, 10, 0.5, 2)
+	ax.SetYLimPower(0, 4, 2)
+	ax = fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.5, Y: 0.5}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXTime(time.Unix(0, 0), time.Unix(86400, 0))
+	return fig
+}
+
+func TestFigureSpecRoundTrip(t *testing.T) {
+	spec := ExportSpec(specTestFigure())
+	if n := len(spec.Axes[0].Series); n != 8 {
+		t.Fatalf("exported %d series, want 8", n)
+	}
+	if s := spec.Axes[0].Series[4]; !reflect.DeepEqual(s.Bottoms, Values{1, 2}) {
+		t.Errorf("stacked bar bottoms %v, want [1 2]", s.Bottoms)
+	}
+	if a := spec.Axes[1]; a.XScale != "symlog" || a.XScaleParams != (ScaleParams{Base: 2, LinThresh: 0.5}) ||
+		a.YScale != "power" || a.YScaleParams != (ScaleParams{Exp: 2}) || spec.Axes[2].XScale != "time" {
+		t.Errorf("exported scales %s %+v, %s %+v, %s", a.XScale, a.XScaleParams, a.YScale, a.YScaleParams, spec.Axes[2].XScale)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded FigureSpec
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	fig, err := BuildFigure(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ExportSpec(fig); !reflect.DeepEqual(got, spec) {
+		t.Errorf("rebuilt figure exports\n%+v\nwant\n%+v", got, spec)
+	}
+}
+
+func TestBuildFigure_Errors(t *testing.T) {
+	bad := []FigureSpec{
+		{},
+		{Width: 10, Height: 10, Axes: []AxesData{{Series: []SeriesData{{Kind: "pie"}}}}},
+		{Width: 10, Height: 10, Axes: []AxesData{{Series: []SeriesData{{Kind: "line", X: []float64{1}, Y: []float64{1, 2}}}}}},
+		{Width: 10, Height: 10, Axes: []AxesData{{Series: []SeriesData{{Kind: "line", Color: []float64{1}}}}}},
+		{Width: 10, Height: 10, Axes: []AxesData{{YScale: "log", YLim: [2]float64{-1, 1}}}},
+		{Width: 10, Height: 10, Axes: []AxesData{{Series: []SeriesData{{Kind: "bar", X: []float64{1}, Y: []float64{1}, Bottoms: []float64{1, 2}}}}}},
+		{Width: 10, Height: 10, Axes: []AxesData{{Series: []SeriesData{{Kind: "bar", X: []float64{1}, Y: []float64{1}, Edges: []float64{1}}}}}},
+	}
+	for i, s := range bad {
+		if _, err := BuildFigure(s); err == nil {
+			t.Errorf("spec %d: no error", i)
+		}
+	}
+}
+
+func TestSavePNGWithData(t *testing.T) {
+	fig := specTestFigure()
+	path := filepath.Join(t.TempDir(), "chart.png")
+	if err := SavePNGWithData(fig, gobasic.New(200, 150, render.Color{R: 1, G: 1, B: 1, A: 1}), path); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := png.Decode(f); err != nil {
+		t.Fatalf("image no longer decodes: %v", err)
+	}
+
+	got, err := LoadFigureData(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ExportSpec(fig); !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadFigureData = %+v, want %+v", got, want)
+	}
+
+	sidecar := filepath.Join(t.TempDir(), "chart.json")
+	if err := SaveFigureData(fig, sidecar); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadFigureData(sidecar); err != nil || got.Width != 200 {
+		t.Errorf("LoadFigureData(sidecar) = %+v, %v", got, err)
+	}
+}
+
+func TestValuesJSON(t *testing.T) {
+	inf := math.Inf(1)
+	data, err := json.Marshal(Values{1.5, math.NaN(), inf, -inf, 1e-300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[1.5,null,"Infinity","-Infinity",1e-300]`; string(data) != want {
+		t.Errorf("marshaled %s, want %s", data, want)
+	}
+	var v Values
+	if err := json.Unmarshal([]byte(`[1.5, null, "Infinity", "-Infinity", "NaN"]`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 5 || v[0] != 1.5 || !math.IsNaN(v[1]) || v[2] != inf || v[3] != -inf || !math.IsNaN(v[4]) {
+		t.Errorf("unmarshaled %v", v)
+	}
+	if err := json.Unmarshal([]byte(`["1"]`), &v); err == nil {
+		t.Error("numeric string accepted")
+	}
+}
+
+func TestSaveFigureData_NonFinite(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Plot([]float64{1, 2, 3}, []float64{1, math.NaN(), math.Inf(1)})
+	path := filepath.Join(t.TempDir(), "chart.json")
+	if err := SaveFigureData(fig, path); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := LoadFigureData(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y := spec.Axes[0].Series[0].Y; len(y) != 3 || !math.IsNaN(y[1]) || !math.IsInf(y[2], 1) {
+		t.Errorf("loaded y %v, want [1 NaN +Inf]", y)
+	}
+}
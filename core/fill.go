@@ -16,6 +16,8 @@ type Fill2D struct {
 	EdgeWidth float64      // edge width in pixels (0 means no edge)
 	Alpha     float64      // alpha transparency override (0-1), if 0 uses Color.A
 	Label     string       // series label for legend
+	Rasterize bool         // draw as an embedded bitmap in vector output
+	Aliased   bool         // draw without anti-aliasing, with hard pixel edges
 	z         float64      // z-order
 }
 
@@ -58,7 +60,8 @@ func (f *Fill2D) Draw(r render.Renderer, ctx *DrawContext) {
 
 	// Create paint for fill area
 	paint := render.Paint{
-		Fill: fillColor,
+		Fill:    fillColor,
+		Aliased: f.Aliased,
 	}
 
 	// Add stroke if edge width is specified and edge color has alpha > 0
@@ -113,6 +116,9 @@ func (f *Fill2D) Z() float64 {
 	return f.z
 }
 
+// Rasterized reports whether the Fill2D should be embedded as a bitmap in vector output.
+func (f *Fill2D) Rasterized() bool { return f.Rasterize }
+
 // Bounds returns the bounding box of the fill area.
 func (f *Fill2D) Bounds(*DrawContext) geom.Rect {
 	if len(f.X) == 0 || len(f.Y1) == 0 {
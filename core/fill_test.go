@@ -411,3 +411,32 @@ func TestFill2D_NegativeValues(t *testing.T) {
 		t.Errorf("Expected Y bounds [-4, 3], got [%v, %v]", bounds.Min.Y, bounds.Max.Y)
 	}
 }
+
+func TestPlotWithBand(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	x := []float64{0, 1, 2}
+	line, band := ax.PlotWithBand(x, []float64{1, 2, 1}, []float64{0.5, 1.5, 0.5}, []float64{1.5, 2.5, 1.5},
+		WithLabel("mean"), WithAlpha(0.8))
+	if line == nil || band == nil {
+		t.Fatal("PlotWithBand returned nil")
+	}
+
+	if len(ax.Artists) != 2 || ax.Artists[0] != band || ax.Artists[1] != line {
+		t.Errorf("band must be added before the line, got %v", ax.Artists)
+	}
+	want := line.Col
+	want.A = 0.8 * bandAlpha
+	if band.Color != want {
+		t.Errorf("band color = %+v, want %+v", band.Color, want)
+	}
+	if line.Col.A != 0.8 || line.Label != "mean" || band.Label != "" {
+		t.Errorf("line %+v / band label %q", line, band.Label)
+	}
+
+	// Both share one color of the cycle.
+	next := ax.Plot(x, x)
+	if next.Col.R == line.Col.R && next.Col.G == line.Col.G && next.Col.B == line.Col.B {
+		t.Errorf("PlotWithBand consumed no color of the cycle")
+	}
+}
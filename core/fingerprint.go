@@ -0,0 +1,150 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"reflect"
+	"sort"
+
+	"matplotlib-go/font"
+	"matplotlib-go/style"
+)
+
+// ErrUnhashable is returned by Fingerprint when a figure holds content that
+// cannot be compared by value, such as function-backed artists or tooltip
+// callbacks.
+var ErrUnhashable = errors.New("figure content cannot be fingerprinted")
+
+// Fingerprint returns a hex SHA-256 digest of the figure's full content: its
+// size, style, axes, scales, and every artist field. Figures that would
+// render identically have equal fingerprints, so the digest can key caches of
+// rendered output. Struct fields tagged `spec:"-"` are ignored.
+func Fingerprint(fig *Figure) (string, error) {
+	for _, ax := range fig.Children {
+		ax.sortArtists() // hash artists in draw order
+	}
+	s := &specHasher{h: sha256.New(), seen: make(map[uintptr]int)}
+	if err := s.value(reflect.ValueOf(fig)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(s.h.Sum(nil)), nil
+}
+
+// specHasher feeds a canonical encoding of a value graph into a hash.
+type specHasher struct {
+	h    hash.Hash
+	seen map[uintptr]int // pointer -> visit index, for shared and cyclic pointers
+	buf  [8]byte
+}
+
+func (s *specHasher) str(v string) {
+	s.u64(uint64(len(v)))
+	s.h.Write([]byte(v))
+}
+
+func (s *specHasher) u64(v uint64) {
+	binary.LittleEndian.PutUint64(s.buf[:], v)
+	s.h.Write(s.buf[:])
+}
+
+func (s *specHasher) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Invalid:
+		s.str("nil")
+	case reflect.Bool:
+		if v.Bool() {
+			s.u64(1)
+		} else {
+			s.u64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.u64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s.u64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		s.u64(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		s.u64(math.Float64bits(real(c)))
+		s.u64(math.Float64bits(imag(c)))
+	case reflect.String:
+		s.str(v.String())
+	case reflect.Pointer:
+		if v.IsNil() {
+			s.str("nil")
+			return nil
+		}
+		if id, ok := s.seen[v.Pointer()]; ok {
+			s.str("ref")
+			s.u64(uint64(id))
+			return nil
+		}
+		s.seen[v.Pointer()] = len(s.seen)
+		s.str("&")
+		return s.value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			s.str("nil")
+			return nil
+		}
+		s.str(v.Elem().Type().String())
+		return s.value(v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		s.str(t.String())
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if f.Tag.Get("spec") == "-" {
+				continue
+			}
+			s.str(f.Name)
+			if err := s.value(v.Field(i)); err != nil {
+				return err
+			}
+		}
+		if t == reflect.TypeFor[style.RC]() {
+			// The font is registered by name; hash its data so that
+			// re-registering a family changes the digest.
+			sum := font.Sum(v.FieldByName("FontKey").String())
+			s.h.Write(sum[:])
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			s.str("nil")
+			return nil
+		}
+		s.u64(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if err := s.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		s.u64(uint64(len(keys)))
+		for _, k := range keys {
+			if err := s.value(k); err != nil {
+				return err
+			}
+			if err := s.value(v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	case reflect.Func:
+		if !v.IsNil() {
+			return fmt.Errorf("%w: %s", ErrUnhashable, v.Type())
+		}
+		s.str("nil")
+	default:
+		return fmt.Errorf("%w: %s", ErrUnhashable, v.Type())
+	}
+	return nil
+}
@@ -1,74 +1,165 @@
 package core
 
 import (
+	"math"
+
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
+	"matplotlib-go/transform"
 )
 
-// Grid renders grid lines at tick positions.
+// Grid renders grid lines at tick positions. Major and minor lines have
+// separate styles; minor lines are drawn first so majors stay on top.
 type Grid struct {
 	Axis      AxisSide     // which axis to use for tick positions
-	Color     render.Color // grid line color
-	LineWidth float64      // width of grid lines
-	Alpha     float64      // alpha override (0-1), if 0 uses Color.A
+	Both      bool         // draw lines for both x and y ticks, ignoring Axis
+	Color     render.Color // major grid line color
+	LineWidth float64      // width of major grid lines
+	Dashes    []float64    // dash pattern of major grid lines, nil for solid
+	Alpha     float64      // alpha override (0-1) for all lines, if 0 uses the colors' A
 	Major     bool         // draw grid at major ticks
-	Minor     bool         // draw grid at minor ticks (not implemented yet)
-	z         float64      // z-order (should be behind data)
+	Minor     bool         // draw grid at minor ticks
+
+	MinorColor     render.Color // minor grid line color
+	MinorLineWidth float64      // width of minor grid lines
+	MinorDashes    []float64    // dash pattern of minor grid lines, nil for solid
+	MinorDivisions int          // minor intervals per major interval on linear scales, 0 for the axis minor ticks or automatic
+	MinorSubs      []float64    // minor multiples of each power on log scales, e.g. LogSubsAll(); nil for the axis minor ticks or every integer multiple 2, ..., base-1
+
+	Snap SnapMode // align lines with the pixel grid, SnapAuto follows the RC
+
+	z    float64 // z-order (should be behind data)
+	axes *Axes   `spec:"-"` // owner, for its axis locators
 }
 
 // NewGrid creates a new grid for the specified axis.
 func NewGrid(axis AxisSide) *Grid {
 	return &Grid{
-		Axis:      axis,
-		Color:     render.Color{R: 0.8, G: 0.8, B: 0.8, A: 1}, // light gray
-		LineWidth: 0.5,
-		Alpha:     0, // use Color.A
-		Major:     true,
-		Minor:     false,
-		z:         -1000, // behind everything else
+		Axis:           axis,
+		Color:          render.Color{R: 0.8, G: 0.8, B: 0.8, A: 1}, // light gray
+		LineWidth:      0.5,
+		Alpha:          0, // use Color.A
+		Major:          true,
+		Minor:          false,
+		MinorColor:     render.Color{R: 0.9, G: 0.9, B: 0.9, A: 1},
+		MinorLineWidth: 0.25,
+		z:              -1000, // behind everything else
 	}
 }
 
 // Draw renders grid lines at tick positions.
 func (g *Grid) Draw(r render.Renderer, ctx *DrawContext) {
-	if !g.Major {
+	if !g.Major && !g.Minor {
 		return // nothing to draw
 	}
 
-	// Get the axis domain and locator
-	var min, max float64
-	var locator Locator
-	var isXAxis bool
-
-	switch g.Axis {
-	case AxisBottom, AxisTop:
-		min, max = ctx.DataToPixel.XScale.Domain()
-		isXAxis = true
-		// Use a default locator since we don't have direct access to axis
-		locator = LinearLocator{}
-	case AxisLeft, AxisRight:
-		min, max = ctx.DataToPixel.YScale.Domain()
-		isXAxis = false
-		locator = LinearLocator{}
+	var sides []bool // true for the x axis
+	switch {
+	case g.Both:
+		sides = []bool{true, false}
+	case g.Axis == AxisBottom || g.Axis == AxisTop:
+		sides = []bool{true}
+	default:
+		sides = []bool{false}
 	}
 
-	// Calculate tick positions
-	ticks := locator.Ticks(min, max, 8)
+	if g.Minor {
+		for _, isXAxis := range sides {
+			_, minor := g.ticks(ctx, isXAxis)
+			for _, tickValue := range minor {
+				g.drawGridLine(r, ctx, tickValue, isXAxis, g.color(g.MinorColor), g.MinorLineWidth, g.MinorDashes)
+			}
+		}
+	}
+	if g.Major {
+		for _, isXAxis := range sides {
+			major, _ := g.ticks(ctx, isXAxis)
+			for _, tickValue := range major {
+				g.drawGridLine(r, ctx, tickValue, isXAxis, g.color(g.Color), g.LineWidth, g.Dashes)
+			}
+		}
+	}
+}
 
-	// Get grid color
-	gridColor := g.Color
+// color applies the Alpha override.
+func (g *Grid) color(c render.Color) render.Color {
 	if g.Alpha > 0 && g.Alpha <= 1 {
-		gridColor.A = g.Alpha
+		c.A = g.Alpha
 	}
+	return c
+}
 
-	// Draw grid lines
-	for _, tickValue := range ticks {
-		g.drawGridLine(r, ctx, tickValue, isXAxis, gridColor)
+// ticks returns the major and minor tick positions of one axis. Majors are
+// those of the axes' x or y axis, so the lines follow a DateLocator or
+// SymLogLocator set on it; grids without one locate their own. Minors follow
+// MinorSubs or MinorDivisions if set, else the axis' minor locator, else
+// integer multiples of each power on log scales and subdivisions of each
+// major interval on others.
+func (g *Grid) ticks(ctx *DrawContext, isXAxis bool) (major, minor []float64) {
+	scale := ctx.DataToPixel.YScale
+	if isXAxis {
+		scale = ctx.DataToPixel.XScale
+	}
+	min, max := scale.Domain()
+	if min > max {
+		min, max = max, min
+	}
+
+	target := ctx.TickTarget(isXAxis)
+	lg, isLog := scale.(transform.Log)
+	var axisMinor []float64
+	hasAxisMinor := false
+	if ax := g.axis(isXAxis); ax != nil {
+		major, axisMinor = ax.ticks(min, max, target)
+		hasAxisMinor = ax.hasMinor()
+	} else if isLog {
+		major = LogLocator{Base: lg.Base}.Ticks(min, max, target)
+	} else {
+		major = LinearLocator{}.Ticks(min, max, target)
+	}
+
+	switch {
+	case isLog && g.MinorSubs != nil:
+		minor = LogLocator{Base: lg.Base, Subs: g.MinorSubs}.MinorTicks(min, max)
+	case !isLog && g.MinorDivisions > 0:
+		minor = AutoMinorLocator{Divisions: g.MinorDivisions}.Ticks(min, max, target)
+	case hasAxisMinor:
+		minor = axisMinor
+	case isLog:
+		minor = LogLocator{Base: lg.Base, Subs: logIntegerSubs(lg.Base)}.MinorTicks(min, max)
+	default:
+		minor = AutoMinorLocator{}.Ticks(min, max, target)
+	}
+	return major, minor
+}
+
+// axis returns the owning axes' x or y axis, or nil.
+func (g *Grid) axis(isXAxis bool) *Axis {
+	switch {
+	case g.axes == nil:
+		return nil
+	case isXAxis:
+		return g.axes.XAxis
+	default:
+		return g.axes.YAxis
 	}
 }
 
+// logIntegerSubs returns the integer multiples 2, ..., base-1 of each power
+// that minor grid lines default to on log scales; non-integer bases get none.
+func logIntegerSubs(base float64) []float64 {
+	subs := []float64{}
+	if base != math.Trunc(base) {
+		return subs
+	}
+	for m := 2.0; m < base; m++ {
+		subs = append(subs, m)
+	}
+	return subs
+}
+
 // drawGridLine draws a single grid line.
-func (g *Grid) drawGridLine(r render.Renderer, ctx *DrawContext, tickValue float64, isXAxis bool, color render.Color) {
+func (g *Grid) drawGridLine(r render.Renderer, ctx *DrawContext, tickValue float64, isXAxis bool, color render.Color, width float64, dashes []float64) {
 	var p1, p2 geom.Pt
 
 	if isXAxis {
@@ -83,6 +174,10 @@ func (g *Grid) drawGridLine(r render.Renderer, ctx *DrawContext, tickValue float
 		p2 = ctx.DataToPixel.Apply(geom.Pt{X: xMax, Y: tickValue})
 	}
 
+	if g.Snap.enabled(ctx) {
+		p1, p2 = snapLine(p1, p2, width)
+	}
+
 	// Create line path
 	path := geom.Path{}
 	path.C = append(path.C, geom.MoveTo)
@@ -92,8 +187,9 @@ func (g *Grid) drawGridLine(r render.Renderer, ctx *DrawContext, tickValue float
 
 	// Draw the grid line
 	paint := render.Paint{
-		LineWidth: g.LineWidth,
+		LineWidth: width,
 		Stroke:    color,
+		Dashes:    dashes,
 		LineCap:   render.CapButt,
 		LineJoin:  render.JoinMiter,
 	}
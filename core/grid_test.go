@@ -0,0 +1,140 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// pathRecorder records the paint of every Path call.
+type pathRecorder struct {
+	render.NullRenderer
+	paints []render.Paint
+}
+
+func (p *pathRecorder) Path(_ geom.Path, paint *render.Paint) {
+	p.paints = append(p.paints, *paint)
+}
+
+// gridTestContext is createTestDrawContext with 400 px axes, large enough
+// for the full 8 tick target of DrawContext.TickTarget.
+func gridTestContext() *DrawContext {
+	ctx := createTestDrawContext()
+	ctx.DataToPixel.AxesToPixel = transform.NewAffine(geom.Affine{A: 400, D: -400, E: 50, F: 450})
+	return ctx
+}
+
+func TestGrid_MajorMinorStyles(t *testing.T) {
+	grid := NewGrid(AxisBottom)
+	grid.Minor = true
+	grid.Dashes = []float64{4, 2}
+	grid.MinorLineWidth = 0.1
+
+	r := &pathRecorder{}
+	grid.Draw(r, gridTestContext()) // x domain 0..10: majors every 1, minors every 0.2
+
+	var major, minor int
+	for i, p := range r.paints {
+		switch p.LineWidth {
+		case grid.LineWidth:
+			major++
+			if len(p.Dashes) != 2 || p.Stroke != grid.Color {
+				t.Errorf("major line %d: paint %+v", i, p)
+			}
+		case grid.MinorLineWidth:
+			minor++
+			if major > 0 {
+				t.Errorf("minor line %d drawn after majors", i)
+			}
+			if p.Dashes != nil || p.Stroke != grid.MinorColor {
+				t.Errorf("minor line %d: paint %+v", i, p)
+			}
+		default:
+			t.Errorf("line %d: unexpected width %v", i, p.LineWidth)
+		}
+	}
+	if major != 11 || minor != 40 {
+		t.Errorf("got %d major and %d minor lines, want 11 and 40", major, minor)
+	}
+}
+
+func TestGrid_Both(t *testing.T) {
+	grid := NewGrid(AxisLeft)
+	grid.Both = true
+
+	r := &pathRecorder{}
+	grid.Draw(r, gridTestContext())
+	if len(r.paints) != 22 {
+		t.Errorf("got %d lines, want 11 per axis", len(r.paints))
+	}
+
+	grid.Major = false
+	r.paints = nil
+	grid.Draw(r, gridTestContext())
+	if len(r.paints) != 0 {
+		t.Errorf("got %d lines with major and minor disabled", len(r.paints))
+	}
+}
+
+func TestGrid_Ticks(t *testing.T) {
+	tests := []struct {
+		name         string
+		scale        transform.Scale
+		major, minor int
+	}{
+		{"linear step 1", transform.NewLinear(0, 10), 11, 40},
+		{"linear step 2", transform.NewLinear(0, 16), 9, 24},
+		{"log", transform.NewLog(1, 1000, 10), 4, 24},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := gridTestContext()
+			ctx.DataToPixel.XScale = tc.scale
+			major, minor := NewGrid(AxisBottom).ticks(ctx, true)
+			if len(major) != tc.major || len(minor) != tc.minor {
+				t.Errorf("got %d major, %d minor ticks, want %d, %d (minor %v)", len(major), len(minor), tc.major, tc.minor, minor)
+			}
+		})
+	}
+}
+
+func TestGrid_AxisLocator(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ax.SetXTime(start, start.AddDate(0, 6, 0))
+	grid := ax.AddXGrid()
+
+	ctx := gridTestContext()
+	ctx.DataToPixel.XScale = ax.XScale
+	major, _ := grid.ticks(ctx, true)
+	min, max := ax.XScale.Domain()
+	want, _ := ax.XAxis.ticks(min, max, ctx.TickTarget(true))
+	if len(major) == 0 || !slices.Equal(major, want) {
+		t.Errorf("grid majors %v, want the date ticks %v", major, want)
+	}
+}
+
+func TestGrid_AxisMinorLocator(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(0, 10)
+	ax.XAxis.MinorLocator = AutoMinorLocator{Divisions: 2}
+	grid := ax.AddXGrid()
+
+	ctx := gridTestContext()
+	_, minor := grid.ticks(ctx, true)
+	_, want := ax.XAxis.ticks(0, 10, ctx.TickTarget(true))
+	if len(minor) != 10 || !slices.Equal(minor, want) {
+		t.Errorf("grid minors %v, want the axis minors %v", minor, want)
+	}
+
+	grid.MinorDivisions = 5
+	if _, minor := grid.ticks(ctx, true); len(minor) != 40 {
+		t.Errorf("got %d minors with MinorDivisions 5, want 40", len(minor))
+	}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// groupNamer assigns stable ids and classes to artists for renderers that
+// implement render.Grouper. Ids have the form "<prefix>-<kind><n>", where
+// kind is the lower-cased artist type name and n counts artists of that kind
+// in draw order, so ids survive unrelated additions of other artist types.
+type groupNamer struct {
+	prefix string
+	counts map[string]int
+}
+
+// next returns the id and class for the next artist in draw order.
+func (g *groupNamer) next(art Artist) (id, class string) {
+	if g.counts == nil {
+		g.counts = make(map[string]int)
+	}
+	kind := artistKind(art)
+	n := g.counts[kind]
+	g.counts[kind] = n + 1
+	return fmt.Sprintf("%s-%s%d", g.prefix, kind, n), kind
+}
+
+// artistKind returns the lower-cased, package-less type name of an artist,
+// e.g. "line2d" for *core.Line2D.
+func artistKind(art Artist) string {
+	name := fmt.Sprintf("%T", art)
+	name = strings.TrimLeft(name, "*")
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.ToLower(name)
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// groupRecorder records BeginGroup/EndGroup calls.
+type groupRecorder struct {
+	render.NullRenderer
+	opened []string
+	depth  int
+}
+
+func (g *groupRecorder) BeginGroup(id, class string) {
+	g.opened = append(g.opened, id+"|"+class)
+	g.depth++
+}
+
+func (g *groupRecorder) EndGroup() { g.depth-- }
+
+func TestDrawFigureGroups(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1})
+	ax.Add(&Scatter2D{XY: []geom.Pt{{X: 0.5, Y: 0.5}}, Size: 2})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 1}, {X: 1, Y: 0}}, W: 1})
+
+	var r groupRecorder
+	DrawFigure(fig, &r)
+
+	want := []string{
+		"axes0|axes",
+		"axes0-line2d0|line2d",
+		"axes0-scatter2d0|scatter2d",
+		"axes0-line2d1|line2d",
+		"axes0-xaxis|axis xaxis",
+		"axes0-yaxis|axis yaxis",
+	}
+	if len(r.opened) != len(want) {
+		t.Fatalf("groups mismatch: got %v want %v", r.opened, want)
+	}
+	for i := range want {
+		if r.opened[i] != want[i] {
+			t.Fatalf("group %d: got %q want %q", i, r.opened[i], want[i])
+		}
+	}
+	if r.depth != 0 {
+		t.Fatalf("unbalanced groups: depth %d", r.depth)
+	}
+}
+
+func TestDrawFigureAxisOrder(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1})
+	top := ax.AddAxis(AxisTop)
+	ax.AddAxis(AxisRight)
+	ax.YAxis.SetZ(-1) // below the data
+	ax.SetVisible(top, false)
+
+	var r groupRecorder
+	DrawFigure(fig, &r)
+
+	want := []string{
+		"axes0|axes",
+		"axes0-yaxis|axis yaxis",
+		"axes0-line2d0|line2d",
+		"axes0-xaxis|axis xaxis",
+		"axes0-yaxis1|axis yaxis",
+	}
+	if len(r.opened) != len(want) {
+		t.Fatalf("groups mismatch: got %v want %v", r.opened, want)
+	}
+	for i := range want {
+		if r.opened[i] != want[i] {
+			t.Fatalf("group %d: got %q want %q", i, r.opened[i], want[i])
+		}
+	}
+}
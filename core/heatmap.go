@@ -0,0 +1,97 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Heatmap draws a matrix as a grid of colored cells, the first row at the
+// top as in matplotlib's imshow. Cell (i, j), row i and column j, covers
+// x from j to j+1 and y from rows-i-1 to rows-i in data coordinates. NaN
+// cells are left empty. Each cell is a filled path, so vector output stays
+// sharp.
+type Heatmap struct {
+	Values     [][]float64    // rows of values
+	Cmap       color.Colormap // colormap, nil for color.Viridis
+	VMin, VMax float64        // values mapped to the ends of Cmap; equal for the data range
+	Label      string         // series label for legend
+	Aliased    bool           // draw cells without anti-aliasing, so that neighbours meet without seams, e.g. for pixel art
+	z          float64        // z-order
+}
+
+// Heatmap draws z as a heatmap and sets the limits to its cells.
+func (a *Axes) Heatmap(z [][]float64) *Heatmap {
+	h := &Heatmap{Values: z}
+	a.Add(h)
+	b := h.Bounds(nil)
+	if b.Max.X > b.Min.X && b.Max.Y > b.Min.Y {
+		a.SetXLim(b.Min.X, b.Max.X)
+		a.SetYLim(b.Min.Y, b.Max.Y)
+	}
+	return h
+}
+
+// Range returns the values mapped to the ends of the colormap.
+func (h *Heatmap) Range() (float64, float64) {
+	if h.VMin != h.VMax {
+		return h.VMin, h.VMax
+	}
+	r := emptyRange()
+	for _, row := range h.Values {
+		r = r.add(row...)
+	}
+	if r.lo > r.hi {
+		return 0, 1
+	}
+	return r.lo, r.hi
+}
+
+// Draw renders the cells.
+func (h *Heatmap) Draw(r render.Renderer, ctx *DrawContext) {
+	cmap := h.Cmap
+	if cmap == nil {
+		cmap = color.Viridis
+	}
+	lo, hi := h.Range()
+	rows := float64(len(h.Values))
+	for i, row := range h.Values {
+		for j, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			t := 0.5
+			if hi > lo {
+				t = (v - lo) / (hi - lo)
+			}
+			x0, y0 := float64(j), rows-float64(i)-1
+			var p geom.Path
+			for k, c := range [4]geom.Pt{{X: x0, Y: y0}, {X: x0 + 1, Y: y0}, {X: x0 + 1, Y: y0 + 1}, {X: x0, Y: y0 + 1}} {
+				cmd := geom.LineTo
+				if k == 0 {
+					cmd = geom.MoveTo
+				}
+				p.C = append(p.C, cmd)
+				p.V = append(p.V, ctx.DataToPixel.Apply(c))
+			}
+			p.C = append(p.C, geom.ClosePath)
+			r.Path(p, &render.Paint{Fill: cmap.At(t), Aliased: h.Aliased})
+		}
+	}
+}
+
+// Z returns the z-order for sorting.
+func (h *Heatmap) Z() float64 {
+	return h.z
+}
+
+// Bounds returns the data extent of the cells.
+func (h *Heatmap) Bounds(*DrawContext) geom.Rect {
+	cols := 0
+	for _, row := range h.Values {
+		cols = max(cols, len(row))
+	}
+	return geom.Rect{Max: geom.Pt{X: float64(cols), Y: float64(len(h.Values))}}
+}
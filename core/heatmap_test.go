@@ -0,0 +1,47 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestHeatmap(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	h := ax.Heatmap([][]float64{{0, 1, 2}, {3, math.NaN(), 4}})
+	if xmin, xmax := ax.XScale.Domain(); xmin != 0 || xmax != 3 {
+		t.Errorf("x limits %v..%v, want 0..3", xmin, xmax)
+	}
+	if ymin, ymax := ax.YScale.Domain(); ymin != 0 || ymax != 2 {
+		t.Errorf("y limits %v..%v, want 0..2", ymin, ymax)
+	}
+	if lo, hi := h.Range(); lo != 0 || hi != 4 {
+		t.Errorf("range %v..%v, want 0..4", lo, hi)
+	}
+
+	var r segmentRecorder
+	h.Draw(&r, ax.drawContext(fig))
+	if len(r.paths) != 5 {
+		t.Fatalf("drew %d cells, want 5 without the NaN", len(r.paths))
+	}
+	// The first row is at the top; its first cell has the lowest value.
+	first := r.paths[0]
+	if !near(first.V[0], geom.Pt{X: 0, Y: 150}) || !near(first.V[2], geom.Pt{X: 400.0 / 3, Y: 0}) {
+		t.Errorf("first cell at %v", first.V)
+	}
+	if r.paints[0].Fill != color.Viridis[0] || r.paints[4].Fill != color.Viridis[len(color.Viridis)-1] {
+		t.Errorf("cell colors %v and %v", r.paints[0].Fill, r.paints[4].Fill)
+	}
+
+	h.VMin, h.VMax = 0, 8
+	h.Cmap = color.Colormap{{A: 1}, {R: 1, A: 1}}
+	r = segmentRecorder{}
+	h.Draw(&r, ax.drawContext(fig))
+	if got := r.paints[4].Fill; got != (render.Color{R: 0.5, A: 1}) {
+		t.Errorf("value 4 of 0..8 colored %v", got)
+	}
+}
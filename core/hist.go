@@ -0,0 +1,185 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/stats"
+)
+
+// Hist draws the distribution of raw samples as bars, one per bin. The
+// samples are binned on every draw, so Data, Edges and the modes can be
+// changed after creation.
+type Hist struct {
+	Data        []float64      // samples; NaNs and values outside the edges are not counted
+	Edges       []float64      // ascending bin edges; bin i spans Edges[i] to Edges[i+1]
+	Density     bool           // normalize so that the bar areas sum to 1
+	Cumulative  bool           // each bar counts the samples up to its right edge; with Density the last bar is 1
+	Orientation BarOrientation // vertical bars, or horizontal bars growing along x
+	Color       render.Color   // bar fill color
+	EdgeColor   render.Color   // bar outline color
+	EdgeWidth   float64        // outline width in pixels (0 means no edge)
+	Alpha       float64        // alpha transparency (0-1), 0 for opaque
+	Label       string         // series label for legend
+	z           float64        // z-order
+}
+
+// HistOptions configures Axes.Hist.
+type HistOptions struct {
+	Bins        int            // number of equal-width bins over the data range, 0 for 10
+	Edges       []float64      // bin edges, overriding Bins
+	Density     bool           // see Hist.Density
+	Cumulative  bool           // see Hist.Cumulative
+	Orientation BarOrientation // see Hist.Orientation
+	Color       *render.Color  // if nil, uses automatic color cycling
+	EdgeColor   *render.Color  // edge color
+	EdgeWidth   *float64       // edge width
+	Alpha       *float64       // alpha transparency
+	Label       string         // series label for legend
+}
+
+// defaultHistBins is the number of bins of Axes.Hist without Bins or Edges.
+const defaultHistBins = 10
+
+// Hist bins data and draws it as a histogram in the next color of the
+// cycle unless opts.Color is set. Without opts.Edges the data's finite
+// range is split into opts.Bins equal bins; a single distinct value gets
+// a bin of width 1 around it.
+func (a *Axes) Hist(data []float64, opts HistOptions) *Hist {
+	if !a.validate(checkLengths("Hist", "data", data), checkEdges("Hist", opts.Edges),
+		checkColor("Hist", "color", opts.Color), checkColor("Hist", "edge color", opts.EdgeColor),
+		checkWidth("Hist", "edge width", opts.EdgeWidth), checkAlpha("Hist", opts.Alpha)) {
+		return nil
+	}
+
+	edges := opts.Edges
+	if edges == nil {
+		bins := opts.Bins
+		if bins <= 0 {
+			bins = defaultHistBins
+		}
+		lo, hi, ilo, _ := finiteRange(data)
+		if ilo < 0 {
+			lo, hi = 0, 1
+		}
+		if lo == hi {
+			lo, hi = lo-0.5, hi+0.5
+		}
+		edges = stats.LinearBins(lo, hi, bins)
+	}
+
+	h := &Hist{
+		Data:        data,
+		Edges:       edges,
+		Density:     opts.Density,
+		Cumulative:  opts.Cumulative,
+		Orientation: opts.Orientation,
+		Color:       a.NextColor(),
+		Label:       opts.Label,
+	}
+	if opts.Color != nil {
+		h.Color = *opts.Color
+	}
+	if opts.EdgeColor != nil {
+		h.EdgeColor = *opts.EdgeColor
+	}
+	if opts.EdgeWidth != nil {
+		h.EdgeWidth = *opts.EdgeWidth
+	}
+	if opts.Alpha != nil {
+		h.Alpha = *opts.Alpha
+	}
+	a.Add(h)
+	return h
+}
+
+// checkEdges requires nil or at least two finite, strictly increasing bin
+// edges.
+func checkEdges(op string, edges []float64) error {
+	if edges == nil {
+		return nil
+	}
+	if len(edges) < 2 {
+		return invalid(op, "bin edges %v are fewer than two", edges)
+	}
+	for i, e := range edges {
+		if math.IsNaN(e) || math.IsInf(e, 0) || i > 0 && !(e > edges[i-1]) {
+			return invalid(op, "bin edges %v are not finite and strictly increasing", edges)
+		}
+	}
+	return nil
+}
+
+// Values returns the bar heights: counts per bin, normalized and
+// accumulated as set by Density and Cumulative.
+func (h *Hist) Values() []float64 {
+	v := stats.Histogram(h.Data, h.Edges)
+	if h.Density {
+		total := 0.0
+		for _, c := range v {
+			total += c
+		}
+		for i := range v {
+			switch {
+			case total == 0:
+			case h.Cumulative:
+				v[i] /= total
+			case h.Edges[i+1] > h.Edges[i]:
+				v[i] /= total * (h.Edges[i+1] - h.Edges[i])
+			default:
+				v[i] = 0 // an empty bin, e.g. between repeated edges
+			}
+		}
+	}
+	if h.Cumulative {
+		for i := 1; i < len(v); i++ {
+			v[i] += v[i-1]
+		}
+	}
+	return v
+}
+
+// bars returns the Bar2D drawing the current bins.
+func (h *Hist) bars() *Bar2D {
+	heights := h.Values()
+	centers := make([]float64, len(heights))
+	widths := make([]float64, len(heights))
+	for i := range heights {
+		centers[i] = (h.Edges[i] + h.Edges[i+1]) / 2
+		widths[i] = h.Edges[i+1] - h.Edges[i]
+	}
+	return &Bar2D{
+		X:           centers,
+		Heights:     heights,
+		Widths:      widths,
+		Edges:       h.Edges,
+		Color:       h.Color,
+		EdgeColor:   h.EdgeColor,
+		EdgeWidth:   h.EdgeWidth,
+		Alpha:       h.Alpha,
+		Orientation: h.Orientation,
+		Label:       h.Label,
+	}
+}
+
+// Draw renders the bins as bars.
+func (h *Hist) Draw(r render.Renderer, ctx *DrawContext) {
+	if len(h.Edges) < 2 {
+		return
+	}
+	h.bars().Draw(r, ctx)
+}
+
+// Z returns the z-order for sorting.
+func (h *Hist) Z() float64 {
+	return h.z
+}
+
+// Bounds returns the extent of the bins, from zero to the highest bar.
+func (h *Hist) Bounds(ctx *DrawContext) geom.Rect {
+	if len(h.Edges) < 2 {
+		return geom.Rect{}
+	}
+	return h.bars().Bounds(ctx)
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestHist(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	first := ax.Hist([]float64{1}, HistOptions{})
+	h := ax.Hist([]float64{0, 1, 1, 3, 4, math.NaN()}, HistOptions{Bins: 2, Label: "samples"})
+	if first.Color == h.Color {
+		t.Error("second histogram did not take the next cycle color")
+	}
+	if len(first.Edges) != defaultHistBins+1 || first.Edges[0] != 0.5 || first.Edges[defaultHistBins] != 1.5 {
+		t.Errorf("single value edges %v, want 0.5..1.5", first.Edges)
+	}
+	if len(h.Edges) != 3 || h.Edges[0] != 0 || h.Edges[1] != 2 || h.Edges[2] != 4 {
+		t.Fatalf("edges %v, want 0, 2, 4", h.Edges)
+	}
+
+	tests := []struct {
+		density, cumulative bool
+		want                []float64
+	}{
+		{false, false, []float64{3, 2}},
+		{true, false, []float64{0.3, 0.2}},
+		{false, true, []float64{3, 5}},
+		{true, true, []float64{0.6, 1}},
+	}
+	for _, tt := range tests {
+		h.Density, h.Cumulative = tt.density, tt.cumulative
+		got := h.Values()
+		if len(got) != 2 || math.Abs(got[0]-tt.want[0]) > 1e-12 || math.Abs(got[1]-tt.want[1]) > 1e-12 {
+			t.Errorf("density %v, cumulative %v: values %v, want %v", tt.density, tt.cumulative, got, tt.want)
+		}
+	}
+
+	h.Density, h.Cumulative = false, false
+	if b := h.Bounds(nil); b != (geom.Rect{Max: geom.Pt{X: 4, Y: 3}}) {
+		t.Errorf("bounds %v, want 0,0..4,3", b)
+	}
+	var r segmentRecorder
+	h.Draw(&r, createTestDrawContext())
+	if len(r.paths) != 2 || r.paints[0].Fill != h.Color {
+		t.Errorf("drew %d bars, want 2 in the histogram color", len(r.paths))
+	}
+}
+
+func TestHist_Strict(t *testing.T) {
+	fig := NewFigure(400, 300)
+	fig.Strict = true
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	if ax.Hist([]float64{1, 2}, HistOptions{Edges: []float64{2, 1}}) != nil || fig.Err() == nil {
+		t.Error("strict Hist accepted descending edges")
+	}
+	if ax.Hist([]float64{1, 2}, HistOptions{Edges: []float64{0, 1, 1, 3}}) != nil {
+		t.Error("strict Hist accepted repeated edges")
+	}
+	red := render.Color{R: 1, A: 1}
+	if h := ax.Hist([]float64{1, 2}, HistOptions{Edges: []float64{0, 1, 3}, Color: &red}); h == nil || h.Color != red {
+		t.Error("strict Hist rejected valid edges or ignored the color")
+	}
+}
+
+func TestHist_RepeatedEdges(t *testing.T) {
+	fig := NewFigure(200, 150)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	h := ax.Hist([]float64{0.5, 1, 2}, HistOptions{Edges: []float64{0, 1, 1, 3}, Density: true})
+	v := h.Values()
+	if v[1] != 0 {
+		t.Errorf("zero-width bin has height %v, want 0", v[1])
+	}
+	for i, x := range v {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			t.Errorf("bin %d has height %v", i, x)
+		}
+	}
+	ax.SetXLim(0, 3)
+	ax.SetYLim(0, 1)
+	DrawFigure(fig, gobasic.New(200, 150, render.Color{R: 1, G: 1, B: 1, A: 1}))
+}
@@ -0,0 +1,37 @@
+package core
+
+import "matplotlib-go/render"
+
+// DrawHook is a callback run by DrawFigure, e.g. to recompute artists that
+// depend on the data limits, to add a dynamic watermark, or to collect
+// timings. ctx is the DrawContext of the figure or axes it is registered
+// on. Hooks run on every draw, including partial redraws of a Redrawer.
+// Since their output cannot be compared, Fingerprint fails with
+// ErrUnhashable for figures with hooks, so RenderCache always renders them.
+type DrawHook func(r render.Renderer, ctx *DrawContext)
+
+// drawHooks holds the callbacks around a draw.
+type drawHooks struct {
+	pre, post []DrawHook
+}
+
+func runHooks(hooks []DrawHook, r render.Renderer, ctx *DrawContext) {
+	for _, fn := range hooks {
+		fn(r, ctx)
+	}
+}
+
+// OnPreDraw registers fn to run before anything of the figure is drawn.
+// Changes it makes to the figure, such as added artists, are drawn.
+func (f *Figure) OnPreDraw(fn DrawHook) { f.hooks.pre = append(f.hooks.pre, fn) }
+
+// OnPostDraw registers fn to run after the whole figure is drawn.
+func (f *Figure) OnPostDraw(fn DrawHook) { f.hooks.post = append(f.hooks.post, fn) }
+
+// OnPreDraw registers fn to run before the artists of the axes are drawn,
+// within the axes clip. Artists it adds or changes are drawn.
+func (a *Axes) OnPreDraw(fn DrawHook) { a.hooks.pre = append(a.hooks.pre, fn) }
+
+// OnPostDraw registers fn to run after the artists and axis lines of the
+// axes are drawn, within the axes clip.
+func (a *Axes) OnPostDraw(fn DrawHook) { a.hooks.post = append(a.hooks.post, fn) }
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// hookRecorder logs Path calls between hook events.
+type hookRecorder struct {
+	render.NullRenderer
+	events []string
+}
+
+func (h *hookRecorder) Path(geom.Path, *render.Paint) { h.events = append(h.events, "path") }
+
+func TestDrawHooks(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+
+	log := func(name string) DrawHook {
+		return func(r render.Renderer, _ *DrawContext) {
+			rec := r.(*hookRecorder)
+			rec.events = append(rec.events, name)
+		}
+	}
+	fig.OnPreDraw(log("fig-pre"))
+	fig.OnPostDraw(log("fig-post"))
+	ax.OnPostDraw(log("ax-post"))
+
+	// A pre-draw hook recomputes an artist from the current limits.
+	var line *Line2D
+	ax.OnPreDraw(func(r render.Renderer, ctx *DrawContext) {
+		log("ax-pre")(r, ctx)
+		if line == nil {
+			min, max := ctx.DataToPixel.XScale.Domain()
+			line = &Line2D{XY: []geom.Pt{{X: min, Y: 0}, {X: max, Y: 1}}, W: 1, Col: render.Color{A: 1}}
+			ax.Add(line)
+		}
+	})
+
+	r := &hookRecorder{}
+	DrawFigure(fig, r)
+	want := []string{"fig-pre", "ax-pre", "path", "ax-post", "fig-post"}
+	if len(r.events) != len(want) {
+		t.Fatalf("events %v, want %v", r.events, want)
+	}
+	for i := range want {
+		if r.events[i] != want[i] {
+			t.Fatalf("events %v, want %v", r.events, want)
+		}
+	}
+
+	if _, err := Fingerprint(fig); !errors.Is(err, ErrUnhashable) {
+		t.Errorf("Fingerprint of a figure with hooks: %v, want ErrUnhashable", err)
+	}
+}
@@ -0,0 +1,212 @@
+package core
+
+import (
+	"matplotlib-go/backends"
+	"matplotlib-go/color"
+	"matplotlib-go/transform"
+)
+
+// KeyAction is a command bound to a key. It acts on ax, the axes under the
+// pointer, or on all axes of the figure if ax is nil, and reports whether
+// the figure changed and needs a redraw.
+type KeyAction func(ax *Axes) (bool, error)
+
+// KeyMap dispatches key presses of interactive frontends to actions, as
+// matplotlib's keymap.* rc settings do. Keys are named as the frontend
+// reports them, e.g. "g", "L", "left" or "ctrl+s"; letters are case
+// sensitive. NewKeyMap binds matplotlib's defaults:
+//
+//	h, r, home          Navigator.Home
+//	c, left, backspace  Navigator.Back
+//	v, right            Navigator.Forward
+//	s, ctrl+s           Save
+//	g                   ToggleGrid
+//	l                   ToggleLogY
+//	k, L                ToggleLogX
+//	m                   CycleColormap
+//
+// Frontends call Handle for each key press and redraw the whole figure,
+// e.g. with Redrawer.InvalidateAll, if it reports a change.
+type KeyMap struct {
+	Nav         *Navigator       // view history and figure of the actions
+	Colormaps   []color.Colormap // colormaps cycled through by CycleColormap
+	SavePath    string           // file written by Save
+	SaveBackend backends.Backend // backend used by Save, which sets the format
+
+	bindings map[string]KeyAction
+	cmap     int // index of the current colormap in Colormaps
+}
+
+// NewKeyMap returns a KeyMap with the default bindings for the figure of
+// nav. Save writes "figure.png" with the GoBasic backend, and
+// CycleColormap steps through viridis, its reverse and gray.
+func NewKeyMap(nav *Navigator) *KeyMap {
+	k := &KeyMap{
+		Nav:         nav,
+		Colormaps:   []color.Colormap{color.Viridis, color.Viridis.Reversed(), color.Gray},
+		SavePath:    "figure.png",
+		SaveBackend: backends.GoBasic,
+		bindings:    make(map[string]KeyAction),
+	}
+	home := func(*Axes) (bool, error) { return nav.Home(), nil }
+	back := func(*Axes) (bool, error) { return nav.Back(), nil }
+	forward := func(*Axes) (bool, error) { return nav.Forward(), nil }
+	defaults := []struct {
+		action KeyAction
+		keys   []string
+	}{
+		{home, []string{"h", "r", "home"}},
+		{back, []string{"c", "left", "backspace"}},
+		{forward, []string{"v", "right"}},
+		{k.Save, []string{"s", "ctrl+s"}},
+		{k.ToggleGrid, []string{"g"}},
+		{k.ToggleLogY, []string{"l"}},
+		{k.ToggleLogX, []string{"k", "L"}},
+		{k.CycleColormap, []string{"m"}},
+	}
+	for _, d := range defaults {
+		for _, key := range d.keys {
+			k.Bind(key, d.action)
+		}
+	}
+	return k
+}
+
+// Bind binds key to action, replacing its previous action. A nil action
+// unbinds the key.
+func (k *KeyMap) Bind(key string, action KeyAction) {
+	if action == nil {
+		delete(k.bindings, key)
+		return
+	}
+	k.bindings[key] = action
+}
+
+// Action returns the action bound to key, or nil.
+func (k *KeyMap) Action(key string) KeyAction { return k.bindings[key] }
+
+// Handle runs the action bound to key on ax, the axes under the pointer or
+// nil, and reports whether the figure needs a redraw. Unbound keys are
+// ignored.
+func (k *KeyMap) Handle(key string, ax *Axes) (bool, error) {
+	action := k.bindings[key]
+	if action == nil {
+		return false, nil
+	}
+	return action(ax)
+}
+
+// axes returns ax, or all axes of the figure if ax is nil.
+func (k *KeyMap) axes(ax *Axes) []*Axes {
+	if ax != nil {
+		return []*Axes{ax}
+	}
+	return k.Nav.fig.Children
+}
+
+// ToggleGrid hides the grids of the axes if any is visible and shows them
+// otherwise, adding x and y grids to axes without one.
+func (k *KeyMap) ToggleGrid(ax *Axes) (bool, error) {
+	for _, a := range k.axes(ax) {
+		var grids []*Grid
+		visible := false
+		for _, art := range a.Artists {
+			if g, ok := art.(*Grid); ok {
+				grids = append(grids, g)
+				visible = visible || a.Visible(g)
+			}
+		}
+		if len(grids) == 0 {
+			a.AddXGrid()
+			a.AddYGrid()
+			continue
+		}
+		for _, g := range grids {
+			a.SetVisible(g, !visible)
+		}
+	}
+	return true, nil
+}
+
+// ToggleLogX and ToggleLogY switch the x or y scale to base-10 log and
+// back, keeping the limits. Switching back restores the scale type and
+// ticks the axis had before, e.g. a time or symlog axis. Limits that are
+// not all positive keep their scale.
+func (k *KeyMap) ToggleLogX(ax *Axes) (bool, error) {
+	changed := false
+	for _, a := range k.axes(ax) {
+		changed = toggleLog(a, a.XAxis, &a.XScale) || changed
+	}
+	return changed, nil
+}
+
+func (k *KeyMap) ToggleLogY(ax *Axes) (bool, error) {
+	changed := false
+	for _, a := range k.axes(ax) {
+		changed = toggleLog(a, a.YAxis, &a.YScale) || changed
+	}
+	return changed, nil
+}
+
+func toggleLog(a *Axes, axis *Axis, dst *transform.Scale) bool {
+	if a.Polar != nil {
+		return false
+	}
+	min, max := (*dst).Domain()
+	if _, ok := (*dst).(transform.Log); ok {
+		if axis == nil || axis.unlog == nil {
+			a.setScale(axis, dst, transform.NewLinear(min, max))
+			return true
+		}
+		u := axis.unlog
+		axis.unlog = nil
+		s, err := specScale("", u.scale, "", ScaleParams{}, [2]float64{min, max})
+		if err != nil {
+			s = transform.NewLinear(min, max)
+		}
+		*dst = s
+		axis.Locator, axis.Formatter, axis.MinorLocator = u.locator, u.formatter, u.minor
+		return true
+	}
+	if !(min > 0 && max > 0) {
+		return false
+	}
+	if axis != nil {
+		axis.unlog = &unlogged{scale: *dst, locator: axis.Locator, formatter: axis.Formatter, minor: axis.MinorLocator}
+	}
+	a.setScale(axis, dst, transform.NewLog(min, max, 10))
+	return true
+}
+
+// unlogged is the scale and ticks of an axis before toggleLog made it
+// logarithmic.
+type unlogged struct {
+	scale          transform.Scale
+	locator, minor Locator
+	formatter      Formatter
+}
+
+// CycleColormap sets the heatmaps of the axes to the next colormap of
+// Colormaps.
+func (k *KeyMap) CycleColormap(ax *Axes) (bool, error) {
+	if len(k.Colormaps) == 0 {
+		return false, nil
+	}
+	k.cmap = (k.cmap + 1) % len(k.Colormaps)
+	changed := false
+	for _, a := range k.axes(ax) {
+		for _, art := range a.Artists {
+			if h, ok := art.(*Heatmap); ok {
+				h.Cmap = k.Colormaps[k.cmap]
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// Save writes the current view of the whole figure to SavePath with
+// SaveBackend, see Navigator.SaveView. It needs no redraw.
+func (k *KeyMap) Save(*Axes) (bool, error) {
+	return false, k.Nav.SaveView(k.SaveBackend, 0, 0, k.SavePath)
+}
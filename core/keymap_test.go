@@ -0,0 +1,110 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestKeyMap(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(-1, 1)
+	ax.SetYLim(1, 100)
+	h := ax.Heatmap([][]float64{{1, 2}, {3, 4}})
+	ax.SetYLim(1, 100)
+	k := NewKeyMap(NewNavigator(fig))
+
+	// Grid: added, then hidden and shown again.
+	if changed, _ := k.Handle("g", nil); !changed || len(gridsOf(ax)) != 2 {
+		t.Fatalf("g added %d grids, want 2", len(gridsOf(ax)))
+	}
+	k.Handle("g", ax)
+	for _, g := range gridsOf(ax) {
+		if ax.Visible(g) {
+			t.Error("grid still visible after second g")
+		}
+	}
+	k.Handle("g", ax)
+	if g := gridsOf(ax)[0]; !ax.Visible(g) || len(gridsOf(ax)) != 2 {
+		t.Error("grid not shown again by third g")
+	}
+
+	// Log y keeps the limits and switches the locator; log x is refused
+	// for limits below zero.
+	if changed, _ := k.Handle("l", ax); !changed {
+		t.Fatal("l did not change the y scale")
+	}
+	if s, ok := ax.YScale.(transform.Log); !ok || s.Min != 1 || s.Max != 100 {
+		t.Errorf("y scale %#v, want log 1..100", ax.YScale)
+	}
+	if _, ok := ax.YAxis.Locator.(LogLocator); !ok {
+		t.Errorf("y locator %T, want LogLocator", ax.YAxis.Locator)
+	}
+	k.Handle("l", ax)
+	if _, ok := ax.YScale.(transform.Linear); !ok {
+		t.Errorf("y scale %T after second l, want linear", ax.YScale)
+	}
+	if changed, _ := k.Handle("L", ax); changed {
+		t.Error("log x accepted for limits -1..1")
+	}
+
+	// Toggling back restores the scale type and ticks of other scales.
+	ax.SetYTime(time.Unix(10, 0), time.Unix(1000, 0))
+	ax.YAxis.MinorLocator = AutoMinorLocator{}
+	k.Handle("l", ax)
+	k.Handle("l", ax)
+	if ax.YScale != (transform.Time{Min: 10, Max: 1000}) {
+		t.Errorf("y scale %#v after two l, want the time scale", ax.YScale)
+	}
+	if _, ok := ax.YAxis.Locator.(DateLocator); !ok {
+		t.Errorf("y locator %T after two l, want DateLocator", ax.YAxis.Locator)
+	}
+	if _, ok := ax.YAxis.MinorLocator.(AutoMinorLocator); !ok {
+		t.Errorf("y minor locator %T after two l, want AutoMinorLocator", ax.YAxis.MinorLocator)
+	}
+
+	// Colormaps cycle and wrap around.
+	k.Colormaps = []color.Colormap{color.Viridis, color.Gray}
+	k.Handle("m", nil)
+	if h.Cmap[0] != color.Gray[0] {
+		t.Errorf("colormap starts at %v, want gray", h.Cmap[0])
+	}
+	k.Handle("m", nil)
+	if h.Cmap[0] != color.Viridis[0] {
+		t.Errorf("colormap starts at %v, want viridis", h.Cmap[0])
+	}
+
+	// Rebinding, unbinding and unbound keys.
+	k.Bind("g", nil)
+	if changed, err := k.Handle("g", ax); changed || err != nil {
+		t.Error("unbound key handled")
+	}
+	k.Bind("x", k.ToggleGrid)
+	if k.Action("x") == nil {
+		t.Error("x not bound")
+	}
+
+	k.SavePath = filepath.Join(t.TempDir(), "fig.png")
+	if changed, err := k.Handle("ctrl+s", nil); changed || err != nil {
+		t.Fatalf("ctrl+s = %v, %v", changed, err)
+	}
+	if _, err := os.Stat(k.SavePath); err != nil {
+		t.Error(err)
+	}
+}
+
+func gridsOf(ax *Axes) []*Grid {
+	var grids []*Grid
+	for _, art := range ax.Artists {
+		if g, ok := art.(*Grid); ok {
+			grids = append(grids, g)
+		}
+	}
+	return grids
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Gap in pixels between the axes edge and its title and axis labels.
+const labelPad = 6.0
+
+// hasLabels reports whether the axes has a title, axis or panel labels to
+// draw.
+func (a *Axes) hasLabels() bool {
+	return a.Title != "" || a.XLabel != "" || a.YLabel != "" || a.Panel != ""
+}
+
+// LabelPanels sets the panel labels of the figure's axes in order, as in
+// multi-panel journal figures. Without arguments the axes are labeled
+// "a", "b", "c", ...; surplus labels are ignored.
+func (f *Figure) LabelPanels(labels ...string) {
+	for i, ax := range f.Children {
+		switch {
+		case len(labels) == 0:
+			ax.Panel = panelLetter(i)
+		case i < len(labels):
+			ax.Panel = labels[i]
+		}
+	}
+}
+
+// panelLetter returns "a".."z", then "aa", "ab", ...
+func panelLetter(i int) string {
+	s := string(rune('a' + i%26))
+	if i >= 26 {
+		s = panelLetter(i/26-1) + s
+	}
+	return s
+}
+
+// drawLabels draws the title above the axes, the x label below it and the
+// y label to its left. Labels are drawn outside the axes clip and need a
+// renderer that supports text.
+//
+// The y label reads upwards, centered on the axes, on renderers that
+// implement render.RotatedTextDrawer, and horizontally, right-aligned
+// against the axes, on others. The panel label sits above the top-left
+// corner, outside the frame, in bold.
+func (a *Axes) drawLabels(r render.Renderer, ctx *DrawContext) {
+	textRen, ok := r.(render.TextDrawer)
+	if !ok || !a.hasLabels() {
+		return
+	}
+	px := ctx.Clip
+	size := ctx.RC.FontSize
+	if size <= 0 {
+		size = 12
+	}
+	tc := ctx.RC.TextColor
+	col := render.Color{R: tc[0], G: tc[1], B: tc[2], A: tc[3]}
+	centerX := (px.Min.X + px.Max.X) / 2
+
+	if a.Title != "" {
+		ts := size * 1.2
+		m := r.MeasureText(a.Title, ts, ctx.RC.FontKey)
+		origin := geom.Pt{X: centerX - m.W/2, Y: px.Min.Y - labelPad - m.Descent}
+		textRen.DrawText(a.Title, origin, ts, col)
+	}
+	if a.XLabel != "" {
+		m := r.MeasureText(a.XLabel, size, ctx.RC.FontKey)
+		origin := geom.Pt{X: centerX - m.W/2, Y: px.Max.Y + labelPad + m.Ascent}
+		textRen.DrawText(a.XLabel, origin, size, col)
+	}
+	if a.YLabel != "" {
+		m := r.MeasureText(a.YLabel, size, ctx.RC.FontKey)
+		centerY := (px.Min.Y + px.Max.Y) / 2
+		if rotRen, ok := r.(render.RotatedTextDrawer); ok {
+			// Turned by 90°, the ascent lies left of the baseline.
+			x := math.Max(px.Min.X-labelPad-m.Descent, m.Ascent)
+			rotRen.DrawTextRotated(a.YLabel, geom.Pt{X: x, Y: centerY + m.W/2}, size, math.Pi/2, col)
+		} else {
+			x := math.Max(px.Min.X-labelPad-m.W, 0)
+			origin := geom.Pt{X: x, Y: centerY + (m.Ascent-m.Descent)/2}
+			textRen.DrawText(a.YLabel, origin, size, col)
+		}
+	}
+	if a.Panel != "" {
+		ps := size * 1.4
+		m := r.MeasureText(a.Panel, ps, ctx.RC.FontKey)
+		x := math.Max(px.Min.X-labelPad-m.W, 0)
+		origin := geom.Pt{X: x, Y: px.Min.Y - labelPad - m.Descent}
+		// Faux bold: the text backends have no font weights yet.
+		textRen.DrawText(a.Panel, origin, ps, col)
+		textRen.DrawText(a.Panel, geom.Pt{X: origin.X + math.Max(1, ps/16), Y: origin.Y}, ps, col)
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// textRecorder records DrawText and DrawTextRotated calls on top of a
+// gobasic renderer.
+type textRecorder struct {
+	*gobasic.Renderer
+	texts  map[string]geom.Pt
+	angles map[string]float64
+}
+
+func (t *textRecorder) DrawText(text string, origin geom.Pt, size float64, c render.Color) {
+	t.DrawTextRotated(text, origin, size, 0, c)
+}
+
+func (t *textRecorder) DrawTextRotated(text string, origin geom.Pt, _, angle float64, _ render.Color) {
+	t.texts[text] = origin
+	if t.angles != nil {
+		t.angles[text] = angle
+	}
+}
+
+func TestAxesLabelsPlacement(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.25, Y: 0.2}, Max: geom.Pt{X: 0.75, Y: 0.8}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.Title, ax.XLabel, ax.YLabel = "title", "xlabel", "ylabel"
+
+	r := &textRecorder{Renderer: gobasic.New(400, 300, render.Color{A: 1}), texts: map[string]geom.Pt{}, angles: map[string]float64{}}
+	DrawFigure(fig, r)
+
+	px := geom.Rect{Min: geom.Pt{X: 100, Y: 60}, Max: geom.Pt{X: 300, Y: 240}}
+	if p, ok := r.texts["title"]; !ok || p.Y >= px.Min.Y || p.X <= px.Min.X || p.X >= 200 {
+		t.Errorf("title at %+v (drawn %v), want above the axes and centered", p, ok)
+	}
+	if p, ok := r.texts["xlabel"]; !ok || p.Y <= px.Max.Y || p.X <= px.Min.X || p.X >= 200 {
+		t.Errorf("xlabel at %+v (drawn %v), want below the axes and centered", p, ok)
+	}
+	if p, ok := r.texts["ylabel"]; !ok || p.X >= px.Min.X || p.Y <= px.Min.Y || p.Y >= px.Max.Y {
+		t.Errorf("ylabel at %+v (drawn %v), want left of the axes", p, ok)
+	}
+	// Reading upwards, the label's middle is level with the axes' middle.
+	m := r.MeasureText("ylabel", 12, "")
+	if r.angles["ylabel"] != math.Pi/2 || math.Abs(r.texts["ylabel"].Y-m.W/2-150) > 1e-9 {
+		t.Errorf("ylabel at %+v turned by %v, want upwards centered on y=150", r.texts["ylabel"], r.angles["ylabel"])
+	}
+}
+
+func TestLabelPanels(t *testing.T) {
+	fig := NewFigure(400, 300)
+	for i := 0; i < 3; i++ {
+		fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1 + 0.3*float64(i), Y: 0.2}, Max: geom.Pt{X: 0.3 + 0.3*float64(i), Y: 0.8}})
+	}
+
+	fig.LabelPanels()
+	for i, want := range []string{"a", "b", "c"} {
+		if got := fig.Children[i].Panel; got != want {
+			t.Errorf("axes %d: Panel = %q, want %q", i, got, want)
+		}
+	}
+	fig.LabelPanels("A", "B")
+	if got := fig.Children[2].Panel; got != "c" {
+		t.Errorf("unlabeled axes changed to %q", got)
+	}
+	if panelLetter(26) != "aa" || panelLetter(27) != "ab" {
+		t.Errorf("panelLetter(26, 27) = %q, %q", panelLetter(26), panelLetter(27))
+	}
+
+	r := &textRecorder{Renderer: gobasic.New(400, 300, render.Color{A: 1}), texts: map[string]geom.Pt{}}
+	DrawFigure(fig, r)
+	// The first axes spans x 40..120 and y 60..240 in pixels.
+	if p, ok := r.texts["A"]; !ok || p.X >= 40 || p.Y >= 60 {
+		t.Errorf("panel label at %+v (drawn %v), want above-left of the axes", p, ok)
+	}
+}
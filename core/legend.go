@@ -0,0 +1,348 @@
+package core
+
+import (
+	"math"
+	"reflect"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// LegendLoc selects the corner of the axes a legend is placed in.
+type LegendLoc uint8
+
+const (
+	LegendUpperRight LegendLoc = iota
+	LegendUpperLeft
+	LegendLowerLeft
+	LegendLowerRight
+)
+
+// Legend layout in pixels.
+const (
+	legendMargin  = 8.0  // gap between the axes edge and the frame
+	legendPad     = 6.0  // gap between the frame and its contents
+	legendHandleW = 24.0 // width of the sample drawn for each entry
+	legendGap     = 6.0  // gap between sample and label
+)
+
+// Legend lists the labeled artists of an Axes, each with a small sample of
+// its style. Artists with an empty Label are left out.
+type Legend struct {
+	Loc        LegendLoc    // corner of the axes
+	FontSize   float64      // label size in pixels
+	TextColor  render.Color // label color
+	Background render.Color // frame fill (0 alpha means no fill)
+	EdgeColor  render.Color // frame outline (0 alpha means no outline)
+	axes       *Axes
+	z          float64     // z-order (should be above data)
+	rows       []legendRow `spec:"-"` // pixel rows of the last Draw, for hit testing
+}
+
+// legendRow is the clickable area of a legend entry.
+type legendRow struct {
+	rect geom.Rect
+	art  Artist
+}
+
+// legendDimAlpha is the opacity of entries whose artist is hidden.
+const legendDimAlpha = 0.35
+
+// AddLegend adds a legend for the labeled artists of the axes. Its font
+// size and frame follow the RC.
+func (a *Axes) AddLegend() *Legend {
+	rc := a.rc()
+	l := &Legend{
+		Loc:        LegendUpperRight,
+		FontSize:   rc.FontSize,
+		TextColor:  rcColor(rc.TextColor),
+		Background: render.Color{R: 1, G: 1, B: 1, A: rc.LegendAlpha},
+		EdgeColor:  render.Color{R: 0.8, G: 0.8, B: 0.8, A: 1},
+		axes:       a,
+		z:          1000, // above everything else
+	}
+	if l.FontSize <= 0 {
+		l.FontSize = 12
+	}
+	if !rc.LegendFrame {
+		l.Background.A, l.EdgeColor.A = 0, 0
+	}
+	a.Add(l)
+	return l
+}
+
+// LegendHandler draws the legend sample of an artist into box (pixels).
+type LegendHandler func(r render.Renderer, box geom.Rect)
+
+// legendEntry is one row of a legend.
+type legendEntry struct {
+	label  string
+	handle LegendHandler
+	art    Artist
+}
+
+// legendEntryFunc builds the legend row of an artist of a registered type.
+type legendEntryFunc func(art Artist) (legendEntry, bool)
+
+// legendArtist is implemented by the built-in artists that appear in
+// legends: legendHandle returns the label and the handler drawing the
+// sample, a nil handler leaving the artist out.
+type legendArtist interface {
+	legendHandle() (string, LegendHandler)
+}
+
+// RegisterLegendHandler makes artists of type T appear in the legends of
+// fig. fn returns the artist's label and the handler drawing its sample; an
+// empty label or a nil handler leaves the artist out. Registering a type
+// again replaces its handler, and registering a built-in artist type
+// overrides its default sample for this figure only. Since handlers are
+// functions, Fingerprint fails with ErrUnhashable for figures with any.
+//
+//	core.RegisterLegendHandler(fig, func(b *ErrorBand) (string, core.LegendHandler) {
+//		return b.Label, func(r render.Renderer, box geom.Rect) { ... }
+//	})
+func RegisterLegendHandler[T Artist](fig *Figure, fn func(art T) (string, LegendHandler)) {
+	if fig.legendHandlers == nil {
+		fig.legendHandlers = make(map[reflect.Type]legendEntryFunc)
+	}
+	fig.legendHandlers[reflect.TypeFor[T]()] = func(art Artist) (legendEntry, bool) {
+		label, handle := fn(art.(T))
+		return legendEntry{label, handle, art}, handle != nil
+	}
+}
+
+// entries collects the legend rows in drawing order.
+func (l *Legend) entries() []legendEntry {
+	if l.axes == nil {
+		return nil
+	}
+	var out []legendEntry
+	for _, art := range l.axes.Artists {
+		if e, ok := l.axes.legendEntryFor(art); ok && e.label != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// legendEntryFor returns the legend row of art: from a handler registered
+// on the figure, else from the built-in sample of its type.
+func (a *Axes) legendEntryFor(art Artist) (legendEntry, bool) {
+	if fig := a.fig; fig != nil {
+		if fn, ok := fig.legendHandlers[reflect.TypeOf(art)]; ok {
+			return fn(art)
+		}
+	}
+	la, ok := art.(legendArtist)
+	if !ok {
+		return legendEntry{}, false
+	}
+	label, handle := la.legendHandle()
+	return legendEntry{label, handle, art}, handle != nil
+}
+
+func (a *Line2D) legendHandle() (string, LegendHandler) {
+	return a.Label, func(r render.Renderer, box geom.Rect) {
+		y := (box.Min.Y + box.Max.Y) / 2
+		var p geom.Path
+		p.MoveTo(geom.Pt{X: box.Min.X, Y: y})
+		p.LineTo(geom.Pt{X: box.Max.X, Y: y})
+		r.Path(p, &render.Paint{
+			LineWidth: math.Min(a.W, box.H()),
+			LineCap:   render.CapButt,
+			Stroke:    a.Col,
+			Dashes:    a.Dashes,
+			DashCap:   a.DashCap,
+		})
+	}
+}
+
+func (a *Scatter2D) legendHandle() (string, LegendHandler) {
+	return a.Label, func(r render.Renderer, box geom.Rect) {
+		center := geom.Pt{X: (box.Min.X + box.Max.X) / 2, Y: (box.Min.Y + box.Max.Y) / 2}
+		alpha := a.Alpha
+		if alpha <= 0 || alpha > 1 {
+			alpha = 1
+		}
+		paint := render.Paint{Fill: a.Color}
+		paint.Fill.A *= alpha
+		if a.EdgeWidth > 0 && a.EdgeColor.A > 0 {
+			paint.Stroke = a.EdgeColor
+			paint.Stroke.A *= alpha
+			paint.LineWidth = a.EdgeWidth
+			paint.LineJoin = render.JoinRound
+		}
+		r.Path(a.createMarkerPath(center, math.Min(a.Size, box.H()/2)), &paint)
+	}
+}
+
+func (a *Bar2D) legendHandle() (string, LegendHandler) {
+	alpha := a.Alpha
+	if alpha <= 0 || alpha > 1 {
+		alpha = 1
+	}
+	fill, edge := a.Color, a.EdgeColor
+	fill.A *= alpha
+	edge.A *= alpha
+	return a.Label, PatchLegendHandler(fill, edge, a.EdgeWidth)
+}
+
+func (a *Hist) legendHandle() (string, LegendHandler) {
+	alpha := a.Alpha
+	if alpha <= 0 || alpha > 1 {
+		alpha = 1
+	}
+	fill, edge := a.Color, a.EdgeColor
+	fill.A *= alpha
+	edge.A *= alpha
+	return a.Label, PatchLegendHandler(fill, edge, a.EdgeWidth)
+}
+
+func (a *Fill2D) legendHandle() (string, LegendHandler) {
+	fill, edge := a.Color, a.EdgeColor
+	if a.Alpha > 0 && a.Alpha <= 1 {
+		fill.A = a.Alpha
+		edge.A *= a.Alpha
+	}
+	return a.Label, PatchLegendHandler(fill, edge, a.EdgeWidth)
+}
+
+// PatchLegendHandler draws a filled rectangle sample, as used for area
+// artists such as bars and fills.
+func PatchLegendHandler(fill, edge render.Color, edgeWidth float64) LegendHandler {
+	return func(r render.Renderer, box geom.Rect) {
+		paint := render.Paint{Fill: fill}
+		if edgeWidth > 0 && edge.A > 0 {
+			paint.Stroke = edge
+			paint.LineWidth = edgeWidth
+			paint.LineJoin = render.JoinMiter
+		}
+		r.Path(rectPath(box), &paint)
+	}
+}
+
+// rectPath returns a closed rectangle path.
+func rectPath(b geom.Rect) geom.Path {
+	var p geom.Path
+	p.MoveTo(b.Min)
+	p.LineTo(geom.Pt{X: b.Max.X, Y: b.Min.Y})
+	p.LineTo(b.Max)
+	p.LineTo(geom.Pt{X: b.Min.X, Y: b.Max.Y})
+	p.Close()
+	return p
+}
+
+// Draw renders the legend frame and one row per labeled artist.
+func (l *Legend) Draw(r render.Renderer, ctx *DrawContext) {
+	entries := l.entries()
+	l.rows = l.rows[:0]
+	if len(entries) == 0 {
+		return
+	}
+	textRen, _ := r.(render.TextDrawer)
+
+	size := l.FontSize
+	if size <= 0 {
+		size = 12
+	}
+	rowH := size * 1.2
+	var textW, ascent float64
+	for _, e := range entries {
+		m := r.MeasureText(e.label, size, ctx.RC.FontKey)
+		textW = math.Max(textW, m.W)
+		ascent = math.Max(ascent, m.Ascent)
+	}
+
+	w := 2*legendPad + legendHandleW + legendGap + textW
+	h := 2*legendPad + rowH*float64(len(entries))
+	frame := l.frame(ctx.Clip, w, h)
+
+	paint := render.Paint{Fill: l.Background}
+	if l.EdgeColor.A > 0 {
+		paint.Stroke = l.EdgeColor
+		paint.LineWidth = 1
+		paint.LineJoin = render.JoinMiter
+	}
+	if paint.Fill.A > 0 || paint.Stroke.A > 0 {
+		r.Path(rectPath(frame), &paint)
+	}
+
+	for i, e := range entries {
+		top := frame.Min.Y + legendPad + rowH*float64(i)
+		x := frame.Min.X + legendPad
+		box := geom.Rect{
+			Min: geom.Pt{X: x, Y: top + rowH*0.25},
+			Max: geom.Pt{X: x + legendHandleW, Y: top + rowH*0.75},
+		}
+		e.handle(r, box)
+		textColor := l.TextColor
+		if !l.axes.Visible(e.art) {
+			// Wash out the sample and label of hidden artists.
+			veil := l.Background
+			if veil.A == 0 {
+				veil = render.Color{R: 1, G: 1, B: 1}
+			}
+			veil.A = 1 - legendDimAlpha
+			r.Path(rectPath(box), &render.Paint{Fill: veil})
+			textColor.A *= legendDimAlpha
+		}
+		if textRen != nil {
+			// Center the text's ascent on the row.
+			baseline := top + (rowH+ascent)/2
+			textRen.DrawText(e.label, geom.Pt{X: box.Max.X + legendGap, Y: baseline}, size, textColor)
+		}
+		l.rows = append(l.rows, legendRow{
+			rect: geom.Rect{Min: geom.Pt{X: frame.Min.X, Y: top}, Max: geom.Pt{X: frame.Max.X, Y: top + rowH}},
+			art:  e.art,
+		})
+	}
+}
+
+// EntryAt returns the artist whose legend entry contains the pixel p, as
+// laid out by the last Draw.
+func (l *Legend) EntryAt(p geom.Pt) (Artist, bool) {
+	for _, row := range l.rows {
+		if p.X >= row.rect.Min.X && p.X < row.rect.Max.X && p.Y >= row.rect.Min.Y && p.Y < row.rect.Max.Y {
+			return row.art, true
+		}
+	}
+	return nil, false
+}
+
+// Toggle handles a click at pixel p: if it hits an entry, the entry's artist
+// is shown or hidden and returned. Interactive frontends call it from their
+// click handler and redraw, e.g. with Redrawer.Invalidate(art, legend).
+func (l *Legend) Toggle(p geom.Pt) (Artist, bool) {
+	art, ok := l.EntryAt(p)
+	if !ok {
+		return nil, false
+	}
+	l.axes.SetVisible(art, !l.axes.Visible(art))
+	return art, true
+}
+
+// frame places a w×h frame in the configured corner of the axes.
+func (l *Legend) frame(clip geom.Rect, w, h float64) geom.Rect {
+	var min geom.Pt
+	switch l.Loc {
+	case LegendUpperLeft:
+		min = geom.Pt{X: clip.Min.X + legendMargin, Y: clip.Min.Y + legendMargin}
+	case LegendLowerLeft:
+		min = geom.Pt{X: clip.Min.X + legendMargin, Y: clip.Max.Y - legendMargin - h}
+	case LegendLowerRight:
+		min = geom.Pt{X: clip.Max.X - legendMargin - w, Y: clip.Max.Y - legendMargin - h}
+	default: // LegendUpperRight
+		min = geom.Pt{X: clip.Max.X - legendMargin - w, Y: clip.Min.Y + legendMargin}
+	}
+	return geom.Rect{Min: min, Max: geom.Pt{X: min.X + w, Y: min.Y + h}}
+}
+
+// Z returns the z-order for sorting.
+func (l *Legend) Z() float64 {
+	return l.z
+}
+
+// Bounds returns an empty rect for now.
+func (l *Legend) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
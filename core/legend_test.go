@@ -0,0 +1,185 @@
+package core
+
+import (
+	"errors"
+	stdcolor "image/color"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestLegendEntries(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.Plot([]float64{0, 1}, []float64{0, 1}, PlotOptions{Label: "line"})
+	ax.Plot([]float64{0, 1}, []float64{1, 0}) // unlabeled
+	ax.Scatter([]float64{0.5}, []float64{0.5}, ScatterOptions{Label: "points"})
+	ax.Bar([]float64{0.5}, []float64{0.5}, BarOptions{Label: "bars"})
+	ax.FillBetweenPlot([]float64{0, 1}, []float64{0, 1}, []float64{0, 0}, FillOptions{Label: "area"})
+	ax.Add(ArtistFunc(func(render.Renderer, *DrawContext) {}))
+	l := ax.AddLegend()
+
+	var labels []string
+	for _, e := range l.entries() {
+		labels = append(labels, e.label)
+	}
+	want := []string{"line", "points", "bars", "area"}
+	if len(labels) != len(want) {
+		t.Fatalf("labels = %q, want %q", labels, want)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Errorf("labels = %q, want %q", labels, want)
+			break
+		}
+	}
+}
+
+func TestLegendFrame(t *testing.T) {
+	clip := geom.Rect{Min: geom.Pt{X: 10, Y: 20}, Max: geom.Pt{X: 210, Y: 120}}
+	tests := []struct {
+		loc LegendLoc
+		min geom.Pt
+	}{
+		{LegendUpperRight, geom.Pt{X: 210 - legendMargin - 50, Y: 20 + legendMargin}},
+		{LegendUpperLeft, geom.Pt{X: 10 + legendMargin, Y: 20 + legendMargin}},
+		{LegendLowerLeft, geom.Pt{X: 10 + legendMargin, Y: 120 - legendMargin - 30}},
+		{LegendLowerRight, geom.Pt{X: 210 - legendMargin - 50, Y: 120 - legendMargin - 30}},
+	}
+	for _, tc := range tests {
+		f := (&Legend{Loc: tc.loc}).frame(clip, 50, 30)
+		if f.Min != tc.min || f.W() != 50 || f.H() != 30 {
+			t.Errorf("loc %d: frame %+v, want min %+v", tc.loc, f, tc.min)
+		}
+	}
+}
+
+func TestLegendDraw(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0, Y: 0}, Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	red := render.Color{R: 1, G: 0, B: 0, A: 1}
+	ax.Plot([]float64{0, 0.1}, []float64{0, 0.1}, PlotOptions{Color: &red, Label: "red"})
+	ax.AddLegend()
+
+	// The red sample line sits in the upper-right frame, away from the data.
+	if !legendSampleDrawn(fig, func(c stdcolor.RGBA) bool { return c.R > 200 && c.G < 50 && c.B < 50 }) {
+		t.Error("legend sample not found in the upper-right corner")
+	}
+}
+
+// legendSampleDrawn draws the 400×300 fig and reports whether a pixel in
+// the upper-right corner, where the legend frame sits, matches.
+func legendSampleDrawn(fig *Figure, match func(stdcolor.RGBA) bool) bool {
+	r := gobasic.New(400, 300, render.Color{R: 1, G: 1, B: 1, A: 1})
+	DrawFigure(fig, r)
+	img := r.GetImage()
+	for y := 0; y < 60; y++ {
+		for x := 300; x < 400; x++ {
+			if match(img.RGBAAt(x, y)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// errorBand is a user-defined artist for TestRegisterLegendHandler.
+type errorBand struct {
+	label string
+	col   render.Color
+}
+
+func (b *errorBand) Draw(render.Renderer, *DrawContext) {}
+func (b *errorBand) Z() float64                         { return 0 }
+func (b *errorBand) Bounds(*DrawContext) geom.Rect      { return geom.Rect{} }
+
+func TestRegisterLegendHandler(t *testing.T) {
+	fig := NewFigure(400, 300)
+	RegisterLegendHandler(fig, func(b *errorBand) (string, LegendHandler) {
+		return b.label, PatchLegendHandler(b.col, render.Color{}, 0)
+	})
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0, Y: 0}, Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	green := render.Color{R: 0, G: 1, B: 0, A: 1}
+	ax.Add(&errorBand{label: "band", col: green})
+	ax.Add(&errorBand{}) // unlabeled
+	l := ax.AddLegend()
+
+	entries := l.entries()
+	if len(entries) != 1 || entries[0].label != "band" {
+		t.Fatalf("entries = %+v, want one entry \"band\"", entries)
+	}
+	if !legendSampleDrawn(fig, func(c stdcolor.RGBA) bool { return c.G > 200 && c.R < 50 && c.B < 50 }) {
+		t.Error("custom legend sample not drawn")
+	}
+	if _, err := Fingerprint(fig); !errors.Is(err, ErrUnhashable) {
+		t.Errorf("Fingerprint with a legend handler: %v, want ErrUnhashable", err)
+	}
+
+	// The handler belongs to fig; legends of other figures leave the type out.
+	other := NewFigure(400, 300)
+	oax := other.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	oax.Add(&errorBand{label: "band"})
+	if n := len(oax.AddLegend().entries()); n != 0 {
+		t.Errorf("other figure has %d entries, want 0", n)
+	}
+}
+
+func TestLegendToggle(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0, Y: 0}, Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	red := render.Color{R: 1, G: 0, B: 0, A: 1}
+	w := 6.0
+	line := ax.Plot([]float64{0, 1}, []float64{0.2, 0.2}, PlotOptions{Color: &red, LineWidth: &w, Label: "red"})
+	l := ax.AddLegend()
+
+	r := gobasic.New(400, 300, render.Color{R: 1, G: 1, B: 1, A: 1})
+	d := NewRedrawer(fig, r)
+	d.Render()
+	if c := r.GetImage().RGBAAt(100, 240); c.R < 200 || c.G > 50 {
+		t.Fatalf("line not drawn before toggle: %+v", c)
+	}
+
+	if _, ok := l.Toggle(geom.Pt{X: 5, Y: 5}); ok {
+		t.Fatal("click outside the legend toggled an entry")
+	}
+	row := l.rows[0].rect
+	center := geom.Pt{X: (row.Min.X + row.Max.X) / 2, Y: (row.Min.Y + row.Max.Y) / 2}
+	art, ok := l.Toggle(center)
+	if !ok || art != line {
+		t.Fatalf("Toggle = %v, %v; want the line", art, ok)
+	}
+	if ax.Visible(line) {
+		t.Fatal("line still visible after toggle")
+	}
+
+	d.Invalidate(art, l)
+	d.Render()
+	img := r.GetImage()
+	// The line at y=0.2 is gone from the plot.
+	if c := img.RGBAAt(100, 240); c.R != 255 || c.G != 255 {
+		t.Errorf("hidden line still drawn: %+v", c)
+	}
+	// The legend sample is dimmed but still present.
+	var dimmed bool
+	for x := int(row.Min.X); x < int(row.Max.X); x++ {
+		if c := img.RGBAAt(x, int(center.Y)); c.R > 200 && c.G > 100 && c.G < 220 {
+			dimmed = true
+			break
+		}
+		if c := img.RGBAAt(x, int(center.Y)); c.R > 200 && c.G < 50 {
+			t.Fatalf("legend sample at x=%d not dimmed: %+v", x, c)
+		}
+	}
+	if !dimmed {
+		t.Error("dimmed legend sample not found")
+	}
+
+	if _, ok := l.Toggle(center); !ok || !ax.Visible(line) {
+		t.Error("second click did not show the line again")
+	}
+}
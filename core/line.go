@@ -1,35 +1,39 @@
 package core
 
 import (
+	"math"
+
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
 )
 
 // Line2D is a minimal polyline artist (stroke only).
 type Line2D struct {
-	XY     []geom.Pt    // data space points
-	W      float64      // stroke width (px for now)
-	Col    render.Color // stroke color
-	Dashes []float64    // dash pattern (on/off pairs)
-	Label  string       // series label for legend
-	z      float64      // z-order
+	XY         []geom.Pt         // data space points
+	Data       Points            // points read instead of XY if set, e.g. memory-mapped, see Axes.PlotData
+	Stream     func() DataSource // opens a source streamed instead of XY and Data if set, see Axes.PlotStream
+	W          float64           // stroke width (px for now)
+	Col        render.Color      // stroke color
+	Dashes     []float64         // dash pattern (on/off pairs)
+	DashCap    render.DashCap    // cap of dash ends, e.g. DashCapRound for dots; the line ends stay round
+	Label      string            // series label for legend
+	Labels     []string          // per-point text labels, "" for none
+	DataLabels *DataLabels       // style of the point labels; without Labels it labels every y value
+	Rasterize  bool              // draw as an embedded bitmap in vector output
+	Aliased    bool              // draw without anti-aliasing, with hard pixel edges
+	z          float64           // z-order
 }
 
 // Draw renders the line by transforming points to pixel space and drawing a path.
 func (l *Line2D) Draw(r render.Renderer, ctx *DrawContext) {
-	if len(l.XY) == 0 {
-		return // nothing to draw
+	var p geom.Path
+	if l.Stream != nil {
+		p = streamPath(l.Stream(), ctx)
+	} else {
+		p = linePath(l.points(), ctx)
 	}
-
-	p := geom.Path{}
-	for i, v := range l.XY {
-		q := (&ctx.DataToPixel).Apply(v)
-		if i == 0 {
-			p.C = append(p.C, geom.MoveTo)
-		} else {
-			p.C = append(p.C, geom.LineTo)
-		}
-		p.V = append(p.V, q)
+	if len(p.V) == 0 {
+		return
 	}
 
 	paint := render.Paint{
@@ -39,8 +43,37 @@ func (l *Line2D) Draw(r render.Renderer, ctx *DrawContext) {
 		MiterLimit: 10.0,             // Standard miter limit
 		Stroke:     l.Col,
 		Dashes:     l.Dashes, // Use dash pattern if provided
+		DashCap:    l.DashCap,
+		Aliased:    l.Aliased,
 	}
 	r.Path(p, &paint)
+	drawDataLabels(r, ctx, l.points(), l.Labels, l.DataLabels, l.W/2)
+}
+
+// linePath returns the line through the pixel positions of pts. Points
+// with NaN or infinite coordinates break the line into pieces.
+func linePath(pts Points, ctx *DrawContext) geom.Path {
+	p := geom.Path{}
+	gap := true
+	for i := range pts.Len() {
+		q := (&ctx.DataToPixel).Apply(pts.At(i))
+		if !finitePt(q) {
+			gap = true
+			continue
+		}
+		if gap {
+			p.C = append(p.C, geom.MoveTo)
+		} else {
+			p.C = append(p.C, geom.LineTo)
+		}
+		p.V = append(p.V, q)
+		gap = false
+	}
+	return p
+}
+
+func finitePt(p geom.Pt) bool {
+	return !math.IsNaN(p.X) && !math.IsNaN(p.Y) && !math.IsInf(p.X, 0) && !math.IsInf(p.Y, 0)
 }
 
 // Z returns the z-order for sorting.
@@ -48,6 +81,9 @@ func (l *Line2D) Z() float64 {
 	return l.z
 }
 
+// Rasterized reports whether the Line2D should be embedded as a bitmap in vector output.
+func (l *Line2D) Rasterized() bool { return l.Rasterize }
+
 // Bounds returns an empty rect for now (will be enhanced in later phases).
 func (l *Line2D) Bounds(*DrawContext) geom.Rect {
 	return geom.Rect{}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"math"
+	"slices"
 	"testing"
 
 	"matplotlib-go/internal/geom"
@@ -117,3 +119,19 @@ func TestLine2D_AsArtist(t *testing.T) {
 	var r render.NullRenderer
 	DrawFigure(fig, &r)
 }
+
+func TestLine2D_NaNGaps(t *testing.T) {
+	line := &Line2D{
+		XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: math.NaN()}, {X: 2, Y: 1}, {X: 3, Y: 2}, {X: math.Inf(1), Y: 0}},
+		W:  1,
+	}
+	var r segmentRecorder
+	line.Draw(&r, createTestDrawContext())
+	if len(r.paths) != 1 {
+		t.Fatalf("got %d paths, want 1", len(r.paths))
+	}
+	want := []geom.Cmd{geom.MoveTo, geom.MoveTo, geom.LineTo}
+	if got := r.paths[0].C; !slices.Equal(got, want) {
+		t.Errorf("commands %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"math"
+	"sort"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// LineEndLabels labels every labeled, visible Line2D of an Axes at its
+// right end in the line's color, as a legend replacement popular for
+// multi-line time series. Labels that would overlap are spread vertically,
+// keeping their order. It is a figure-level artist, so labels of lines
+// ending at the right edge extend into the figure margin unclipped.
+type LineEndLabels struct {
+	Axes     *Axes   // axes whose lines are labeled
+	FontSize float64 // label size in pixels
+	Gap      float64 // horizontal gap between line end and label in pixels
+	Spacing  float64 // minimum vertical gap between labels in pixels
+	fig      *Figure
+	z        float64 // z-order among figure-level artists
+}
+
+// AddLineEndLabels labels the lines of ax at their right ends.
+func (f *Figure) AddLineEndLabels(ax *Axes) *LineEndLabels {
+	l := &LineEndLabels{
+		Axes:     ax,
+		FontSize: 12,
+		Gap:      4,
+		Spacing:  2,
+		fig:      f,
+	}
+	f.Add(l)
+	return l
+}
+
+// endLabel is a label being placed, with its vertical extent in pixels.
+type endLabel struct {
+	text    string
+	color   render.Color
+	x, y    float64 // left end and current center
+	h, asc  float64 // height and ascent
+	desired float64 // center at the line end
+}
+
+// Draw renders the labels.
+func (l *LineEndLabels) Draw(r render.Renderer, ctx *DrawContext) {
+	td, ok := r.(render.TextDrawer)
+	if !ok || l.Axes == nil || l.fig == nil {
+		return
+	}
+	labels := l.place(r)
+	for _, e := range labels {
+		td.DrawText(e.text, geom.Pt{X: e.x, Y: e.y - e.h/2 + e.asc}, l.FontSize, e.color)
+	}
+}
+
+// place returns the labels at their end points, spread apart vertically.
+func (l *LineEndLabels) place(r render.Renderer) []endLabel {
+	ctx := l.Axes.drawContext(l.fig)
+	var labels []endLabel
+	for _, art := range l.Axes.Artists {
+		line, ok := art.(*Line2D)
+		if !ok || line.Label == "" || !l.Axes.Visible(line) {
+			continue
+		}
+		end, ok := lastFinite(line.points())
+		if !ok {
+			continue
+		}
+		p := l.Axes.artistContext(ctx, line).DataToPixel.Apply(end)
+		m := r.MeasureText(line.Label, l.FontSize, ctx.RC.FontKey)
+		h := m.Ascent + m.Descent
+		labels = append(labels, endLabel{
+			text: line.Label, color: line.Col,
+			x: p.X + l.Gap, y: p.Y, h: h, asc: m.Ascent, desired: p.Y,
+		})
+	}
+	spreadLabels(labels, l.Spacing, ctx.Clip.Min.Y, ctx.Clip.Max.Y)
+	return labels
+}
+
+// spreadLabels moves the labels apart so that they keep spacing between
+// them, staying between top and bottom where they fit. Each run of
+// touching labels is centered on the mean of its desired positions.
+func spreadLabels(labels []endLabel, spacing, top, bottom float64) {
+	if len(labels) == 0 {
+		return
+	}
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].desired < labels[j].desired })
+
+	// Merge overlapping runs into blocks, recentering each on its labels.
+	type block struct{ start, end int } // labels[start:end]
+	var blocks []block
+	height := func(b block) float64 {
+		h := 0.0
+		for _, e := range labels[b.start:b.end] {
+			h += e.h + spacing
+		}
+		return h - spacing
+	}
+	center := func(b block) float64 {
+		c := 0.0
+		for _, e := range labels[b.start:b.end] {
+			c += e.desired
+		}
+		return c / float64(b.end-b.start)
+	}
+	for i := range labels {
+		blocks = append(blocks, block{i, i + 1})
+		for len(blocks) > 1 {
+			a, b := blocks[len(blocks)-2], blocks[len(blocks)-1]
+			if center(a)+height(a)/2+spacing <= center(b)-height(b)/2 {
+				break
+			}
+			blocks = append(blocks[:len(blocks)-2], block{a.start, b.end})
+		}
+	}
+
+	for _, b := range blocks {
+		y := center(b) - height(b)/2
+		for i := b.start; i < b.end; i++ {
+			labels[i].y = y + labels[i].h/2
+			y += labels[i].h + spacing
+		}
+	}
+
+	// Keep the labels inside [top, bottom], pushing neighbours along; the
+	// top wins if they do not fit.
+	n := len(labels)
+	labels[n-1].y = math.Min(labels[n-1].y, bottom-labels[n-1].h/2)
+	for i := n - 2; i >= 0; i-- {
+		limit := labels[i+1].y - labels[i+1].h/2 - spacing - labels[i].h/2
+		labels[i].y = math.Min(labels[i].y, limit)
+	}
+	labels[0].y = math.Max(labels[0].y, top+labels[0].h/2)
+	for i := 1; i < n; i++ {
+		limit := labels[i-1].y + labels[i-1].h/2 + spacing + labels[i].h/2
+		labels[i].y = math.Max(labels[i].y, limit)
+	}
+}
+
+// lastFinite returns the last point of xy with finite coordinates.
+func lastFinite(xy Points) (geom.Pt, bool) {
+	for i := xy.Len() - 1; i >= 0; i-- {
+		if p := xy.At(i); finitePt(p) {
+			return p, true
+		}
+	}
+	return geom.Pt{}, false
+}
+
+// Z returns the z-order for sorting.
+func (l *LineEndLabels) Z() float64 {
+	return l.z
+}
+
+// Bounds returns an empty rect for now.
+func (l *LineEndLabels) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestLineEndLabels(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.7, Y: 0.9}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.SetXLim(0, 10)
+	ax.SetYLim(0, 10)
+	x := []float64{0, 10}
+	ax.Plot(x, []float64{0, 5}, WithLabel("north"))
+	ax.Plot(x, []float64{0, 5.1}, WithLabel("south"))
+	ax.Plot(x, []float64{0, 9}, WithLabel("east"))
+	ax.Plot(x, []float64{0, 1}) // unlabeled
+	fig.AddLineEndLabels(ax)
+
+	r := &textRecorder{Renderer: gobasic.New(400, 300, render.Color{A: 1}), texts: map[string]geom.Pt{}}
+	DrawFigure(fig, r)
+	if len(r.texts) != 3 {
+		t.Fatalf("drew %v, want the three labeled lines", r.texts)
+	}
+	north, south, east := r.texts["north"], r.texts["south"], r.texts["east"]
+	if north.X <= 280 {
+		t.Errorf("label x = %v, want right of the line end at 280", north.X)
+	}
+	h := r.MeasureText("north", 12, "").H
+	if math.Abs(north.Y-south.Y) < h {
+		t.Errorf("labels at y=%v and %v overlap (height %v)", north.Y, south.Y, h)
+	}
+	if south.Y >= north.Y {
+		t.Errorf("south (%v) should stay above north (%v), as its line ends higher", south.Y, north.Y)
+	}
+	if d := math.Abs(east.Y - (30 + 240*0.1)); d > h {
+		t.Errorf("east at y=%v, want next to its line end at %v", east.Y, 30+240*0.1)
+	}
+}
+
+func TestSpreadLabels_Bounds(t *testing.T) {
+	labels := []endLabel{{h: 10, desired: 95}, {h: 10, desired: 96}, {h: 10, desired: 97}}
+	spreadLabels(labels, 2, 0, 100)
+	if bottom := labels[2].y + 5; bottom > 100 {
+		t.Errorf("last label ends at %v, want inside 100", bottom)
+	}
+	for i := 1; i < len(labels); i++ {
+		if gap := labels[i].y - labels[i-1].y; gap < 12-1e-9 {
+			t.Errorf("labels %d and %d are %v apart, want 12", i-1, i, gap)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+// lineStyles are matplotlib's named line styles with their dash patterns
+// in multiples of the line width; solid lines have none.
+var lineStyles = map[string][]float64{
+	"-":       nil,
+	"solid":   nil,
+	"--":      {3.7, 1.6},
+	"dashed":  {3.7, 1.6},
+	"-.":      {6.4, 1.6, 1, 1.6},
+	"dashdot": {6.4, 1.6, 1, 1.6},
+	":":       {1, 1.65},
+	"dotted":  {1, 1.65},
+}
+
+// LineStyleDashes returns the dash pattern in pixels of a named line style
+// for lines of the given width: "-" or "solid", "--" or "dashed", "-." or
+// "dashdot", ":" or "dotted". The pattern scales with the width, as in
+// matplotlib, so that thick dashed lines keep their look. Solid lines
+// return nil; ok is false for unknown names.
+func LineStyleDashes(style string, width float64) (dashes []float64, ok bool) {
+	pattern, ok := lineStyles[style]
+	if !ok {
+		return nil, false
+	}
+	for _, v := range pattern {
+		dashes = append(dashes, v*width)
+	}
+	return dashes, true
+}
+
+// WithLineStyle sets the Plot dash pattern by name, see LineStyleDashes.
+// It replaces an earlier WithDashes.
+func WithLineStyle(style string) Option {
+	return func(v *optionValues) { v.lineStyle = &style }
+}
+
+// dashes returns the dash pattern of lines of the given width: Dashes if
+// set, else the pattern of LineStyle, where unknown names are solid.
+func (o PlotOptions) dashes(width float64) []float64 {
+	if o.Dashes != nil || o.LineStyle == "" {
+		return o.Dashes
+	}
+	d, _ := LineStyleDashes(o.LineStyle, width)
+	return d
+}
+
+// checkLineStyle requires style to be empty or a known line style name.
+func checkLineStyle(op, style string) error {
+	if _, ok := lineStyles[style]; style != "" && !ok {
+		return invalid(op, "unknown line style %q", style)
+	}
+	return nil
+}
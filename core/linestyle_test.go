@@ -0,0 +1,52 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestLineStyleDashes(t *testing.T) {
+	if d, ok := LineStyleDashes("--", 2); !ok || !slices.Equal(d, []float64{7.4, 3.2}) {
+		t.Errorf("dashed at width 2 = %v, want [7.4 3.2]", d)
+	}
+	if d, ok := LineStyleDashes("solid", 2); !ok || d != nil {
+		t.Errorf("solid = %v, %v, want nil, true", d, ok)
+	}
+	if _, ok := LineStyleDashes("wavy", 1); ok {
+		t.Error("unknown style accepted")
+	}
+}
+
+func TestPlot_LineStyle(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	x := []float64{0, 1}
+
+	line := ax.Plot(x, x, WithLineWidth(2), WithLineStyle(":"))
+	if !slices.Equal(line.Dashes, []float64{2, 3.3}) {
+		t.Errorf("dotted dashes = %v, want [2 3.3]", line.Dashes)
+	}
+	line = ax.Plot(x, x, PlotOptions{LineStyle: "dashdot"})
+	if len(line.Dashes) != 4 || line.Dashes[0] != 6.4*line.W {
+		t.Errorf("dashdot dashes = %v at width %v", line.Dashes, line.W)
+	}
+	// Later options win.
+	if line = ax.Plot(x, x, WithLineStyle("--"), WithDashes(1, 1)); !slices.Equal(line.Dashes, []float64{1, 1}) {
+		t.Errorf("dashes after a style = %v, want [1 1]", line.Dashes)
+	}
+	if line = ax.Plot(x, x, WithDashes(1, 1), WithLineStyle("-")); line.Dashes != nil {
+		t.Errorf("solid style after dashes = %v, want nil", line.Dashes)
+	}
+
+	if line = ax.Plot(x, x, WithLineStyle(":"), WithDashCap(render.DashCapRound)); line.DashCap != render.DashCapRound {
+		t.Errorf("dash cap = %v, want round", line.DashCap)
+	}
+
+	fig.Strict = true
+	if ax.Plot(x, x, WithLineStyle("wavy")) != nil || fig.Err() == nil {
+		t.Error("strict Plot accepted an unknown line style")
+	}
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// mplFigure is the subset of a matplotlib figure written by
+// tools/mpl_export.py; docs/matplotlib-import.md describes the format.
+// Sizes are in matplotlib units: inches, points and points².
+type mplFigure struct {
+	FigSize [2]float64 `json:"figsize"`
+	DPI     float64    `json:"dpi"`
+	Axes    []mplAxes  `json:"axes"`
+}
+
+type mplAxes struct {
+	Position [4]float64   `json:"position"` // left, bottom, width, height in figure fractions
+	Title    string       `json:"title"`
+	XLabel   string       `json:"xlabel"`
+	YLabel   string       `json:"ylabel"`
+	XLim     [2]float64   `json:"xlim"`
+	YLim     [2]float64   `json:"ylim"`
+	XScale   string       `json:"xscale"`
+	YScale   string       `json:"yscale"`
+	XParams  ScaleParams  `json:"xscaleparams"` // base and linthresh of log and symlog scales
+	YParams  ScaleParams  `json:"yscaleparams"`
+	Grid     bool         `json:"grid"`
+	Legend   bool         `json:"legend"`
+	Lines    []mplLine    `json:"lines"`
+	Scatters []mplScatter `json:"scatters"`
+	Bars     []mplBars    `json:"bars"`
+	Fills    []mplFill    `json:"fills"`
+}
+
+type mplLine struct {
+	X          Values    `json:"x"`
+	Y          Values    `json:"y"`
+	Color      []float64 `json:"color"`
+	LineWidth  float64   `json:"linewidth"`
+	LineStyle  string    `json:"linestyle"`
+	Marker     string    `json:"marker"`
+	MarkerSize float64   `json:"markersize"`
+	Label      string    `json:"label"`
+}
+
+type mplScatter struct {
+	Offsets [][2]float64 `json:"offsets"`
+	Color   []float64    `json:"color"`
+	Sizes   []float64    `json:"sizes"`
+	Marker  string       `json:"marker"`
+	Label   string       `json:"label"`
+}
+
+type mplBars struct {
+	X           []float64 `json:"x"` // bar centers
+	Height      []float64 `json:"height"`
+	Width       []float64 `json:"width"`
+	Bottom      []float64 `json:"bottom"`
+	Orientation string    `json:"orientation"` // "vertical" or "horizontal"
+	Color       []float64 `json:"color"`
+	Label       string    `json:"label"`
+}
+
+type mplFill struct {
+	X     Values    `json:"x"`
+	Y1    Values    `json:"y1"`
+	Y2    Values    `json:"y2"`
+	Color []float64 `json:"color"`
+	Label string    `json:"label"`
+}
+
+// mplMarkers maps matplotlib marker codes to marker names of SeriesData.
+var mplMarkers = map[string]string{
+	"o": "circle", ".": "circle", "s": "square", "^": "triangle",
+	"D": "diamond", "d": "diamond", "+": "plus", "x": "cross",
+}
+
+// ImportMatplotlib reads a figure exported from matplotlib with
+// tools/mpl_export.py and recreates it, e.g. to migrate existing plot
+// definitions. Only the subset in docs/matplotlib-import.md is supported:
+// lines, scatters, bars and fill_between areas with their colors, labels
+// and axes settings. Unsupported values are errors, not silently dropped.
+func ImportMatplotlib(r io.Reader) (*Figure, error) {
+	var m mplFigure
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("matplotlib import: %w", err)
+	}
+	spec, err := m.spec()
+	if err != nil {
+		return nil, fmt.Errorf("matplotlib import: %w", err)
+	}
+	return BuildFigure(spec)
+}
+
+// spec converts m to a FigureSpec, scaling points to pixels.
+func (m mplFigure) spec() (FigureSpec, error) {
+	dpi := m.DPI
+	if dpi <= 0 {
+		dpi = 100
+	}
+	pt := dpi / 72 // pixels per point
+	s := FigureSpec{Width: int(math.Round(m.FigSize[0] * dpi)), Height: int(math.Round(m.FigSize[1] * dpi))}
+	for i, a := range m.Axes {
+		l, b, w, h := a.Position[0], a.Position[1], a.Position[2], a.Position[3]
+		d := AxesData{
+			Rect:   [4]float64{l, 1 - b - h, l + w, 1 - b}, // figure fractions from the top
+			Title:  a.Title,
+			XLabel: a.XLabel,
+			YLabel: a.YLabel,
+			XLim:   a.XLim,
+			YLim:   a.YLim,
+			XScale: a.XScale,
+			YScale: a.YScale,
+			Grid:   a.Grid,
+			Legend: a.Legend,
+
+			XScaleParams: a.XParams,
+			YScaleParams: a.YParams,
+		}
+		for _, ln := range a.Lines {
+			sd, err := ln.series(pt)
+			if err != nil {
+				return s, fmt.Errorf("axes %d: %w", i, err)
+			}
+			d.Series = append(d.Series, sd)
+		}
+		for _, sc := range a.Scatters {
+			sd, err := sc.series(pt)
+			if err != nil {
+				return s, fmt.Errorf("axes %d: %w", i, err)
+			}
+			d.Series = append(d.Series, sd)
+		}
+		for _, br := range a.Bars {
+			sd, err := br.series()
+			if err != nil {
+				return s, fmt.Errorf("axes %d: %w", i, err)
+			}
+			d.Series = append(d.Series, sd)
+		}
+		for _, f := range a.Fills {
+			d.Series = append(d.Series, SeriesData{Kind: "fill", Label: f.Label, X: f.X, Y: f.Y1, Y2: f.Y2, Color: f.Color})
+		}
+		s.Axes = append(s.Axes, d)
+	}
+	return s, nil
+}
+
+// series converts a line; lines drawn only with markers become scatters.
+func (l mplLine) series(pt float64) (SeriesData, error) {
+	label := mplLabel(l.Label)
+	if l.LineStyle == "None" || l.LineStyle == "" || l.LineStyle == " " {
+		marker, ok := mplMarkers[l.Marker]
+		if !ok {
+			return SeriesData{}, fmt.Errorf("line %q: unsupported marker %q", l.Label, l.Marker)
+		}
+		size := l.MarkerSize
+		if size <= 0 {
+			size = 6
+		}
+		return SeriesData{Kind: "scatter", Label: label, X: l.X, Y: l.Y, Color: l.Color, Width: size / 2 * pt, Marker: marker}, nil
+	}
+	sd := SeriesData{Kind: "line", Label: label, X: l.X, Y: l.Y, Color: l.Color, Width: l.LineWidth * pt}
+	dashes, ok := LineStyleDashes(l.LineStyle, sd.Width)
+	if !ok {
+		return SeriesData{}, fmt.Errorf("line %q: unsupported linestyle %q", l.Label, l.LineStyle)
+	}
+	sd.Dashes = dashes
+	return sd, nil
+}
+
+// series converts a scatter; its marker area s in points² gives the radius
+// sqrt(s)/2 points. Only one size is supported.
+func (c mplScatter) series(pt float64) (SeriesData, error) {
+	marker, ok := mplMarkers[c.Marker]
+	if c.Marker == "" {
+		marker, ok = "circle", true
+	}
+	if !ok {
+		return SeriesData{}, fmt.Errorf("scatter %q: unsupported marker %q", c.Label, c.Marker)
+	}
+	size, ok := uniform(c.Sizes, 36)
+	if !ok {
+		return SeriesData{}, fmt.Errorf("scatter %q: varying marker sizes are not supported", c.Label)
+	}
+	sd := SeriesData{Kind: "scatter", Label: mplLabel(c.Label), Color: c.Color, Width: math.Sqrt(size) / 2 * pt, Marker: marker}
+	for _, o := range c.Offsets {
+		sd.X = append(sd.X, o[0])
+		sd.Y = append(sd.Y, o[1])
+	}
+	return sd, nil
+}
+
+// series converts a bar container. Widths must be the same for all bars;
+// bottoms that vary, as in stacked bars, become per-bar Bottoms.
+func (b mplBars) series() (SeriesData, error) {
+	width, ok := uniform(b.Width, 0.8)
+	if !ok {
+		return SeriesData{}, fmt.Errorf("bars %q: varying widths are not supported", b.Label)
+	}
+	kind := "bar"
+	if b.Orientation == "horizontal" {
+		kind = "barh"
+	}
+	sd := SeriesData{Kind: kind, Label: mplLabel(b.Label), X: b.X, Y: b.Height, Color: b.Color, Width: width}
+	if bottom, ok := uniform(b.Bottom, 0); ok {
+		sd.Baseline = bottom
+	} else {
+		sd.Bottoms = b.Bottom
+	}
+	return sd, nil
+}
+
+// uniform returns the common value of vs, or def for none.
+func uniform(vs []float64, def float64) (float64, bool) {
+	if len(vs) == 0 {
+		return def, true
+	}
+	for _, v := range vs[1:] {
+		if v != vs[0] {
+			return 0, false
+		}
+	}
+	return vs[0], true
+}
+
+// mplLabel drops matplotlib's placeholder labels such as "_child0", which
+// mark artists excluded from the legend.
+func mplLabel(l string) string {
+	if len(l) > 0 && l[0] == '_' {
+		return ""
+	}
+	return l
+}
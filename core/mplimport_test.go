@@ -0,0 +1,117 @@
+package core
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mplTestFigure = `{
+ "figsize": [6.4, 4.8],
+ "dpi": 100,
+ "axes": [
+  {
+   "position": [0.125, 0.11, 0.775, 0.77],
+   "title": "Growth",
+   "xlabel": "year",
+   "ylabel": "users",
+   "xlim": [0, 4],
+   "ylim": [1, 1000],
+   "xscale": "linear",
+   "yscale": "log",
+   "yscaleparams": {"base": 2},
+   "grid": true,
+   "legend": true,
+   "lines": [
+    {"x": [0, 1, 2, 3], "y": [1, 10, 100, 1000], "color": [0.12, 0.47, 0.71, 1], "linewidth": 1.5, "linestyle": "--", "marker": "None", "markersize": 6, "label": "model"},
+    {"x": [0.5, 1.5], "y": [3, 30], "color": [1, 0.5, 0.05, 1], "linewidth": 1.5, "linestyle": "None", "marker": "o", "markersize": 6, "label": "_child1"}
+   ],
+   "scatters": [
+    {"offsets": [[1, 20], [2, 200]], "color": [0.17, 0.63, 0.17, 1], "sizes": [36], "marker": "o", "label": "measured"}
+   ],
+   "bars": [
+    {"x": [0, 1, 2], "height": [5, 50, 500], "width": [0.8, 0.8, 0.8], "bottom": [0, 0, 0], "orientation": "vertical", "color": [0.84, 0.15, 0.16, 1], "label": "sales"}
+   ],
+   "fills": [
+    {"x": [0, 1], "y1": [2, 20], "y2": [1, 10], "color": [0.58, 0.4, 0.74, 0.3], "label": "_child4"}
+   ]
+  }
+ ]
+}`
+
+func TestImportMatplotlib(t *testing.T) {
+	fig, err := ImportMatplotlib(strings.NewReader(mplTestFigure))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fig.SizePx.X != 640 || fig.SizePx.Y != 480 {
+		t.Errorf("size = %v, want 640x480", fig.SizePx)
+	}
+	spec := ExportSpec(fig)
+	ax := spec.Axes[0]
+	if r := ax.Rect; math.Abs(r[1]-0.12) > 1e-9 || math.Abs(r[3]-0.89) > 1e-9 {
+		t.Errorf("rect = %v, want y from 0.12 to 0.89 from the top", r)
+	}
+	if ax.Title != "Growth" || ax.YScale != "log" || ax.YScaleParams.Base != 2 || !ax.Grid || !ax.Legend {
+		t.Errorf("axes settings = %+v", ax)
+	}
+
+	kinds := []string{"line", "scatter", "scatter", "bar", "fill"}
+	if len(ax.Series) != len(kinds) {
+		t.Fatalf("imported %d series, want %d", len(ax.Series), len(kinds))
+	}
+	for i, k := range kinds {
+		if ax.Series[i].Kind != k {
+			t.Errorf("series %d is a %s, want %s", i, ax.Series[i].Kind, k)
+		}
+	}
+	line := ax.Series[0]
+	if want := 1.5 * 100 / 72; math.Abs(line.Width-want) > 1e-9 || math.Abs(line.Dashes[0]-3.7*want) > 1e-9 {
+		t.Errorf("line width %v dashes %v, want width %v in pixels", line.Width, line.Dashes, want)
+	}
+	if ax.Series[1].Label != "" {
+		t.Errorf("placeholder label kept: %q", ax.Series[1].Label)
+	}
+	if want := 3 * 100 / 72.0; math.Abs(ax.Series[2].Width-want) > 1e-9 {
+		t.Errorf("scatter radius = %v, want %v", ax.Series[2].Width, want)
+	}
+	if fill := ax.Series[4]; fill.Color[3] != 0.3 || fill.Y2[1] != 10 {
+		t.Errorf("fill = %+v", fill)
+	}
+}
+
+func TestImportMatplotlib_StackedBars(t *testing.T) {
+	// plt.bar(x, a); plt.bar(x, b, bottom=a)
+	const in = `{"figsize": [4, 3], "dpi": 100, "axes": [{"position": [0.1, 0.1, 0.8, 0.8], "xlim": [-1, 2], "ylim": [0, 10], "bars": [
+	 {"x": [0, 1], "height": [1, 2], "width": [0.8, 0.8], "bottom": [0, 0], "orientation": "vertical", "label": "a"},
+	 {"x": [0, 1], "height": [3, 4], "width": [0.8, 0.8], "bottom": [1, 2], "orientation": "vertical", "label": "b"}
+	]}]}`
+	fig, err := ImportMatplotlib(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := ExportSpec(fig).Axes[0].Series
+	if len(series) != 2 {
+		t.Fatalf("imported %d series, want 2", len(series))
+	}
+	if s := series[0]; s.Bottoms != nil || s.Baseline != 0 {
+		t.Errorf("lower bars have bottoms %v, baseline %v; want baseline 0", s.Bottoms, s.Baseline)
+	}
+	if s := series[1]; !reflect.DeepEqual(s.Bottoms, Values{1, 2}) {
+		t.Errorf("upper bars have bottoms %v, want [1 2]", s.Bottoms)
+	}
+}
+
+func TestImportMatplotlib_Unsupported(t *testing.T) {
+	for _, in := range []string{
+		`{"figsize": [1, 1], "axes": [{"lines": [{"linestyle": "-", "marker": "None"}, {"linestyle": "steps"}]}]}`,
+		`{"figsize": [1, 1], "axes": [{"scatters": [{"sizes": [1, 2]}]}]}`,
+		`{"figsize": [1, 1], "axes": [{"bars": [{"x": [0, 1], "height": [1, 1], "width": [1, 2]}]}]}`,
+		`not json`,
+	} {
+		if _, err := ImportMatplotlib(strings.NewReader(in)); err == nil {
+			t.Errorf("no error for %s", in)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"matplotlib-go/internal/geom"
+)
+
+// SmallMultiples fills the figure with a grid of small axes, one per entry
+// of data, titled with its key and arranged in ncols columns in key order.
+// Every series is plotted as in BuildFigure, so that series i has the same
+// color in each panel. All panels share the x and y limits, which span the
+// data of all panels plus a 5% margin; tick labels are drawn only along
+// the left column and below the bottom panel of each column. It returns
+// the axes in panel order.
+func (f *Figure) SmallMultiples(data map[string][]SeriesData, ncols int) ([]*Axes, error) {
+	if ncols <= 0 {
+		return nil, errors.New("small multiples: ncols must be positive")
+	}
+	titles := make([]string, 0, len(data))
+	for t := range data {
+		titles = append(titles, t)
+	}
+	sort.Strings(titles)
+
+	xr, yr := emptyRange(), emptyRange()
+	for _, t := range titles {
+		for _, sd := range data[t] {
+			sx, sy := seriesRange(sd)
+			xr, yr = xr.union(sx), yr.union(sy)
+		}
+	}
+	xmin, xmax := xr.margin(0.05)
+	ymin, ymax := yr.margin(0.05)
+
+	n := len(titles)
+	nrows := (n + ncols - 1) / ncols
+	cells := f.multiplesLayout(nrows, ncols)
+	axes := make([]*Axes, n)
+	for i, t := range titles {
+		ax := f.AddAxes(cells[i])
+		for j, sd := range data[t] {
+			if err := buildSeries(ax, sd); err != nil {
+				return nil, fmt.Errorf("small multiples: %q series %d: %w", t, j, err)
+			}
+		}
+		ax.Title = t
+		ax.SetXLim(xmin, xmax)
+		ax.SetYLim(ymin, ymax)
+		ax.XAxis.ShowLabels = i+ncols >= n // no panel below
+		ax.YAxis.ShowLabels = i%ncols == 0
+		axes[i] = ax
+	}
+	return axes, nil
+}
+
+// multiplesLayout returns the figure rectangles of an nrows×ncols grid in
+// row-major order, leaving room for tick labels at the left and bottom and
+// for the titles above each row.
+func (f *Figure) multiplesLayout(nrows, ncols int) []geom.Rect {
+	fs := f.fontSize()
+	return f.gridLayout(nrows, ncols, SubplotOptions{}, func(float64, float64) (float64, float64) {
+		return fs / f.SizePx.X, 2.5 * fs / f.SizePx.Y
+	})
+}
+
+// valueRange is a range of finite values; lo > hi when it is empty.
+type valueRange struct{ lo, hi float64 }
+
+func emptyRange() valueRange { return valueRange{math.Inf(1), math.Inf(-1)} }
+
+func (r valueRange) add(vs ...float64) valueRange {
+	for _, v := range vs {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			r.lo, r.hi = math.Min(r.lo, v), math.Max(r.hi, v)
+		}
+	}
+	return r
+}
+
+func (r valueRange) union(o valueRange) valueRange {
+	return valueRange{math.Min(r.lo, o.lo), math.Max(r.hi, o.hi)}
+}
+
+// margin returns the range extended by frac of its span on both sides,
+// 0..1 if it is empty and ±1 around a single value.
+func (r valueRange) margin(frac float64) (float64, float64) {
+	switch {
+	case r.lo > r.hi:
+		return 0, 1
+	case r.lo == r.hi:
+		return r.lo - 1, r.hi + 1
+	}
+	d := (r.hi - r.lo) * frac
+	return r.lo - d, r.hi + d
+}
+
+// seriesRange returns the x and y data ranges sd covers when plotted.
+func seriesRange(sd SeriesData) (x, y valueRange) {
+	x, y = emptyRange(), emptyRange()
+	switch sd.Kind {
+	case "bar", "barh":
+		w := sd.Width
+		if w <= 0 {
+			w = 0.8
+		}
+		for i, p := range sd.X {
+			x = x.add(p-w/2, p+w/2)
+			if i < len(sd.Y) {
+				y = y.add(sd.Baseline, sd.Baseline+sd.Y[i])
+			}
+		}
+		if sd.Kind == "barh" {
+			x, y = y, x
+		}
+		return x, y
+	case "fill":
+		if sd.Y2 == nil {
+			y = y.add(sd.Baseline)
+		}
+		y = y.add(sd.Y2...)
+	}
+	return x.add(sd.X...), y.add(sd.Y...)
+}
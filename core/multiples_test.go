@@ -0,0 +1,72 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSmallMultiples(t *testing.T) {
+	fig := NewFigure(600, 400)
+	data := map[string][]SeriesData{
+		"c": {{Kind: "line", X: []float64{0, 10}, Y: []float64{0, 1}}},
+		"a": {
+			{Kind: "line", X: []float64{0, 5}, Y: []float64{2, 3}},
+			{Kind: "scatter", X: []float64{1}, Y: []float64{-1}},
+		},
+		"b": {{Kind: "bar", X: []float64{4}, Y: []float64{5}}},
+	}
+	axes, err := fig.SmallMultiples(data, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(axes) != 3 || len(fig.Children) != 3 {
+		t.Fatalf("got %d axes", len(axes))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if axes[i].Title != want {
+			t.Errorf("panel %d titled %q, want %q", i, axes[i].Title, want)
+		}
+	}
+
+	// Shared limits: x 0..10, y -1..5, each with a 5% margin.
+	for i, ax := range axes {
+		xmin, xmax := ax.XScale.Domain()
+		ymin, ymax := ax.YScale.Domain()
+		if math.Abs(xmin+0.5) > 1e-9 || math.Abs(xmax-10.5) > 1e-9 || math.Abs(ymin+1.3) > 1e-9 || math.Abs(ymax-5.3) > 1e-9 {
+			t.Errorf("panel %d limits x %v..%v y %v..%v", i, xmin, xmax, ymin, ymax)
+		}
+	}
+
+	// Series i has the same color in every panel.
+	if a, c := axes[0].Artists[0].(*Line2D), axes[2].Artists[0].(*Line2D); a.Col != c.Col {
+		t.Errorf("first series colors differ: %v and %v", a.Col, c.Col)
+	}
+
+	// Tick labels only at the left column and the bottom of each column;
+	// "b" has no panel below it.
+	for i, want := range [][2]bool{{false, true}, {true, false}, {true, true}} {
+		if got := [2]bool{axes[i].XAxis.ShowLabels, axes[i].YAxis.ShowLabels}; got != want {
+			t.Errorf("panel %d shows x, y labels %v, want %v", i, got, want)
+		}
+	}
+
+	// Panels in a row line up and do not overlap.
+	a, b := axes[0].RectFraction, axes[1].RectFraction
+	if a.Min.Y != b.Min.Y || a.Max.X >= b.Min.X {
+		t.Errorf("panels a %v and b %v are not side by side", a, b)
+	}
+	if c := axes[2].RectFraction; c.Min.Y <= a.Max.Y {
+		t.Errorf("panel c %v overlaps the first row", c)
+	}
+}
+
+func TestSmallMultiples_Errors(t *testing.T) {
+	fig := NewFigure(200, 200)
+	if _, err := fig.SmallMultiples(nil, 0); err == nil {
+		t.Error("no error for zero columns")
+	}
+	bad := map[string][]SeriesData{"x": {{Kind: "pie"}}}
+	if _, err := fig.SmallMultiples(bad, 1); err == nil {
+		t.Error("no error for an unknown series kind")
+	}
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+
+	"matplotlib-go/backends"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+// Navigator keeps the view history of a figure for interactive frontends,
+// as matplotlib's navigation toolbar does: every pan or zoom adds a view
+// of all axes limits, Back and Forward step through them and Home returns
+// to the first. Frontends redraw the whole figure after each call that
+// changed the limits, e.g. with Redrawer.InvalidateAll.
+//
+//	nav := core.NewNavigator(fig)
+//	onDragEnd := func(ax *core.Axes, rect geom.Rect) { nav.Zoom(ax, rect) }
+//	onKey := func(key string) { if key == "left" { nav.Back() } }
+type Navigator struct {
+	fig   *Figure
+	views []figureView // views[0] is home
+	pos   int          // index of the current view
+}
+
+// figureView holds the x and y scales of each axes.
+type figureView map[*Axes][2]transform.Scale
+
+// NewNavigator returns a Navigator whose home view is the figure's current
+// limits.
+func NewNavigator(fig *Figure) *Navigator {
+	n := &Navigator{fig: fig}
+	n.views = []figureView{n.capture()}
+	return n
+}
+
+// Push records the current limits as a new view after the frontend changed
+// them, e.g. at the end of a pan drag. Views after the current one are
+// discarded, as in a browser history. Unchanged limits are not recorded.
+func (n *Navigator) Push() {
+	v := n.capture()
+	if v.equal(n.views[n.pos]) {
+		return
+	}
+	n.views = append(n.views[:n.pos+1], v)
+	n.pos++
+}
+
+// Back restores the previous view and reports whether there was one.
+func (n *Navigator) Back() bool { return n.move(n.pos - 1) }
+
+// Forward restores the view undone by Back and reports whether there was
+// one.
+func (n *Navigator) Forward() bool { return n.move(n.pos + 1) }
+
+// Home restores the first view, keeping the history so that Back returns
+// to where the user was. It reports whether the limits changed.
+func (n *Navigator) Home() bool {
+	if n.capture().equal(n.views[0]) {
+		return false
+	}
+	n.views[0].restore()
+	n.Push()
+	return true
+}
+
+// CanBack and CanForward report whether Back and Forward have a view to go
+// to, e.g. to enable toolbar buttons.
+func (n *Navigator) CanBack() bool    { return n.pos > 0 }
+func (n *Navigator) CanForward() bool { return n.pos < len(n.views)-1 }
+
+// Zoom sets the limits of ax to the data under the pixel rectangle rect,
+// e.g. dragged with the zoom tool, and records the view. Log, symlog,
+// power and time scales keep their type. Empty rectangles and polar axes
+// are ignored.
+func (n *Navigator) Zoom(ax *Axes, rect geom.Rect) {
+	if ax.Polar != nil || rect.W() == 0 || rect.H() == 0 {
+		return
+	}
+	a, okA := n.unit(ax, rect.Min)
+	b, okB := n.unit(ax, rect.Max)
+	if !okA || !okB {
+		return
+	}
+	ax.XScale = rescale(ax.XScale, a.X, b.X)
+	ax.YScale = rescale(ax.YScale, a.Y, b.Y)
+	n.Push()
+}
+
+// Pan shifts the limits of ax so that the data moves by delta pixels, e.g.
+// while dragging with the pan tool. It does not record the view; call Push
+// when the drag ends.
+func (n *Navigator) Pan(ax *Axes, delta geom.Pt) {
+	if ax.Polar != nil {
+		return
+	}
+	a, okA := n.unit(ax, geom.Pt{})
+	b, okB := n.unit(ax, geom.Pt{X: -delta.X, Y: -delta.Y})
+	if !okA || !okB {
+		return
+	}
+	du, dv := b.X-a.X, b.Y-a.Y
+	ax.XScale = rescale(ax.XScale, du, 1+du)
+	ax.YScale = rescale(ax.YScale, dv, 1+dv)
+}
+
+// unit maps a pixel to the axes' unit square, (0,0) at the lower left.
+func (n *Navigator) unit(ax *Axes, p geom.Pt) (geom.Pt, bool) {
+	return ax.drawContext(n.fig).DataToPixel.AxesToPixel.Invert(p)
+}
+
+// rescale returns s with the domain spanning the unit positions u0 and u1
+// of its current domain, keeping its orientation.
+func rescale(s transform.Scale, u0, u1 float64) transform.Scale {
+	if u0 > u1 {
+		u0, u1 = u1, u0
+	}
+	min, okMin := s.Inv(u0)
+	max, okMax := s.Inv(u1)
+	if !okMin || !okMax || min == max {
+		return s
+	}
+	switch s := s.(type) {
+	case transform.Log:
+		return transform.NewLog(min, max, s.Base)
+	case transform.Time:
+		return transform.Time{Min: min, Max: max}
+	case transform.SymLog:
+		return transform.NewSymLog(min, max, s.LinThresh, s.Base)
+	case transform.Power:
+		return transform.NewPower(min, max, s.Exp)
+	}
+	return transform.NewLinear(min, max)
+}
+
+func (n *Navigator) move(pos int) bool {
+	if pos < 0 || pos >= len(n.views) {
+		return false
+	}
+	n.pos = pos
+	n.views[pos].restore()
+	return true
+}
+
+func (n *Navigator) capture() figureView {
+	v := make(figureView, len(n.fig.Children))
+	for _, ax := range n.fig.Children {
+		v[ax] = [2]transform.Scale{ax.XScale, ax.YScale}
+	}
+	return v
+}
+
+// restore sets the limits of the recorded axes; axes added since are left
+// alone.
+func (v figureView) restore() {
+	for ax, s := range v {
+		ax.XScale, ax.YScale = s[0], s[1]
+	}
+}
+
+func (v figureView) equal(o figureView) bool {
+	if len(v) != len(o) {
+		return false
+	}
+	for ax, s := range v {
+		// DeepEqual, as == panics on scales holding slices or maps.
+		if t, ok := o[ax]; !ok || !reflect.DeepEqual(t, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// SaveView writes the figure with its current, possibly zoomed or panned
+// limits to path, e.g. for the save button of a toolbar. It renders w×h
+// pixels, 0 for the figure's size, with a registered backend, in that
+// backend's format: PNG for raster backends, SVG, PGF or EMF for vector
+// ones. As with SaveMulti, fonts and line widths keep their pixel sizes
+// and the figure's own size is left unchanged.
+func (n *Navigator) SaveView(backend backends.Backend, w, h int, path string) error {
+	fig := n.fig
+	orig := fig.SizePx
+	defer func() { fig.SizePx = orig }()
+	if w > 0 {
+		fig.SizePx.X = float64(w)
+	}
+	if h > 0 {
+		fig.SizePx.Y = float64(h)
+	}
+	r, err := backends.Create(backend, fig.RendererConfig())
+	if err != nil {
+		return fmt.Errorf("SaveView: %w", err)
+	}
+	switch r.(type) {
+	case PNGExporter:
+		return SavePNG(fig, r, path)
+	case SVGExporter:
+		return SaveSVG(fig, r, path)
+	case PGFExporter:
+		return SavePGF(fig, r, path)
+	case EMFExporter:
+		return SaveEMF(fig, r, path)
+	}
+	return fmt.Errorf("SaveView: backend %s cannot save files", backend)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"matplotlib-go/backends"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestNavigator(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(0, 16)
+	ax.SetYLimLog(1, 1e4, 10)
+	nav := NewNavigator(fig)
+	if nav.CanBack() || nav.CanForward() || nav.Back() || nav.Home() {
+		t.Fatal("fresh navigator has history")
+	}
+	lim := func(s transform.Scale) [2]float64 {
+		min, max := s.Domain()
+		return [2]float64{min, max}
+	}
+	nearLim := func(s transform.Scale, want [2]float64) bool {
+		got := lim(s)
+		return math.Abs(got[0]-want[0]) < 1e-9*math.Abs(want[0])+1e-9 && math.Abs(got[1]-want[1]) < 1e-9*math.Abs(want[1])+1e-9
+	}
+
+	// The axes span pixels 20..180: zoom to the middle half in x and the
+	// lower half in y (decades 1..100 on the log axis).
+	nav.Zoom(ax, geom.Rect{Min: geom.Pt{X: 60, Y: 100}, Max: geom.Pt{X: 140, Y: 180}})
+	if !nearLim(ax.XScale, [2]float64{4, 12}) || !nearLim(ax.YScale, [2]float64{1, 100}) {
+		t.Fatalf("zoomed to x %v y %v", lim(ax.XScale), lim(ax.YScale))
+	}
+	if _, ok := ax.YScale.(transform.Log); !ok {
+		t.Errorf("zoom turned the log scale into %T", ax.YScale)
+	}
+
+	// Pan right by a quarter of the width: the view moves left by 2.
+	nav.Pan(ax, geom.Pt{X: 40})
+	nav.Push()
+	if !nearLim(ax.XScale, [2]float64{2, 10}) || !nav.CanBack() {
+		t.Fatalf("panned to x %v", lim(ax.XScale))
+	}
+
+	if !nav.Back() || !nearLim(ax.XScale, [2]float64{4, 12}) || !nav.CanForward() {
+		t.Errorf("back to x %v", lim(ax.XScale))
+	}
+	if !nav.Back() || lim(ax.XScale) != [2]float64{0, 16} || lim(ax.YScale) != [2]float64{1, 1e4} {
+		t.Errorf("back to x %v y %v, want home", lim(ax.XScale), lim(ax.YScale))
+	}
+	if !nav.Forward() || !nearLim(ax.XScale, [2]float64{4, 12}) {
+		t.Errorf("forward to x %v", lim(ax.XScale))
+	}
+
+	// Home keeps the history; a new zoom drops the forward views.
+	if !nav.Home() || lim(ax.XScale) != [2]float64{0, 16} || !nav.CanBack() {
+		t.Errorf("home at x %v", lim(ax.XScale))
+	}
+	if !nav.Back() || !nearLim(ax.XScale, [2]float64{4, 12}) {
+		t.Errorf("back from home to x %v", lim(ax.XScale))
+	}
+	nav.Zoom(ax, geom.Rect{Min: geom.Pt{X: 20, Y: 20}, Max: geom.Pt{X: 100, Y: 180}})
+	if nav.CanForward() || !nearLim(ax.XScale, [2]float64{4, 8}) {
+		t.Errorf("zoom kept forward views or zoomed to x %v", lim(ax.XScale))
+	}
+	nav.Push() // unchanged limits add no view
+	if nav.Back(); !nearLim(ax.XScale, [2]float64{4, 12}) {
+		t.Errorf("unchanged push recorded: back at x %v", lim(ax.XScale))
+	}
+}
+
+// sliceScale is a linear scale that is not comparable with ==.
+type sliceScale struct {
+	transform.Linear
+	Marks []float64
+}
+
+func TestNavigator_UncomparableScale(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.XScale = sliceScale{Linear: transform.NewLinear(0, 1), Marks: []float64{0.5}}
+	nav := NewNavigator(fig)
+	nav.Push() // compares the unchanged view
+	if nav.CanBack() {
+		t.Error("unchanged push recorded")
+	}
+}
+
+func TestNavigator_SaveView(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1})
+	nav := NewNavigator(fig)
+	nav.Zoom(ax, geom.Rect{Min: geom.Pt{X: 40, Y: 30}, Max: geom.Pt{X: 200, Y: 150}})
+
+	path := filepath.Join(t.TempDir(), "view.png")
+	if err := nav.SaveView(backends.GoBasic, 160, 120, path); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if cfg, err := png.DecodeConfig(f); err != nil || cfg.Width != 160 || cfg.Height != 120 {
+		t.Errorf("saved %dx%d (%v), want 160x120", cfg.Width, cfg.Height, err)
+	}
+	if fig.SizePx != (geom.Pt{X: 400, Y: 300}) {
+		t.Errorf("figure size not restored: %v", fig.SizePx)
+	}
+	if err := nav.SaveView("nonexistent", 0, 0, path); err == nil {
+		t.Error("unknown backend accepted")
+	}
+}
@@ -0,0 +1,283 @@
+package core
+
+import "matplotlib-go/render"
+
+// Option is a functional option for Plot, Scatter, Bar, FillBetweenPlot
+// and FillToBaselinePlot:
+//
+//	ax.Bar(x, h, core.WithWidth(0.4), core.WithLabel("2024"))
+//
+// Options that do not apply to a plot type are ignored, e.g. WithMarker
+// for Bar. The option structs (PlotOptions etc.) remain accepted and can
+// be mixed with functional options; later arguments win.
+type Option func(*optionValues)
+
+// optionValues collects the settings of functional options; nil means unset.
+type optionValues struct {
+	color       *render.Color
+	edgeColor   *render.Color
+	lineWidth   *float64
+	edgeWidth   *float64
+	alpha       *float64
+	size        *float64
+	width       *float64
+	baseline    *float64
+	marker      *MarkerType
+	orientation *BarOrientation
+	dashes      []float64
+	lineStyle   *string
+	dashCap     *render.DashCap
+	angles      []float64
+	dataSize    *bool
+	label       *string
+}
+
+func (f Option) values() optionValues {
+	var v optionValues
+	if f != nil {
+		f(&v)
+	}
+	return v
+}
+
+// WithColor sets the series color instead of the next color of the cycle.
+func WithColor(c render.Color) Option { return func(v *optionValues) { v.color = &c } }
+
+// WithEdgeColor sets the outline color of markers, bars and fills.
+func WithEdgeColor(c render.Color) Option { return func(v *optionValues) { v.edgeColor = &c } }
+
+// WithLineWidth sets the line width of Plot in pixels.
+func WithLineWidth(w float64) Option { return func(v *optionValues) { v.lineWidth = &w } }
+
+// WithEdgeWidth sets the outline width of markers, bars and fills in pixels.
+func WithEdgeWidth(w float64) Option { return func(v *optionValues) { v.edgeWidth = &w } }
+
+// WithAlpha sets the opacity (0-1).
+func WithAlpha(a float64) Option { return func(v *optionValues) { v.alpha = &a } }
+
+// WithSize sets the Scatter marker radius in pixels.
+func WithSize(s float64) Option { return func(v *optionValues) { v.size = &s } }
+
+// WithAngles rotates the Scatter markers, one angle per point in degrees
+// counterclockwise, e.g. for oriented glyphs such as wind barbs.
+func WithAngles(a ...float64) Option { return func(v *optionValues) { v.angles = a } }
+
+// WithDataSize makes the Scatter marker size a radius in x data units, so
+// that markers keep their physical size and grow when zooming in.
+func WithDataSize() Option {
+	return func(v *optionValues) { t := true; v.dataSize = &t }
+}
+
+// WithMarker sets the Scatter marker shape.
+func WithMarker(m MarkerType) Option { return func(v *optionValues) { v.marker = &m } }
+
+// WithWidth sets the Bar width in data units.
+func WithWidth(w float64) Option { return func(v *optionValues) { v.width = &w } }
+
+// WithBaseline sets the value bars and fills extend from.
+func WithBaseline(b float64) Option { return func(v *optionValues) { v.baseline = &b } }
+
+// WithOrientation sets the Bar orientation.
+func WithOrientation(o BarOrientation) Option { return func(v *optionValues) { v.orientation = &o } }
+
+// WithDashes sets the Plot dash pattern (on/off pairs in pixels).
+func WithDashes(d ...float64) Option { return func(v *optionValues) { v.dashes = d } }
+
+// WithDashCap sets the cap of the Plot dash ends apart from the line's
+// end caps, e.g. render.DashCapRound for dotted lines of round dots.
+func WithDashCap(c render.DashCap) Option { return func(v *optionValues) { v.dashCap = &c } }
+
+// WithLabel sets the series label for legends.
+func WithLabel(l string) Option { return func(v *optionValues) { v.label = &l } }
+
+// PlotOption configures Plot: a PlotOptions struct or an Option.
+type PlotOption interface{ applyPlot(*PlotOptions) }
+
+// ScatterOption configures Scatter: a ScatterOptions struct or an Option.
+type ScatterOption interface{ applyScatter(*ScatterOptions) }
+
+// BarOption configures Bar: a BarOptions struct or an Option.
+type BarOption interface{ applyBar(*BarOptions) }
+
+// FillOption configures the fill helpers: a FillOptions struct or an Option.
+type FillOption interface{ applyFill(*FillOptions) }
+
+func (f Option) applyPlot(o *PlotOptions) {
+	v := f.values()
+	setIf(&o.Color, v.color)
+	setIf(&o.LineWidth, v.lineWidth)
+	setIf(&o.Alpha, v.alpha)
+	if v.lineStyle != nil {
+		o.LineStyle, o.Dashes = *v.lineStyle, nil
+	}
+	if v.dashes != nil {
+		o.Dashes, o.LineStyle = v.dashes, ""
+	}
+	if v.dashCap != nil {
+		o.DashCap = *v.dashCap
+	}
+	if v.label != nil {
+		o.Label = *v.label
+	}
+}
+
+func (f Option) applyScatter(o *ScatterOptions) {
+	v := f.values()
+	setIf(&o.Color, v.color)
+	setIf(&o.Size, v.size)
+	setIf(&o.Marker, v.marker)
+	setIf(&o.EdgeColor, v.edgeColor)
+	setIf(&o.EdgeWidth, v.edgeWidth)
+	setIf(&o.Alpha, v.alpha)
+	if v.angles != nil {
+		o.Angles = v.angles
+	}
+	if v.dataSize != nil {
+		o.DataSize = *v.dataSize
+	}
+	if v.label != nil {
+		o.Label = *v.label
+	}
+}
+
+func (f Option) applyBar(o *BarOptions) {
+	v := f.values()
+	setIf(&o.Color, v.color)
+	setIf(&o.Width, v.width)
+	setIf(&o.EdgeColor, v.edgeColor)
+	setIf(&o.EdgeWidth, v.edgeWidth)
+	setIf(&o.Alpha, v.alpha)
+	setIf(&o.Baseline, v.baseline)
+	setIf(&o.Orientation, v.orientation)
+	if v.label != nil {
+		o.Label = *v.label
+	}
+}
+
+func (f Option) applyFill(o *FillOptions) {
+	v := f.values()
+	setIf(&o.Color, v.color)
+	setIf(&o.EdgeColor, v.edgeColor)
+	setIf(&o.EdgeWidth, v.edgeWidth)
+	setIf(&o.Alpha, v.alpha)
+	setIf(&o.Baseline, v.baseline)
+	if v.label != nil {
+		o.Label = *v.label
+	}
+}
+
+// The option structs apply their set (non-nil, non-empty) fields, so calls
+// written for the struct-only API keep compiling unchanged.
+var (
+	_ PlotOption    = PlotOptions{}
+	_ ScatterOption = ScatterOptions{}
+	_ BarOption     = BarOptions{}
+	_ FillOption    = FillOptions{}
+)
+
+func (s PlotOptions) applyPlot(o *PlotOptions) {
+	setIf(&o.Color, s.Color)
+	setIf(&o.LineWidth, s.LineWidth)
+	setIf(&o.Alpha, s.Alpha)
+	if s.LineStyle != "" {
+		o.LineStyle, o.Dashes = s.LineStyle, nil
+	}
+	if s.Dashes != nil {
+		o.Dashes = s.Dashes
+	}
+	if s.DashCap != render.DashCapLine {
+		o.DashCap = s.DashCap
+	}
+	if s.Label != "" {
+		o.Label = s.Label
+	}
+}
+
+func (s ScatterOptions) applyScatter(o *ScatterOptions) {
+	setIf(&o.Color, s.Color)
+	setIf(&o.Size, s.Size)
+	setIf(&o.Marker, s.Marker)
+	setIf(&o.EdgeColor, s.EdgeColor)
+	setIf(&o.EdgeWidth, s.EdgeWidth)
+	setIf(&o.Alpha, s.Alpha)
+	if s.Angles != nil {
+		o.Angles = s.Angles
+	}
+	if s.DataSize {
+		o.DataSize = true
+	}
+	if s.Label != "" {
+		o.Label = s.Label
+	}
+}
+
+func (s BarOptions) applyBar(o *BarOptions) {
+	setIf(&o.Color, s.Color)
+	setIf(&o.Width, s.Width)
+	setIf(&o.EdgeColor, s.EdgeColor)
+	setIf(&o.EdgeWidth, s.EdgeWidth)
+	setIf(&o.Alpha, s.Alpha)
+	setIf(&o.Baseline, s.Baseline)
+	setIf(&o.Orientation, s.Orientation)
+	if s.Label != "" {
+		o.Label = s.Label
+	}
+}
+
+func (s FillOptions) applyFill(o *FillOptions) {
+	setIf(&o.Color, s.Color)
+	setIf(&o.EdgeColor, s.EdgeColor)
+	setIf(&o.EdgeWidth, s.EdgeWidth)
+	setIf(&o.Alpha, s.Alpha)
+	setIf(&o.Baseline, s.Baseline)
+	if s.Label != "" {
+		o.Label = s.Label
+	}
+}
+
+// setIf stores v in *dst unless v is nil.
+func setIf[T any](dst **T, v *T) {
+	if v != nil {
+		*dst = v
+	}
+}
+
+func resolvePlotOptions(opts []PlotOption) PlotOptions {
+	var o PlotOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyPlot(&o)
+		}
+	}
+	return o
+}
+
+func resolveScatterOptions(opts []ScatterOption) ScatterOptions {
+	var o ScatterOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyScatter(&o)
+		}
+	}
+	return o
+}
+
+func resolveBarOptions(opts []BarOption) BarOptions {
+	var o BarOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyBar(&o)
+		}
+	}
+	return o
+}
+
+func resolveFillOptions(opts []FillOption) FillOptions {
+	var o FillOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyFill(&o)
+		}
+	}
+	return o
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestOptions_Bar(t *testing.T) {
+	ax := NewFigure(100, 100).AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	red := render.Color{R: 1, A: 1}
+	bar := ax.Bar([]float64{1, 2}, []float64{3, 4},
+		WithWidth(0.4), WithColor(red), WithOrientation(BarHorizontal), WithLabel("b"), WithMarker(MarkerSquare))
+
+	if bar.Width != 0.4 {
+		t.Errorf("Width = %v, want 0.4", bar.Width)
+	}
+	if bar.Color != red {
+		t.Errorf("Color = %+v, want %+v", bar.Color, red)
+	}
+	if bar.Orientation != BarHorizontal {
+		t.Errorf("Orientation = %v, want horizontal", bar.Orientation)
+	}
+	if bar.Label != "b" {
+		t.Errorf("Label = %q, want %q", bar.Label, "b")
+	}
+}
+
+func TestOptions_PlotAndScatter(t *testing.T) {
+	ax := NewFigure(100, 100).AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	line := ax.Plot([]float64{0, 1}, []float64{0, 1}, WithLineWidth(2), WithDashes(4, 2), WithAlpha(0.5))
+	if line.W != 2 {
+		t.Errorf("line width = %v, want 2", line.W)
+	}
+	if len(line.Dashes) != 2 {
+		t.Errorf("Dashes = %v, want [4 2]", line.Dashes)
+	}
+
+	sc := ax.Scatter([]float64{0}, []float64{0}, WithSize(7), WithMarker(MarkerTriangle), WithEdgeWidth(1.5))
+	if sc.Size != 7 || sc.Marker != MarkerTriangle || sc.EdgeWidth != 1.5 {
+		t.Errorf("scatter = size %v marker %v edge %v, want 7 triangle 1.5", sc.Size, sc.Marker, sc.EdgeWidth)
+	}
+}
+
+func TestOptions_MixedWithStructs(t *testing.T) {
+	ax := NewFigure(100, 100).AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	w := 0.3
+	bar := ax.Bar([]float64{1}, []float64{1}, BarOptions{Width: &w, Label: "struct"}, WithLabel("func"))
+	if bar.Width != 0.3 {
+		t.Errorf("Width = %v, want 0.3 from the struct", bar.Width)
+	}
+	if bar.Label != "func" {
+		t.Errorf("Label = %q, want the later option to win", bar.Label)
+	}
+
+	base := 2.0
+	fill := ax.FillToBaselinePlot([]float64{0, 1}, []float64{1, 1}, WithBaseline(5), FillOptions{Baseline: &base})
+	if fill.Baseline != 2 {
+		t.Errorf("Baseline = %v, want 2 from the later struct", fill.Baseline)
+	}
+}
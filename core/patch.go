@@ -0,0 +1,116 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// circleSegments is the number of edges circles are approximated with. The
+// outline is sampled in data space so that it stays correct on log axes.
+const circleSegments = 64
+
+// Patch is a shape in data coordinates, such as a circle or a polygon,
+// drawn filled and/or outlined. Patches also serve as clip shapes for other
+// artists, see Axes.SetClipPath.
+type Patch struct {
+	Path      geom.Path       // outline in data coordinates; several subpaths form one shape
+	FillRule  render.FillRule // which subpaths are holes, see render.FillRule
+	Color     render.Color    // fill color (0 alpha means no fill)
+	EdgeColor render.Color    // outline color (0 alpha means no outline)
+	EdgeWidth float64         // outline width in pixels
+	Label     string          // label for legend
+	Aliased   bool            // draw without anti-aliasing, with hard pixel edges
+	z         float64         // z-order
+}
+
+// NewPolygon returns a closed polygon patch through xy. It has no fill or
+// outline until Color or EdgeColor is set.
+func NewPolygon(xy []geom.Pt) *Patch {
+	p := &Patch{}
+	addRing(&p.Path, xy)
+	return p
+}
+
+// NewPolygonWithHoles returns a polygon patch with holes, e.g. land with
+// lakes. It uses the even-odd rule, so the rings may run in any direction;
+// holes must not overlap each other.
+func NewPolygonWithHoles(outer []geom.Pt, holes ...[]geom.Pt) *Patch {
+	p := &Patch{FillRule: render.FillEvenOdd}
+	addRing(&p.Path, outer)
+	for _, h := range holes {
+		addRing(&p.Path, h)
+	}
+	return p
+}
+
+// NewCircle returns a circle patch in data coordinates. It has no fill or
+// outline until Color or EdgeColor is set.
+func NewCircle(center geom.Pt, radius float64) *Patch {
+	return NewPolygon(circleRing(center, radius))
+}
+
+// NewAnnulus returns a ring between two circles around center.
+func NewAnnulus(center geom.Pt, outer, inner float64) *Patch {
+	return NewPolygonWithHoles(circleRing(center, outer), circleRing(center, inner))
+}
+
+func circleRing(center geom.Pt, radius float64) []geom.Pt {
+	xy := make([]geom.Pt, circleSegments)
+	for i := range xy {
+		theta := 2 * math.Pi * float64(i) / circleSegments
+		xy[i] = geom.Pt{X: center.X + radius*math.Cos(theta), Y: center.Y + radius*math.Sin(theta)}
+	}
+	return xy
+}
+
+// addRing appends xy to p as a closed subpath.
+func addRing(p *geom.Path, xy []geom.Pt) {
+	if len(xy) == 0 {
+		return
+	}
+	p.MoveTo(xy[0])
+	for _, v := range xy[1:] {
+		p.LineTo(v)
+	}
+	p.Close()
+}
+
+// Draw renders the patch.
+func (p *Patch) Draw(r render.Renderer, ctx *DrawContext) {
+	paint := render.Paint{Fill: p.Color, FillRule: p.FillRule, Aliased: p.Aliased}
+	if p.EdgeWidth > 0 && p.EdgeColor.A > 0 {
+		paint.Stroke = p.EdgeColor
+		paint.LineWidth = p.EdgeWidth
+		paint.LineJoin = render.JoinMiter
+		paint.MiterLimit = 10
+	}
+	if paint.Fill.A == 0 && paint.Stroke.A == 0 {
+		return
+	}
+	r.Path(p.pixelPath(ctx), &paint)
+}
+
+// pixelPath maps the outline to pixels.
+func (p *Patch) pixelPath(ctx *DrawContext) geom.Path {
+	out := geom.Path{C: p.Path.C, V: make([]geom.Pt, len(p.Path.V))}
+	for i, v := range p.Path.V {
+		out.V[i] = ctx.DataToPixel.Apply(v)
+	}
+	return out
+}
+
+// Z returns the z-order for sorting.
+func (p *Patch) Z() float64 {
+	return p.z
+}
+
+// Bounds returns an empty rect for now.
+func (p *Patch) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
+
+func (p *Patch) legendHandle() (string, LegendHandler) {
+	return p.Label, PatchLegendHandler(p.Color, p.EdgeColor, p.EdgeWidth)
+}
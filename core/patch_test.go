@@ -0,0 +1,120 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestPatch_Circle(t *testing.T) {
+	c := NewCircle(geom.Pt{X: 5, Y: 5}, 2)
+	c.Color = render.Color{R: 1, A: 1}
+
+	r := &segmentRecorder{}
+	c.Draw(r, createTestDrawContext())
+	if len(r.paths) != 1 {
+		t.Fatalf("got %d paths, want 1", len(r.paths))
+	}
+	p := r.paths[0]
+	if n := len(p.V); n != circleSegments {
+		t.Fatalf("got %d vertices, want %d", n, circleSegments)
+	}
+	if p.C[len(p.C)-1] != geom.ClosePath {
+		t.Error("circle is not closed")
+	}
+	// Data (5,5) is pixel (100,400); radius 2 is 20 pixels.
+	for _, v := range p.V {
+		if d := math.Hypot(v.X-100, v.Y-400); math.Abs(d-20) > 1e-9 {
+			t.Fatalf("vertex %v at distance %v from the center, want 20", v, d)
+		}
+	}
+	if r.paints[0].Fill != c.Color || r.paints[0].Stroke.A != 0 {
+		t.Errorf("paint %+v", r.paints[0])
+	}
+}
+
+func TestPatch_Unstyled(t *testing.T) {
+	r := &segmentRecorder{}
+	NewPolygon([]geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}}).Draw(r, createTestDrawContext())
+	if len(r.paths) != 0 {
+		t.Errorf("patch without fill or outline drew %d paths", len(r.paths))
+	}
+}
+
+// clipRecorder logs the state calls around Path.
+type clipRecorder struct {
+	render.NullRenderer
+	calls []string
+	clips []geom.Path
+}
+
+func (c *clipRecorder) Save()    { c.calls = append(c.calls, "save") }
+func (c *clipRecorder) Restore() { c.calls = append(c.calls, "restore") }
+func (c *clipRecorder) ClipPath(p geom.Path) {
+	c.calls = append(c.calls, "clip")
+	c.clips = append(c.clips, p)
+}
+func (c *clipRecorder) Path(geom.Path, *render.Paint) { c.calls = append(c.calls, "path") }
+
+func TestAxes_SetClipPath(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	fill := &Fill2D{X: []float64{0, 1}, Y1: []float64{1, 1}, Color: render.Color{A: 1}}
+	line := &Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1, Col: render.Color{A: 1}}
+	ax.Add(fill)
+	ax.Add(line)
+	circle := NewCircle(geom.Pt{X: 0.5, Y: 0.5}, 0.5)
+	ax.SetClipPath(fill, circle)
+
+	if ax.ClipPath(fill) != circle || ax.ClipPath(line) != nil {
+		t.Fatal("ClipPath does not report the clip shapes")
+	}
+
+	r := &clipRecorder{}
+	DrawFigure(fig, r)
+	// Axes save, fill clipped in its own state, line unclipped.
+	want := []string{"save", "save", "clip", "path", "restore", "path", "restore"}
+	if len(r.calls) != len(want) {
+		t.Fatalf("calls %v, want %v", r.calls, want)
+	}
+	for i := range want {
+		if r.calls[i] != want[i] {
+			t.Fatalf("calls %v, want %v", r.calls, want)
+		}
+	}
+	if v := r.clips[0].V[0]; math.Abs(v.X-100) > 1e-9 || math.Abs(v.Y-50) > 1e-9 {
+		t.Errorf("clip path starts at %v, want pixel (100, 50)", v)
+	}
+
+	ax.SetClipPath(fill, nil)
+	if ax.ClipPath(fill) != nil {
+		t.Error("nil clip did not remove the clip path")
+	}
+}
+
+func TestPatch_AnnulusHole(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ring := NewAnnulus(geom.Pt{X: 0.5, Y: 0.5}, 0.4, 0.2)
+	ring.Color = render.Color{A: 1}
+	ax.Add(ring)
+
+	if ring.FillRule != render.FillEvenOdd || len(ring.Path.C) != 2*(circleSegments+1) {
+		t.Fatalf("annulus has rule %v and %d commands", ring.FillRule, len(ring.Path.C))
+	}
+
+	r := gobasic.New(100, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+	DrawFigure(fig, r)
+	img := r.GetImage()
+	if c := img.RGBAAt(50, 50); c.R != 255 {
+		t.Errorf("center %v, want the hole's background", c)
+	}
+	if c := img.RGBAAt(20, 50); c.R != 0 {
+		t.Errorf("ring %v, want filled", c)
+	}
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+)
+
+// LabelBox is a label to be placed next to the point it annotates.
+type LabelBox struct {
+	Anchor geom.Pt // annotated point in pixels
+	W, H   float64 // label extent in pixels
+}
+
+// PlaceOptions configures PlaceLabels.
+type PlaceOptions struct {
+	Bounds   geom.Rect // pixels the labels must stay inside, e.g. the axes; empty for no limit
+	Distance float64   // gap between anchor and label in pixels, 0 for 4
+	Rings    int       // number of ever farther candidate rings tried, 0 for 3
+	Pad      float64   // extra space kept free around each label in pixels
+	Drop     bool      // hide labels that cannot be placed without overlap or clipping
+}
+
+// Placement is the position PlaceLabels chose for a label.
+type Placement struct {
+	Rect    geom.Rect // label box in pixels
+	Visible bool      // false for labels dropped with PlaceOptions.Drop
+}
+
+// labelDirections are the sides of the anchor tried in order, as unit
+// offsets: upper right first, as in most hand-labeled plots.
+var labelDirections = []geom.Pt{
+	{X: 1, Y: -1}, {X: -1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1},
+	{X: 0, Y: -1}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: -1, Y: 0},
+}
+
+// PlaceLabels positions labels so that they overlap neither each other nor
+// the other labels' anchors, e.g. to keep the data labels of a dense
+// scatter readable. It is greedy: labels are placed in order, so earlier
+// ones take precedence, each at the first free candidate position around
+// its anchor, trying the eight compass sides at one to Rings times
+// Distance. Without a free position the least overlapping candidate is
+// used, or with Drop the label is hidden. Labels are kept inside Bounds
+// where they fit.
+func PlaceLabels(labels []LabelBox, opts PlaceOptions) []Placement {
+	dist := opts.Distance
+	if dist <= 0 {
+		dist = 4
+	}
+	rings := opts.Rings
+	if rings <= 0 {
+		rings = 3
+	}
+
+	out := make([]Placement, len(labels))
+	var placed []geom.Rect
+	for i, l := range labels {
+		best, bestCost := geom.Rect{}, math.Inf(1)
+	search:
+		for ring := 1; ring <= rings; ring++ {
+			for _, d := range labelDirections {
+				r := labelRect(l, d, dist*float64(ring))
+				cost := placementCost(r.Inflate(opts.Pad, opts.Pad), placed, labels, i, opts.Bounds)
+				if cost < bestCost {
+					best, bestCost = r, cost
+				}
+				if cost == 0 {
+					break search
+				}
+			}
+		}
+		out[i] = Placement{Rect: best, Visible: bestCost == 0 || !opts.Drop}
+		if out[i].Visible {
+			placed = append(placed, best.Inflate(opts.Pad, opts.Pad))
+		}
+	}
+	return out
+}
+
+// labelRect returns the box of l on side d of its anchor at distance dist.
+// On a diagonal the nearest corner sits at the offset; on an axis the
+// label is centered on it.
+func labelRect(l LabelBox, d geom.Pt, dist float64) geom.Rect {
+	x := l.Anchor.X + d.X*dist + (d.X-1)*l.W/2
+	y := l.Anchor.Y + d.Y*dist + (d.Y-1)*l.H/2
+	return geom.Rect{Min: geom.Pt{X: x, Y: y}, Max: geom.Pt{X: x + l.W, Y: y + l.H}}
+}
+
+// placementCost is the area of r covering placed labels or lying outside
+// bounds, plus a penalty for every other label's anchor it covers.
+func placementCost(r geom.Rect, placed []geom.Rect, labels []LabelBox, self int, bounds geom.Rect) float64 {
+	var cost float64
+	for _, p := range placed {
+		cost += rectArea(r.Intersect(p))
+	}
+	if bounds.W() > 0 && bounds.H() > 0 {
+		cost += rectArea(r) - rectArea(r.Intersect(bounds))
+	}
+	for j, l := range labels {
+		if j != self && r.Contains(l.Anchor) {
+			cost += rectArea(r) / 4
+		}
+	}
+	return cost
+}
+
+func rectArea(r geom.Rect) float64 { return r.W() * r.H() }
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestPlaceLabels_NoOverlap(t *testing.T) {
+	// Four labels on nearby points would all go to the upper right.
+	var labels []LabelBox
+	for i := 0; i < 4; i++ {
+		labels = append(labels, LabelBox{Anchor: geom.Pt{X: 100 + float64(i)*5, Y: 100}, W: 30, H: 10})
+	}
+	got := PlaceLabels(labels, PlaceOptions{})
+	for i := range got {
+		if !got[i].Visible {
+			t.Fatalf("label %d hidden without Drop", i)
+		}
+		for j := 0; j < i; j++ {
+			if rectArea(got[i].Rect.Intersect(got[j].Rect)) > 0 {
+				t.Errorf("labels %d and %d overlap: %v, %v", j, i, got[j].Rect, got[i].Rect)
+			}
+		}
+	}
+	// The first label keeps the preferred upper right position.
+	if want := (geom.Rect{Min: geom.Pt{X: 104, Y: 86}, Max: geom.Pt{X: 134, Y: 96}}); got[0].Rect != want {
+		t.Errorf("first label at %v, want %v", got[0].Rect, want)
+	}
+}
+
+func TestPlaceLabels_Bounds(t *testing.T) {
+	bounds := geom.Rect{Max: geom.Pt{X: 100, Y: 100}}
+	got := PlaceLabels([]LabelBox{{Anchor: geom.Pt{X: 95, Y: 5}, W: 30, H: 10}}, PlaceOptions{Bounds: bounds})
+	r := got[0].Rect
+	if r.Min.X < 0 || r.Max.X > 100 || r.Min.Y < 0 || r.Max.Y > 100 {
+		t.Errorf("label at %v leaves the bounds", r)
+	}
+}
+
+func TestPlaceLabels_Drop(t *testing.T) {
+	bounds := geom.Rect{Max: geom.Pt{X: 40, Y: 40}}
+	var labels []LabelBox
+	for i := 0; i < 10; i++ {
+		labels = append(labels, LabelBox{Anchor: geom.Pt{X: 20, Y: 20}, W: 15, H: 8})
+	}
+	got := PlaceLabels(labels, PlaceOptions{Bounds: bounds, Drop: true})
+	visible := 0
+	for _, p := range got {
+		if p.Visible {
+			visible++
+		}
+	}
+	if !got[0].Visible || visible == len(labels) {
+		t.Errorf("%d of %d labels visible, want the first and not all", visible, len(labels))
+	}
+}
@@ -1,41 +1,63 @@
 package core
 
 import (
+	"errors"
+
 	"matplotlib-go/internal/geom"
 	"matplotlib-go/render"
 )
 
 // PlotOptions holds optional parameters for plotting functions.
 type PlotOptions struct {
-	Color      *render.Color // if nil, uses automatic color cycling
-	LineWidth  *float64      // if nil, uses default
-	Dashes     []float64     // dash pattern
-	Label      string        // series label for legend
-	Alpha      *float64      // alpha transparency
+	Color     *render.Color  // if nil, uses automatic color cycling
+	LineWidth *float64       // if nil, uses default
+	Dashes    []float64      // dash pattern
+	LineStyle string         // named dash pattern scaled by the line width, e.g. "--", see LineStyleDashes; Dashes wins
+	DashCap   render.DashCap // cap of the dash ends, separate from the line's end caps
+	Label     string         // series label for legend
+	Alpha     *float64       // alpha transparency
 }
 
 // Plot creates a line plot with automatic color cycling if no color is specified.
-func (a *Axes) Plot(x, y []float64, opts ...PlotOptions) *Line2D {
+// If x and y differ in length, the extra values of the longer slice are
+// ignored; in strict mode they are rejected.
+func (a *Axes) Plot(x, y []float64, opts ...PlotOption) *Line2D {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(checkLengths("Plot", "x,y", x, y), opt.check("Plot")) {
+		return nil
+	}
 	if len(x) == 0 || len(y) == 0 {
 		return nil
 	}
 
-	// Create points
-	points := make([]geom.Pt, len(x))
-	n := len(x)
-	if len(y) < n {
-		n = len(y)
-	}
+	// Create points; extra values of the longer slice are ignored
+	n := min(len(x), len(y))
+	points := make([]geom.Pt, n)
 	for i := 0; i < n; i++ {
 		points[i] = geom.Pt{X: x[i], Y: y[i]}
 	}
+	line := a.newLine(opt)
+	line.XY = points
+	a.Add(line)
+	return line
+}
 
-	// Default options
-	var opt PlotOptions
-	if len(opts) > 0 {
-		opt = opts[0]
+// PlotData creates a line plot reading its points from data on every draw
+// instead of copying them, e.g. a PackedXY over a memory-mapped file.
+// Options are as for Plot.
+func (a *Axes) PlotData(data Points, opts ...PlotOption) *Line2D {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(opt.check("PlotData")) || data == nil || data.Len() == 0 {
+		return nil
 	}
+	line := a.newLine(opt)
+	line.Data = data
+	a.Add(line)
+	return line
+}
 
+// newLine returns a line without points styled by opt.
+func (a *Axes) newLine(opt PlotOptions) *Line2D {
 	// Get color (automatic cycling if not specified)
 	color := a.NextColor()
 	if opt.Color != nil {
@@ -43,62 +65,99 @@ func (a *Axes) Plot(x, y []float64, opts ...PlotOptions) *Line2D {
 	}
 
 	// Get line width
-	lineWidth := 2.0
+	lineWidth := a.rc().LineWidth
+	if lineWidth <= 0 {
+		lineWidth = 2
+	}
 	if opt.LineWidth != nil {
 		lineWidth = *opt.LineWidth
 	}
 
 	// Create line
 	line := &Line2D{
-		XY:     points,
-		W:      lineWidth,
-		Col:    color,
-		Dashes: opt.Dashes,
-		Label:  opt.Label,
+		W:       lineWidth,
+		Col:     color,
+		Dashes:  opt.dashes(lineWidth),
+		DashCap: opt.DashCap,
+		Label:   opt.Label,
 	}
 
 	// Apply alpha if specified
 	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
 		line.Col.A = *opt.Alpha
 	}
-
-	a.Add(line)
 	return line
 }
 
 // ScatterOptions holds optional parameters for scatter plots.
 type ScatterOptions struct {
-	Color      *render.Color // if nil, uses automatic color cycling
-	Size       *float64      // marker size
-	Marker     *MarkerType   // marker type
-	EdgeColor  *render.Color // edge color
-	EdgeWidth  *float64      // edge width
-	Alpha      *float64      // alpha transparency
-	Label      string        // series label for legend
+	Color     *render.Color // if nil, uses automatic color cycling
+	Size      *float64      // marker size
+	Marker    *MarkerType   // marker type
+	EdgeColor *render.Color // edge color
+	EdgeWidth *float64      // edge width
+	Alpha     *float64      // alpha transparency
+	Angles    []float64     // per-point marker rotation in degrees counterclockwise
+	DataSize  bool          // Size is a radius in x data units instead of pixels
+	Label     string        // series label for legend
 }
 
 // Scatter creates a scatter plot with automatic color cycling if no color is specified.
-func (a *Axes) Scatter(x, y []float64, opts ...ScatterOptions) *Scatter2D {
+// If x and y differ in length, the extra values of the longer slice are
+// ignored; in strict mode they are rejected.
+func (a *Axes) Scatter(x, y []float64, opts ...ScatterOption) *Scatter2D {
+	opt := resolveScatterOptions(opts)
+	if !a.validate(checkLengths("Scatter", "x,y", x, y),
+		checkColor("Scatter", "color", opt.Color), checkColor("Scatter", "edge color", opt.EdgeColor),
+		checkWidth("Scatter", "size", opt.Size), checkWidth("Scatter", "edge width", opt.EdgeWidth),
+		checkAlpha("Scatter", opt.Alpha), checkAngles("Scatter", x, opt.Angles)) {
+		return nil
+	}
 	if len(x) == 0 || len(y) == 0 {
 		return nil
 	}
 
-	// Create points
-	points := make([]geom.Pt, len(x))
-	n := len(x)
-	if len(y) < n {
-		n = len(y)
-	}
+	// Create points; extra values of the longer slice are ignored
+	n := min(len(x), len(y))
+	points := make([]geom.Pt, n)
 	for i := 0; i < n; i++ {
 		points[i] = geom.Pt{X: x[i], Y: y[i]}
 	}
+	scatter := a.newScatter(opt)
+	scatter.XY = points
+	a.Add(scatter)
+	return scatter
+}
 
-	// Default options
-	var opt ScatterOptions
-	if len(opts) > 0 {
-		opt = opts[0]
+// ScatterData creates a scatter plot reading its points from data on every
+// draw instead of copying them, e.g. a PackedXY over a memory-mapped file.
+// Options are as for Scatter.
+func (a *Axes) ScatterData(data Points, opts ...ScatterOption) *Scatter2D {
+	opt := resolveScatterOptions(opts)
+	n := 0
+	if data != nil {
+		n = data.Len()
+	}
+	var angles error
+	if opt.Angles != nil && len(opt.Angles) != n {
+		angles = invalid("ScatterData", "data has %d points, angles has %d", n, len(opt.Angles))
+	}
+	if !a.validate(checkColor("ScatterData", "color", opt.Color), checkColor("ScatterData", "edge color", opt.EdgeColor),
+		checkWidth("ScatterData", "size", opt.Size), checkWidth("ScatterData", "edge width", opt.EdgeWidth),
+		checkAlpha("ScatterData", opt.Alpha), angles) {
+		return nil
 	}
+	if n == 0 {
+		return nil
+	}
+	scatter := a.newScatter(opt)
+	scatter.Data = data
+	a.Add(scatter)
+	return scatter
+}
 
+// newScatter returns a scatter without points styled by opt.
+func (a *Axes) newScatter(opt ScatterOptions) *Scatter2D {
 	// Get color (automatic cycling if not specified)
 	color := a.NextColor()
 	if opt.Color != nil {
@@ -106,7 +165,10 @@ func (a *Axes) Scatter(x, y []float64, opts ...ScatterOptions) *Scatter2D {
 	}
 
 	// Get size
-	size := 8.0
+	size := a.rc().MarkerSize
+	if size <= 0 {
+		size = 8
+	}
 	if opt.Size != nil {
 		size = *opt.Size
 	}
@@ -136,17 +198,16 @@ func (a *Axes) Scatter(x, y []float64, opts ...ScatterOptions) *Scatter2D {
 
 	// Create scatter
 	scatter := &Scatter2D{
-		XY:        points,
 		Size:      size,
 		Color:     color,
 		EdgeColor: edgeColor,
 		EdgeWidth: edgeWidth,
 		Alpha:     alpha,
 		Marker:    marker,
+		Angles:    opt.Angles,
+		DataSize:  opt.DataSize,
 		Label:     opt.Label,
 	}
-
-	a.Add(scatter)
 	return scatter
 }
 
@@ -163,15 +224,16 @@ type BarOptions struct {
 }
 
 // Bar creates a bar plot with automatic color cycling if no color is specified.
-func (a *Axes) Bar(x, heights []float64, opts ...BarOptions) *Bar2D {
-	if len(x) == 0 || len(heights) == 0 {
+func (a *Axes) Bar(x, heights []float64, opts ...BarOption) *Bar2D {
+	opt := resolveBarOptions(opts)
+	if !a.validate(checkLengths("Bar", "x,heights", x, heights),
+		checkColor("Bar", "color", opt.Color), checkColor("Bar", "edge color", opt.EdgeColor),
+		checkWidth("Bar", "width", opt.Width), checkWidth("Bar", "edge width", opt.EdgeWidth),
+		checkAlpha("Bar", opt.Alpha)) {
 		return nil
 	}
-
-	// Default options
-	var opt BarOptions
-	if len(opts) > 0 {
-		opt = opts[0]
+	if len(x) == 0 || len(heights) == 0 {
+		return nil
 	}
 
 	// Get color (automatic cycling if not specified)
@@ -244,15 +306,13 @@ type FillOptions struct {
 }
 
 // FillBetweenPlot creates a fill between two curves with automatic color cycling.
-func (a *Axes) FillBetweenPlot(x, y1, y2 []float64, opts ...FillOptions) *Fill2D {
-	if len(x) == 0 || len(y1) == 0 || len(y2) == 0 {
+func (a *Axes) FillBetweenPlot(x, y1, y2 []float64, opts ...FillOption) *Fill2D {
+	opt := resolveFillOptions(opts)
+	if !a.validate(checkLengths("FillBetweenPlot", "x,y1,y2", x, y1, y2), opt.check("FillBetweenPlot")) {
 		return nil
 	}
-
-	// Default options
-	var opt FillOptions
-	if len(opts) > 0 {
-		opt = opts[0]
+	if len(x) == 0 || len(y1) == 0 || len(y2) == 0 {
+		return nil
 	}
 
 	// Get color (automatic cycling if not specified)
@@ -295,15 +355,13 @@ func (a *Axes) FillBetweenPlot(x, y1, y2 []float64, opts ...FillOptions) *Fill2D
 }
 
 // FillToBaselinePlot creates a fill from a curve to baseline with automatic color cycling.
-func (a *Axes) FillToBaselinePlot(x, y []float64, opts ...FillOptions) *Fill2D {
-	if len(x) == 0 || len(y) == 0 {
+func (a *Axes) FillToBaselinePlot(x, y []float64, opts ...FillOption) *Fill2D {
+	opt := resolveFillOptions(opts)
+	if !a.validate(checkLengths("FillToBaselinePlot", "x,y", x, y), opt.check("FillToBaselinePlot")) {
 		return nil
 	}
-
-	// Default options
-	var opt FillOptions
-	if len(opts) > 0 {
-		opt = opts[0]
+	if len(x) == 0 || len(y) == 0 {
+		return nil
 	}
 
 	// Get color (automatic cycling if not specified)
@@ -350,4 +408,102 @@ func (a *Axes) FillToBaselinePlot(x, y []float64, opts ...FillOptions) *Fill2D {
 
 	a.Add(fill)
 	return fill
-}
\ No newline at end of file
+}
+
+// eventLineLength is the height of event ticks in y data units, leaving a
+// gap between neighbouring rows.
+const eventLineLength = 0.8
+
+// EventPlot draws a short vertical tick at each event time, with row i of
+// rows centered on y=i, e.g. for spike rasters or log timelines. All ticks
+// form one LineCollection in a single color (automatic cycling if not
+// specified); Dashes, LineWidth (default 1), Alpha and Label apply as in
+// Plot.
+func (a *Axes) EventPlot(rows [][]float64, opts ...PlotOption) *LineCollection {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(opt.check("EventPlot")) {
+		return nil
+	}
+
+	color := a.NextColor()
+	if opt.Color != nil {
+		color = *opt.Color
+	}
+	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
+		color.A = *opt.Alpha
+	}
+	lineWidth := 1.0
+	if opt.LineWidth != nil {
+		lineWidth = *opt.LineWidth
+	}
+
+	var n int
+	for _, row := range rows {
+		n += len(row)
+	}
+	segs := make([][]geom.Pt, 0, n)
+	pts := make([]geom.Pt, 0, 2*n) // one backing array for all segments
+	for i, row := range rows {
+		y0, y1 := float64(i)-eventLineLength/2, float64(i)+eventLineLength/2
+		for _, t := range row {
+			pts = append(pts, geom.Pt{X: t, Y: y0}, geom.Pt{X: t, Y: y1})
+			segs = append(segs, pts[len(pts)-2:len(pts):len(pts)])
+		}
+	}
+
+	lc := &LineCollection{
+		Segments: segs,
+		Color:    color,
+		W:        lineWidth,
+		Dashes:   opt.dashes(lineWidth),
+		DashCap:  opt.DashCap,
+		Label:    opt.Label,
+	}
+	a.Add(lc)
+	return lc
+}
+
+// bandAlpha is the opacity of PlotWithBand's band relative to its line.
+const bandAlpha = 0.25
+
+// PlotWithBand draws y over x as a line with a translucent band from lower
+// to upper behind it, e.g. a mean with its confidence interval. The band
+// takes the line's color (automatic cycling if not specified) at a quarter
+// of its opacity and has no legend entry of its own; opts configure the
+// line as in Plot.
+func (a *Axes) PlotWithBand(x, y, lower, upper []float64, opts ...PlotOption) (*Line2D, *Fill2D) {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(checkLengths("PlotWithBand", "x,y,lower,upper", x, y, lower, upper), opt.check("PlotWithBand")) {
+		return nil, nil
+	}
+	if len(x) == 0 || len(y) == 0 || len(lower) == 0 || len(upper) == 0 {
+		return nil, nil
+	}
+	if opt.Color == nil {
+		color := a.NextColor()
+		opt.Color = &color
+	}
+
+	// The band is added first so that it is drawn below the line.
+	bandColor := *opt.Color
+	if opt.Alpha != nil && *opt.Alpha >= 0 && *opt.Alpha <= 1 {
+		bandColor.A = *opt.Alpha
+	}
+	bandColor.A *= bandAlpha
+	fill := &Fill2D{X: x, Y1: lower, Y2: upper, Color: bandColor}
+	a.Add(fill)
+
+	return a.Plot(x, y, opt), fill
+}
+
+// check validates the styling options of op for strict figures.
+func (o PlotOptions) check(op string) error {
+	return errors.Join(checkColor(op, "color", o.Color), checkWidth(op, "line width", o.LineWidth), checkAlpha(op, o.Alpha),
+		checkLineStyle(op, o.LineStyle))
+}
+
+// check validates the styling options of op for strict figures.
+func (o FillOptions) check(op string) error {
+	return errors.Join(checkColor(op, "color", o.Color), checkColor(op, "edge color", o.EdgeColor),
+		checkWidth(op, "edge width", o.EdgeWidth), checkAlpha(op, o.Alpha))
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"encoding/binary"
+	"math"
+
+	"matplotlib-go/internal/geom"
+)
+
+// Points is a read-only sequence of data points that Line2D and Scatter2D
+// can read instead of an in-memory []geom.Pt, e.g. a memory-mapped file or
+// a database-backed series. Artists call At for indices 0 to Len()-1 on
+// every draw and never keep the points, so large series are not copied.
+type Points interface {
+	Len() int
+	At(i int) geom.Pt
+}
+
+// PointSlice adapts a slice of points to Points.
+type PointSlice []geom.Pt
+
+func (p PointSlice) Len() int         { return len(p) }
+func (p PointSlice) At(i int) geom.Pt { return p[i] }
+
+// XYs adapts separate x and y slices to Points; extra values of the longer
+// slice are ignored.
+type XYs struct{ X, Y []float64 }
+
+func (p XYs) Len() int         { return min(len(p.X), len(p.Y)) }
+func (p XYs) At(i int) geom.Pt { return geom.Pt{X: p.X[i], Y: p.Y[i]} }
+
+// PackedXY reads points from little-endian float64 x, y pairs, 16 bytes per
+// point, e.g. the bytes of a memory-mapped file as returned by syscall.Mmap.
+// A trailing partial point is ignored.
+type PackedXY []byte
+
+func (p PackedXY) Len() int { return len(p) / 16 }
+
+func (p PackedXY) At(i int) geom.Pt {
+	b := p[16*i : 16*i+16]
+	return geom.Pt{
+		X: math.Float64frombits(binary.LittleEndian.Uint64(b)),
+		Y: math.Float64frombits(binary.LittleEndian.Uint64(b[8:])),
+	}
+}
+
+// points returns Data, or XY if Data is nil.
+func (l *Line2D) points() Points {
+	if l.Data != nil {
+		return l.Data
+	}
+	return PointSlice(l.XY)
+}
+
+// points returns Data, or XY if Data is nil.
+func (s *Scatter2D) points() Points {
+	if s.Data != nil {
+		return s.Data
+	}
+	return PointSlice(s.XY)
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestPackedXY(t *testing.T) {
+	want := []geom.Pt{{X: 1, Y: 2}, {X: -3.5, Y: math.Inf(1)}}
+	var b []byte
+	for _, p := range want {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+	}
+	b = append(b, 0, 0, 0) // partial point
+	pts := PackedXY(b)
+	if pts.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", pts.Len())
+	}
+	for i, p := range want {
+		if got := pts.At(i); got != p {
+			t.Errorf("At(%d) = %v, want %v", i, got, p)
+		}
+	}
+	if n := (XYs{X: []float64{1, 2, 3}, Y: []float64{4, 5}}).Len(); n != 2 {
+		t.Errorf("XYs.Len = %d, want the shorter 2", n)
+	}
+}
+
+func TestAxes_PlotData(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	data := XYs{X: []float64{0, 1, 2, math.NaN(), 4}, Y: []float64{1, 3, 2, 0, 5}}
+	line := ax.PlotData(data, WithLabel("stream"))
+	if line == nil || line.XY != nil || line.Label != "stream" {
+		t.Fatalf("PlotData = %+v", line)
+	}
+	sc := ax.ScatterData(PointSlice{{X: -1, Y: 10}})
+	if b := sc.Bounds(nil); !b.Contains(geom.Pt{X: -1, Y: 10}) || b.W() > 1 {
+		t.Errorf("scatter bounds %v, want around (-1, 10)", b)
+	}
+
+	// Lines and scatters read through Data when drawing, autoscaling and
+	// exporting.
+	r := &segmentRecorder{}
+	line.Draw(r, ax.drawContext(fig))
+	if len(r.paths) != 1 || len(r.paths[0].V) != 4 {
+		t.Errorf("drew %d paths, want one with the 4 finite points", len(r.paths))
+	}
+	ax.AutoScale(0)
+	if x0, x1 := ax.XScale.Domain(); x0 != -1 || x1 != 4 {
+		t.Errorf("x limits %v..%v, want -1..4", x0, x1)
+	}
+	sd, _ := seriesSpec(line)
+	if len(sd.X) != 5 || sd.Y[4] != 5 {
+		t.Errorf("spec x %v, y %v", sd.X, sd.Y)
+	}
+
+	fig.Strict = true
+	if ax.ScatterData(data, WithAngles(1, 2)) != nil || fig.Err() == nil {
+		t.Error("angles of the wrong length accepted in strict mode")
+	}
+	if ax.PlotData(nil) != nil {
+		t.Error("nil data plotted")
+	}
+}
@@ -0,0 +1,328 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// Polar turns an Axes into a polar plot: x data is the angle θ in radians
+// and y data the radius, mapped through YScale so that the bottom of the
+// y domain sits at the center and the top on the outer circle. The circle
+// is centered in the axes rectangle.
+type Polar struct {
+	ThetaZero      float64 // screen angle of θ=0 in radians, counterclockwise from east
+	ThetaDirection float64 // 1 for counterclockwise θ, -1 for clockwise
+	Margin         float64 // pixels between the axes edge and the outer circle, for labels
+}
+
+// polarLocations maps compass locations to screen angles.
+var polarLocations = map[string]float64{
+	"E": 0, "NE": math.Pi / 4, "N": math.Pi / 2, "NW": 3 * math.Pi / 4,
+	"W": math.Pi, "SW": 5 * math.Pi / 4, "S": 3 * math.Pi / 2, "SE": 7 * math.Pi / 4,
+}
+
+// AddPolarAxes appends a polar Axes with a radius range of [0,1], θ=0 at
+// east increasing counterclockwise, and a PolarGrid.
+func (f *Figure) AddPolarAxes(r geom.Rect) *Axes {
+	ax := f.AddAxes(r)
+	ax.Polar = &Polar{ThetaDirection: 1, Margin: 24}
+	ax.XScale = transform.NewLinear(0, 2*math.Pi)
+	ax.XAxis, ax.YAxis = nil, nil // replaced by the polar grid
+	ax.Add(NewPolarGrid())
+	return ax
+}
+
+// SetThetaZeroLocation places θ=0 at a compass location ("N", "NE", "E",
+// ..., "NW") of a polar axes, e.g. "N" for clock-like plots.
+func (a *Axes) SetThetaZeroLocation(loc string) error {
+	if a.Polar == nil {
+		return fmt.Errorf("axes is not polar")
+	}
+	angle, ok := polarLocations[loc]
+	if !ok {
+		return fmt.Errorf("unknown theta zero location %q", loc)
+	}
+	a.Polar.ThetaZero = angle
+	return nil
+}
+
+// SetThetaDirection sets whether θ increases counterclockwise (1) or
+// clockwise (-1) on a polar axes.
+func (a *Axes) SetThetaDirection(dir int) error {
+	if a.Polar == nil {
+		return fmt.Errorf("axes is not polar")
+	}
+	if dir != 1 && dir != -1 {
+		return fmt.Errorf("theta direction must be 1 or -1, got %d", dir)
+	}
+	a.Polar.ThetaDirection = float64(dir)
+	return nil
+}
+
+// apply maps (θ, r) data to pixels within the axes rectangle described by
+// the axes->pixel affine.
+func (p *Polar) apply(t *Transform2D, pt geom.Pt) geom.Pt {
+	w, h := math.Abs(t.AxesToPixel.M.A), math.Abs(t.AxesToPixel.M.D)
+	radius := math.Max(math.Min(w, h)/2-p.Margin, 0)
+	dir := p.ThetaDirection
+	if dir == 0 {
+		dir = 1
+	}
+	r := t.YScale.Fwd(pt.Y) * radius
+	sin, cos := math.Sincos(p.ThetaZero + dir*pt.X)
+	// Work in axes units so the affine places the circle.
+	return t.AxesToPixel.Apply(geom.Pt{X: 0.5 + r*cos/w, Y: 0.5 + r*sin/h})
+}
+
+// invert maps pixels back to (θ, r) data, with θ in [0, 2π).
+func (p *Polar) invert(t *Transform2D, px geom.Pt) (geom.Pt, bool) {
+	a, ok := t.AxesToPixel.Invert(px)
+	if !ok {
+		return geom.Pt{}, false
+	}
+	w, h := math.Abs(t.AxesToPixel.M.A), math.Abs(t.AxesToPixel.M.D)
+	radius := math.Max(math.Min(w, h)/2-p.Margin, 0)
+	if radius == 0 {
+		return geom.Pt{}, false
+	}
+	dir := p.ThetaDirection
+	if dir == 0 {
+		dir = 1
+	}
+	dx, dy := (a.X-0.5)*w, (a.Y-0.5)*h
+	r, ok := t.YScale.Inv(math.Hypot(dx, dy) / radius)
+	if !ok {
+		return geom.Pt{}, false
+	}
+	theta := math.Mod(dir*(math.Atan2(dy, dx)-p.ThetaZero), 2*math.Pi)
+	if theta < 0 {
+		theta += 2 * math.Pi
+	}
+	return geom.Pt{X: theta, Y: r}, true
+}
+
+// PolarGrid draws the grid of a polar axes: concentric circles at radius
+// ticks, spokes at fixed angles, the outer circle, and angle and radius
+// labels.
+type PolarGrid struct {
+	Color       render.Color // grid line color
+	LineWidth   float64      // width of grid lines
+	Dashes      []float64    // dash pattern of grid lines, nil for solid
+	EdgeColor   render.Color // outer circle color (0 alpha means none)
+	ThetaStep   float64      // spoke spacing in degrees
+	Formatter   Formatter    // angle labels, nil for DegreeFormatter
+	RLabelAngle float64      // θ in degrees of the spoke the radius labels follow
+	RFormatter  Formatter    // radius labels, nil for ScalarFormatter{Prec: 3}
+	z           float64      // z-order (should be behind data)
+}
+
+// NewPolarGrid creates a polar grid with spokes every 45°.
+func NewPolarGrid() *PolarGrid {
+	return &PolarGrid{
+		Color:       render.Color{R: 0.8, G: 0.8, B: 0.8, A: 1},
+		LineWidth:   0.5,
+		EdgeColor:   render.Color{R: 0, G: 0, B: 0, A: 1},
+		ThetaStep:   45,
+		RLabelAngle: 22.5,
+		z:           -1000,
+	}
+}
+
+// polarCircleSegments is the number of chords approximating a circle.
+const polarCircleSegments = 128
+
+// Draw renders the grid. It draws nothing on non-polar axes.
+func (g *PolarGrid) Draw(r render.Renderer, ctx *DrawContext) {
+	tr := &ctx.DataToPixel
+	if tr.Polar == nil {
+		return
+	}
+	rmin, rmax := tr.YScale.Domain()
+	paint := render.Paint{LineWidth: g.LineWidth, Stroke: g.Color, Dashes: g.Dashes, LineCap: render.CapButt}
+
+	rticks := g.radiusTicks(tr.YScale)
+	for _, rv := range rticks {
+		if rv != rmin && rv != rmax {
+			r.Path(polarCircle(tr, rv), &paint)
+		}
+	}
+	thetas := g.thetaTicks()
+	for _, th := range thetas {
+		var p geom.Path
+		p.MoveTo(tr.Apply(geom.Pt{X: th, Y: rmin}))
+		p.LineTo(tr.Apply(geom.Pt{X: th, Y: rmax}))
+		r.Path(p, &paint)
+	}
+	if g.EdgeColor.A > 0 {
+		r.Path(polarCircle(tr, rmax), &render.Paint{LineWidth: 1, Stroke: g.EdgeColor})
+	}
+
+	textRen, ok := r.(render.TextDrawer)
+	if !ok {
+		return
+	}
+	size := ctx.RC.FontSize
+	if size <= 0 {
+		size = 12
+	}
+	tc := ctx.RC.TextColor
+	col := render.Color{R: tc[0], G: tc[1], B: tc[2], A: tc[3]}
+	center := tr.Apply(geom.Pt{X: 0, Y: rmin})
+
+	thetaFmt := g.Formatter
+	if thetaFmt == nil {
+		thetaFmt = DegreeFormatter{}
+	}
+	for _, th := range thetas {
+		label := thetaFmt.Format(th)
+		edge := tr.Apply(geom.Pt{X: th, Y: rmax})
+		dx, dy := edge.X-center.X, edge.Y-center.Y
+		n := math.Hypot(dx, dy)
+		if n == 0 {
+			continue
+		}
+		m := r.MeasureText(label, size, ctx.RC.FontKey)
+		// Push the label's center out until its box clears the circle.
+		off := labelPad + math.Abs(dx/n)*m.W/2 + math.Abs(dy/n)*m.H/2
+		pos := geom.Pt{X: edge.X + dx/n*off, Y: edge.Y + dy/n*off}
+		textRen.DrawText(label, geom.Pt{X: pos.X - m.W/2, Y: pos.Y + (m.Ascent-m.Descent)/2}, size, col)
+	}
+
+	rFmt := g.RFormatter
+	if rFmt == nil {
+		rFmt = ScalarFormatter{Prec: 3}
+	}
+	rFmt = withRCTypography(rFmt, ctx.RC)
+	rth := g.RLabelAngle * math.Pi / 180
+	for _, rv := range rticks {
+		if rv == rmin {
+			continue
+		}
+		p := tr.Apply(geom.Pt{X: rth, Y: rv})
+		textRen.DrawText(rFmt.Format(rv), geom.Pt{X: p.X + 2, Y: p.Y - 2}, size, col)
+	}
+}
+
+// radiusTicks returns the radius tick values within the y domain.
+func (g *PolarGrid) radiusTicks(s transform.Scale) []float64 {
+	min, max := s.Domain()
+	if min > max {
+		min, max = max, min
+	}
+	var ticks []float64
+	if lg, ok := s.(transform.Log); ok {
+		ticks = LogLocator{Base: lg.Base}.Ticks(min, max, 5)
+	} else {
+		ticks = LinearLocator{}.Ticks(min, max, 5)
+	}
+	out := ticks[:0]
+	for _, v := range ticks {
+		if v >= min && v <= max {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// thetaTicks returns the spoke angles in radians over one turn.
+func (g *PolarGrid) thetaTicks() []float64 {
+	step := g.ThetaStep
+	if step <= 0 {
+		step = 45
+	}
+	var out []float64
+	for d := 0.0; d < 360-1e-9; d += step {
+		out = append(out, d*math.Pi/180)
+	}
+	return out
+}
+
+// polarCircle returns the circle of radius rv as a closed polygon.
+func polarCircle(tr *Transform2D, rv float64) geom.Path {
+	var p geom.Path
+	for i := 0; i < polarCircleSegments; i++ {
+		pt := tr.Apply(geom.Pt{X: 2 * math.Pi * float64(i) / polarCircleSegments, Y: rv})
+		if i == 0 {
+			p.MoveTo(pt)
+		} else {
+			p.LineTo(pt)
+		}
+	}
+	p.Close()
+	return p
+}
+
+// Z returns the z-order for sorting.
+func (g *PolarGrid) Z() float64 {
+	return g.z
+}
+
+// Bounds returns an empty rect for now.
+func (g *PolarGrid) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
+
+// DegreeFormatter formats angles given in radians as degrees in [0, 360),
+// e.g. "90°". Symbol replaces "°" for fonts without the glyph, e.g.
+// " deg"; "-" means no symbol.
+type DegreeFormatter struct {
+	Prec   int
+	Symbol string
+}
+
+func (f DegreeFormatter) Format(x float64) string {
+	sym := f.Symbol
+	switch sym {
+	case "":
+		sym = "°"
+	case "-":
+		sym = ""
+	}
+	d := math.Mod(x*180/math.Pi, 360)
+	if d < 0 {
+		d += 360
+	}
+	if s := (ScalarFormatter{Prec: f.Prec}).Format(d); s != "360" {
+		return s + sym
+	}
+	return "0" + sym
+}
+
+// RadianFormatter formats angles as fractions of π such as "π/2" or
+// "3π/4", falling back to decimals for other values. Symbol replaces "π"
+// for fonts without the glyph, e.g. "pi".
+type RadianFormatter struct{ Symbol string }
+
+func (f RadianFormatter) Format(x float64) string {
+	sym := f.Symbol
+	if sym == "" {
+		sym = "π"
+	}
+	q := x / math.Pi
+	for _, den := range []int{1, 2, 3, 4, 6, 8, 12} {
+		num := math.Round(q * float64(den))
+		if math.Abs(q*float64(den)-num) > 1e-9 {
+			continue
+		}
+		n := int(num)
+		switch {
+		case n == 0:
+			return "0"
+		case n == 1:
+		case n == -1:
+			sym = "-" + sym
+		default:
+			sym = strconv.Itoa(n) + sym
+		}
+		if den == 1 {
+			return sym
+		}
+		return sym + "/" + strconv.Itoa(den)
+	}
+	return ScalarFormatter{Prec: 3}.Format(x)
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestPolarTransform(t *testing.T) {
+	fig := NewFigure(400, 200)
+	ax := fig.AddPolarAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Polar.Margin = 10
+	ax.SetYLim(0, 2)
+	// The circle is centered at (200, 100) with radius 100-10.
+
+	tests := []struct {
+		zero  string
+		dir   int
+		theta float64
+		want  geom.Pt
+	}{
+		{"E", 1, 0, geom.Pt{X: 290, Y: 100}},
+		{"E", 1, math.Pi / 2, geom.Pt{X: 200, Y: 10}},
+		{"N", 1, 0, geom.Pt{X: 200, Y: 10}},
+		{"N", 1, math.Pi / 2, geom.Pt{X: 110, Y: 100}},
+		{"N", -1, math.Pi / 2, geom.Pt{X: 290, Y: 100}},
+		{"SW", 1, 0, geom.Pt{X: 200 - 90/math.Sqrt2, Y: 100 + 90/math.Sqrt2}},
+	}
+	for _, tc := range tests {
+		if err := ax.SetThetaZeroLocation(tc.zero); err != nil {
+			t.Fatal(err)
+		}
+		if err := ax.SetThetaDirection(tc.dir); err != nil {
+			t.Fatal(err)
+		}
+		ctx := ax.drawContext(fig)
+		got := ctx.DataToPixel.Apply(geom.Pt{X: tc.theta, Y: 2})
+		if math.Abs(got.X-tc.want.X) > 1e-9 || math.Abs(got.Y-tc.want.Y) > 1e-9 {
+			t.Errorf("zero %s dir %d θ=%v: got %+v, want %+v", tc.zero, tc.dir, tc.theta, got, tc.want)
+		}
+		if back, ok := ctx.DataToPixel.Invert(got); !ok || math.Abs(back.X-tc.theta) > 1e-9 || math.Abs(back.Y-2) > 1e-9 {
+			t.Errorf("zero %s dir %d θ=%v: Invert gives %+v, %v", tc.zero, tc.dir, tc.theta, back, ok)
+		}
+		if c := ctx.DataToPixel.Apply(geom.Pt{X: tc.theta, Y: 0}); c != (geom.Pt{X: 200, Y: 100}) {
+			t.Errorf("r=0 maps to %+v, want the center", c)
+		}
+	}
+}
+
+func TestPolarSettersErrors(t *testing.T) {
+	fig := NewFigure(100, 100)
+	cart := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	if cart.SetThetaZeroLocation("N") == nil || cart.SetThetaDirection(1) == nil {
+		t.Error("polar setters accepted a cartesian axes")
+	}
+	polar := fig.AddPolarAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	if polar.SetThetaZeroLocation("up") == nil {
+		t.Error("unknown location accepted")
+	}
+	if polar.SetThetaDirection(0) == nil {
+		t.Error("direction 0 accepted")
+	}
+}
+
+func TestPolarGridDraw(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddPolarAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetYLim(0, 1)
+	grid := ax.Artists[0].(*PolarGrid)
+
+	r := &pathRecorder{}
+	grid.Draw(r, ax.drawContext(fig))
+	// Circles at 0.2..0.8, 8 spokes, and the outer circle.
+	if got, want := len(r.paints), 4+8+1; got != want {
+		t.Errorf("got %d paths, want %d", got, want)
+	}
+
+	// Cartesian axes draw nothing.
+	r.paints = nil
+	cart := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	grid.Draw(r, cart.drawContext(fig))
+	if len(r.paints) != 0 {
+		t.Errorf("got %d paths on cartesian axes", len(r.paints))
+	}
+}
+
+func TestAngleFormatters(t *testing.T) {
+	deg := DegreeFormatter{}
+	for x, want := range map[float64]string{0: "0°", math.Pi / 2: "90°", 2 * math.Pi: "0°", -math.Pi / 4: "315°", 0.1: "6°"} {
+		if got := deg.Format(x); got != want {
+			t.Errorf("DegreeFormatter(%v) = %q, want %q", x, got, want)
+		}
+	}
+	if got := (DegreeFormatter{Prec: 1, Symbol: "-"}).Format(0.1); got != "5.7" {
+		t.Errorf("DegreeFormatter without symbol = %q, want 5.7", got)
+	}
+
+	rad := RadianFormatter{}
+	for x, want := range map[float64]string{
+		0: "0", math.Pi: "π", math.Pi / 2: "π/2", 3 * math.Pi / 4: "3π/4",
+		2 * math.Pi: "2π", -math.Pi / 6: "-π/6", 1: "1",
+	} {
+		if got := rad.Format(x); got != want {
+			t.Errorf("RadianFormatter(%v) = %q, want %q", x, got, want)
+		}
+	}
+	if got := (RadianFormatter{Symbol: "pi"}).Format(math.Pi / 3); got != "pi/3" {
+		t.Errorf("RadianFormatter with symbol = %q, want pi/3", got)
+	}
+}
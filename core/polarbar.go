@@ -0,0 +1,81 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// PolarBars draws bars on polar axes as annular wedges: bar i spans the
+// angle Theta[i]±Width/2 and the radius Bottom[i] to Bottom[i]+Heights[i].
+// Stacking works by passing the previous layer's tops as Bottom.
+type PolarBars struct {
+	Theta     []float64    // bar centers in radians
+	Heights   []float64    // radial extent of each bar
+	Bottom    []float64    // radial start of each bar, nil for the radius domain minimum
+	Width     float64      // angular width in radians
+	Color     render.Color // fill color
+	EdgeColor render.Color // outline color (0 alpha means none)
+	EdgeWidth float64      // outline width in pixels
+	Label     string       // series label for legend
+	z         float64      // z-order
+}
+
+// polarArcStep is the maximum angle in radians between arc vertices.
+const polarArcStep = math.Pi / 90
+
+// Draw renders one wedge per bar.
+func (b *PolarBars) Draw(r render.Renderer, ctx *DrawContext) {
+	paint := render.Paint{Fill: b.Color}
+	if b.EdgeWidth > 0 && b.EdgeColor.A > 0 {
+		paint.Stroke = b.EdgeColor
+		paint.LineWidth = b.EdgeWidth
+		paint.LineJoin = render.JoinMiter
+	}
+	base, _ := ctx.DataToPixel.YScale.Domain()
+	for i, th := range b.Theta {
+		if i >= len(b.Heights) || b.Heights[i] == 0 {
+			continue
+		}
+		r0 := base
+		if i < len(b.Bottom) {
+			r0 = b.Bottom[i]
+		}
+		r.Path(wedgePath(&ctx.DataToPixel, th-b.Width/2, th+b.Width/2, r0, r0+b.Heights[i]), &paint)
+	}
+}
+
+// wedgePath returns the closed annular sector between the angles t0 < t1
+// and the radii r0, r1 in data units.
+func wedgePath(tr *Transform2D, t0, t1, r0, r1 float64) geom.Path {
+	n := int(math.Ceil((t1-t0)/polarArcStep)) + 1
+	var p geom.Path
+	for i := 0; i <= n; i++ {
+		pt := tr.Apply(geom.Pt{X: t0 + (t1-t0)*float64(i)/float64(n), Y: r1})
+		if i == 0 {
+			p.MoveTo(pt)
+		} else {
+			p.LineTo(pt)
+		}
+	}
+	for i := n; i >= 0; i-- {
+		p.LineTo(tr.Apply(geom.Pt{X: t0 + (t1-t0)*float64(i)/float64(n), Y: r0}))
+	}
+	p.Close()
+	return p
+}
+
+// Z returns the z-order for sorting.
+func (b *PolarBars) Z() float64 {
+	return b.z
+}
+
+// Bounds returns an empty rect for now.
+func (b *PolarBars) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
+
+func (b *PolarBars) legendHandle() (string, LegendHandler) {
+	return b.Label, PatchLegendHandler(b.Color, b.EdgeColor, b.EdgeWidth)
+}
@@ -0,0 +1,316 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Profiler is a Renderer wrapper that measures where drawing time goes. It
+// forwards every call to the wrapped renderer and records its wall time and
+// vertex count under the artist being drawn, using the groups DrawFigure
+// opens per axes and artist:
+//
+//	p := core.NewProfiler(r)
+//	core.DrawFigure(fig, p)
+//	p.WriteFolded(os.Stdout) // input for flamegraph.pl or speedscope
+//
+// Repeated draws accumulate until Reset. The optional renderer extensions
+// are forwarded when the wrapped renderer implements them and ignored
+// otherwise.
+type Profiler struct {
+	r     render.Renderer
+	root  *profNode
+	stack []*profNode
+	open  []time.Time // start times of the groups on stack
+	began time.Time   // start of the current drawing session
+}
+
+// profNode is a frame of the profile: a group or a renderer verb.
+type profNode struct {
+	name     string
+	kind     string
+	time     time.Duration // inclusive wall time
+	calls    int
+	vertices int
+	children []*profNode
+}
+
+// ProfileStat is the accumulated cost of one artist, axis or renderer verb.
+type ProfileStat struct {
+	Name     string        // group id, e.g. "axes0-line2d1", or verb for renderer calls
+	Kind     string        // group class, e.g. "line2d", or "call" for renderer calls
+	Time     time.Duration // wall time including nested calls
+	Calls    int           // renderer calls made
+	Vertices int           // path vertices and glyphs sent to the renderer
+}
+
+var (
+	_ render.Renderer          = (*Profiler)(nil)
+	_ render.TextDrawer        = (*Profiler)(nil)
+	_ render.RotatedTextDrawer = (*Profiler)(nil)
+	_ render.Grouper           = (*Profiler)(nil)
+	_ render.Titler            = (*Profiler)(nil)
+	_ render.Describer         = (*Profiler)(nil)
+	_ render.VectorOutput      = (*Profiler)(nil)
+)
+
+// NewProfiler returns a profiler drawing into r.
+func NewProfiler(r render.Renderer) *Profiler {
+	p := &Profiler{r: r}
+	p.Reset()
+	return p
+}
+
+// Reset discards the recorded profile.
+func (p *Profiler) Reset() {
+	p.root = &profNode{name: "figure"}
+	p.stack = []*profNode{p.root}
+	p.open = nil
+}
+
+// child returns the frame name below the current one, creating it if needed.
+func (p *Profiler) child(name, kind string) *profNode {
+	top := p.stack[len(p.stack)-1]
+	for _, c := range top.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &profNode{name: name, kind: kind}
+	top.children = append(top.children, c)
+	return c
+}
+
+// record times fn as a call of verb with the given vertex count.
+func (p *Profiler) record(verb string, vertices int, fn func()) {
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	c := p.child(verb, "call")
+	c.time += elapsed
+	c.calls++
+	c.vertices += vertices
+	for _, n := range p.stack {
+		n.calls++
+		n.vertices += vertices
+	}
+}
+
+// Begin starts a drawing session on the wrapped renderer.
+func (p *Profiler) Begin(viewport geom.Rect) error {
+	p.began = time.Now()
+	var err error
+	p.record("Begin", 0, func() { err = p.r.Begin(viewport) })
+	return err
+}
+
+// End ends the drawing session on the wrapped renderer.
+func (p *Profiler) End() error {
+	var err error
+	p.record("End", 0, func() { err = p.r.End() })
+	p.root.time += time.Since(p.began)
+	return err
+}
+
+// Save pushes state.
+func (p *Profiler) Save() { p.record("Save", 0, p.r.Save) }
+
+// Restore pops state.
+func (p *Profiler) Restore() { p.record("Restore", 0, p.r.Restore) }
+
+// ClipRect intersects the clip with a rectangle.
+func (p *Profiler) ClipRect(rect geom.Rect) {
+	p.record("ClipRect", 0, func() { p.r.ClipRect(rect) })
+}
+
+// ClipPath intersects the clip with a path.
+func (p *Profiler) ClipPath(path geom.Path) {
+	p.record("ClipPath", len(path.V), func() { p.r.ClipPath(path) })
+}
+
+// Path draws a path.
+func (p *Profiler) Path(path geom.Path, paint *render.Paint) {
+	p.record("Path", len(path.V), func() { p.r.Path(path, paint) })
+}
+
+// Image draws an image.
+func (p *Profiler) Image(img render.Image, dst geom.Rect) {
+	p.record("Image", 0, func() { p.r.Image(img, dst) })
+}
+
+// GlyphRun draws a run of glyphs.
+func (p *Profiler) GlyphRun(run render.GlyphRun, color render.Color) {
+	p.record("GlyphRun", len(run.Glyphs), func() { p.r.GlyphRun(run, color) })
+}
+
+// MeasureText measures text with the wrapped renderer.
+func (p *Profiler) MeasureText(text string, size float64, fontKey string) render.TextMetrics {
+	var m render.TextMetrics
+	p.record("MeasureText", 0, func() { m = p.r.MeasureText(text, size, fontKey) })
+	return m
+}
+
+// DrawText draws text if the wrapped renderer supports it.
+func (p *Profiler) DrawText(text string, origin geom.Pt, size float64, textColor render.Color) {
+	if td, ok := p.r.(render.TextDrawer); ok {
+		p.record("DrawText", 0, func() { td.DrawText(text, origin, size, textColor) })
+	}
+}
+
+// DrawTextRotated draws rotated text if the wrapped renderer supports it,
+// and upright text otherwise.
+func (p *Profiler) DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor render.Color) {
+	if td, ok := p.r.(render.RotatedTextDrawer); ok {
+		p.record("DrawText", 0, func() { td.DrawTextRotated(text, origin, size, angle, textColor) })
+		return
+	}
+	p.DrawText(text, origin, size, textColor)
+}
+
+// BeginGroup opens a profile frame for the group and forwards it.
+func (p *Profiler) BeginGroup(id, class string) {
+	if g, ok := p.r.(render.Grouper); ok {
+		g.BeginGroup(id, class)
+	}
+	p.stack = append(p.stack, p.child(id, class))
+	p.open = append(p.open, time.Now())
+}
+
+// EndGroup closes the innermost profile frame and forwards it.
+func (p *Profiler) EndGroup() {
+	if len(p.open) > 0 {
+		n := p.stack[len(p.stack)-1]
+		n.time += time.Since(p.open[len(p.open)-1])
+		p.stack = p.stack[:len(p.stack)-1]
+		p.open = p.open[:len(p.open)-1]
+	}
+	if g, ok := p.r.(render.Grouper); ok {
+		g.EndGroup()
+	}
+}
+
+// Title forwards a tooltip if the wrapped renderer supports it.
+func (p *Profiler) Title(text string) {
+	if t, ok := p.r.(render.Titler); ok {
+		t.Title(text)
+	}
+}
+
+// Describe forwards accessibility metadata if the wrapped renderer
+// supports it.
+func (p *Profiler) Describe(role, title, desc string) {
+	if d, ok := p.r.(render.Describer); ok {
+		d.Describe(role, title, desc)
+	}
+}
+
+// VectorOutput reports whether the wrapped renderer produces vector output.
+func (p *Profiler) VectorOutput() bool {
+	v, ok := p.r.(render.VectorOutput)
+	return ok && v.VectorOutput()
+}
+
+// Total returns the wall time of all drawing sessions, from Begin to End.
+func (p *Profiler) Total() time.Duration {
+	return p.root.time
+}
+
+// Stats returns the cost of every group, i.e. of every axes, artist and
+// axis drawn, most expensive first.
+func (p *Profiler) Stats() []ProfileStat {
+	var out []ProfileStat
+	var walk func(n *profNode)
+	walk = func(n *profNode) {
+		for _, c := range n.children {
+			if c.kind == "call" {
+				continue
+			}
+			out = append(out, c.stat())
+			walk(c)
+		}
+	}
+	walk(p.root)
+	sortStats(out)
+	return out
+}
+
+// Calls returns the cost per renderer verb over the whole profile, most
+// expensive first.
+func (p *Profiler) Calls() []ProfileStat {
+	byVerb := map[string]*ProfileStat{}
+	var walk func(n *profNode)
+	walk = func(n *profNode) {
+		for _, c := range n.children {
+			if c.kind != "call" {
+				walk(c)
+				continue
+			}
+			s := byVerb[c.name]
+			if s == nil {
+				s = &ProfileStat{Name: c.name, Kind: c.kind}
+				byVerb[c.name] = s
+			}
+			s.Time += c.time
+			s.Calls += c.calls
+			s.Vertices += c.vertices
+		}
+	}
+	walk(p.root)
+	out := make([]ProfileStat, 0, len(byVerb))
+	for _, s := range byVerb {
+		out = append(out, *s)
+	}
+	sortStats(out)
+	return out
+}
+
+func (n *profNode) stat() ProfileStat {
+	return ProfileStat{Name: n.name, Kind: n.kind, Time: n.time, Calls: n.calls, Vertices: n.vertices}
+}
+
+func sortStats(s []ProfileStat) {
+	sort.SliceStable(s, func(i, j int) bool {
+		if s[i].Time != s[j].Time {
+			return s[i].Time > s[j].Time
+		}
+		return s[i].Name < s[j].Name
+	})
+}
+
+// WriteFolded writes the profile in the folded stack format read by flame
+// graph tools: one line per frame with the frames from the figure down
+// joined by ';', followed by its self time in microseconds. A group's self
+// time is spent outside renderer calls, e.g. computing its geometry.
+//
+//	figure;axes0;axes0-line2d0;Path 1520
+func (p *Profiler) WriteFolded(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	var walk func(n *profNode, stack []string)
+	walk = func(n *profNode, stack []string) {
+		stack = append(stack, n.name)
+		if self := selfTime(n); self > 0 {
+			fmt.Fprintf(bw, "%s %d\n", strings.Join(stack, ";"), self.Microseconds())
+		}
+		for _, c := range n.children {
+			walk(c, stack)
+		}
+	}
+	walk(p.root, nil)
+	return bw.Flush()
+}
+
+// selfTime returns the time of a frame not accounted for by its children.
+func selfTime(n *profNode) time.Duration {
+	self := n.time
+	for _, c := range n.children {
+		self -= c.time
+	}
+	return max(self, 0)
+}
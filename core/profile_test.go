@@ -0,0 +1,64 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// slowRenderer makes every path take a measurable time.
+type slowRenderer struct{ render.NullRenderer }
+
+func (s *slowRenderer) Path(geom.Path, *render.Paint) { time.Sleep(time.Millisecond) }
+
+func TestProfiler(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 0.5, Y: 1}, {X: 1, Y: 0}}, W: 1, Col: render.Color{A: 1}})
+
+	p := NewProfiler(&slowRenderer{})
+	DrawFigure(fig, p)
+
+	var line *ProfileStat
+	for _, s := range p.Stats() {
+		if s.Name == "axes0-line2d0" {
+			line = &s
+		}
+	}
+	if line == nil {
+		t.Fatalf("no stats for the line: %+v", p.Stats())
+	}
+	if line.Kind != "line2d" || line.Calls != 1 || line.Vertices != 3 {
+		t.Errorf("line stats %+v, want 1 call with 3 vertices", *line)
+	}
+	if line.Time < time.Millisecond || p.Total() < line.Time {
+		t.Errorf("line time %v, total %v", line.Time, p.Total())
+	}
+
+	calls := p.Calls()
+	if len(calls) == 0 || calls[0].Name != "Path" || calls[0].Calls != 1 {
+		t.Errorf("calls %+v, want Path first", calls)
+	}
+
+	var b strings.Builder
+	if err := p.WriteFolded(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "figure;axes0;axes0-line2d0;Path ") {
+		t.Errorf("folded output lacks the line's path frame:\n%s", b.String())
+	}
+
+	// Draws accumulate until Reset.
+	DrawFigure(fig, p)
+	if c := p.Calls(); c[0].Calls != 2 {
+		t.Errorf("after two draws Path calls = %d, want 2", c[0].Calls)
+	}
+	p.Reset()
+	if len(p.Stats()) != 0 || p.Total() != 0 {
+		t.Error("Reset kept the profile")
+	}
+}
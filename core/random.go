@@ -0,0 +1,43 @@
+package core
+
+import (
+	"hash/fnv"
+	"math/rand"
+)
+
+// Rand returns the figure's random stream with the given name, seeded from
+// RC.Seed and the name. Randomized helpers such as StripPlot draw from
+// these streams, so a figure built by the same calls renders the same
+// every time, and each helper has its own stream: adding jitter to one
+// plot does not change the random numbers of another. The stream is
+// created on first use and then shared by every caller of the name;
+// changing RC.Seed later does not reseed it.
+func (f *Figure) Rand(name string) *rand.Rand {
+	return namedRand(&f.rngs, f.RC.Seed, name)
+}
+
+// rand returns the named random stream of the axes' figure. Outside a
+// figure the axes keep their own streams, seeded from their RC, so that
+// repeated calls continue a stream as they would in a figure.
+func (a *Axes) rand(name string) *rand.Rand {
+	if a.fig == nil {
+		return namedRand(&a.rngs, a.rc().Seed, name)
+	}
+	return a.fig.Rand(name)
+}
+
+// namedRand returns the stream name of rngs, creating it from seed and the
+// name on first use.
+func namedRand(rngs *map[string]*rand.Rand, seed int64, name string) *rand.Rand {
+	if rng, ok := (*rngs)[name]; ok {
+		return rng
+	}
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	rng := rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+	if *rngs == nil {
+		*rngs = make(map[string]*rand.Rand)
+	}
+	(*rngs)[name] = rng
+	return rng
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/style"
+)
+
+func TestFigureRand(t *testing.T) {
+	draw := func(seed int64, name string) []float64 {
+		fig := NewFigure(100, 100, style.WithSeed(seed))
+		rng := fig.Rand(name)
+		if fig.Rand(name) != rng {
+			t.Fatal("second call returned a new stream")
+		}
+		return []float64{rng.Float64(), rng.Float64()}
+	}
+	a, b := draw(0, "jitter"), draw(0, "jitter")
+	if a[0] != b[0] || a[1] != b[1] {
+		t.Errorf("default seed gave %v and %v", a, b)
+	}
+	if c := draw(7, "jitter"); c[0] == a[0] {
+		t.Error("different seeds gave the same stream")
+	}
+	if d := draw(0, "layout"); d[0] == a[0] {
+		t.Error("different names gave the same stream")
+	}
+
+	// StripPlot without its own seed follows RC.Seed.
+	strip := func(seed int64) float64 {
+		fig := NewFigure(400, 300, style.WithSeed(seed))
+		ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+		return ax.StripPlot([][]float64{{1, 2}}, CategoryOptions{})[0].XY[0].X
+	}
+	if strip(3) != strip(3) {
+		t.Error("strip plot not reproducible with the same figure seed")
+	}
+	if strip(3) == strip(4) {
+		t.Error("strip plot ignores the figure seed")
+	}
+}
+
+func TestAxesRand_Standalone(t *testing.T) {
+	ax := &Axes{}
+	rng := ax.rand("jitter")
+	if ax.rand("jitter") != rng {
+		t.Error("axes outside a figure restarted their stream")
+	}
+	fig := NewFigure(100, 100)
+	if want := fig.Rand("jitter").Float64(); rng.Float64() != want {
+		t.Error("stream outside a figure not seeded like a figure's")
+	}
+}
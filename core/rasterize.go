@@ -0,0 +1,45 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// rasterizable is implemented by artists that can ask to be drawn as a
+// bitmap when the target renderer produces vector output.
+type rasterizable interface {
+	Rasterized() bool
+}
+
+func isRasterized(art Artist) bool {
+	ra, ok := art.(rasterizable)
+	return ok && ra.Rasterized()
+}
+
+// drawRasterized draws art into an offscreen bitmap covering the axes clip
+// rectangle and embeds the result with r.Image. This keeps vector files small
+// for dense artists such as scatter plots with many thousands of points.
+func drawRasterized(r render.Renderer, art Artist, ctx *DrawContext) {
+	clip := ctx.Clip
+	w, h := int(math.Ceil(clip.W())), int(math.Ceil(clip.H()))
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	// Redraw with the axes moved to the bitmap origin.
+	local := *ctx
+	local.Clip = geom.Rect{Max: geom.Pt{X: clip.W(), Y: clip.H()}}
+	local.DataToPixel.AxesToPixel = transform.NewAffine(axesToPixel(local.Clip))
+
+	off := gobasic.New(w, h, render.Color{})
+	_ = off.Begin(geom.Rect{Max: geom.Pt{X: float64(w), Y: float64(h)}})
+	art.Draw(off, &local)
+	_ = off.End()
+
+	dst := geom.Rect{Min: clip.Min, Max: geom.Pt{X: clip.Min.X + float64(w), Y: clip.Min.Y + float64(h)}}
+	r.Image(render.RGBAImage{RGBA: off.GetImage()}, dst)
+}
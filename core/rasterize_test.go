@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// vectorRecorder is a vector renderer that counts paths and embedded images.
+type vectorRecorder struct {
+	render.NullRenderer
+	paths  int
+	images []geom.Rect
+	pixels int
+}
+
+func (v *vectorRecorder) VectorOutput() bool { return true }
+
+func (v *vectorRecorder) Path(_ geom.Path, _ *render.Paint) { v.paths++ }
+
+func (v *vectorRecorder) Image(img render.Image, dst geom.Rect) {
+	v.images = append(v.images, dst)
+	if rgba, ok := img.(render.RGBAImage); ok {
+		for i := 3; i < len(rgba.Pix); i += 4 {
+			if rgba.Pix[i] != 0 {
+				v.pixels++
+			}
+		}
+	}
+}
+
+func TestRasterizedArtistEmbedsImage(t *testing.T) {
+	fig := NewFigure(200, 100)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.Add(&Scatter2D{
+		XY:        []geom.Pt{{X: 0.25, Y: 0.5}, {X: 0.75, Y: 0.5}},
+		Size:      6,
+		Color:     render.Color{R: 1, A: 1},
+		Rasterize: true,
+	})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1, Col: render.Color{A: 1}})
+
+	var r vectorRecorder
+	DrawFigure(fig, &r)
+
+	if r.paths != 1 {
+		t.Errorf("expected only the line as a vector path, got %d paths", r.paths)
+	}
+	if len(r.images) != 1 {
+		t.Fatalf("expected one embedded image, got %d", len(r.images))
+	}
+	want := geom.Rect{Min: geom.Pt{X: 20, Y: 10}, Max: geom.Pt{X: 180, Y: 90}}
+	if r.images[0] != want {
+		t.Errorf("image placed at %v, want %v", r.images[0], want)
+	}
+	if r.pixels == 0 {
+		t.Error("rasterized image is empty")
+	}
+}
+
+func TestRasterizeIgnoredForNonVector(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1, Rasterize: true})
+
+	var r titleRecorder // no VectorOutput
+	DrawFigure(fig, &r)
+	if r.paths != 1 {
+		t.Errorf("expected line drawn directly, got %d paths", r.paths)
+	}
+}
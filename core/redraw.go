@@ -0,0 +1,241 @@
+package core
+
+import (
+	"image"
+	"math"
+	"reflect"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Redrawer keeps a figure rendered in a retained gobasic buffer and, after
+// some artists change, redraws only the pixels they covered before or cover
+// now. It suits live plots and widget interactions where most of a figure is
+// static. Changes to limits, scales, or layout require InvalidateAll.
+type Redrawer struct {
+	fig    *Figure
+	r      *gobasic.Renderer
+	bounds map[Artist]image.Rectangle // pixel bounds from the last Render
+	texts  []image.Rectangle          // text bounds from the last Render
+	dirty  []Artist
+	full   bool
+}
+
+// NewRedrawer creates a Redrawer drawing fig into r. The first Render draws
+// the whole figure.
+func NewRedrawer(fig *Figure, r *gobasic.Renderer) *Redrawer {
+	return &Redrawer{fig: fig, r: r, full: true}
+}
+
+// Invalidate marks artists whose data or style changed, or that were added
+// to or removed from an axes since the last Render.
+func (d *Redrawer) Invalidate(arts ...Artist) {
+	for _, a := range arts {
+		if !trackable(a) {
+			d.full = true // bounds cannot be tracked; fall back to a full redraw
+			continue
+		}
+		d.dirty = append(d.dirty, a)
+	}
+}
+
+// InvalidateAll forces the next Render to redraw the whole figure.
+func (d *Redrawer) InvalidateAll() {
+	d.full = true
+	d.dirty = d.dirty[:0]
+}
+
+// Render brings the buffer up to date and returns the pixel rectangles that
+// were redrawn, e.g. to push only those regions to a display.
+func (d *Redrawer) Render() []image.Rectangle {
+	w, h := d.r.Size()
+	canvas := image.Rect(0, 0, w, h)
+
+	old := d.bounds
+	d.measure()
+	if d.full || old == nil {
+		d.full = false
+		d.dirty = d.dirty[:0]
+		d.r.Clear()
+		DrawFigure(d.fig, d.r)
+		return []image.Rectangle{canvas}
+	}
+
+	var rects []image.Rectangle
+	for _, a := range d.dirty {
+		if rc := old[a].Union(d.bounds[a]).Intersect(canvas); !rc.Empty() {
+			rects = append(rects, rc)
+		}
+	}
+	d.dirty = d.dirty[:0]
+	rects = d.expand(rects)
+
+	for _, rc := range rects {
+		d.r.ClearRect(rc)
+		region := geom.Rect{
+			Min: geom.Pt{X: float64(rc.Min.X), Y: float64(rc.Min.Y)},
+			Max: geom.Pt{X: float64(rc.Max.X), Y: float64(rc.Max.Y)},
+		}
+		drawFigure(d.fig, d.r, &region, func(a Artist) bool {
+			if !trackable(a) {
+				return true
+			}
+			b, ok := d.bounds[a]
+			return !ok || b.Overlaps(rc)
+		})
+	}
+	return rects
+}
+
+// expand merges overlapping rectangles and grows them to cover any text they
+// touch. Text is only drawn when its origin lies inside the clip, so a region
+// must contain every label it cuts through.
+func (d *Redrawer) expand(rects []image.Rectangle) []image.Rectangle {
+	for changed := true; changed; {
+		changed = false
+		for i := 0; i < len(rects); i++ {
+			for _, t := range d.texts {
+				if rects[i].Overlaps(t) && !t.In(rects[i]) {
+					rects[i] = rects[i].Union(t)
+					changed = true
+				}
+			}
+			for j := i + 1; j < len(rects); j++ {
+				if rects[i].Overlaps(rects[j]) {
+					rects[i] = rects[i].Union(rects[j])
+					rects = append(rects[:j], rects[j+1:]...)
+					j--
+					changed = true
+				}
+			}
+		}
+	}
+	return rects
+}
+
+// measure records the current pixel bounds of all artists and text without
+// rasterizing anything.
+func (d *Redrawer) measure() {
+	rec := &boundsRecorder{measure: d.r, fontKey: d.fig.RC.FontKey}
+	d.bounds = make(map[Artist]image.Rectangle)
+	for _, ax := range d.fig.Children {
+		ctx := ax.drawContext(d.fig)
+		for _, art := range ax.Artists {
+			if !trackable(art) {
+				continue
+			}
+			if !ax.Visible(art) {
+				d.bounds[art] = image.Rectangle{}
+				continue
+			}
+			rec.reset()
+			art.Draw(rec, ax.artistContext(ctx, art))
+			d.bounds[art] = rec.rect().Intersect(pixelRect(ctx.Clip))
+		}
+		for _, axis := range ax.AxisList() {
+			if ax.Visible(axis) {
+				axis.Draw(rec, ctx)
+			}
+		}
+	}
+	ctx := d.fig.drawContext()
+	for _, art := range d.fig.Artists {
+		if trackable(art) {
+			rec.reset()
+			art.Draw(rec, ctx)
+			d.bounds[art] = rec.rect()
+		}
+	}
+	d.texts = rec.texts
+}
+
+// trackable reports whether a can be used as a map key. Function-backed
+// artists such as ArtistFunc cannot, and are redrawn in every region.
+func trackable(a Artist) bool {
+	return a != nil && reflect.TypeOf(a).Comparable()
+}
+
+// boundsRecorder is a renderer that only accumulates the pixel extent of
+// what is drawn.
+type boundsRecorder struct {
+	render.NullRenderer
+	measure render.Renderer // answers MeasureText like the real target
+	fontKey string          // font text is drawn in, as drawFigure configures the target
+	cur     geom.Rect
+	has     bool
+	texts   []image.Rectangle
+}
+
+func (b *boundsRecorder) reset() { b.has = false }
+
+func (b *boundsRecorder) add(r geom.Rect) {
+	if !b.has {
+		b.cur, b.has = r, true
+		return
+	}
+	b.cur.Min.X = math.Min(b.cur.Min.X, r.Min.X)
+	b.cur.Min.Y = math.Min(b.cur.Min.Y, r.Min.Y)
+	b.cur.Max.X = math.Max(b.cur.Max.X, r.Max.X)
+	b.cur.Max.Y = math.Max(b.cur.Max.Y, r.Max.Y)
+}
+
+// rect returns the accumulated extent rounded out to whole pixels.
+func (b *boundsRecorder) rect() image.Rectangle {
+	if !b.has {
+		return image.Rectangle{}
+	}
+	return pixelRect(b.cur)
+}
+
+// Path adds the control polygon of p, padded for stroke width, miter joins,
+// and antialiasing.
+func (b *boundsRecorder) Path(p geom.Path, paint *render.Paint) {
+	if paint == nil || len(p.V) == 0 {
+		return
+	}
+	pad := 1.0
+	if paint.Stroke.A > 0 && paint.LineWidth > 0 {
+		limit := 1.5 // square caps
+		if paint.LineJoin == render.JoinMiter {
+			limit = math.Max(paint.MiterLimit, 10)
+		}
+		pad += paint.LineWidth / 2 * limit
+	}
+	for _, v := range p.V {
+		b.add(geom.Rect{Min: v, Max: v}.Inflate(pad, pad))
+	}
+}
+
+// Image adds the destination rectangle.
+func (b *boundsRecorder) Image(_ render.Image, dst geom.Rect) { b.add(dst) }
+
+// MeasureText delegates to the target renderer.
+func (b *boundsRecorder) MeasureText(text string, size float64, fontKey string) render.TextMetrics {
+	return b.measure.MeasureText(text, size, fontKey)
+}
+
+// DrawText adds the measured text box and remembers it for region growth.
+func (b *boundsRecorder) DrawText(text string, origin geom.Pt, size float64, c render.Color) {
+	b.DrawTextRotated(text, origin, size, 0, c)
+}
+
+// DrawTextRotated adds the bounds of the turned text box and remembers them
+// for region growth.
+func (b *boundsRecorder) DrawTextRotated(text string, origin geom.Pt, size, angle float64, _ render.Color) {
+	if text == "" {
+		return
+	}
+	r := b.MeasureText(text, size, b.fontKey).Bounds(origin, angle).Inflate(1, 1)
+	b.add(r)
+	b.texts = append(b.texts, pixelRect(r))
+}
+
+// pixelRect rounds r out to whole pixels.
+func pixelRect(r geom.Rect) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(r.Min.X)), int(math.Floor(r.Min.Y)),
+		int(math.Ceil(r.Max.X)), int(math.Ceil(r.Max.Y)),
+	)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func redrawFigure() (*Figure, *Line2D, *Scatter2D) {
+	fig := NewFigure(200, 150)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.15, Y: 0.15}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(0, 10)
+	ax.SetYLim(0, 10)
+	ax.Add(&Fill2D{X: []float64{0, 10}, Y1: []float64{5, 5}, Color: render.Color{B: 1, A: 0.3}})
+	line := &Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 10, Y: 10}}, W: 2, Col: render.Color{A: 1}}
+	ax.Add(line)
+	pt := &Scatter2D{XY: []geom.Pt{{X: 2, Y: 8}}, Size: 6, Color: render.Color{R: 1, A: 0.8}}
+	ax.Add(pt)
+	return fig, line, pt
+}
+
+func TestRedrawerMatchesFullRender(t *testing.T) {
+	white := render.Color{R: 1, G: 1, B: 1, A: 1}
+	fig, _, pt := redrawFigure()
+	r := gobasic.New(200, 150, white)
+	d := NewRedrawer(fig, r)
+
+	if rects := d.Render(); len(rects) != 1 || rects[0] != r.GetImage().Bounds() {
+		t.Fatalf("first render should cover the canvas, got %v", rects)
+	}
+
+	// Move the marker across the line and the fill.
+	pt.XY[0] = geom.Pt{X: 6, Y: 5}
+	d.Invalidate(pt)
+	rects := d.Render()
+	if len(rects) == 0 {
+		t.Fatal("expected dirty regions")
+	}
+	area := 0
+	for _, rc := range rects {
+		area += rc.Dx() * rc.Dy()
+	}
+	if area >= 200*150/4 {
+		t.Errorf("dirty area %d too large for a single marker move: %v", area, rects)
+	}
+
+	// Rasterizing a clipped region may round antialiased edges differently by
+	// one level; anything larger means stale or missing content.
+	want := gobasic.New(200, 150, white)
+	DrawFigure(fig, want)
+	got, exp := r.GetImage().Pix, want.GetImage().Pix
+	for i := range exp {
+		if d := int(got[i]) - int(exp[i]); d > 2 || d < -2 {
+			t.Fatalf("incremental render differs from a full render at byte %d: %d vs %d", i, got[i], exp[i])
+		}
+	}
+
+	// Nothing dirty: nothing redrawn.
+	if rects := d.Render(); len(rects) != 0 {
+		t.Errorf("expected no regions, got %v", rects)
+	}
+}
+
+func TestRedrawerFallsBackForUntrackableArtists(t *testing.T) {
+	fig, _, _ := redrawFigure()
+	fn := ArtistFunc(func(render.Renderer, *DrawContext) {})
+	fig.Children[0].Add(fn)
+	r := gobasic.New(200, 150, render.Color{R: 1, G: 1, B: 1, A: 1})
+	d := NewRedrawer(fig, r)
+	d.Render()
+
+	d.Invalidate(fn)
+	if rects := d.Render(); len(rects) != 1 || rects[0] != r.GetImage().Bounds() {
+		t.Errorf("expected full redraw, got %v", rects)
+	}
+}
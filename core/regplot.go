@@ -0,0 +1,81 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/stats"
+)
+
+// RegOptions configures RegPlot.
+type RegOptions struct {
+	Lowess bool          // fit a LOWESS curve instead of an OLS line
+	Frac   float64       // LOWESS span as a fraction of the points, 0 for 2/3
+	CI     float64       // confidence level of the band around the OLS line, 0 for 0.95, negative for none
+	Color  *render.Color // points, fit and band color, nil for automatic cycling
+	Label  string        // series label of the points for the legend
+}
+
+// regSamples is the number of points the OLS line and band are drawn with.
+const regSamples = 100
+
+// RegPlot draws the points (x, y) as a scatter with a regression fit over
+// them, like seaborn's regplot: an ordinary least squares line with a
+// band for the confidence interval of its mean, or a LOWESS curve without
+// band. Points with a NaN or infinite coordinate are not fitted. Without
+// a fit, e.g. for fewer than two points, only the scatter is drawn.
+func (a *Axes) RegPlot(x, y []float64, opts RegOptions) (*Scatter2D, *Line2D, *Fill2D) {
+	if !a.validate(checkLengths("RegPlot", "x,y", x, y), checkColor("RegPlot", "color", opts.Color)) {
+		return nil, nil, nil
+	}
+	n := min(len(x), len(y)) // extra values of the longer slice are ignored
+	x, y = x[:n], y[:n]
+	color := a.NextColor()
+	if opts.Color != nil {
+		color = *opts.Color
+	}
+
+	var fx, fy, lower, upper []float64
+	if opts.Lowess {
+		var err error
+		if fx, fy, err = stats.Lowess(x, y, opts.Frac); err != nil {
+			a.validate(invalid("RegPlot", "%v", err))
+		}
+	} else if fit, err := stats.FitLinear(x, y); err != nil {
+		a.validate(invalid("RegPlot", "%v", err))
+	} else {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for i := range x {
+			if finitePt(geom.Pt{X: x[i], Y: y[i]}) {
+				lo, hi = math.Min(lo, x[i]), math.Max(hi, x[i])
+			}
+		}
+		fx, fy = make([]float64, regSamples), make([]float64, regSamples)
+		for i := range fx {
+			fx[i] = lo + (hi-lo)*float64(i)/(regSamples-1)
+			fy[i] = fit.At(fx[i])
+		}
+		if opts.CI >= 0 {
+			level := opts.CI
+			if level == 0 {
+				level = 0.95
+			}
+			lower, upper = make([]float64, regSamples), make([]float64, regSamples)
+			for i, v := range fx {
+				lower[i], upper[i] = fit.Band(v, math.Min(level, 1-1e-9))
+			}
+		}
+	}
+
+	var line *Line2D
+	var band *Fill2D
+	switch {
+	case lower != nil:
+		line, band = a.PlotWithBand(fx, fy, lower, upper, WithColor(color))
+	case fx != nil:
+		line = a.Plot(fx, fy, WithColor(color))
+	}
+	points := a.Scatter(x, y, WithColor(color), WithLabel(opts.Label))
+	return points, line, band
+}
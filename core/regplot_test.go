@@ -0,0 +1,43 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestAxesRegPlot(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	x := []float64{0, 1, 2, 3, 4, math.NaN()}
+	y := []float64{1, 2.5, 5, 7.5, 9, 0}
+	points, line, band := ax.RegPlot(x, y, RegOptions{Label: "data"})
+	if points == nil || line == nil || band == nil {
+		t.Fatalf("got %v, %v, %v", points, line, band)
+	}
+	if points.Label != "data" || len(points.XY) != 6 {
+		t.Errorf("points %+v", points)
+	}
+	// OLS over the finite points: y = 0.8 + 2.1x from x 0 to 4.
+	first, last := line.XY[0], line.XY[len(line.XY)-1]
+	if math.Abs(first.Y-0.8) > 1e-9 || last.X != 4 || math.Abs(last.Y-9.2) > 1e-9 {
+		t.Errorf("fit from %v to %v", first, last)
+	}
+	if !(band.Y1[0] < first.Y && band.Y2[0] > first.Y) {
+		t.Errorf("band %v..%v does not enclose the fit", band.Y1[0], band.Y2[0])
+	}
+	if line.Col != points.Color || band.Color.R != points.Color.R {
+		t.Error("points, fit and band differ in color")
+	}
+
+	// LOWESS draws a curve through the sorted points without a band.
+	_, line, band = ax.RegPlot([]float64{2, 0, 1}, []float64{4, 0, 2}, RegOptions{Lowess: true})
+	if band != nil || line == nil || len(line.XY) != 3 || line.XY[0] != (geom.Pt{}) {
+		t.Errorf("lowess line %v, band %v", line, band)
+	}
+
+	if _, line, _ := ax.RegPlot([]float64{1}, []float64{1}, RegOptions{CI: -1}); line != nil {
+		t.Error("fit drawn for a single point")
+	}
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"maps"
+	"math"
+	"sort"
+
+	"matplotlib-go/internal/geom"
+)
+
+// FontPolicy decides how Figure.Resize changes font sizes.
+type FontPolicy uint8
+
+const (
+	// FontFixed keeps font sizes in pixels, so text stays equally legible
+	// and takes relatively more room in small figures.
+	FontFixed FontPolicy = iota
+	// FontScale scales font sizes with the square root of the figure's
+	// area relative to its size at the first Resize, within
+	// ResizeOptions.MinFontSize and MaxFontSize.
+	FontScale
+)
+
+// ResizeOptions configures Figure.Resize.
+type ResizeOptions struct {
+	Fonts       FontPolicy
+	MinFontSize float64 // smallest font size in pixels for FontScale, 0 for 9
+	MaxFontSize float64 // largest font size in pixels for FontScale, 0 for no limit
+}
+
+// figureDesign records the layout and font sizes a figure was designed
+// for, so that resizing back and forth restores them exactly. It is taken
+// at the first Resize and again whenever axes or fonts were changed since
+// the last one.
+type figureDesign struct {
+	size geom.Pt
+	orig design
+	set  design // what the last Resize set, to detect later changes
+}
+
+// design holds the axes rectangles and font sizes of a figure.
+type design struct {
+	rects    map[*Axes]geom.Rect
+	fontSize float64
+	axes     map[*Axes]float64 // font sizes of axes with their own RC
+}
+
+// Resize changes the figure's pixel size and lays it out again, e.g. when
+// an interactive window or an HTTP request changes the output size, instead
+// of scaling a rendered raster. Margins around and gaps between axes keep
+// their pixel sizes, so tick labels and titles keep fitting, and the axes
+// take up the rest; a figure too small for its margins shrinks everything
+// in proportion. Ticks are located anew when drawn, and font sizes follow
+// opts.Fonts. Layout and fonts are computed from the figure as it was
+// before the first Resize, or before the first one after adding axes or
+// changing rectangles or font sizes. Non-positive sizes are ignored.
+//
+// Interactive frontends render into a new canvas of the new size afterwards,
+// e.g. with a new Redrawer.
+func (f *Figure) Resize(w, h int, opts ResizeOptions) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	cur := f.currentDesign()
+	if f.design == nil || !cur.equal(f.design.set) {
+		f.design = &figureDesign{size: f.SizePx, orig: cur}
+	}
+	d := f.design
+	size := geom.Pt{X: float64(w), Y: float64(h)}
+	mapX := resizeMap(d.size.X, size.X, d.orig.rects, func(r geom.Rect) (float64, float64) { return r.Min.X, r.Max.X })
+	mapY := resizeMap(d.size.Y, size.Y, d.orig.rects, func(r geom.Rect) (float64, float64) { return r.Min.Y, r.Max.Y })
+	for ax, r := range d.orig.rects {
+		ax.RectFraction = geom.Rect{
+			Min: geom.Pt{X: mapX(r.Min.X), Y: mapY(r.Min.Y)},
+			Max: geom.Pt{X: mapX(r.Max.X), Y: mapY(r.Max.Y)},
+		}
+	}
+	f.SizePx = size
+
+	scale := func(size float64) float64 { return size }
+	if opts.Fonts == FontScale && d.size.X > 0 && d.size.Y > 0 {
+		k := math.Sqrt(size.X * size.Y / (d.size.X * d.size.Y))
+		lo, hi := opts.MinFontSize, opts.MaxFontSize
+		if lo <= 0 {
+			lo = 9
+		}
+		if hi <= 0 {
+			hi = math.Inf(1)
+		}
+		scale = func(s float64) float64 {
+			// Fonts designed below the minimum are not enlarged.
+			return math.Min(math.Max(s*k, math.Min(lo, s)), math.Max(hi, lo))
+		}
+	}
+	f.RC.FontSize = scale(d.orig.fontSize)
+	for ax, s := range d.orig.axes {
+		ax.RC.FontSize = scale(s)
+	}
+	d.set = f.currentDesign()
+}
+
+// currentDesign captures the figure's axes rectangles and font sizes.
+func (f *Figure) currentDesign() design {
+	d := design{rects: make(map[*Axes]geom.Rect, len(f.Children)), fontSize: f.RC.FontSize, axes: make(map[*Axes]float64)}
+	for _, ax := range f.Children {
+		d.rects[ax] = ax.RectFraction
+		if ax.RC != nil {
+			d.axes[ax] = ax.RC.FontSize
+		}
+	}
+	return d
+}
+
+func (d design) equal(o design) bool {
+	return d.fontSize == o.fontSize && maps.Equal(d.rects, o.rects) && maps.Equal(d.axes, o.axes)
+}
+
+// resizeMap returns the mapping of figure fractions along one dimension
+// from length from to length to. Spans covered by an axes stretch and the
+// uncovered ones, the margins and gaps, keep their pixel lengths.
+func resizeMap(from, to float64, rects map[*Axes]geom.Rect, span func(geom.Rect) (float64, float64)) func(float64) float64 {
+	cuts := []float64{0, 1}
+	for _, r := range rects {
+		a, b := span(r)
+		cuts = append(cuts, a, b)
+	}
+	sort.Float64s(cuts)
+	// covered[i] reports whether an axes spans cuts[i-1] to cuts[i].
+	covered := make([]bool, len(cuts))
+	for i := 1; i < len(cuts); i++ {
+		for _, r := range rects {
+			lo, hi := span(r)
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if lo <= cuts[i-1] && hi >= cuts[i] {
+				covered[i] = true
+				break
+			}
+		}
+	}
+	// Pixel lengths of the fixed and stretching spans.
+	var fixed, stretch float64
+	for i := 1; i < len(cuts); i++ {
+		n := (cuts[i] - cuts[i-1]) * from
+		if covered[i] {
+			stretch += n
+		} else {
+			fixed += n
+		}
+	}
+	kFixed, kStretch := 1.0, 0.0
+	if stretch > 0 && to > fixed {
+		kStretch = (to - fixed) / stretch
+	} else {
+		kFixed, kStretch = to/from, to/from
+	}
+	return func(u float64) float64 {
+		pos := 0.0
+		for i := 1; i < len(cuts); i++ {
+			a, b := cuts[i-1], math.Min(cuts[i], u)
+			if a >= u {
+				break
+			}
+			k := kFixed
+			if covered[i] {
+				k = kStretch
+			}
+			pos += (b - a) * from * k
+		}
+		return pos / to
+	}
+}
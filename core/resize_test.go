@@ -0,0 +1,71 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/style"
+)
+
+func TestFigure_Resize(t *testing.T) {
+	fig := NewFigure(800, 600)
+	axs := fig.Subplots(2, 2, SubplotOptions{})
+	orig := fig.Children[0].RectFraction
+	px := func(ax *Axes) geom.Rect { return ax.layout(fig) }
+	left, gap := px(axs[0][0]).Min.X, px(axs[0][1]).Min.X-px(axs[0][0]).Max.X
+	top, right := px(axs[0][0]).Min.Y, 800-px(axs[0][1]).Max.X
+
+	// Margins and gaps keep their pixel sizes; the axes shrink.
+	fig.Resize(400, 300, ResizeOptions{})
+	if fig.SizePx != (geom.Pt{X: 400, Y: 300}) {
+		t.Fatalf("size %v", fig.SizePx)
+	}
+	a, b := px(axs[0][0]), px(axs[0][1])
+	if math.Abs(a.Min.X-left) > 1e-9 || math.Abs(b.Min.X-a.Max.X-gap) > 1e-9 || math.Abs(a.Min.Y-top) > 1e-9 {
+		t.Errorf("left %v, gap %v, top %v; want %v, %v, %v", a.Min.X, b.Min.X-a.Max.X, a.Min.Y, left, gap, top)
+	}
+	if got := 400 - b.Max.X; math.Abs(got-right) > 1e-9 {
+		t.Errorf("right margin %v, want %v", got, right)
+	}
+	if fig.RC.FontSize != 12 {
+		t.Errorf("font size %v with FontFixed, want 12", fig.RC.FontSize)
+	}
+
+	// Too small for the margins: everything shrinks in proportion.
+	fig.Resize(80, 60, ResizeOptions{})
+	if got := px(axs[0][0]).Min.X; math.Abs(got-left/10) > 1e-9 {
+		t.Errorf("left margin %v at 80 px, want %v", got, left/10)
+	}
+
+	// Back at the design size the layout is restored exactly.
+	fig.Resize(800, 600, ResizeOptions{})
+	if r := fig.Children[0].RectFraction; math.Abs(r.Min.X-orig.Min.X) > 1e-12 || math.Abs(r.Max.Y-orig.Max.Y) > 1e-12 {
+		t.Errorf("rect %v after resizing back, want %v", r, orig)
+	}
+}
+
+func TestFigure_ResizeFonts(t *testing.T) {
+	fig := NewFigure(400, 400)
+	fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	small := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.6, Y: 0.6}, Max: geom.Pt{X: 0.8, Y: 0.8}})
+	rc := style.Apply(style.Default, style.WithFont("DejaVuSans", 8))
+	small.RC = &rc
+
+	opts := ResizeOptions{Fonts: FontScale, MaxFontSize: 20}
+	fig.Resize(800, 800, opts)
+	if fig.RC.FontSize != 20 || small.RC.FontSize != 16 {
+		t.Errorf("font sizes %v, %v at twice the size, want 20 (clamped), 16", fig.RC.FontSize, small.RC.FontSize)
+	}
+	fig.Resize(200, 200, opts)
+	if fig.RC.FontSize != 9 || small.RC.FontSize != 8 {
+		t.Errorf("font sizes %v, %v at half the size, want 9 (clamped), 8 (not enlarged)", fig.RC.FontSize, small.RC.FontSize)
+	}
+
+	// Fonts changed in between become the new design.
+	fig.RC.FontSize = 10
+	fig.Resize(400, 400, opts)
+	if fig.RC.FontSize != 20 {
+		t.Errorf("font size %v after changing it at half the size, want 20", fig.RC.FontSize)
+	}
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"errors"
+
+	"matplotlib-go/render"
+)
+
+// EMFExporter defines the interface for renderers that can export Windows
+// Enhanced Metafiles.
+type EMFExporter interface {
+	SaveEMF(path string) error
+}
+
+// SaveEMF draws a figure with the provided renderer and writes the result as
+// an EMF file for use in Office documents.
+func SaveEMF(fig *Figure, r render.Renderer, path string) error {
+	if err := fig.strictErr(); err != nil {
+		return err
+	}
+	DrawFigure(fig, r)
+
+	if exporter, ok := r.(EMFExporter); ok {
+		return exporter.SaveEMF(path)
+	}
+	return errors.New("EMF export not supported for this renderer type")
+}
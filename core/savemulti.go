@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"matplotlib-go/render"
+)
+
+// Size is a target output size in pixels.
+type Size struct {
+	W, H int
+}
+
+// RendererFactory creates a renderer for a w×h pixel canvas.
+type RendererFactory func(w, h int) (render.Renderer, error)
+
+// SaveMulti renders fig once per target size and saves each result as PNG,
+// e.g. for responsive-web image sets and thumbnails.
+//
+// The path of each file is pattern with "{w}" and "{h}" replaced by the
+// target width and height, so "chart-{w}x{h}.png" yields "chart-640x480.png".
+// Axes are laid out in figure fractions, so only the pixel transforms are
+// recomputed per size; fonts and line widths keep their pixel sizes. The
+// figure's own size is left unchanged. SaveMulti returns the written paths.
+func SaveMulti(fig *Figure, newRenderer RendererFactory, sizes []Size, pattern string) ([]string, error) {
+	if newRenderer == nil {
+		return nil, errors.New("SaveMulti: nil renderer factory")
+	}
+	if err := fig.strictErr(); err != nil {
+		return nil, err
+	}
+	if len(sizes) > 1 && !strings.Contains(pattern, "{w}") && !strings.Contains(pattern, "{h}") {
+		return nil, errors.New("SaveMulti: pattern must contain {w} or {h} for multiple sizes")
+	}
+
+	orig := fig.SizePx
+	defer func() { fig.SizePx = orig }()
+
+	paths := make([]string, 0, len(sizes))
+	for _, s := range sizes {
+		if s.W <= 0 || s.H <= 0 {
+			return paths, fmt.Errorf("SaveMulti: invalid size %dx%d", s.W, s.H)
+		}
+		r, err := newRenderer(s.W, s.H)
+		if err != nil {
+			return paths, fmt.Errorf("SaveMulti: %dx%d: %w", s.W, s.H, err)
+		}
+
+		fig.SizePx.X, fig.SizePx.Y = float64(s.W), float64(s.H)
+		path := strings.NewReplacer("{w}", strconv.Itoa(s.W), "{h}", strconv.Itoa(s.H)).Replace(pattern)
+		if err := SavePNG(fig, r, path); err != nil {
+			return paths, fmt.Errorf("SaveMulti: %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
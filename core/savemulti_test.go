@@ -0,0 +1,53 @@
+package core
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestSaveMulti(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 0, Y: 0}, {X: 1, Y: 1}}, W: 1})
+
+	newRenderer := func(w, h int) (render.Renderer, error) {
+		return gobasic.New(w, h, render.Color{R: 1, G: 1, B: 1, A: 1}), nil
+	}
+	sizes := []Size{{W: 64, H: 48}, {W: 320, H: 240}}
+	pattern := filepath.Join(t.TempDir(), "fig-{w}x{h}.png")
+
+	paths, err := SaveMulti(fig, newRenderer, sizes, pattern)
+	if err != nil {
+		t.Fatalf("SaveMulti failed: %v", err)
+	}
+	if len(paths) != len(sizes) {
+		t.Fatalf("expected %d paths, got %v", len(sizes), paths)
+	}
+	for i, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			t.Fatalf("open %s: %v", p, err)
+		}
+		cfg, err := png.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("decode %s: %v", p, err)
+		}
+		if cfg.Width != sizes[i].W || cfg.Height != sizes[i].H {
+			t.Errorf("%s: got %dx%d", p, cfg.Width, cfg.Height)
+		}
+	}
+	if fig.SizePx != (geom.Pt{X: 400, Y: 300}) {
+		t.Errorf("figure size not restored: %v", fig.SizePx)
+	}
+
+	if _, err := SaveMulti(fig, newRenderer, sizes, "fixed.png"); err == nil {
+		t.Error("expected error for pattern without placeholders")
+	}
+}
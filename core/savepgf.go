@@ -0,0 +1,26 @@
+package core
+
+import (
+	"errors"
+
+	"matplotlib-go/render"
+)
+
+// PGFExporter defines the interface for renderers that can export PGF code.
+type PGFExporter interface {
+	SavePGF(path string) error
+}
+
+// SavePGF draws a figure with the provided renderer and writes the result as
+// PGF code for inclusion in LaTeX documents.
+func SavePGF(fig *Figure, r render.Renderer, path string) error {
+	if err := fig.strictErr(); err != nil {
+		return err
+	}
+	DrawFigure(fig, r)
+
+	if exporter, ok := r.(PGFExporter); ok {
+		return exporter.SavePGF(path)
+	}
+	return errors.New("PGF export not supported for this renderer type")
+}
@@ -3,6 +3,8 @@ package core
 import (
 	"errors"
 
+	"matplotlib-go/backends"
+
 	"matplotlib-go/backends/gobasic"
 	"matplotlib-go/render"
 )
@@ -12,9 +14,33 @@ type PNGExporter interface {
 	SavePNG(path string) error
 }
 
+// RendererConfig returns the backend configuration for saving fig at its
+// pixel size, with the RC's SaveDPI and SaveFacecolor, or DPI and
+// Background where those are unset:
+//
+//	r, err := backends.Create(backends.GoBasic, fig.RendererConfig())
+func (f *Figure) RendererConfig() backends.Config {
+	dpi, bg := f.RC.SaveDPI, f.RC.SaveFacecolor
+	if dpi <= 0 {
+		dpi = f.RC.DPI
+	}
+	if bg[3] == 0 {
+		bg = f.RC.Background
+	}
+	return backends.Config{
+		Width:      int(f.SizePx.X),
+		Height:     int(f.SizePx.Y),
+		Background: rcColor(bg),
+		DPI:        dpi,
+	}
+}
+
 // SavePNG saves a figure to a PNG file using the provided renderer.
 // This function draws the figure using the renderer and then exports to PNG.
 func SavePNG(fig *Figure, r render.Renderer, path string) error {
+	if err := fig.strictErr(); err != nil {
+		return err
+	}
 	// Draw the figure using the renderer
 	DrawFigure(fig, r)
 
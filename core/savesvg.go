@@ -0,0 +1,27 @@
+package core
+
+import (
+	"errors"
+
+	"matplotlib-go/render"
+)
+
+// SVGExporter defines the interface for renderers that can export SVG
+// documents.
+type SVGExporter interface {
+	SaveSVG(path string) error
+}
+
+// SaveSVG draws a figure with the provided renderer and writes the result
+// as an SVG document for the web and publications.
+func SaveSVG(fig *Figure, r render.Renderer, path string) error {
+	if err := fig.strictErr(); err != nil {
+		return err
+	}
+	DrawFigure(fig, r)
+
+	if exporter, ok := r.(SVGExporter); ok {
+		return exporter.SaveSVG(path)
+	}
+	return errors.New("SVG export not supported for this renderer type")
+}
@@ -22,7 +22,10 @@ const (
 // Scatter2D renders points with configurable markers.
 type Scatter2D struct {
 	XY         []geom.Pt      // data space points
+	Data       Points         // points read instead of XY if set, e.g. memory-mapped, see Axes.ScatterData
 	Sizes      []float64      // marker sizes (radius in pixels), if nil uses Size
+	Angles     []float64      // per-point marker rotation in degrees counterclockwise, if nil none
+	DataSize   bool           // Size and Sizes are radii in x data units, so markers scale with zoom
 	Colors     []render.Color // marker colors, if nil uses Color
 	EdgeColors []render.Color // edge colors for marker outlines, if nil uses EdgeColor
 	Size       float64        // default marker size (radius in pixels)
@@ -32,16 +35,25 @@ type Scatter2D struct {
 	Alpha      float64        // alpha transparency (0-1), applied to both fill and edge
 	Marker     MarkerType     // marker shape
 	Label      string         // series label for legend
+	Tooltip    TooltipFunc    // per-point tooltip text for vector backends, if nil none
+	Labels     []string       // per-point text labels, "" for none
+	DataLabels *DataLabels    // style of the point labels; without Labels it labels every y value
+	Rasterize  bool           // draw as an embedded bitmap in vector output
+	Aliased    bool           // draw without anti-aliasing, with hard pixel edges
 	z          float64        // z-order
 }
 
 // Draw renders scatter points by creating filled paths for each marker.
 func (s *Scatter2D) Draw(r render.Renderer, ctx *DrawContext) {
-	if len(s.XY) == 0 {
+	pts := s.points()
+	n := pts.Len()
+	if n == 0 {
 		return // nothing to draw
 	}
+	titler, _ := r.(render.Titler)
 
-	for i, pt := range s.XY {
+	for i := 0; i < n; i++ {
+		pt := pts.At(i)
 		// Transform to pixel coordinates
 		pixelPt := ctx.DataToPixel.Apply(pt)
 
@@ -51,6 +63,10 @@ func (s *Scatter2D) Draw(r render.Renderer, ctx *DrawContext) {
 			size = s.Sizes[i]
 		}
 
+		if s.DataSize {
+			size = dataRadius(ctx, pt, size)
+		}
+
 		// Get fill color for this point
 		fillColor := s.Color
 		if s.Colors != nil && i < len(s.Colors) {
@@ -81,10 +97,14 @@ func (s *Scatter2D) Draw(r render.Renderer, ctx *DrawContext) {
 		if len(markerPath.C) == 0 {
 			continue // skip invalid markers
 		}
+		if i < len(s.Angles) && s.Angles[i] != 0 {
+			rotateMarker(&markerPath, pixelPt, s.Angles[i])
+		}
 
 		// Create paint for marker
 		paint := render.Paint{
-			Fill: fillColor,
+			Fill:    fillColor,
+			Aliased: s.Aliased,
 		}
 
 		// Add stroke if edge width is specified
@@ -96,8 +116,34 @@ func (s *Scatter2D) Draw(r render.Renderer, ctx *DrawContext) {
 		}
 
 		// Draw marker
+		if titler != nil && s.Tooltip != nil {
+			titler.Title(s.Tooltip(i, pt))
+		}
 		r.Path(markerPath, &paint)
 	}
+	gap := s.Size
+	if s.DataSize {
+		gap = dataRadius(ctx, pts.At(0), s.Size)
+	}
+	drawDataLabels(r, ctx, pts, s.Labels, s.DataLabels, gap)
+}
+
+// dataRadius converts the radius r in x data units at pt to pixels.
+func dataRadius(ctx *DrawContext, pt geom.Pt, r float64) float64 {
+	p0 := ctx.DataToPixel.Apply(pt)
+	p1 := ctx.DataToPixel.Apply(geom.Pt{X: pt.X + r, Y: pt.Y})
+	return math.Abs(p1.X - p0.X)
+}
+
+// rotateMarker rotates the vertices of p by deg degrees counterclockwise
+// on screen around center. Pixel y grows downwards, so the rotation is
+// clockwise in pixel coordinates.
+func rotateMarker(p *geom.Path, center geom.Pt, deg float64) {
+	sin, cos := math.Sincos(deg * math.Pi / 180)
+	for i, v := range p.V {
+		dx, dy := v.X-center.X, v.Y-center.Y
+		p.V[i] = geom.Pt{X: center.X + dx*cos + dy*sin, Y: center.Y - dx*sin + dy*cos}
+	}
 }
 
 // createMarkerPath creates a filled path for the given marker type at the specified position and size.
@@ -315,9 +361,14 @@ func (s *Scatter2D) Z() float64 {
 	return s.z
 }
 
+// Rasterized reports whether the Scatter2D should be embedded as a bitmap in vector output.
+func (s *Scatter2D) Rasterized() bool { return s.Rasterize }
+
 // Bounds returns the bounding box of all points, including marker size.
 func (s *Scatter2D) Bounds(*DrawContext) geom.Rect {
-	if len(s.XY) == 0 {
+	pts := s.points()
+	n := pts.Len()
+	if n == 0 {
 		return geom.Rect{}
 	}
 
@@ -333,12 +384,13 @@ func (s *Scatter2D) Bounds(*DrawContext) geom.Rect {
 
 	// Initialize bounds with first point
 	bounds := geom.Rect{
-		Min: s.XY[0],
-		Max: s.XY[0],
+		Min: pts.At(0),
+		Max: pts.At(0),
 	}
 
 	// Expand bounds to include all points
-	for _, pt := range s.XY[1:] {
+	for i := 1; i < n; i++ {
+		pt := pts.At(i)
 		if pt.X < bounds.Min.X {
 			bounds.Min.X = pt.X
 		}
@@ -357,6 +409,9 @@ func (s *Scatter2D) Bounds(*DrawContext) geom.Rect {
 	// Note: This is an approximation since marker size is in pixels
 	// A more accurate implementation would need the transform context
 	sizeInData := maxSize * 0.01 // rough approximation
+	if s.DataSize {
+		sizeInData = maxSize
+	}
 	bounds.Min.X -= sizeInData
 	bounds.Min.Y -= sizeInData
 	bounds.Max.X += sizeInData
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
@@ -348,3 +349,61 @@ func TestScatter2D_LargeDataset(t *testing.T) {
 		t.Error("Expected non-empty bounds for large dataset")
 	}
 }
+
+func TestScatter2D_Polar(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddPolarAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.Polar.Margin = 0
+	ax.SetYLim(0, 2)
+
+	// Points are (θ, r) pairs.
+	s := &Scatter2D{XY: []geom.Pt{{X: math.Pi / 2, Y: 2}}, Size: 4, Color: render.Color{A: 1}}
+	r := &segmentRecorder{}
+	s.Draw(r, ax.drawContext(fig))
+	if len(r.paths) == 0 {
+		t.Fatal("no marker drawn")
+	}
+	var c geom.Pt
+	for _, v := range r.paths[0].V {
+		c.X += v.X / float64(len(r.paths[0].V))
+		c.Y += v.Y / float64(len(r.paths[0].V))
+	}
+	if math.Abs(c.X-100) > 1 || math.Abs(c.Y) > 1 {
+		t.Errorf("marker at %+v, want the top of the circle (100, 0)", c)
+	}
+}
+
+func TestScatter2D_AnglesAndDataSize(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	s := ax.Scatter([]float64{5, 5}, []float64{5, 5}, WithMarker(MarkerSquare), WithSize(1),
+		WithDataSize(), WithAngles(0, 45))
+	if s == nil || !s.DataSize || len(s.Angles) != 2 {
+		t.Fatalf("scatter %+v", s)
+	}
+
+	var r segmentRecorder
+	s.Draw(&r, createTestDrawContext()) // 10 pixels per data unit
+	if len(r.paths) != 2 {
+		t.Fatalf("drew %d markers", len(r.paths))
+	}
+	// A radius of 1 data unit is 10 pixels around (100, 400).
+	if !near(r.paths[0].V[0], geom.Pt{X: 90, Y: 390}) || !near(r.paths[0].V[2], geom.Pt{X: 110, Y: 410}) {
+		t.Errorf("square at %v", r.paths[0].V)
+	}
+	// Turned by 45° the corners lie on the axes; the bottom-left one on
+	// screen (90, 410) moves to the bottom.
+	d := 10 * math.Sqrt2
+	if !near(r.paths[1].V[3], geom.Pt{X: 100, Y: 400 + d}) {
+		t.Errorf("rotated square at %v", r.paths[1].V)
+	}
+
+	if b := s.Bounds(nil); b.Min.X != 4 || b.Max.Y != 6 {
+		t.Errorf("bounds %v, want the data radius", b)
+	}
+
+	fig.Strict = true
+	if ax.Scatter([]float64{1, 2}, []float64{1, 2}, WithAngles(30)) != nil {
+		t.Error("strict Scatter accepted one angle for two points")
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+)
+
+// SnapMode selects whether an artist aligns its lines and edges with the
+// pixel grid, see style.RC.PixelSnap. A 1-pixel line on a pixel boundary
+// is antialiased into two half-covered gray pixels; snapped, it covers
+// one pixel fully.
+type SnapMode uint8
+
+const (
+	SnapAuto SnapMode = iota // follow the RC's PixelSnap
+	SnapOn                   // always snap
+	SnapOff                  // never snap
+)
+
+// enabled reports whether snapping applies in ctx.
+func (m SnapMode) enabled(ctx *DrawContext) bool {
+	switch m {
+	case SnapOn:
+		return true
+	case SnapOff:
+		return false
+	}
+	return ctx.RC.PixelSnap
+}
+
+// snapCoord moves the pixel coordinate v so that a line of the given
+// width centered on it covers whole pixels: odd widths are centered on a
+// pixel, even widths (and fill edges, width 0) on a pixel boundary.
+func snapCoord(v, width float64) float64 {
+	if int(math.Round(width))%2 == 1 {
+		return math.Floor(v) + 0.5
+	}
+	return math.Round(v)
+}
+
+// snapLine snaps a horizontal or vertical line across its direction;
+// slanted lines are returned unchanged.
+func snapLine(p1, p2 geom.Pt, width float64) (geom.Pt, geom.Pt) {
+	switch {
+	case p1.X == p2.X:
+		p1.X = snapCoord(p1.X, width)
+		p2.X = p1.X
+	case p1.Y == p2.Y:
+		p1.Y = snapCoord(p1.Y, width)
+		p2.Y = p1.Y
+	}
+	return p1, p2
+}
+
+// snapPath snaps every vertex of p, e.g. the corners of a rectangle
+// outlined with the given width.
+func snapPath(p geom.Path, width float64) {
+	for i, v := range p.V {
+		p.V[i] = geom.Pt{X: snapCoord(v.X, width), Y: snapCoord(v.Y, width)}
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/render"
+)
+
+func TestSnapCoord(t *testing.T) {
+	for _, tt := range []struct{ v, width, want float64 }{
+		{10.3, 1, 10.5},
+		{10.9, 1, 10.5},
+		{10.3, 0.5, 10.5},
+		{10.3, 2, 10},
+		{10.6, 2, 11},
+		{10.6, 0, 11},
+		{10.4, 3, 10.5},
+	} {
+		if got := snapCoord(tt.v, tt.width); got != tt.want {
+			t.Errorf("snapCoord(%v, %v) = %v, want %v", tt.v, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPixelSnap(t *testing.T) {
+	ctx := createTestDrawContext()
+	grid := NewGrid(AxisLeft)
+	grid.LineWidth = 1
+
+	// y = 2.25 lies at pixel 427.5 with the test mapping.
+	var r segmentRecorder
+	grid.drawGridLine(&r, ctx, 2.25, false, render.Color{A: 1}, 1, nil)
+	if y := r.paths[0].V[0].Y; y != 427.5 {
+		t.Fatalf("unsnapped line at y %v", y)
+	}
+
+	ctx.RC.PixelSnap = true
+	r = segmentRecorder{}
+	grid.drawGridLine(&r, ctx, 2.22, false, render.Color{A: 1}, 1, nil)
+	if p := r.paths[0]; p.V[0].Y != 427.5 || p.V[1].Y != 427.5 {
+		t.Errorf("snapped line at %v", p.V)
+	}
+	grid.Snap = SnapOff
+	r = segmentRecorder{}
+	grid.drawGridLine(&r, ctx, 2.22, false, render.Color{A: 1}, 1, nil)
+	if y := r.paths[0].V[0].Y; y == 427.5 {
+		t.Error("SnapOff line was snapped")
+	}
+
+	bar := &Bar2D{X: []float64{1.02}, Heights: []float64{3.01}, Width: 0.5, Color: render.Color{A: 1}, Snap: SnapOn}
+	ctx.RC.PixelSnap = false
+	r = segmentRecorder{}
+	bar.Draw(&r, ctx)
+	for _, v := range r.paths[0].V {
+		if v.X != float64(int(v.X)) || v.Y != float64(int(v.Y)) {
+			t.Errorf("bar corner %v not on the pixel grid", v)
+		}
+	}
+}
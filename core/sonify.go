@@ -0,0 +1,83 @@
+package core
+
+import (
+	"math"
+	"sort"
+)
+
+// SonifyEvent is a data point of a figure prepared for sonification: audio
+// tooling plays Value, e.g. as pitch, at Time. Both are normalized through
+// the scales of the point's axes, so log axes sound as they look.
+type SonifyEvent struct {
+	Time   float64 // x within the x limits, in [0, 1]; for horizontal bars the bar position within the y limits
+	Value  float64 // y within the y limits, in [0, 1]; for horizontal bars the bar end within the x limits
+	X, Y   float64 // the point in data coordinates
+	Axes   int     // index of the axes in Figure.Children
+	Series int     // index of the series among the axes' series
+	Kind   string  // series kind as in SeriesData: "line", "scatter", "bar", "barh" or "fill"
+	Label  string  // series label, "" if unlabeled
+}
+
+// Sonify walks the visible lines, scatters, bars and fills of fig, the
+// series of ExportSpec, and calls emit with one event per finite point:
+// axes in order, their series in drawing order and each series' points by
+// time. Points outside the limits are clamped to 0 or 1. Sonify stops at
+// the first error of emit and returns it.
+//
+//	core.Sonify(fig, func(e core.SonifyEvent) error {
+//		return synth.Note(e.Time*duration, 220*math.Pow(4, e.Value))
+//	})
+func Sonify(fig *Figure, emit func(SonifyEvent) error) error {
+	for i, ax := range fig.Children {
+		series := 0
+		for _, art := range ax.Artists {
+			if !ax.Visible(art) {
+				continue
+			}
+			sd, ok := seriesSpec(art)
+			if !ok {
+				continue
+			}
+			for _, e := range ax.sonifySeries(sd) {
+				e.Axes, e.Series, e.Kind, e.Label = i, series, sd.Kind, sd.Label
+				if err := emit(e); err != nil {
+					return err
+				}
+			}
+			series++
+		}
+	}
+	return nil
+}
+
+// sonifySeries returns the events of sd sorted by time.
+func (a *Axes) sonifySeries(sd SeriesData) []SonifyEvent {
+	unit := func(v float64) float64 { return math.Max(0, math.Min(1, v)) }
+	var events []SonifyEvent
+	for j, x := range sd.X {
+		if j >= len(sd.Y) {
+			break
+		}
+		y := sd.Y[j]
+		if sd.Kind == "bar" || sd.Kind == "barh" {
+			if j < len(sd.Bottoms) {
+				y += sd.Bottoms[j]
+			} else {
+				y += sd.Baseline
+			}
+		}
+		if sd.Kind == "barh" {
+			x, y = y, x // bar end along x at position y
+		}
+		t, v := a.XScale.Fwd(x), a.YScale.Fwd(y)
+		if sd.Kind == "barh" {
+			t, v = v, t
+		}
+		if math.IsNaN(t) || math.IsNaN(v) || math.IsInf(t, 0) || math.IsInf(v, 0) {
+			continue
+		}
+		events = append(events, SonifyEvent{Time: unit(t), Value: unit(v), X: x, Y: y})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+	return events
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestSonify(t *testing.T) {
+	fig := NewFigure(200, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetXLim(0, 10)
+	ax.SetYLim(0, 100)
+	ax.Add(&Line2D{XY: []geom.Pt{{X: 5, Y: 50}, {X: 0, Y: 0}, {X: 7, Y: math.NaN()}, {X: 10, Y: 200}}, Label: "up"})
+	hidden := &Scatter2D{XY: []geom.Pt{{X: 1, Y: 1}}}
+	ax.Add(hidden)
+	ax.SetVisible(hidden, false)
+	ax.Add(&Bar2D{X: []float64{2}, Heights: []float64{3}, Baseline: 1, Orientation: BarHorizontal})
+	ax.Add(&Bar2D{X: []float64{6}, Heights: []float64{10}, Bottoms: []float64{20}, Baseline: 1})
+	logAx := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	logAx.XScale = transform.NewLinear(0, 1)
+	logAx.YScale = transform.NewLog(1, 100, 10)
+	logAx.Add(&Scatter2D{XY: []geom.Pt{{X: 0.5, Y: 10}}})
+
+	var got []SonifyEvent
+	if err := Sonify(fig, func(e SonifyEvent) error { got = append(got, e); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	want := []SonifyEvent{
+		{Time: 0, Value: 0, X: 0, Y: 0, Kind: "line", Label: "up"},
+		{Time: 0.5, Value: 0.5, X: 5, Y: 50, Kind: "line", Label: "up"},
+		{Time: 1, Value: 1, X: 10, Y: 200, Kind: "line", Label: "up"}, // clamped
+		{Time: 0.02, Value: 0.4, X: 4, Y: 2, Series: 1, Kind: "barh"},
+		{Time: 0.6, Value: 0.3, X: 6, Y: 30, Series: 2, Kind: "bar"},
+		{Time: 0.5, Value: 0.5, X: 0.5, Y: 10, Axes: 1, Kind: "scatter"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		g := got[i]
+		if math.Abs(g.Time-want[i].Time) > 1e-12 || math.Abs(g.Value-want[i].Value) > 1e-12 {
+			t.Errorf("event %d at time %v value %v, want %v, %v", i, g.Time, g.Value, want[i].Time, want[i].Value)
+		}
+		g.Time, g.Value = want[i].Time, want[i].Value
+		if g != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	if err := Sonify(fig, func(SonifyEvent) error { n++; return stop }); err != stop || n != 1 {
+		t.Errorf("Sonify returned %v after %d events, want stop after 1", err, n)
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// SparklineOptions configures NewSparkline. The zero value draws a 1 pixel
+// line in the first color of the default cycle without markers.
+type SparklineOptions struct {
+	Color      render.Color // line color, 0 alpha for the first cycle color
+	LineWidth  float64      // line width in pixels, 0 for 1
+	Pad        float64      // margin around the data in pixels, 0 for 2
+	YLim       [2]float64   // fixed value range, e.g. 0..100 for percentages; zero for the data range
+	MinMax     bool         // mark the minimum and maximum
+	MinColor   render.Color // minimum marker color, 0 alpha for red
+	MaxColor   render.Color // maximum marker color, 0 alpha for green
+	MarkerSize float64      // marker radius in pixels, 0 for 1.5
+}
+
+var (
+	sparkMinColor = render.Color{R: 0.84, G: 0.15, B: 0.16, A: 1}
+	sparkMaxColor = render.Color{R: 0.17, G: 0.63, B: 0.17, A: 1}
+)
+
+// NewSparkline creates a w×h pixel figure with a single line of y over x
+// and nothing else: no ticks, labels or frame, and only opts.Pad as margin.
+// It is meant for the tiny inline charts of dashboards and monitoring UIs,
+// e.g. the recent samples of a metric, where thousands are rendered at
+// once. A nil x plots y over its indices. NaN values leave gaps.
+func NewSparkline(w, h int, x, y []float64, opts SparklineOptions) (*Figure, error) {
+	if x == nil {
+		x = make([]float64, len(y))
+		for i := range x {
+			x[i] = float64(i)
+		}
+	}
+	if len(x) != len(y) {
+		return nil, errors.New("sparkline: x and y differ in length")
+	}
+	if opts.LineWidth <= 0 {
+		opts.LineWidth = 1
+	}
+	if opts.Pad <= 0 {
+		opts.Pad = 2
+	}
+	if opts.MarkerSize <= 0 {
+		opts.MarkerSize = 1.5
+	}
+	if opts.MinColor.A == 0 {
+		opts.MinColor = sparkMinColor
+	}
+	if opts.MaxColor.A == 0 {
+		opts.MaxColor = sparkMaxColor
+	}
+
+	// The axes fill the figure and the padding is added to the limits, so
+	// that line caps and markers at the extremes are not clipped.
+	fig := NewFigure(w, h)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+
+	xmin, xmax, _, _ := finiteRange(x)
+	ymin, ymax, imin, imax := finiteRange(y)
+	if opts.YLim != [2]float64{} {
+		ymin, ymax = opts.YLim[0], opts.YLim[1]
+	}
+	ax.SetXLim(padLimits(xmin, xmax, opts.Pad, float64(w)))
+	ax.SetYLim(padLimits(ymin, ymax, opts.Pad, float64(h)))
+
+	color := opts.Color
+	if color.A == 0 {
+		color = ax.PeekColor()
+	}
+	ax.Plot(x, y, WithColor(color), WithLineWidth(opts.LineWidth))
+	if opts.MinMax && imin >= 0 {
+		ax.Scatter([]float64{x[imin]}, []float64{y[imin]}, WithColor(opts.MinColor), WithSize(opts.MarkerSize))
+		ax.Scatter([]float64{x[imax]}, []float64{y[imax]}, WithColor(opts.MaxColor), WithSize(opts.MarkerSize))
+	}
+	return fig, nil
+}
+
+// finiteRange returns the range of the finite values of v and the indices
+// of their first minimum and maximum, or -1 indices if there are none.
+func finiteRange(v []float64) (lo, hi float64, ilo, ihi int) {
+	ilo, ihi = -1, -1
+	for i, f := range v {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		if ilo < 0 || f < v[ilo] {
+			ilo = i
+		}
+		if ihi < 0 || f > v[ihi] {
+			ihi = i
+		}
+	}
+	if ilo < 0 {
+		return 0, 1, -1, -1
+	}
+	return v[ilo], v[ihi], ilo, ihi
+}
+
+// padLimits returns limits that map lo..hi to an axis of size pixels less
+// pad pixels on each side. A flat range is widened by 1 first.
+func padLimits(lo, hi, pad, size float64) (float64, float64) {
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	inner := math.Max(size-2*pad, 1)
+	d := (hi - lo) * pad / inner
+	return lo - d, hi + d
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestNewSparkline(t *testing.T) {
+	y := []float64{3, 1, math.NaN(), 4, 2}
+	fig, err := NewSparkline(100, 20, nil, y, SparklineOptions{MinMax: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ax := fig.Children[0]
+	if len(ax.AxisList()) != 0 {
+		t.Error("sparkline has axes decorations")
+	}
+	if len(ax.Artists) != 3 {
+		t.Fatalf("got %d artists, want line and two markers", len(ax.Artists))
+	}
+
+	// The extremes sit Pad pixels inside the figure.
+	ctx := ax.drawContext(fig)
+	for _, tc := range []struct{ data, px geom.Pt }{
+		{geom.Pt{X: 0, Y: 4}, geom.Pt{X: 2, Y: 2}},
+		{geom.Pt{X: 4, Y: 1}, geom.Pt{X: 98, Y: 18}},
+	} {
+		if got := ctx.DataToPixel.Apply(tc.data); !near(got, tc.px) {
+			t.Errorf("%v maps to %v, want %v", tc.data, got, tc.px)
+		}
+	}
+
+	lo, hi := ax.Artists[1].(*Scatter2D), ax.Artists[2].(*Scatter2D)
+	if lo.XY[0] != (geom.Pt{X: 1, Y: 1}) || lo.Color != sparkMinColor {
+		t.Errorf("minimum marker %v %v", lo.XY, lo.Color)
+	}
+	if hi.XY[0] != (geom.Pt{X: 3, Y: 4}) || hi.Color != sparkMaxColor {
+		t.Errorf("maximum marker %v %v", hi.XY, hi.Color)
+	}
+
+	r := gobasic.New(100, 20, render.Color{R: 1, G: 1, B: 1, A: 1})
+	DrawFigure(fig, r)
+	if c := r.GetImage().RGBAAt(26, 18); c.R == 255 && c.G == 255 && c.B == 255 {
+		t.Error("minimum marker not drawn")
+	}
+}
+
+func TestNewSparkline_Flat(t *testing.T) {
+	fig, err := NewSparkline(50, 10, []float64{10, 20}, []float64{5, 5}, SparklineOptions{YLim: [2]float64{0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	min, max := fig.Children[0].YScale.Domain()
+	if !(min < 5 && max > 5) {
+		t.Errorf("flat y limits %v..%v do not contain 5", min, max)
+	}
+	if _, err := NewSparkline(50, 10, []float64{1}, []float64{1, 2}, SparklineOptions{}); err == nil {
+		t.Error("no error for x and y of different lengths")
+	}
+}
+
+func BenchmarkSparkline(b *testing.B) {
+	y := make([]float64, 60)
+	for i := range y {
+		y[i] = math.Sin(float64(i) / 5)
+	}
+	r := gobasic.New(120, 24, render.Color{R: 1, G: 1, B: 1, A: 1})
+	for b.Loop() {
+		fig, _ := NewSparkline(120, 24, nil, y, SparklineOptions{MinMax: true})
+		DrawFigure(fig, r)
+	}
+}
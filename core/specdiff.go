@@ -0,0 +1,152 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// SpecChange is a difference between two FigureSpecs found by DiffSpecs.
+type SpecChange struct {
+	Path string // JSON path of the property, e.g. "axes[0].series[1].y[4]"
+	Old  string // old value, "" if the element was added
+	New  string // new value, "" if the element was removed
+	More int    // further differing values of the same array after Path's
+}
+
+func (c SpecChange) String() string {
+	var s string
+	switch {
+	case c.Old == "":
+		s = fmt.Sprintf("%s: added %s", c.Path, c.New)
+	case c.New == "":
+		s = fmt.Sprintf("%s: removed %s", c.Path, c.Old)
+	default:
+		s = fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+	}
+	if c.More > 0 {
+		s += fmt.Sprintf(" (and %d more)", c.More)
+	}
+	return s
+}
+
+// DiffSpecs compares two figures structurally, e.g. a figure and its
+// golden FigureSpec, and returns the changed properties in document order.
+// Axes and series are matched by index; appended or dropped ones are
+// reported whole. Data arrays of equal length report their first differing
+// value and the number of further ones. Numbers differ if they are more
+// than tol apart, relative to their magnitude above 1; NaNs equal each
+// other. Pixel diffs show that a chart changed; the changes tell which
+// artist and property did.
+func DiffSpecs(before, after FigureSpec, tol float64) []SpecChange {
+	d := specDiffer{tol: tol}
+	d.value("", reflect.ValueOf(before), reflect.ValueOf(after))
+	return d.changes
+}
+
+type specDiffer struct {
+	tol     float64
+	changes []SpecChange
+}
+
+func (d *specDiffer) value(path string, a, b reflect.Value) {
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := range t.NumField() {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			d.value(joinPath(path, name), a.Field(i), b.Field(i))
+		}
+	case reflect.Slice:
+		if a.Type().Elem().Kind() == reflect.Float64 {
+			floats := reflect.TypeFor[[]float64]()
+			d.floats(path, a.Convert(floats).Interface().([]float64), b.Convert(floats).Interface().([]float64))
+			return
+		}
+		for i := range max(a.Len(), b.Len()) {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= b.Len():
+				d.add(SpecChange{Path: p, Old: describeSpec(a.Index(i))})
+			case i >= a.Len():
+				d.add(SpecChange{Path: p, New: describeSpec(b.Index(i))})
+			default:
+				d.value(p, a.Index(i), b.Index(i))
+			}
+		}
+	case reflect.Array:
+		for i := range a.Len() {
+			d.value(fmt.Sprintf("%s[%d]", path, i), a.Index(i), b.Index(i))
+		}
+	case reflect.Float64:
+		if !d.equal(a.Float(), b.Float()) {
+			d.add(SpecChange{Path: path, Old: fmt.Sprint(a.Float()), New: fmt.Sprint(b.Float())})
+		}
+	case reflect.String:
+		if a.String() != b.String() {
+			d.add(SpecChange{Path: path, Old: fmt.Sprintf("%q", a.String()), New: fmt.Sprintf("%q", b.String())})
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			d.add(SpecChange{Path: path, Old: fmt.Sprint(a.Interface()), New: fmt.Sprint(b.Interface())})
+		}
+	}
+}
+
+// floats compares a data array, reporting a length change or the first
+// differing value.
+func (d *specDiffer) floats(path string, a, b []float64) {
+	if len(a) != len(b) {
+		d.add(SpecChange{Path: path, Old: fmt.Sprintf("%d values", len(a)), New: fmt.Sprintf("%d values", len(b))})
+		return
+	}
+	first := -1
+	more := 0
+	for i := range a {
+		if d.equal(a[i], b[i]) {
+			continue
+		}
+		if first < 0 {
+			first = i
+		} else {
+			more++
+		}
+	}
+	if first >= 0 {
+		d.add(SpecChange{Path: fmt.Sprintf("%s[%d]", path, first), Old: fmt.Sprint(a[first]), New: fmt.Sprint(b[first]), More: more})
+	}
+}
+
+func (d *specDiffer) equal(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b || math.Abs(a-b) <= d.tol*max(1, math.Abs(a), math.Abs(b))
+}
+
+func (d *specDiffer) add(c SpecChange) { d.changes = append(d.changes, c) }
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// describeSpec names an added or removed axes or series for a SpecChange.
+func describeSpec(v reflect.Value) string {
+	switch s := v.Interface().(type) {
+	case AxesData:
+		if s.Title != "" {
+			return fmt.Sprintf("axes %q with %d series", s.Title, len(s.Series))
+		}
+		return fmt.Sprintf("axes with %d series", len(s.Series))
+	case SeriesData:
+		if s.Label != "" {
+			return fmt.Sprintf("%s %q with %d points", s.Kind, s.Label, len(s.X))
+		}
+		return fmt.Sprintf("%s with %d points", s.Kind, len(s.X))
+	}
+	return fmt.Sprint(v.Interface())
+}
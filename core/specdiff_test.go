@@ -0,0 +1,55 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiffSpecs(t *testing.T) {
+	base := func() FigureSpec {
+		return FigureSpec{Width: 400, Height: 300, Axes: []AxesData{{
+			Title: "a",
+			XLim:  [2]float64{0, 10},
+			Series: []SeriesData{
+				{Kind: "line", Label: "up", X: []float64{0, 1, 2, 3}, Y: []float64{0, 1, math.NaN(), 3}},
+				{Kind: "scatter", X: []float64{1}, Y: []float64{1}},
+			},
+		}}}
+	}
+	if c := DiffSpecs(base(), base(), 0); len(c) != 0 {
+		t.Errorf("equal specs differ: %v", c)
+	}
+
+	changed := base()
+	changed.Width = 500
+	ax := &changed.Axes[0]
+	ax.Title = "b"
+	ax.XLim[1] = 10 + 1e-12 // within tolerance
+	ax.YLim[0] = -1
+	ax.Series[0].Y = []float64{0, 1.5, math.NaN(), 4}
+	ax.Series[1].X = []float64{1, 2}
+	ax.Series = append(ax.Series, SeriesData{Kind: "bar", Label: "new", X: []float64{1}, Y: []float64{2}})
+	changed.Axes = append(changed.Axes, AxesData{})
+
+	want := []string{
+		"width: 400 -> 500",
+		`axes[0].title: "a" -> "b"`,
+		"axes[0].ylim[0]: 0 -> -1",
+		"axes[0].series[0].y[1]: 1 -> 1.5 (and 1 more)",
+		"axes[0].series[1].x: 1 values -> 2 values",
+		`axes[0].series[2]: added bar "new" with 1 points`,
+		"axes[1]: added axes with 0 series",
+	}
+	got := DiffSpecs(base(), changed, 1e-9)
+	if len(got) != len(want) {
+		t.Fatalf("got %d changes %v, want %d", len(got), got, len(want))
+	}
+	for i, c := range got {
+		if c.String() != want[i] {
+			t.Errorf("change %d = %q, want %q", i, c, want[i])
+		}
+	}
+	if back := DiffSpecs(changed, base(), 1e-9); back[6].String() != "axes[1]: removed axes with 0 series" {
+		t.Errorf("reverse diff ends with %q", back[6])
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"matplotlib-go/render"
+)
+
+// StackMode selects how StackPlot and BarStacked stack their layers.
+type StackMode uint8
+
+const (
+	StackZero    StackMode = iota // layers stacked on top of each other from zero
+	StackPercent                  // each x position normalized to 100%, e.g. for composition over time
+)
+
+// StackOptions configures StackPlot and BarStacked.
+type StackOptions struct {
+	Mode    StackMode      // how the layers are stacked
+	Weights []float64      // per-layer factors applied before stacking, nil for 1
+	Colors  []render.Color // per-layer colors, nil for automatic cycling
+	Labels  []string       // per-layer series labels for the legend
+	Width   float64        // BarStacked bar width in data units, 0 for 0.8
+}
+
+// StackPlot draws the layers ys as areas stacked on top of each other, the
+// first at the bottom. In StackPercent mode the y axis runs from 0 to 100
+// and is labeled with a PercentFormatter.
+func (a *Axes) StackPlot(x []float64, ys [][]float64, opts StackOptions) []*Fill2D {
+	if !a.validate(checkStack("StackPlot", x, ys, opts)) || len(x) == 0 || len(ys) == 0 {
+		return nil
+	}
+	bottoms, tops := stackLayers(len(x), ys, opts)
+	fills := make([]*Fill2D, len(ys))
+	for i := range ys {
+		fills[i] = &Fill2D{
+			X:     x,
+			Y1:    tops[i],
+			Y2:    bottoms[i],
+			Color: a.stackColor(opts, i),
+			Label: stackLabel(opts, i),
+		}
+		a.Add(fills[i])
+	}
+	a.stackAxis(opts)
+	return fills
+}
+
+// BarStacked draws one Bar2D per layer of heights, each on top of the
+// previous ones at the same x. In StackPercent mode the y axis runs from 0
+// to 100 and is labeled with a PercentFormatter.
+func (a *Axes) BarStacked(x []float64, heights [][]float64, opts StackOptions) []*Bar2D {
+	if !a.validate(checkStack("BarStacked", x, heights, opts)) || len(x) == 0 || len(heights) == 0 {
+		return nil
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = 0.8
+	}
+	bottoms, tops := stackLayers(len(x), heights, opts)
+	bars := make([]*Bar2D, len(heights))
+	for i := range heights {
+		h := make([]float64, len(x))
+		for j := range h {
+			h[j] = tops[i][j] - bottoms[i][j]
+		}
+		bars[i] = &Bar2D{
+			X:       x,
+			Heights: h,
+			Bottoms: bottoms[i],
+			Width:   width,
+			Color:   a.stackColor(opts, i),
+			Alpha:   1,
+			Label:   stackLabel(opts, i),
+		}
+		a.Add(bars[i])
+	}
+	a.stackAxis(opts)
+	return bars
+}
+
+// stackLayers returns the lower and upper edge of every layer. NaNs and
+// missing values of short layers count as zero; in percent mode positions
+// where all layers are zero stay empty.
+func stackLayers(n int, ys [][]float64, opts StackOptions) (bottoms, tops [][]float64) {
+	scaled := make([][]float64, len(ys))
+	total := make([]float64, n)
+	for i, y := range ys {
+		w := 1.0
+		if i < len(opts.Weights) {
+			w = opts.Weights[i]
+		}
+		scaled[i] = make([]float64, n)
+		for j := range min(n, len(y)) {
+			if v := y[j] * w; !math.IsNaN(v) {
+				scaled[i][j] = v
+				total[j] += v
+			}
+		}
+	}
+	if opts.Mode == StackPercent {
+		for _, y := range scaled {
+			for j := range y {
+				if total[j] != 0 {
+					y[j] = y[j] / total[j] * 100
+				}
+			}
+		}
+	}
+
+	bottoms = make([][]float64, len(ys))
+	tops = make([][]float64, len(ys))
+	cur := make([]float64, n)
+	for i, y := range scaled {
+		bottoms[i] = append([]float64(nil), cur...)
+		for j := range cur {
+			cur[j] += y[j]
+		}
+		tops[i] = append([]float64(nil), cur...)
+	}
+	return bottoms, tops
+}
+
+// stackAxis sets up the y axis of a percent-stacked chart.
+func (a *Axes) stackAxis(opts StackOptions) {
+	if opts.Mode != StackPercent {
+		return
+	}
+	a.SetYLim(0, 100)
+	a.YAxis.Formatter = PercentFormatter{}
+}
+
+func (a *Axes) stackColor(opts StackOptions, i int) render.Color {
+	if i < len(opts.Colors) {
+		return opts.Colors[i]
+	}
+	return a.NextColor()
+}
+
+func stackLabel(opts StackOptions, i int) string {
+	if i < len(opts.Labels) {
+		return opts.Labels[i]
+	}
+	return ""
+}
+
+// checkStack requires every layer to match x and the weights to match the
+// layers.
+func checkStack(op string, x []float64, ys [][]float64, opts StackOptions) error {
+	if len(ys) == 0 {
+		return invalid(op, "no layers")
+	}
+	for i, y := range ys {
+		if err := checkLengths(op, fmt.Sprintf("x,layer %d", i), x, y); err != nil {
+			return err
+		}
+	}
+	if opts.Weights != nil && len(opts.Weights) != len(ys) {
+		return invalid(op, "%d weights for %d layers", len(opts.Weights), len(ys))
+	}
+	return nil
+}
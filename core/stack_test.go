@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestStackLayers_Percent(t *testing.T) {
+	ys := [][]float64{{1, 0, 2}, {1, 0, math.NaN()}, {2, 0, 2}}
+	bottoms, tops := stackLayers(3, ys, StackOptions{Mode: StackPercent, Weights: []float64{2, 1, 1}})
+
+	// x=0: weighted 2, 1, 2 of 5; x=1: all zero; x=2: 4, 0, 2 of 6.
+	wantTops := [][]float64{{40, 0, 200.0 / 3}, {60, 0, 200.0 / 3}, {100, 0, 100}}
+	for i := range wantTops {
+		for j, want := range wantTops[i] {
+			if math.Abs(tops[i][j]-want) > 1e-9 {
+				t.Errorf("tops[%d][%d] = %v, want %v", i, j, tops[i][j], want)
+			}
+		}
+	}
+	if bottoms[0][0] != 0 || bottoms[2][0] != tops[1][0] {
+		t.Errorf("bottoms = %v, want each layer on the previous", bottoms)
+	}
+}
+
+func TestAxes_BarStacked(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	bars := ax.BarStacked([]float64{0, 1}, [][]float64{{1, 3}, {3, 1}}, StackOptions{Mode: StackPercent, Labels: []string{"a", "b"}})
+	if len(bars) != 2 {
+		t.Fatalf("BarStacked returned %d layers, want 2", len(bars))
+	}
+	if got := bars[1].Bottoms; got[0] != 25 || got[1] != 75 {
+		t.Errorf("second layer bottoms = %v, want [25 75]", got)
+	}
+	if got := bars[1].Heights; got[0] != 75 || got[1] != 25 {
+		t.Errorf("second layer heights = %v, want [75 25]", got)
+	}
+	if bars[0].Label != "a" || bars[0].Color == bars[1].Color {
+		t.Errorf("layers should be labeled and cycle colors")
+	}
+	if _, ok := ax.YAxis.Formatter.(PercentFormatter); !ok {
+		t.Errorf("y formatter = %T, want PercentFormatter", ax.YAxis.Formatter)
+	}
+	if lo, hi := ax.YScale.Domain(); lo != 0 || hi != 100 {
+		t.Errorf("y limits = [%v, %v], want [0, 100]", lo, hi)
+	}
+
+	fills := ax.StackPlot([]float64{0, 1}, [][]float64{{1, 2}, {2, 2}}, StackOptions{})
+	if len(fills) != 2 || fills[1].Y2[1] != 2 || fills[1].Y1[1] != 4 {
+		t.Errorf("StackPlot layers = %+v", fills)
+	}
+
+	fig.Strict = true
+	if ax.StackPlot([]float64{0, 1}, [][]float64{{1}}, StackOptions{}) != nil {
+		t.Error("StackPlot accepted a short layer in strict mode")
+	}
+	if !errors.Is(fig.Err(), ErrInvalidData) {
+		t.Errorf("Err = %v, want ErrInvalidData", fig.Err())
+	}
+}
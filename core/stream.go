@@ -0,0 +1,143 @@
+package core
+
+import (
+	"io"
+	"math"
+
+	"matplotlib-go/internal/geom"
+)
+
+// DataSource streams the points of a series in chunks, e.g. from a
+// telemetry pipeline or a file larger than memory. NextChunk returns the
+// next points in order and whether more may follow; the chunk returned with
+// false, if any, is the last. Chunks are only read until the next call, so
+// a source may reuse its buffer. A source that is also an io.Closer, e.g.
+// one reading a file, is closed once it has been read.
+type DataSource interface {
+	NextChunk() ([]geom.Pt, bool)
+}
+
+// NewPointsSource returns a DataSource reading p in chunks of size points,
+// at least 1.
+func NewPointsSource(p Points, size int) DataSource {
+	return &pointsSource{p: p, size: max(size, 1)}
+}
+
+type pointsSource struct {
+	p    Points
+	size int
+	next int
+	buf  []geom.Pt
+}
+
+func (s *pointsSource) NextChunk() ([]geom.Pt, bool) {
+	n := s.p.Len()
+	s.buf = s.buf[:0]
+	for ; s.next < n && len(s.buf) < s.size; s.next++ {
+		s.buf = append(s.buf, s.p.At(s.next))
+	}
+	return s.buf, s.next < n
+}
+
+// PlotStream creates a line plot whose points are streamed from a source
+// opened anew by open on every draw and decimated on the fly, so that only
+// a few points per pixel column are held at a time. Options are as for
+// Plot. Each source is read to the end and closed if it is an io.Closer.
+// Streamed lines are only drawn: AutoScale, figure specs and data
+// labels see no points, and Fingerprint fails with ErrUnhashable since the
+// source may change between draws.
+func (a *Axes) PlotStream(open func() DataSource, opts ...PlotOption) *Line2D {
+	opt := resolvePlotOptions(opts)
+	if !a.validate(opt.check("PlotStream")) || open == nil {
+		return nil
+	}
+	line := a.newLine(opt)
+	line.Stream = open
+	a.Add(line)
+	return line
+}
+
+// streamPath reads all chunks of src, closing it if it is an io.Closer, and
+// returns the line through their pixel positions, decimated.
+func streamPath(src DataSource, ctx *DrawContext) geom.Path {
+	if c, ok := src.(io.Closer); ok {
+		defer c.Close() // drawing has no error to report
+	}
+	var d decimator
+	for more := true; more; {
+		var chunk []geom.Pt
+		chunk, more = src.NextChunk()
+		for _, v := range chunk {
+			d.add(ctx.DataToPixel.Apply(v))
+		}
+	}
+	d.flush()
+	return d.path
+}
+
+// decimator reduces a line in pixel space to at most four points per run
+// through a pixel column: the first, lowest, highest and last, in their
+// original order. The result covers the same pixels as the full line, so
+// series of any length draw with a few points per pixel of width.
+// Non-finite points break the line as in Line2D.
+type decimator struct {
+	path  geom.Path
+	gap   bool       // the next point starts a new piece
+	col   float64    // pixel column of the current run
+	n     int        // points seen, for ordering
+	run   [4]geom.Pt // first, lowest, highest and last point of the run
+	order [4]int     // their indices
+	inRun bool       // run holds points
+}
+
+func (d *decimator) add(q geom.Pt) {
+	d.n++
+	if !finitePt(q) {
+		d.flush()
+		d.gap = true
+		return
+	}
+	if col := math.Floor(q.X); !d.inRun || col != d.col {
+		d.flush()
+		d.col, d.inRun = col, true
+		d.run = [4]geom.Pt{q, q, q, q}
+		d.order = [4]int{d.n, d.n, d.n, d.n}
+		return
+	}
+	if q.Y < d.run[1].Y {
+		d.run[1], d.order[1] = q, d.n
+	}
+	if q.Y > d.run[2].Y {
+		d.run[2], d.order[2] = q, d.n
+	}
+	d.run[3], d.order[3] = q, d.n
+}
+
+// flush appends the current run to the path.
+func (d *decimator) flush() {
+	if !d.inRun {
+		return
+	}
+	d.inRun = false
+	// Sort the four points by index; duplicates are the same point.
+	idx := [4]int{0, 1, 2, 3}
+	for i := 1; i < 4; i++ {
+		for j := i; j > 0 && d.order[idx[j]] < d.order[idx[j-1]]; j-- {
+			idx[j], idx[j-1] = idx[j-1], idx[j]
+		}
+	}
+	last := -1
+	for _, i := range idx {
+		if d.order[i] == last {
+			continue
+		}
+		last = d.order[i]
+		if len(d.path.V) == 0 || d.gap {
+			d.path.C = append(d.path.C, geom.MoveTo)
+			d.gap = false
+		} else {
+			d.path.C = append(d.path.C, geom.LineTo)
+		}
+		d.path.V = append(d.path.V, d.run[i])
+	}
+}
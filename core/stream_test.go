@@ -0,0 +1,104 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestPlotStream(t *testing.T) {
+	fig := NewFigure(120, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetXLim(0, 1)
+	ax.SetYLim(-1, 1)
+
+	// 100k points of a fast sine over 120 px, with one gap.
+	const n = 100000
+	pts := make(PointSlice, n)
+	for i := range pts {
+		x := float64(i) / n
+		pts[i] = geom.Pt{X: x, Y: math.Sin(2000 * x)}
+	}
+	pts[n/2].Y = math.NaN()
+	opened := 0
+	line := ax.PlotStream(func() DataSource {
+		opened++
+		return NewPointsSource(pts, 4096)
+	})
+
+	r := &segmentRecorder{}
+	line.Draw(r, ax.drawContext(fig))
+	line.Draw(r, ax.drawContext(fig))
+	if opened != 2 || len(r.paths) != 2 {
+		t.Fatalf("opened %d sources for %d paths, want one per draw", opened, len(r.paths))
+	}
+	p := r.paths[0]
+	if len(p.V) > 4*(120+2) {
+		t.Errorf("%d vertices after decimation, want at most 4 per pixel column", len(p.V))
+	}
+	if moveCount(p) != 2 {
+		t.Errorf("%d pieces, want 2 around the NaN", moveCount(p))
+	}
+	// The extremes of the sine are kept.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range p.V {
+		lo, hi = math.Min(lo, v.Y), math.Max(hi, v.Y)
+	}
+	full := linePath(pts, ax.drawContext(fig))
+	flo, fhi := math.Inf(1), math.Inf(-1)
+	for _, v := range full.V {
+		flo, fhi = math.Min(flo, v.Y), math.Max(fhi, v.Y)
+	}
+	if lo != flo || hi != fhi {
+		t.Errorf("decimated y range %v..%v, full %v..%v", lo, hi, flo, fhi)
+	}
+
+	if _, err := Fingerprint(fig); !errors.Is(err, ErrUnhashable) {
+		t.Errorf("Fingerprint error %v, want ErrUnhashable", err)
+	}
+	if ax.PlotStream(nil) != nil {
+		t.Error("nil source plotted")
+	}
+}
+
+// closingSource counts how often it is closed.
+type closingSource struct {
+	DataSource
+	closed int
+}
+
+func (s *closingSource) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestPlotStream_Close(t *testing.T) {
+	fig := NewFigure(120, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	src := &closingSource{DataSource: NewPointsSource(PointSlice{{X: 0, Y: 0}, {X: 1, Y: 1}}, 1)}
+	line := ax.PlotStream(func() DataSource { return src })
+	line.Draw(&segmentRecorder{}, ax.drawContext(fig))
+	if src.closed != 1 {
+		t.Errorf("source closed %d times after a draw, want 1", src.closed)
+	}
+}
+
+func TestDecimator_KeepsOrder(t *testing.T) {
+	// Within one column: first, lowest, highest and last in input order.
+	var d decimator
+	for _, q := range []geom.Pt{{X: 0.1, Y: 5}, {X: 0.2, Y: 9}, {X: 0.3, Y: 7}, {X: 0.4, Y: 1}, {X: 0.5, Y: 4}, {X: 1.5, Y: 3}} {
+		d.add(q)
+	}
+	d.flush()
+	want := []float64{5, 9, 1, 4, 3}
+	if len(d.path.V) != len(want) {
+		t.Fatalf("vertices %v, want y %v", d.path.V, want)
+	}
+	for i, y := range want {
+		if d.path.V[i].Y != y {
+			t.Fatalf("vertices %v, want y %v", d.path.V, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"matplotlib-go/render"
+)
+
+// ErrInvalidData is wrapped by the errors a strict figure records, see
+// Figure.Strict.
+var ErrInvalidData = errors.New("invalid plot data")
+
+// Err returns the validation errors recorded since the figure was created
+// or since ClearErr, joined into one, or nil. Only strict figures record
+// errors.
+func (f *Figure) Err() error { return errors.Join(f.errs...) }
+
+// ClearErr discards the recorded validation errors.
+func (f *Figure) ClearErr() { f.errs = nil }
+
+// strictErr returns the recorded errors of a strict figure, for the save
+// functions to fail on.
+func (f *Figure) strictErr() error {
+	if !f.Strict {
+		return nil
+	}
+	return f.Err()
+}
+
+// validate reports whether a constructor or setter may go ahead despite
+// errs. Outside strict mode it always may; in strict mode any error is
+// recorded on the figure and the call is skipped.
+func (a *Axes) validate(errs ...error) bool {
+	err := errors.Join(errs...)
+	if err == nil || a.fig == nil || !a.fig.Strict {
+		return true
+	}
+	a.fig.errs = append(a.fig.errs, err)
+	return false
+}
+
+func invalid(op, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidData, op, fmt.Sprintf(format, args...))
+}
+
+// checkLengths requires the named slices to be non-empty and of equal
+// length. names is a comma-separated list matching data.
+func checkLengths(op, names string, data ...[]float64) error {
+	name := strings.Split(names, ",")
+	for i, d := range data {
+		if len(d) == 0 {
+			return invalid(op, "%s is empty", name[i])
+		}
+		if len(d) != len(data[0]) {
+			return invalid(op, "%s has %d values, %s has %d", name[0], len(data[0]), name[i], len(d))
+		}
+	}
+	return nil
+}
+
+// checkAngles requires one angle per point, if any are given.
+func checkAngles(op string, x, angles []float64) error {
+	if angles != nil && len(angles) != len(x) {
+		return invalid(op, "x has %d values, angles has %d", len(x), len(angles))
+	}
+	return nil
+}
+
+// checkLimits requires finite, distinct limits, and positive ones for log
+// scales.
+func checkLimits(op string, min, max float64, log bool) error {
+	switch {
+	case math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0):
+		return invalid(op, "limits [%v, %v] are not finite", min, max)
+	case min == max:
+		return invalid(op, "limits [%v, %v] span no range", min, max)
+	case log && (min <= 0 || max <= 0):
+		return invalid(op, "log limits [%v, %v] must be positive", min, max)
+	}
+	return nil
+}
+
+// checkAbove requires a finite scale parameter greater than lo.
+func checkAbove(op, name string, v, lo float64) error {
+	if !(v > lo) || math.IsInf(v, 0) {
+		return invalid(op, "%s %v is not a finite value above %v", name, v, lo)
+	}
+	return nil
+}
+
+// checkColor requires color components in [0, 1].
+func checkColor(op, name string, c *render.Color) error {
+	if c == nil {
+		return nil
+	}
+	for _, v := range []float64{c.R, c.G, c.B, c.A} {
+		if !(v >= 0 && v <= 1) {
+			return invalid(op, "%s %v has components outside [0, 1]", name, *c)
+		}
+	}
+	return nil
+}
+
+// checkWidth requires a finite, non-negative width.
+func checkWidth(op, name string, w *float64) error {
+	if w != nil && !(*w >= 0 && !math.IsInf(*w, 0)) {
+		return invalid(op, "%s %v is negative or not finite", name, *w)
+	}
+	return nil
+}
+
+// checkAlpha requires an alpha in [0, 1], which the constructors otherwise
+// ignore silently.
+func checkAlpha(op string, alpha *float64) error {
+	if alpha != nil && !(*alpha >= 0 && *alpha <= 1) {
+		return invalid(op, "alpha %v is outside [0, 1]", *alpha)
+	}
+	return nil
+}
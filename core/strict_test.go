@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestStrictMode(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+
+	// Lenient by default: mismatched slices are truncated.
+	line := ax.Plot([]float64{0, 1, 2}, []float64{0, 1})
+	if line == nil || len(line.XY) != 2 {
+		t.Fatalf("lenient Plot = %+v, want 2 points", line)
+	}
+	if fig.Err() != nil {
+		t.Fatalf("lenient figure recorded %v", fig.Err())
+	}
+
+	fig.Strict = true
+	if ax.Plot([]float64{0, 1, 2}, []float64{0, 1}) != nil {
+		t.Error("strict Plot accepted mismatched lengths")
+	}
+	if ax.Scatter(nil, nil) != nil {
+		t.Error("strict Scatter accepted empty data")
+	}
+	if ax.Bar([]float64{1}, []float64{2}, WithColor(render.Color{R: 2, A: 1})) != nil {
+		t.Error("strict Bar accepted an invalid color")
+	}
+	if ax.FillBetweenPlot([]float64{0, 1}, []float64{0, 1}, []float64{1, 2}, WithAlpha(1.5)) != nil {
+		t.Error("strict fill accepted alpha 1.5")
+	}
+	ax.SetXLim(0, math.NaN())
+	if min, max := ax.XScale.Domain(); min != 0 || max != 1 {
+		t.Errorf("strict SetXLim applied NaN limits: [%v, %v]", min, max)
+	}
+	if n := len(ax.Artists); n != 1 {
+		t.Errorf("%d artists, want only the lenient line", n)
+	}
+
+	// Valid input still works.
+	if ax.Plot([]float64{0, 1}, []float64{1, 0}, WithLineWidth(1)) == nil {
+		t.Error("strict Plot rejected valid data")
+	}
+
+	err := fig.Err()
+	if !errors.Is(err, ErrInvalidData) {
+		t.Fatalf("Err = %v, want ErrInvalidData", err)
+	}
+	if got := len(fig.errs); got != 5 {
+		t.Errorf("recorded %d errors, want 5: %v", got, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.png")
+	if err := SavePNG(fig, gobasic.New(100, 100, render.Color{}), path); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("SavePNG = %v, want the recorded errors", err)
+	}
+	fig.ClearErr()
+	if err := SavePNG(fig, gobasic.New(100, 100, render.Color{}), path); err != nil {
+		t.Errorf("SavePNG after ClearErr = %v", err)
+	}
+}
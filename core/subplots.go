@@ -0,0 +1,90 @@
+package core
+
+import "matplotlib-go/internal/geom"
+
+// SubplotOptions configures Figure.Subplots. The margins surround the
+// whole grid and are shared by its axes: only the outer axes border on
+// them, the inner ones are separated by WSpace and HSpace alone.
+type SubplotOptions struct {
+	Left, Right float64  // widths of the margins as figure fractions, 0 for room for y tick labels at the left and a font size at the right
+	Top, Bottom float64  // heights of the margins as figure fractions, 0 for room for a title above and x tick labels below
+	WSpace      *float64 // gap between columns as a fraction of the axes width, nil for 0.2
+	HSpace      *float64 // gap between rows as a fraction of the axes height, nil for 0.2
+}
+
+// Subplots adds an nrows×ncols grid of equally sized axes to the figure
+// and returns them indexed by row and column, row 0 at the top. It returns
+// nil if nrows or ncols is not positive.
+func (f *Figure) Subplots(nrows, ncols int, opts SubplotOptions) [][]*Axes {
+	if nrows <= 0 || ncols <= 0 {
+		return nil
+	}
+	cells := f.subplotsLayout(nrows, ncols, opts)
+	axes := make([][]*Axes, nrows)
+	for r := range axes {
+		axes[r] = make([]*Axes, ncols)
+		for c := range axes[r] {
+			axes[r][c] = f.AddAxes(cells[r*ncols+c])
+		}
+	}
+	return axes
+}
+
+// subplotsLayout returns the figure rectangles of an nrows×ncols grid in
+// row-major order. As in matplotlib, the gaps are fractions of the average
+// axes size, so that n columns and n-1 gaps of w·wspace fill the width
+// between the margins.
+func (f *Figure) subplotsLayout(nrows, ncols int, opts SubplotOptions) []geom.Rect {
+	wspace, hspace := 0.2, 0.2
+	if opts.WSpace != nil {
+		wspace = *opts.WSpace
+	}
+	if opts.HSpace != nil {
+		hspace = *opts.HSpace
+	}
+	return f.gridLayout(nrows, ncols, opts, func(w, h float64) (float64, float64) {
+		cw := w / (float64(ncols) + wspace*float64(ncols-1))
+		ch := h / (float64(nrows) + hspace*float64(nrows-1))
+		return cw * wspace, ch * hspace
+	})
+}
+
+// gridLayout returns the figure rectangles of an nrows×ncols grid of equal
+// cells in row-major order, between the margins of opts. gaps returns the
+// gaps between columns and between rows, given the width and height
+// between the margins, all as figure fractions.
+func (f *Figure) gridLayout(nrows, ncols int, opts SubplotOptions, gaps func(w, h float64) (hgap, vgap float64)) []geom.Rect {
+	fs := f.fontSize()
+	orDefault := func(v, def float64) float64 {
+		if v > 0 {
+			return v
+		}
+		return def
+	}
+	w, h := f.SizePx.X, f.SizePx.Y
+	left := orDefault(opts.Left, 4*fs/w)
+	right := 1 - orDefault(opts.Right, fs/w)
+	top := orDefault(opts.Top, 2*fs/h)
+	bottom := 1 - orDefault(opts.Bottom, 2.5*fs/h)
+	hgap, vgap := gaps(right-left, bottom-top)
+	cw := (right - left - float64(ncols-1)*hgap) / float64(ncols)
+	ch := (bottom - top - float64(nrows-1)*vgap) / float64(nrows)
+
+	cells := make([]geom.Rect, 0, nrows*ncols)
+	for r := range nrows {
+		for c := range ncols {
+			x := left + float64(c)*(cw+hgap)
+			y := top + float64(r)*(ch+vgap)
+			cells = append(cells, geom.Rect{Min: geom.Pt{X: x, Y: y}, Max: geom.Pt{X: x + cw, Y: y + ch}})
+		}
+	}
+	return cells
+}
+
+// fontSize returns the figure's font size in pixels, 12 if unset.
+func (f *Figure) fontSize() float64 {
+	if f.RC.FontSize <= 0 {
+		return 12
+	}
+	return f.RC.FontSize
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSubplots(t *testing.T) {
+	fig := NewFigure(1000, 500)
+	wspace, hspace := 0.25, 0.0
+	axes := fig.Subplots(2, 3, SubplotOptions{Left: 0.1, Right: 0.1, Top: 0.1, Bottom: 0.1, WSpace: &wspace, HSpace: &hspace})
+	if len(axes) != 2 || len(axes[0]) != 3 || len(fig.Children) != 6 {
+		t.Fatalf("got %d rows, %d axes in the figure", len(axes), len(fig.Children))
+	}
+	if axes[0][0] != fig.Children[0] || axes[1][2] != fig.Children[5] {
+		t.Error("axes not added in row-major order")
+	}
+
+	// Width 0.8 holds 3 axes and 2 gaps of a quarter axes: w = 0.8/3.5.
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }
+	w, h := 0.8/3.5, 0.4
+	for r, row := range axes {
+		for c, ax := range row {
+			got := ax.RectFraction
+			x, y := 0.1+float64(c)*1.25*w, 0.1+float64(r)*h
+			if !near(got.Min.X, x) || !near(got.Max.X, x+w) || !near(got.Min.Y, y) || !near(got.Max.Y, y+h) {
+				t.Errorf("axes [%d][%d] at %v, want x %v+%v, y %v+%v", r, c, got, x, w, y, h)
+			}
+		}
+	}
+
+	// The default margins and spacing keep the grid inside the figure.
+	fig = NewFigure(600, 400)
+	axes = fig.Subplots(2, 2, SubplotOptions{})
+	a, b, c := axes[0][0].RectFraction, axes[0][1].RectFraction, axes[1][0].RectFraction
+	if a.Min.X <= 0 || a.Min.Y <= 0 || a.Max.X >= b.Min.X || a.Max.Y >= c.Min.Y {
+		t.Errorf("default layout %v %v %v overlaps", a, b, c)
+	}
+	if d := axes[1][1].RectFraction; d.Max.X >= 1 || d.Max.Y >= 1 {
+		t.Errorf("last axes %v leaves the figure", d)
+	}
+
+	if fig.Subplots(0, 2, SubplotOptions{}) != nil {
+		t.Error("empty grid not rejected")
+	}
+}
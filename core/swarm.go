@@ -0,0 +1,189 @@
+package core
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// CategoryOptions configures StripPlot and SwarmPlot.
+type CategoryOptions struct {
+	Labels []string       // group names as x tick labels, nil to leave the x-axis alone
+	Colors []render.Color // per-group colors, nil for automatic cycling
+	Size   float64        // marker radius in pixels, 0 for 3
+	Jitter float64        // StripPlot: largest offset from the group center in data units, 0 for 0.2
+	Seed   int64          // StripPlot: seed of the jitter, 0 for the figure's "jitter" stream (Figure.Rand)
+	Width  float64        // SwarmPlot: largest spread of a group in data units, 0 for 0.8
+}
+
+// StripPlot draws the values of each group as points at x = 0, 1, ...,
+// spread sideways by a random jitter to reduce overplotting. The jitter
+// comes from opts.Seed or, if that is 0, from the figure's "jitter" stream
+// seeded by RC.Seed, so a plot is reproducible either way. It sets the x
+// limits to the groups and the y limits to the data; NaNs are skipped.
+func (a *Axes) StripPlot(groups [][]float64, opts CategoryOptions) []*Scatter2D {
+	jitter := opts.Jitter
+	if jitter <= 0 {
+		jitter = 0.2
+	}
+	rng := a.rand("jitter")
+	if opts.Seed != 0 {
+		rng = rand.New(rand.NewSource(opts.Seed))
+	}
+	return a.categoryPlot(groups, opts, func(i int, ys []float64) []float64 {
+		xs := make([]float64, len(ys))
+		for j := range xs {
+			xs[j] = float64(i) + (2*rng.Float64()-1)*jitter
+		}
+		return xs
+	})
+}
+
+// SwarmPlot draws the values of each group as points at x = 0, 1, ...,
+// moved sideways just enough that no markers overlap (a bee swarm), so
+// that the spread of the points shows the distribution. The layout is
+// deterministic. It sets the x limits to the groups and the y limits to
+// the data, and places the points for the axes' current pixel size:
+// resizing the figure or changing the y limits later does not re-solve
+// overlaps. Points of groups that need more than opts.Width are clamped
+// to its edges and may overlap. NaNs are skipped.
+func (a *Axes) SwarmPlot(groups [][]float64, opts CategoryOptions) []*Scatter2D {
+	width := opts.Width
+	if width <= 0 {
+		width = 0.8
+	}
+	return a.categoryPlot(groups, opts, func(i int, ys []float64) []float64 {
+		ctx := a.swarmContext()
+		p0 := ctx.DataToPixel.Apply(geom.Pt{})
+		p1 := ctx.DataToPixel.Apply(geom.Pt{X: 1, Y: 1})
+		sx, sy := math.Abs(p1.X-p0.X), math.Abs(p1.Y-p0.Y) // pixels per data unit
+		offsets := beeswarm(ys, sy, 2*categorySize(opts), width/2*sx)
+		xs := make([]float64, len(ys))
+		for j, o := range offsets {
+			xs[j] = float64(i) + o/sx
+		}
+		return xs
+	})
+}
+
+// categoryPlot sets up the axes for groups and adds one Scatter2D per
+// group at the x positions returned by place.
+func (a *Axes) categoryPlot(groups [][]float64, opts CategoryOptions, place func(i int, ys []float64) []float64) []*Scatter2D {
+	yr := emptyRange()
+	for _, g := range groups {
+		yr = yr.add(g...)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	a.SetXLim(-0.5, float64(len(groups))-0.5)
+	a.SetYLim(yr.margin(0.05))
+	if opts.Labels != nil && a.XAxis != nil {
+		locs := make([]float64, len(groups))
+		for i := range locs {
+			locs[i] = float64(i)
+		}
+		a.XAxis.Locator = FixedLocator{Locs: locs}
+		a.XAxis.Formatter = CategoryFormatter{Labels: opts.Labels}
+	}
+
+	out := make([]*Scatter2D, len(groups))
+	for i, g := range groups {
+		var ys []float64
+		for _, v := range g {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				ys = append(ys, v)
+			}
+		}
+		color := a.NextColor()
+		if i < len(opts.Colors) {
+			color = opts.Colors[i]
+		}
+		xs := place(i, ys)
+		s := &Scatter2D{
+			XY:    make([]geom.Pt, len(ys)),
+			Size:  categorySize(opts),
+			Color: color,
+			Alpha: 1,
+		}
+		for j := range ys {
+			s.XY[j] = geom.Pt{X: xs[j], Y: ys[j]}
+		}
+		a.Add(s)
+		out[i] = s
+	}
+	return out
+}
+
+func categorySize(opts CategoryOptions) float64 {
+	if opts.Size > 0 {
+		return opts.Size
+	}
+	return 3
+}
+
+// swarmContext returns the DrawContext of the axes, or one for a 640×480
+// pixel figure when the axes belongs to none.
+func (a *Axes) swarmContext() *DrawContext {
+	if a.fig != nil {
+		return a.drawContext(a.fig)
+	}
+	return a.drawContext(NewFigure(640, 480))
+}
+
+// beeswarm returns pixel offsets from the center line for values ys with
+// scale pixels per unit, so that circles of diameter d at the points do
+// not overlap. Points are placed from the smallest value up, each at the
+// free offset closest to the center, and clamped to ±limit.
+func beeswarm(ys []float64, scale, d, limit float64) []float64 {
+	order := make([]int, len(ys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return ys[order[i]] < ys[order[j]] })
+
+	type placed struct{ x, y float64 }
+	var done []placed
+	offsets := make([]float64, len(ys))
+	for _, i := range order {
+		y := ys[i] * scale
+		// Neighbours within reach vertically, and the candidate offsets
+		// touching them on either side.
+		var near []placed
+		candidates := []float64{0}
+		for k := len(done) - 1; k >= 0 && y-done[k].y < d; k-- {
+			p := done[k]
+			near = append(near, p)
+			dx := math.Sqrt(d*d - (y-p.y)*(y-p.y))
+			candidates = append(candidates, p.x-dx, p.x+dx)
+		}
+		sort.SliceStable(candidates, func(a, b int) bool {
+			ca, cb := math.Abs(candidates[a]), math.Abs(candidates[b])
+			if ca != cb {
+				return ca < cb
+			}
+			return candidates[a] < candidates[b]
+		})
+		x := candidates[0]
+		for _, c := range candidates {
+			free := true
+			for _, p := range near {
+				if math.Hypot(c-p.x, y-p.y) < d-1e-9 {
+					free = false
+					break
+				}
+			}
+			if free {
+				x = c
+				break
+			}
+		}
+		x = math.Max(-limit, math.Min(limit, x))
+		offsets[i] = x
+		done = append(done, placed{x, y})
+	}
+	return offsets
+}
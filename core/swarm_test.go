@@ -0,0 +1,70 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestStripPlot_Seeded(t *testing.T) {
+	groups := [][]float64{{1, 2, 3}, {4, math.NaN(), 5}}
+	plot := func(seed int64) []*Scatter2D {
+		fig := NewFigure(400, 300)
+		ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+		return ax.StripPlot(groups, CategoryOptions{Seed: seed, Labels: []string{"a", "b"}})
+	}
+	a, b, c := plot(1), plot(1), plot(2)
+	if len(a) != 2 || len(a[1].XY) != 2 {
+		t.Fatalf("got %d groups, %d points in the second", len(a), len(a[1].XY))
+	}
+	same := true
+	for i := range a {
+		for j := range a[i].XY {
+			p := a[i].XY[j]
+			if p != b[i].XY[j] {
+				t.Fatalf("same seed gave %v and %v", p, b[i].XY[j])
+			}
+			if math.Abs(p.X-float64(i)) > 0.2 {
+				t.Errorf("point %v jittered more than 0.2 from group %d", p, i)
+			}
+			same = same && p == c[i].XY[j]
+		}
+	}
+	if same {
+		t.Error("different seeds gave the same jitter")
+	}
+}
+
+func TestSwarmPlot_NoOverlap(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	vals := []float64{5, 5, 5, 5, 5.01, 4.99, 6, 3, 5.02}
+	s := ax.SwarmPlot([][]float64{vals, {1, 9}}, CategoryOptions{Labels: []string{"a", "b"}})
+	if len(s) != 2 || len(s[0].XY) != len(vals) {
+		t.Fatalf("unexpected result %v", s)
+	}
+	if _, ok := ax.XAxis.Formatter.(CategoryFormatter); !ok {
+		t.Error("x-axis not categorical")
+	}
+
+	ctx := ax.drawContext(fig)
+	px := make([]geom.Pt, len(s[0].XY))
+	for i, p := range s[0].XY {
+		px[i] = ctx.DataToPixel.Apply(p)
+		if math.Abs(p.X) > 0.4 {
+			t.Errorf("point %v outside the group width", p)
+		}
+	}
+	for i := range px {
+		for j := i + 1; j < len(px); j++ {
+			if d := math.Hypot(px[i].X-px[j].X, px[i].Y-px[j].Y); d < 6-1e-6 {
+				t.Errorf("points %d and %d are %.2f px apart", i, j, d)
+			}
+		}
+	}
+	// The lone extreme values stay on the center line.
+	if s[0].XY[6].X != 0 || s[0].XY[7].X != 0 {
+		t.Errorf("isolated points moved: %v %v", s[0].XY[6], s[0].XY[7])
+	}
+}
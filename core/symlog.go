@@ -0,0 +1,143 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/transform"
+)
+
+// SymLogLocator places ticks for symmetric log axes (transform.SymLog): at
+// 0 and at ±Base^k outside the linear range ±LinThresh. With more decades
+// than targetCount, every n-th decade is kept.
+type SymLogLocator struct {
+	Base      float64
+	LinThresh float64
+}
+
+func (l SymLogLocator) Ticks(min, max float64, targetCount int) []float64 {
+	if l.Base <= 1 || l.LinThresh <= 0 || math.IsNaN(min) || math.IsNaN(max) {
+		return nil
+	}
+	if min > max {
+		min, max = max, min
+	}
+	lb := math.Log(l.Base)
+	k0 := math.Ceil(math.Log(l.LinThresh)/lb - 1e-10)
+	k1 := math.Floor(math.Log(math.Max(math.Abs(min), math.Abs(max)))/lb + 1e-10)
+	stride := 1.0
+	if decades := 2 * (k1 - k0 + 1); targetCount > 0 && decades > float64(targetCount) {
+		stride = math.Ceil(decades / float64(targetCount))
+	}
+	var neg, pos []float64
+	for k := k0; k <= k1; k += stride {
+		v := math.Pow(l.Base, k)
+		if -v >= min {
+			neg = append(neg, -v)
+		}
+		if v <= max {
+			pos = append(pos, v)
+		}
+	}
+	ticks := make([]float64, 0, len(neg)+len(pos)+1)
+	for i := len(neg) - 1; i >= 0; i-- {
+		ticks = append(ticks, neg[i])
+	}
+	if min <= 0 && max >= 0 {
+		ticks = append(ticks, 0)
+	}
+	return append(ticks, pos...)
+}
+
+// SymLogFormatter labels symmetric log ticks like LogFormatter, with the
+// sign of negative values and "0" at zero.
+type SymLogFormatter struct {
+	Base       float64
+	Typography Typography
+}
+
+// WithTypography returns a copy that also uses the forms enabled in t.
+func (f SymLogFormatter) WithTypography(t Typography) Formatter {
+	f.Typography = f.Typography.union(t)
+	return f
+}
+
+func (f SymLogFormatter) Format(x float64) string {
+	if x == 0 {
+		return "0"
+	}
+	s := LogFormatter{Base: f.Base, Typography: f.Typography}.Format(math.Abs(x))
+	if x > 0 || math.IsNaN(x) {
+		return s
+	}
+	if f.Typography.UnicodeMinus {
+		return minusSign + s
+	}
+	return "-" + s
+}
+
+// SetXLimSymLog sets the x-axis to a symmetric log scale with the given
+// limits, linear within ±linThresh.
+func (a *Axes) SetXLimSymLog(min, max, linThresh, base float64) {
+	if !a.validate(checkLimits("SetXLimSymLog", min, max, false),
+		checkAbove("SetXLimSymLog", "linThresh", linThresh, 0), checkAbove("SetXLimSymLog", "base", base, 1)) {
+		return
+	}
+	a.XScale = transform.NewSymLog(min, max, linThresh, base)
+	if a.XAxis != nil {
+		a.XAxis.Locator = SymLogLocator{Base: base, LinThresh: linThresh}
+		a.XAxis.Formatter = SymLogFormatter{Base: base}
+		if a.XAxis.MinorLocator != nil {
+			a.XAxis.setMinor(a.XScale, true)
+		}
+	}
+}
+
+// SetYLimSymLog sets the y-axis to a symmetric log scale with the given
+// limits, linear within ±linThresh.
+func (a *Axes) SetYLimSymLog(min, max, linThresh, base float64) {
+	if !a.validate(checkLimits("SetYLimSymLog", min, max, false),
+		checkAbove("SetYLimSymLog", "linThresh", linThresh, 0), checkAbove("SetYLimSymLog", "base", base, 1)) {
+		return
+	}
+	a.YScale = transform.NewSymLog(min, max, linThresh, base)
+	if a.YAxis != nil {
+		a.YAxis.Locator = SymLogLocator{Base: base, LinThresh: linThresh}
+		a.YAxis.Formatter = SymLogFormatter{Base: base}
+		if a.YAxis.MinorLocator != nil {
+			a.YAxis.setMinor(a.YScale, true)
+		}
+	}
+}
+
+// SetXLimPower sets the x-axis to a power scale x^exp with the given
+// limits, e.g. exp 0.5 for a square root axis. Ticks stay at round
+// numbers (LinearLocator).
+func (a *Axes) SetXLimPower(min, max, exp float64) {
+	if !a.validate(checkLimits("SetXLimPower", min, max, false), checkAbove("SetXLimPower", "exp", exp, 0)) {
+		return
+	}
+	a.XScale = transform.NewPower(min, max, exp)
+	if a.XAxis != nil {
+		a.XAxis.Locator = LinearLocator{}
+		a.XAxis.Formatter = ScalarFormatter{Prec: 3}
+		if a.XAxis.MinorLocator != nil {
+			a.XAxis.setMinor(a.XScale, true)
+		}
+	}
+}
+
+// SetYLimPower sets the y-axis to a power scale y^exp with the given
+// limits, see SetXLimPower.
+func (a *Axes) SetYLimPower(min, max, exp float64) {
+	if !a.validate(checkLimits("SetYLimPower", min, max, false), checkAbove("SetYLimPower", "exp", exp, 0)) {
+		return
+	}
+	a.YScale = transform.NewPower(min, max, exp)
+	if a.YAxis != nil {
+		a.YAxis.Locator = LinearLocator{}
+		a.YAxis.Formatter = ScalarFormatter{Prec: 3}
+		if a.YAxis.MinorLocator != nil {
+			a.YAxis.setMinor(a.YScale, true)
+		}
+	}
+}
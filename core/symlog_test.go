@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/transform"
+)
+
+func TestSymLogTicks(t *testing.T) {
+	l := SymLogLocator{Base: 10, LinThresh: 1}
+	f := SymLogFormatter{Base: 10}
+	label := func(ticks []float64) []string {
+		var out []string
+		for _, v := range ticks {
+			out = append(out, f.Format(v))
+		}
+		return out
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	want := []string{"-1e2", "-1e1", "-1e0", "0", "1e0", "1e1", "1e2", "1e3"}
+	if got := label(l.Ticks(-500, 5000, 8)); !equal(got, want) {
+		t.Errorf("ticks %q, want %q", got, want)
+	}
+	// Twelve decades on each side are thinned to every third.
+	want = []string{"-1e9", "-1e6", "-1e3", "-1e0", "0", "1e0", "1e3", "1e6", "1e9"}
+	if got := label(l.Ticks(-1e11, 1e11, 8)); !equal(got, want) {
+		t.Errorf("thinned ticks %q, want %q", got, want)
+	}
+	if got := (SymLogFormatter{Base: 10, Typography: Typography{UnicodeMinus: true, TimesTen: true}}).Format(-100); got != "−10²" {
+		t.Errorf("typeset label %q", got)
+	}
+}
+
+func TestSetLimSymLogPower(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetXLimSymLog(-100, 100, 1, 10)
+	ax.SetYLimPower(0, 100, 0.5)
+	if _, ok := ax.XScale.(transform.SymLog); !ok {
+		t.Errorf("x scale %T", ax.XScale)
+	}
+	if _, ok := ax.XAxis.Locator.(SymLogLocator); !ok {
+		t.Errorf("x locator %T", ax.XAxis.Locator)
+	}
+	if u := ax.YScale.Fwd(25); u != 0.5 {
+		t.Errorf("y 25 at %v, want 0.5", u)
+	}
+}
+
+func TestSetLimSymLogPower_Strict(t *testing.T) {
+	fig := NewFigure(200, 200)
+	fig.Strict = true
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetXLimSymLog(-100, 100, 0, 10)
+	ax.SetXLimSymLog(-100, 100, 1, 1)
+	ax.SetYLimPower(0, 100, -1)
+	if ax.XScale != transform.NewLinear(0, 1) || ax.YScale != transform.NewLinear(0, 1) {
+		t.Errorf("invalid parameters applied: %#v %#v", ax.XScale, ax.YScale)
+	}
+	if !errors.Is(fig.Err(), ErrInvalidData) || len(fig.errs) != 3 {
+		t.Errorf("Err = %v, want 3 invalid data errors", fig.Err())
+	}
+}
+
+func TestSetLimSymLogPower_MinorTicks(t *testing.T) {
+	fig := NewFigure(200, 200)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.SetXLimLog(1, 100, 10)
+	ax.SetYLimLog(1, 100, 10)
+	ax.MinorTicksOn()
+	ax.SetXLimSymLog(-100, 100, 1, 10)
+	ax.SetYLimPower(0, 100, 0.5)
+	for _, axis := range []*Axis{ax.XAxis, ax.YAxis} {
+		if _, ok := axis.MinorLocator.(AutoMinorLocator); !ok {
+			t.Errorf("minor locator %#v after leaving the log scale, want AutoMinorLocator", axis.MinorLocator)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"sync"
+
+	"matplotlib-go/render"
+)
+
+// SyncFigure guards a Figure for concurrent use.
+//
+// A plain Figure is not safe for concurrent use: DrawFigure reorders artist
+// lists by z, and artists are read while drawing. SyncFigure serializes all
+// access, so a data goroutine can append points or artists through Update
+// while another goroutine renders with Draw. Every read or write of the
+// wrapped figure, its axes, and its artists must go through these methods.
+type SyncFigure struct {
+	mu  sync.Mutex
+	fig *Figure
+}
+
+// NewSyncFigure wraps fig. The caller must not use fig directly afterwards.
+func NewSyncFigure(fig *Figure) *SyncFigure {
+	return &SyncFigure{fig: fig}
+}
+
+// Update runs fn with exclusive access to the figure.
+func (s *SyncFigure) Update(fn func(fig *Figure)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.fig)
+}
+
+// Add appends an artist to ax, which must belong to the wrapped figure.
+func (s *SyncFigure) Add(ax *Axes, art Artist) {
+	s.Update(func(*Figure) { ax.Add(art) })
+}
+
+// Draw renders the figure into r. Updates wait until drawing finishes, so a
+// frame never shows a half-applied change.
+func (s *SyncFigure) Draw(r render.Renderer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	DrawFigure(s.fig, r)
+}
+
+// Fingerprint returns the figure fingerprint, taken under the lock.
+func (s *SyncFigure) Fingerprint() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Fingerprint(s.fig)
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// countingRenderer counts paths to observe what a frame contained.
+type countingRenderer struct {
+	render.NullRenderer
+	paths int
+}
+
+func (c *countingRenderer) Path(_ geom.Path, _ *render.Paint) { c.paths++ }
+
+func TestSyncFigureConcurrentAppend(t *testing.T) {
+	fig := NewFigure(100, 100)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	ax.XAxis, ax.YAxis = nil, nil
+	line := &Line2D{W: 1, Col: render.Color{A: 1}}
+	ax.Add(line)
+	sf := NewSyncFigure(fig)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			sf.Update(func(*Figure) {
+				line.XY = append(line.XY, geom.Pt{X: float64(i) / 200, Y: 0.5})
+			})
+			if i%50 == 0 {
+				sf.Add(ax, &Scatter2D{XY: []geom.Pt{{X: 0.5, Y: 0.5}}, Size: 2, z: float64(-i)})
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			sf.Draw(&countingRenderer{})
+		}
+	}()
+	wg.Wait()
+
+	var r countingRenderer
+	sf.Draw(&r)
+	if r.paths != 5 { // one line and four scatter markers
+		t.Errorf("expected 5 paths, got %d", r.paths)
+	}
+	sf.Update(func(f *Figure) {
+		if n := len(line.XY); n != 200 {
+			t.Errorf("expected 200 points, got %d", n)
+		}
+	})
+}
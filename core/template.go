@@ -0,0 +1,110 @@
+package core
+
+import (
+	"slices"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/style"
+)
+
+// AxesTemplate is the style of an Axes, taken with Axes.Template and
+// applied to other axes with ApplyTemplate or Figure.AddAxesFrom, so that
+// charts built in different places look alike. It holds copies: changing
+// a template does not affect the axes it was taken from or applied to.
+type AxesTemplate struct {
+	RC        *style.RC // nil inherits the figure's RC
+	XAxis     *Axis     // nil for no x-axis
+	YAxis     *Axis     // nil for no y-axis
+	OtherAxes []*Axis
+	Grids     []*Grid
+	Palette   color.Palette // color cycle colors, nil keeps the default cycle
+}
+
+// Template returns the style of the axes: its RC, axes including their
+// locators and formatters, grids and color cycle colors. Data, limits and
+// text are not part of it.
+func (a *Axes) Template() AxesTemplate {
+	var t AxesTemplate
+	if a.RC != nil {
+		rc := *a.RC
+		t.RC = &rc
+	}
+	t.XAxis, t.YAxis, t.OtherAxes = copyAxes(a.XAxis, a.YAxis, a.OtherAxes)
+	for _, art := range a.Artists {
+		if g, ok := art.(*Grid); ok {
+			t.Grids = append(t.Grids, copyGrid(g))
+		}
+	}
+	if c := a.ColorCycle; c != nil {
+		for i := range c.Length() {
+			t.Palette = append(t.Palette, c.At(i))
+		}
+	}
+	return t
+}
+
+// ApplyTemplate gives the axes the style of t, replacing its RC, axes,
+// grids and color cycle.
+func (a *Axes) ApplyTemplate(t AxesTemplate) {
+	a.RC = nil
+	if t.RC != nil {
+		rc := *t.RC
+		a.RC = &rc
+	}
+	a.XAxis, a.YAxis, a.OtherAxes = copyAxes(t.XAxis, t.YAxis, t.OtherAxes)
+	a.Artists = slices.DeleteFunc(a.Artists, func(art Artist) bool {
+		_, ok := art.(*Grid)
+		return ok
+	})
+	for _, g := range t.Grids {
+		a.Add(copyGrid(g))
+	}
+	a.ColorCycle = color.NewColorCycle(slices.Clone(t.Palette))
+}
+
+// AddAxesFrom adds an Axes styled by t. As with AddAxes, opts override
+// individual RC settings; they apply to the template's RC, or to the
+// figure's when the template inherits it.
+func (f *Figure) AddAxesFrom(r geom.Rect, t AxesTemplate, opts ...style.Option) *Axes {
+	ax := f.AddAxes(r)
+	ax.ApplyTemplate(t)
+	if len(opts) > 0 {
+		ax.RC = new(style.RC)
+		*ax.RC = style.Apply(ax.effectiveRC(f), opts...)
+	}
+	return ax
+}
+
+// copyAxes copies x, y and others, pointing mirrors among them to the
+// copies.
+func copyAxes(x, y *Axis, others []*Axis) (*Axis, *Axis, []*Axis) {
+	copies := make(map[*Axis]*Axis)
+	dup := func(ax *Axis) *Axis {
+		if ax == nil {
+			return nil
+		}
+		c := *ax
+		copies[ax] = &c
+		return &c
+	}
+	cx, cy := dup(x), dup(y)
+	var co []*Axis
+	for _, ax := range others {
+		co = append(co, dup(ax))
+	}
+	for _, c := range copies {
+		if m, ok := copies[c.Mirror]; ok {
+			c.Mirror = m
+		}
+	}
+	return cx, cy, co
+}
+
+func copyGrid(g *Grid) *Grid {
+	c := *g
+	c.Dashes = slices.Clone(g.Dashes)
+	c.MinorDashes = slices.Clone(g.MinorDashes)
+	c.MinorSubs = slices.Clone(g.MinorSubs)
+	return &c
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/color"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/style"
+)
+
+func TestAxesTemplate(t *testing.T) {
+	fig := NewFigure(400, 300)
+	src := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 0.5, Y: 1}}, style.WithFont("Mono", 9))
+	src.XAxis.Color = render.Color{R: 1, A: 1}
+	src.YAxis.ShowTicks = false
+	top := src.MirrorAxis(AxisTop)
+	g := src.AddYGrid()
+	g.Dashes = []float64{2, 2}
+	src.ColorCycle = color.NewColorCycle(color.Palette{{G: 1, A: 1}})
+	src.Plot([]float64{0, 1}, []float64{0, 1})
+
+	tmpl := src.Template()
+	dst := fig.AddAxesFrom(geom.Rect{Min: geom.Pt{X: 0.5}, Max: geom.Pt{X: 1, Y: 1}}, tmpl, style.WithFont("Mono", 11))
+
+	if dst.RC == nil || dst.RC.FontKey != "Mono" || dst.RC.FontSize != 11 || src.RC.FontSize != 9 {
+		t.Errorf("RC not inherited and overridden: %+v", dst.RC)
+	}
+	if dst.XAxis == src.XAxis || dst.XAxis.Color != src.XAxis.Color || dst.YAxis.ShowTicks {
+		t.Error("axes not copied")
+	}
+	if len(dst.OtherAxes) != 1 || dst.OtherAxes[0] == top || dst.OtherAxes[0].Mirror != dst.XAxis {
+		t.Error("mirrored top axis should mirror the new x-axis")
+	}
+	if len(dst.Artists) != 1 {
+		t.Fatalf("got %d artists, want only the grid", len(dst.Artists))
+	}
+	dg := dst.Artists[0].(*Grid)
+	dg.Dashes[0] = 5
+	if g.Dashes[0] != 2 || dg.Axis != AxisLeft {
+		t.Error("grid not copied")
+	}
+	if c := dst.NextColor(); c != (render.Color{G: 1, A: 1}) {
+		t.Errorf("color cycle starts with %v", c)
+	}
+
+	// Applying replaces the grids and restores figure RC inheritance.
+	plain := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	plain.AddXGrid()
+	plain.AddYGrid()
+	src.RC = nil
+	plain.ApplyTemplate(src.Template())
+	if plain.RC != nil || len(plain.Artists) != 1 {
+		t.Errorf("apply: RC %v, %d artists", plain.RC, len(plain.Artists))
+	}
+}
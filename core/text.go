@@ -0,0 +1,210 @@
+package core
+
+import (
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// HAlign selects which point of a text along its baseline lies at the
+// anchor.
+type HAlign uint8
+
+const (
+	HAlignLeft   HAlign = iota // start of the text at the anchor
+	HAlignCenter               // middle of the text at the anchor
+	HAlignRight                // end of the text at the anchor
+)
+
+// VAlign selects which line of a text lies at the anchor.
+type VAlign uint8
+
+const (
+	VAlignBaseline VAlign = iota // baseline at the anchor
+	VAlignTop                    // top of the tallest glyphs at the anchor
+	VAlignCenter                 // middle between top and bottom at the anchor
+	VAlignBottom                 // bottom of the descenders at the anchor
+)
+
+// Text is a single line of text anchored at a point, the equivalent of
+// matplotlib's Text artist. It draws through render.TextDrawer, so it
+// works on every backend with text support, and takes part in z-ordering
+// like other artists. Rotated text needs render.RotatedTextDrawer; other
+// renderers draw it upright.
+//
+// Vertical text stacks its characters top to bottom in a column, the
+// traditional layout of Chinese, Japanese and Korean, e.g. for a y label:
+//
+//	label := ax.AddText(geom.Pt{X: -0.08, Y: 0.5}, "温度")
+//	label.Coords, label.Vertical = core.CoordsAxes, true
+//	label.HAlign, label.VAlign = core.HAlignCenter, core.VAlignCenter
+type Text struct {
+	Pos      geom.Pt      // anchor point
+	Coords   Coords       // coordinate system of Pos
+	Text     string       // content
+	HAlign   HAlign       // horizontal alignment at Pos
+	VAlign   VAlign       // vertical alignment at Pos
+	Rotation float64      // degrees counterclockwise around Pos, ignored for vertical text
+	Vertical bool         // characters stacked top to bottom, aligned by the column's box
+	FontSize float64      // size in pixels, 0 for the RC font size
+	Color    render.Color // text color, 0 alpha for the RC text color
+	z        float64      // z-order
+}
+
+// AddText adds text anchored at pos in data coordinates. Set Coords to
+// anchor it relative to the axes or figure instead.
+func (a *Axes) AddText(pos geom.Pt, text string) *Text {
+	t := &Text{Pos: pos, Text: text, z: 100} // above data, below legends
+	a.Add(t)
+	return t
+}
+
+// AddText adds figure-level text anchored at pos in figure fractions,
+// (0,0) lower left, e.g. for a suptitle or a footnote.
+func (f *Figure) AddText(pos geom.Pt, text string) *Text {
+	t := &Text{Pos: pos, Text: text}
+	f.Add(t)
+	return t
+}
+
+// Draw renders the text.
+func (t *Text) Draw(r render.Renderer, ctx *DrawContext) {
+	td, ok := r.(render.TextDrawer)
+	if !ok || t.Text == "" {
+		return
+	}
+	if t.Vertical {
+		chars, origins, size, color := t.verticalLayout(r, ctx)
+		for i, ch := range chars {
+			td.DrawText(ch, origins[i], size, color)
+		}
+		return
+	}
+	origin, angle, size, color := t.layout(r, ctx)
+	if angle != 0 {
+		r.(render.RotatedTextDrawer).DrawTextRotated(t.Text, origin, size, angle, color)
+		return
+	}
+	td.DrawText(t.Text, origin, size, color)
+}
+
+// layout returns the baseline origin, angle in radians, size and color the
+// text is drawn with by r.
+func (t *Text) layout(r render.Renderer, ctx *DrawContext) (geom.Pt, float64, float64, render.Color) {
+	size, color := t.style(ctx)
+	angle := 0.0
+	if _, ok := r.(render.RotatedTextDrawer); ok {
+		angle = t.Rotation * math.Pi / 180
+	}
+
+	m := r.MeasureText(t.Text, size, ctx.RC.FontKey)
+	tr := ctx.coords(t.Coords)
+	anchor := tr.Apply(t.Pos)
+	f := float64(t.HAlign) / 2 // fraction of the width left of the anchor
+	var up float64             // height of the anchor above the baseline
+	switch t.VAlign {
+	case VAlignTop:
+		up = m.Ascent
+	case VAlignCenter:
+		up = (m.Ascent - m.Descent) / 2
+	case VAlignBottom:
+		up = -m.Descent
+	}
+	sin, cos := math.Sincos(angle)
+	dir := geom.Pt{X: cos, Y: -sin}    // along the baseline
+	upDir := geom.Pt{X: -sin, Y: -cos} // towards the top of the glyphs
+	origin := geom.Pt{
+		X: anchor.X - f*m.W*dir.X - up*upDir.X,
+		Y: anchor.Y - f*m.W*dir.Y - up*upDir.Y,
+	}
+	return origin, angle, size, color
+}
+
+// verticalLayout returns the characters of vertical text with their
+// baseline origins, and the size and color they are drawn with. Each
+// character is centered in a column as wide as the widest one, and the
+// characters are one line height apart.
+func (t *Text) verticalLayout(r render.Renderer, ctx *DrawContext) ([]string, []geom.Pt, float64, render.Color) {
+	size, color := t.style(ctx)
+	line := r.MeasureText(t.Text, size, ctx.RC.FontKey)
+	pitch := line.Ascent + line.Descent
+	var chars []string
+	var widths []float64
+	colW := 0.0
+	for _, ch := range t.Text {
+		m := r.MeasureText(string(ch), size, ctx.RC.FontKey)
+		chars = append(chars, string(ch))
+		widths = append(widths, m.W)
+		colW = math.Max(colW, m.W)
+	}
+	colH := float64(len(chars)) * pitch
+
+	tr := ctx.coords(t.Coords)
+	anchor := tr.Apply(t.Pos)
+	left := anchor.X - float64(t.HAlign)/2*colW
+	top := anchor.Y // VAlignBaseline and VAlignTop
+	switch t.VAlign {
+	case VAlignCenter:
+		top -= colH / 2
+	case VAlignBottom:
+		top -= colH
+	}
+	origins := make([]geom.Pt, len(chars))
+	for i, w := range widths {
+		origins[i] = geom.Pt{X: left + (colW-w)/2, Y: top + float64(i)*pitch + line.Ascent}
+	}
+	return chars, origins, size, color
+}
+
+// style returns the size and color of the text.
+func (t *Text) style(ctx *DrawContext) (float64, render.Color) {
+	size := t.FontSize
+	if size <= 0 {
+		size = ctx.RC.FontSize
+	}
+	if size <= 0 {
+		size = 12
+	}
+	color := t.Color
+	if color.A == 0 {
+		color = rcColor(ctx.RC.TextColor)
+	}
+	return size, color
+}
+
+// Extent returns the pixel bounds of the text as drawn by r, e.g. to avoid
+// overlaps with other labels. It is empty for renderers without text
+// support.
+func (t *Text) Extent(r render.Renderer, ctx *DrawContext) geom.Rect {
+	if _, ok := r.(render.TextDrawer); !ok || t.Text == "" {
+		return geom.Rect{}
+	}
+	if t.Vertical {
+		chars, origins, size, _ := t.verticalLayout(r, ctx)
+		var b geom.Rect
+		for i, ch := range chars {
+			box := r.MeasureText(ch, size, ctx.RC.FontKey).Bounds(origins[i], 0)
+			if i == 0 {
+				b = box
+				continue
+			}
+			b.Min.X, b.Min.Y = math.Min(b.Min.X, box.Min.X), math.Min(b.Min.Y, box.Min.Y)
+			b.Max.X, b.Max.Y = math.Max(b.Max.X, box.Max.X), math.Max(b.Max.Y, box.Max.Y)
+		}
+		return b
+	}
+	origin, angle, size, _ := t.layout(r, ctx)
+	return r.MeasureText(t.Text, size, ctx.RC.FontKey).Bounds(origin, angle)
+}
+
+// Z returns the z-order for sorting.
+func (t *Text) Z() float64 {
+	return t.z
+}
+
+// Bounds returns an empty rect; the extent depends on the renderer's font
+// metrics, see Extent.
+func (t *Text) Bounds(*DrawContext) geom.Rect {
+	return geom.Rect{}
+}
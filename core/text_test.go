@@ -0,0 +1,129 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// fixedTextRenderer measures every text as 40×(8 up, 2 down) pixels and
+// records what is drawn.
+type fixedTextRenderer struct {
+	render.NullRenderer
+	texts   []string
+	origins []geom.Pt
+	angles  []float64
+	colors  []render.Color
+}
+
+func (f *fixedTextRenderer) MeasureText(string, float64, string) render.TextMetrics {
+	return render.TextMetrics{W: 40, H: 10, Ascent: 8, Descent: 2}
+}
+
+func (f *fixedTextRenderer) DrawText(text string, origin geom.Pt, _ float64, c render.Color) {
+	f.DrawTextRotated(text, origin, 0, 0, c)
+}
+
+func (f *fixedTextRenderer) DrawTextRotated(text string, origin geom.Pt, _, angle float64, c render.Color) {
+	f.texts = append(f.texts, text)
+	f.origins = append(f.origins, origin)
+	f.angles = append(f.angles, angle)
+	f.colors = append(f.colors, c)
+}
+
+func TestText_Alignment(t *testing.T) {
+	ctx := createTestDrawContext() // data (10, 10) is pixel (150, 350)
+	tests := []struct {
+		h    HAlign
+		v    VAlign
+		want geom.Pt
+	}{
+		{HAlignLeft, VAlignBaseline, geom.Pt{X: 150, Y: 350}},
+		{HAlignCenter, VAlignTop, geom.Pt{X: 130, Y: 358}},
+		{HAlignRight, VAlignBottom, geom.Pt{X: 110, Y: 348}},
+		{HAlignLeft, VAlignCenter, geom.Pt{X: 150, Y: 353}},
+	}
+	for _, tt := range tests {
+		r := &fixedTextRenderer{}
+		txt := &Text{Pos: geom.Pt{X: 10, Y: 10}, Text: "label", HAlign: tt.h, VAlign: tt.v}
+		txt.Draw(r, ctx)
+		if len(r.origins) != 1 || !near(r.origins[0], tt.want) {
+			t.Errorf("%d/%d: origin %v, want %v", tt.h, tt.v, r.origins, tt.want)
+		}
+		if r.colors[0] != (render.Color{A: 1}) {
+			t.Errorf("default color %v, want the RC text color", r.colors[0])
+		}
+	}
+}
+
+func TestText_Rotation(t *testing.T) {
+	ctx := createTestDrawContext()
+	r := &fixedTextRenderer{}
+	txt := &Text{Pos: geom.Pt{X: 10, Y: 10}, Text: "up", Rotation: 90, HAlign: HAlignCenter, VAlign: VAlignCenter}
+	txt.Draw(r, ctx)
+	// Reading upwards, centered: the baseline starts 20 px below and 3 px
+	// right of the anchor.
+	if !near(r.origins[0], geom.Pt{X: 153, Y: 370}) || math.Abs(r.angles[0]-math.Pi/2) > 1e-12 {
+		t.Errorf("origin %v angle %v", r.origins[0], r.angles[0])
+	}
+	ext := txt.Extent(r, ctx)
+	if !near(ext.Min, geom.Pt{X: 145, Y: 330}) || !near(ext.Max, geom.Pt{X: 155, Y: 370}) {
+		t.Errorf("extent %v", ext)
+	}
+}
+
+func TestText_Vertical(t *testing.T) {
+	ctx := createTestDrawContext()
+	r := &fixedTextRenderer{}
+	txt := &Text{Pos: geom.Pt{X: 10, Y: 10}, Text: "温度计", Vertical: true, Rotation: 90, HAlign: HAlignCenter, VAlign: VAlignCenter}
+	txt.Draw(r, ctx)
+	// Three 10 px lines centered on (150, 350) in a 40 px column.
+	want := []geom.Pt{{X: 130, Y: 343}, {X: 130, Y: 353}, {X: 130, Y: 363}}
+	if len(r.texts) != 3 || r.texts[0] != "温" || r.texts[2] != "计" {
+		t.Fatalf("drawn %q, want one character per call", r.texts)
+	}
+	for i, o := range r.origins {
+		if !near(o, want[i]) || r.angles[i] != 0 {
+			t.Errorf("character %d at %v angle %v, want %v upright", i, o, r.angles[i], want[i])
+		}
+	}
+	ext := txt.Extent(r, ctx)
+	if !near(ext.Min, geom.Pt{X: 130, Y: 335}) || !near(ext.Max, geom.Pt{X: 170, Y: 365}) {
+		t.Errorf("extent %v", ext)
+	}
+
+	r = &fixedTextRenderer{}
+	txt.HAlign, txt.VAlign = HAlignLeft, VAlignBottom
+	txt.Draw(r, ctx)
+	if !near(r.origins[0], geom.Pt{X: 150, Y: 328}) {
+		t.Errorf("bottom-left aligned column starts at %v", r.origins[0])
+	}
+}
+
+func TestText_ZOrderAndCoords(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	txt := ax.AddText(geom.Pt{X: 0.5, Y: 0.5}, "center")
+	txt.Coords = CoordsAxes
+	txt.Color = render.Color{R: 1, A: 1}
+	ax.Plot([]float64{0, 1}, []float64{0, 1})
+	if ax.drawOrder()[len(ax.drawOrder())-1] == Artist(txt) {
+		t.Error("text drawn above the axes")
+	}
+	sup := fig.AddText(geom.Pt{X: 0.5, Y: 1}, "suptitle")
+	sup.HAlign, sup.VAlign = HAlignCenter, VAlignTop
+
+	r := &fixedTextRenderer{}
+	DrawFigure(fig, r)
+	var got []geom.Pt
+	for i, c := range r.colors {
+		if c == txt.Color || i == len(r.colors)-1 {
+			got = append(got, r.origins[i])
+		}
+	}
+	if len(got) != 2 || !near(got[0], geom.Pt{X: 200, Y: 150}) || !near(got[1], geom.Pt{X: 180, Y: 8}) {
+		t.Errorf("text origins %v", got)
+	}
+}
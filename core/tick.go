@@ -81,12 +81,18 @@ func (LinearLocator) Ticks(min, max float64, targetCount int) []float64 {
 
 // LogLocator produces logarithmic ticks for positive domains. Major ticks
 // at Base^k within [min,max]. If Minor is true, places minor ticks at
-// 2×Base^k and 5×Base^k where they lie within [min,max].
+// s×Base^k for each s in Subs where they lie within [min,max].
 type LogLocator struct {
 	Base  float64
 	Minor bool
+	Subs  []float64 // minor multiples of each power, e.g. LogSubsAll(); nil for 2 and 5
 }
 
+// LogSubsAll returns the multiples 2, 3, ..., 9 for minor ticks at every
+// integer multiple of each power of ten, as matplotlib places them on
+// base-10 axes spanning few decades. Each call returns a new slice.
+func LogSubsAll() []float64 { return []float64{2, 3, 4, 5, 6, 7, 8, 9} }
+
 func (l LogLocator) Ticks(min, max float64, targetCount int) []float64 {
 	base := l.Base
 	if base <= 1 {
@@ -98,6 +104,10 @@ func (l LogLocator) Ticks(min, max float64, targetCount int) []float64 {
 	if min <= 0 || max <= 0 {
 		return nil
 	}
+	subs := l.Subs
+	if subs == nil {
+		subs = []float64{2, 5}
+	}
 	// Find exponent range
 	lb := math.Log(base)
 	kmin := math.Ceil(math.Log(min) / lb)
@@ -105,19 +115,16 @@ func (l LogLocator) Ticks(min, max float64, targetCount int) []float64 {
 	var ticks []float64
 	// Majors
 	for k := kmin; k <= kmax; k++ {
-		v := math.Pow(base, k)
-		if v >= min && v <= max {
+		if v := math.Pow(base, k); v >= min && v <= max {
 			ticks = append(ticks, v)
 		}
-		if l.Minor {
-			// Minors at 2,5 per decade (common convention)
-			m2 := 2 * math.Pow(base, k)
-			m5 := 5 * math.Pow(base, k)
-			if m2 > v && m2 < math.Pow(base, k+1) && m2 >= min && m2 <= max {
-				ticks = append(ticks, m2)
-			}
-			if m5 > v && m5 < math.Pow(base, k+1) && m5 >= min && m5 <= max {
-				ticks = append(ticks, m5)
+	}
+	// Minors, including those below the first major
+	for k := kmin - 1; l.Minor && k <= kmax; k++ {
+		v := math.Pow(base, k)
+		for _, s := range subs {
+			if m := s * v; s > 1 && s < base && m >= min && m <= max {
+				ticks = append(ticks, m)
 			}
 		}
 	}
@@ -136,9 +143,94 @@ func (l LogLocator) Ticks(min, max float64, targetCount int) []float64 {
 	return out
 }
 
+// MinorTicks returns the minor positions s×Base^k for s in Subs within
+// [min,max], without the majors. It is independent of the Minor flag.
+func (l LogLocator) MinorTicks(min, max float64) []float64 {
+	all := LogLocator{Base: l.Base, Minor: true, Subs: l.Subs}.Ticks(min, max, 0)
+	majors := LogLocator{Base: l.Base}.Ticks(min, max, 0)
+	out := make([]float64, 0, len(all))
+	j := 0
+	for _, v := range all {
+		for j < len(majors) && majors[j] < v {
+			j++
+		}
+		if j < len(majors) && majors[j] == v {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// AutoMinorLocator places minor ticks by dividing each interval between
+// the LinearLocator majors for the same target count, skipping the majors,
+// like matplotlib's AutoMinorLocator. Use it as Axis.MinorLocator of
+// linear axes.
+type AutoMinorLocator struct {
+	Divisions int // minor intervals per major interval, 0 for 4 on steps of 2 or 2.5 times a power of ten and 5 otherwise
+}
+
+func (l AutoMinorLocator) Ticks(min, max float64, targetCount int) []float64 {
+	if min > max {
+		min, max = max, min
+	}
+	major := LinearLocator{}.Ticks(min, max, targetCount)
+	if len(major) < 2 {
+		return nil
+	}
+	step := major[1] - major[0]
+	n := l.Divisions
+	if n <= 0 {
+		n = autoMinorDivisions(step)
+	}
+	ms := step / float64(n)
+	var minor []float64
+	for j := math.Ceil(min / ms); j <= math.Floor(max/ms); j++ {
+		v := j * ms
+		if k := v / step; math.Abs(k-math.Round(k)) < 1e-9 {
+			continue // on a major tick
+		}
+		minor = append(minor, v)
+	}
+	return minor
+}
+
+// autoMinorDivisions picks 4 minor intervals for major steps of 2 or 2.5
+// times a power of ten, and 5 otherwise, like matplotlib's AutoMinorLocator.
+func autoMinorDivisions(step float64) int {
+	mantissa := step / math.Pow(10, math.Floor(math.Log10(step)))
+	if math.Abs(mantissa-2) < 1e-9 || math.Abs(mantissa-2.5) < 1e-9 {
+		return 4
+	}
+	return 5
+}
+
+// FixedLocator places ticks at the given positions, e.g. at the centers of
+// categories, ignoring the target count.
+type FixedLocator struct{ Locs []float64 }
+
+func (l FixedLocator) Ticks(min, max float64, _ int) []float64 {
+	var out []float64
+	for _, v := range l.Locs {
+		if v >= min && v <= max {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // ScalarFormatter formats numbers with fixed precision and trims trailing zeros.
 // Uses scientific notation if |x| >= 1e6 or (0 < |x| <= 1e-4).
-type ScalarFormatter struct{ Prec int }
+type ScalarFormatter struct {
+	Prec       int
+	Typography Typography // publication forms of minus signs, digits and exponents
+}
+
+// WithTypography returns a copy that also uses the forms enabled in t.
+func (f ScalarFormatter) WithTypography(t Typography) Formatter {
+	f.Typography = f.Typography.union(t)
+	return f
+}
 
 func (f ScalarFormatter) Format(x float64) string {
 	if math.IsNaN(x) {
@@ -155,33 +247,39 @@ func (f ScalarFormatter) Format(x float64) string {
 		p = 0
 	}
 	ax := math.Abs(x)
-	var s string
+	s := strconv.FormatFloat(x, 'f', p, 64)
+	exp := ""
 	if (ax >= 1e6) || (ax > 0 && ax <= 1e-4) {
 		s = strconv.FormatFloat(x, 'e', p, 64)
 		// normalize exponent: remove leading zeros in e+00X
-		if i := strings.LastIndexByte(s, 'e'); i >= 0 && i+2 < len(s) {
-			sign := s[i+1]
-			exp := strings.TrimLeft(s[i+2:], "0")
-			if exp == "" {
-				exp = "0"
-			}
-			s = s[:i+2] + string(sign) + exp
+		i := strings.LastIndexByte(s, 'e')
+		digits := strings.TrimLeft(s[i+2:], "0")
+		if digits == "" {
+			digits = "0"
 		}
-	} else {
-		s = strconv.FormatFloat(x, 'f', p, 64)
+		s, exp = s[:i], s[i:i+2]+digits // mantissa and exponent
 	}
-	// Trim trailing zeros and possible dot
+	// Trim trailing zeros and possible dot of the mantissa
 	if strings.ContainsAny(s, ".") {
 		s = strings.TrimRight(s, "0")
 		s = strings.TrimRight(s, ".")
 	}
-	return s
+	return f.Typography.typeset(s + exp)
 }
 
 // LogFormatter formats tick labels on a log axis. For Base==10 it prefers
 // forms like 1e3, 2e3, 5e3 when values are exact multiples. Otherwise it
 // falls back to ScalarFormatter.
-type LogFormatter struct{ Base float64 }
+type LogFormatter struct {
+	Base       float64
+	Typography Typography // e.g. TimesTen for 10³ and 2×10³
+}
+
+// WithTypography returns a copy that also uses the forms enabled in t.
+func (f LogFormatter) WithTypography(t Typography) Formatter {
+	f.Typography = f.Typography.union(t)
+	return f
+}
 
 func (f LogFormatter) Format(x float64) string {
 	if f.Base == 10 {
@@ -193,17 +291,168 @@ func (f LogFormatter) Format(x float64) string {
 		m := x / pow
 		// Tolerate small rounding
 		if approx(m, 1, 1e-12) {
-			return "1e" + strconv.FormatFloat(k, 'f', 0, 64)
+			return f.Typography.typeset("1e" + strconv.FormatFloat(k, 'f', 0, 64))
 		}
 		if approx(m, 2, 1e-12) {
-			return "2e" + strconv.FormatFloat(k, 'f', 0, 64)
+			return f.Typography.typeset("2e" + strconv.FormatFloat(k, 'f', 0, 64))
 		}
 		if approx(m, 5, 1e-12) {
-			return "5e" + strconv.FormatFloat(k, 'f', 0, 64)
+			return f.Typography.typeset("5e" + strconv.FormatFloat(k, 'f', 0, 64))
 		}
 	}
 	// Fallback
-	return (ScalarFormatter{Prec: 6}).Format(x)
+	return (ScalarFormatter{Prec: 6, Typography: f.Typography}).Format(x)
+}
+
+// EngFormatter formats numbers with SI prefixes and an optional unit, e.g.
+// "1.5 kHz" or "200 mV". Prec is the number of decimals (trailing zeros are
+// trimmed).
+type EngFormatter struct {
+	Unit string
+	Prec int
+}
+
+// engPrefixes are the SI prefixes from 1e-24 to 1e24 in steps of 1e3.
+var engPrefixes = []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}
+
+func (f EngFormatter) Format(x float64) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ScalarFormatter{}.Format(x)
+	}
+	i := 8 // no prefix
+	m := x
+	if x != 0 {
+		i += int(math.Floor(math.Log10(math.Abs(x)) / 3))
+		i = min(max(i, 0), len(engPrefixes)-1)
+		m = x / math.Pow(10, float64(3*(i-8)))
+		// Rounding may carry into the next prefix, e.g. 999.96 -> 1000.
+		if r := math.Pow(10, float64(f.Prec)); math.Abs(math.Round(m*r)/r) >= 1000 && i < len(engPrefixes)-1 {
+			i++
+			m /= 1000
+		}
+	}
+	s := strconv.FormatFloat(m, 'f', max(f.Prec, 0), 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	if suffix := engPrefixes[i] + f.Unit; suffix != "" {
+		s += " " + suffix
+	}
+	return s
+}
+
+// DBFormatter formats decibel values, e.g. "-20 dB".
+type DBFormatter struct{ Prec int }
+
+func (f DBFormatter) Format(x float64) string {
+	return ScalarFormatter{Prec: f.Prec}.Format(x) + " dB"
+}
+
+// PercentFormatter formats values as percentages, e.g. "25%". Max is the
+// value shown as 100%, 0 for 100, i.e. values that are percentages
+// already; use 1 for fractions.
+type PercentFormatter struct {
+	Prec int
+	Max  float64
+}
+
+func (f PercentFormatter) Format(x float64) string {
+	if f.Max != 0 {
+		x = x / f.Max * 100
+	}
+	return ScalarFormatter{Prec: f.Prec}.Format(x) + "%"
+}
+
+// CurrencyFormatter formats amounts of money with a currency symbol and
+// thousands separators, e.g. "$1,234.5k" or "-€12,000". Prec is the number
+// of decimals (trailing zeros are trimmed).
+//
+// Scale abbreviates large amounts: "k", "M", "B" or "T" shows every value
+// in thousands, millions, billions or trillions, which keeps the ticks of
+// an axis consistent; "auto" picks the largest of them per value; "" shows
+// full amounts.
+type CurrencyFormatter struct {
+	Symbol  string // prefix such as "$" or "€"
+	Prec    int
+	Scale   string
+	Sep     string // thousands separator, "" for ","
+	Decimal string // decimal mark, "" for "."
+}
+
+// currencyScales are the abbreviations of CurrencyFormatter, largest first.
+var currencyScales = []struct {
+	suffix string
+	div    float64
+}{{"T", 1e12}, {"B", 1e9}, {"M", 1e6}, {"k", 1e3}}
+
+func (f CurrencyFormatter) Format(x float64) string {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return ScalarFormatter{}.Format(x)
+	}
+	prec := max(f.Prec, 0)
+	suffix, m := "", x
+	if f.Scale == "auto" {
+		// Walk up from full amounts while the value rounds to 1000 or more,
+		// so that 999,999.9 becomes "1M", not "1000k".
+		for i := len(currencyScales) - 1; i >= 0 && math.Abs(roundTo(m, prec)) >= 1000; i-- {
+			suffix, m = currencyScales[i].suffix, x/currencyScales[i].div
+		}
+	}
+	for _, sc := range currencyScales {
+		if f.Scale == sc.suffix {
+			suffix, m = sc.suffix, x/sc.div
+		}
+	}
+
+	s := strconv.FormatFloat(math.Abs(m), 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+
+	sep, dec := f.Sep, f.Decimal
+	if sep == "" {
+		sep = ","
+	}
+	if dec == "" {
+		dec = "."
+	}
+	var b strings.Builder
+	if m < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	b.WriteString(f.Symbol)
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	if frac != "" {
+		b.WriteString(dec + frac)
+	}
+	b.WriteString(suffix)
+	return b.String()
+}
+
+// CategoryFormatter labels the integer positions 0, 1, ... of categorical
+// axes with Labels; other values get no label.
+type CategoryFormatter struct{ Labels []string }
+
+func (f CategoryFormatter) Format(x float64) string {
+	i := math.Round(x)
+	if i != x || i < 0 || i >= float64(len(f.Labels)) {
+		return ""
+	}
+	return f.Labels[int(i)]
+}
+
+func roundTo(x float64, prec int) float64 {
+	p := math.Pow(10, float64(prec))
+	return math.Round(x*p) / p
 }
 
 func approx(a, b, eps float64) bool {
@@ -109,6 +109,54 @@ func TestLogLocator_MinorsBetweenMajors(t *testing.T) {
 	}
 }
 
+func TestLogLocator_MinorTicks(t *testing.T) {
+	got := LogLocator{Base: 10}.MinorTicks(1, 1e3)
+	want := []float64{2, 5, 20, 50, 200, 500}
+	if len(got) != len(want) {
+		t.Fatalf("minor ticks %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("minor ticks %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestLogLocator_Subs(t *testing.T) {
+	// Every multiple per decade, including those below the first major.
+	got := LogLocator{Base: 10, Subs: LogSubsAll()}.MinorTicks(3, 30)
+	want := []float64{3, 4, 5, 6, 7, 8, 9, 20, 30}
+	if len(got) != len(want) {
+		t.Fatalf("minor ticks %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("minor ticks %+v, want %+v", got, want)
+		}
+	}
+	// Multiples not below the base are dropped.
+	if got := (LogLocator{Base: 2, Subs: LogSubsAll()}).MinorTicks(1, 8); len(got) != 0 {
+		t.Errorf("base 2 minor ticks %+v, want none", got)
+	}
+}
+
+func TestAutoMinorLocator(t *testing.T) {
+	// Majors every 2 get 4 divisions, majors every 10 get 5.
+	got := AutoMinorLocator{}.Ticks(0, 4, 2)
+	want := []float64{0.5, 1, 1.5, 2.5, 3, 3.5}
+	if len(got) != len(want) {
+		t.Fatalf("minor ticks %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("minor ticks %+v, want %+v", got, want)
+		}
+	}
+	if got := (AutoMinorLocator{Divisions: 2}).Ticks(0, 20, 2); len(got) != 2 || got[0] != 5 || got[1] != 15 {
+		t.Errorf("two divisions %+v, want [5 15]", got)
+	}
+}
+
 func TestScalarFormatter_TrimAndScientific(t *testing.T) {
 	f := ScalarFormatter{Prec: 6}
 	if got := f.Format(1.0); got != "1" {
@@ -124,3 +172,90 @@ func TestScalarFormatter_TrimAndScientific(t *testing.T) {
 		t.Fatalf("expected scientific for small: %q", got)
 	}
 }
+
+func TestEngFormatter(t *testing.T) {
+	hz := EngFormatter{Unit: "Hz", Prec: 1}
+	tests := map[float64]string{
+		0:       "0 Hz",
+		10:      "10 Hz",
+		1500:    "1.5 kHz",
+		1e6:     "1 MHz",
+		999.96:  "1 kHz",
+		0.002:   "2 mHz",
+		-2.5e-6: "-2.5 µHz",
+		1e30:    "1000000 YHz",
+	}
+	for x, want := range tests {
+		if got := hz.Format(x); got != want {
+			t.Errorf("Format(%v) = %q, want %q", x, got, want)
+		}
+	}
+	if got := (EngFormatter{}).Format(2000); got != "2 k" {
+		t.Errorf("without unit: %q, want %q", got, "2 k")
+	}
+	if got := (EngFormatter{}).Format(20); got != "20" {
+		t.Errorf("without prefix or unit: %q, want %q", got, "20")
+	}
+}
+
+func TestDBFormatter(t *testing.T) {
+	if got := (DBFormatter{Prec: 1}).Format(-20); got != "-20 dB" {
+		t.Errorf("Format(-20) = %q", got)
+	}
+}
+
+func TestCurrencyFormatter(t *testing.T) {
+	tests := []struct {
+		f    CurrencyFormatter
+		x    float64
+		want string
+	}{
+		{CurrencyFormatter{Symbol: "$"}, 0, "$0"},
+		{CurrencyFormatter{Symbol: "$"}, 1234567, "$1,234,567"},
+		{CurrencyFormatter{Symbol: "$", Prec: 2}, 1234.5, "$1,234.5"},
+		{CurrencyFormatter{Symbol: "$", Prec: 1, Scale: "k"}, 1234500, "$1,234.5k"},
+		{CurrencyFormatter{Symbol: "$", Prec: 1, Scale: "k"}, 500, "$0.5k"},
+		{CurrencyFormatter{Symbol: "$", Prec: 1, Scale: "auto"}, 2.5e9, "$2.5B"},
+		{CurrencyFormatter{Symbol: "$", Prec: 1, Scale: "auto"}, 999999.9, "$1M"},
+		{CurrencyFormatter{Symbol: "$", Prec: 1, Scale: "auto"}, 999, "$999"},
+		{CurrencyFormatter{Symbol: "$"}, -12000, "-$12,000"},
+		{CurrencyFormatter{Symbol: "$"}, -0.2, "$0"},
+		{CurrencyFormatter{Symbol: "€", Prec: 2, Sep: ".", Decimal: ","}, 1234.25, "€1.234,25"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Format(tt.x); got != tt.want {
+			t.Errorf("%+v.Format(%v) = %q, want %q", tt.f, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestPercentFormatter(t *testing.T) {
+	tests := []struct {
+		f    PercentFormatter
+		x    float64
+		want string
+	}{
+		{PercentFormatter{}, 25, "25%"},
+		{PercentFormatter{Prec: 1}, 12.34, "12.3%"},
+		{PercentFormatter{Max: 1}, 0.5, "50%"},
+		{PercentFormatter{Max: 200}, 50, "25%"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Format(tt.x); got != tt.want {
+			t.Errorf("%+v.Format(%v) = %q, want %q", tt.f, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryTicks(t *testing.T) {
+	got := FixedLocator{Locs: []float64{0, 1, 2, 3}}.Ticks(0.5, 3, 5)
+	if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("FixedLocator ticks %v, want [1 2 3]", got)
+	}
+	f := CategoryFormatter{Labels: []string{"a", "b"}}
+	for x, want := range map[float64]string{0: "a", 1: "b", 0.5: "", -1: "", 2: ""} {
+		if got := f.Format(x); got != want {
+			t.Errorf("Format(%v) = %q, want %q", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package core
+
+import "matplotlib-go/internal/geom"
+
+// TooltipFunc returns the tooltip text for the i-th data point of an artist.
+// Returning "" attaches no tooltip to that point.
+type TooltipFunc func(i int, p geom.Pt) string
+
+// XYTooltip returns a TooltipFunc that formats points as "(x, y)" using f.
+// A nil formatter defaults to ScalarFormatter{Prec: 3}.
+func XYTooltip(f Formatter) TooltipFunc {
+	if f == nil {
+		f = ScalarFormatter{Prec: 3}
+	}
+	return func(_ int, p geom.Pt) string {
+		return "(" + f.Format(p.X) + ", " + f.Format(p.Y) + ")"
+	}
+}
+
+// LabeledTooltip returns a TooltipFunc that shows labels[i], falling back to
+// the "(x, y)" form for points without a label.
+func LabeledTooltip(labels []string) TooltipFunc {
+	xy := XYTooltip(nil)
+	return func(i int, p geom.Pt) string {
+		if i < len(labels) && labels[i] != "" {
+			return labels[i]
+		}
+		return xy(i, p)
+	}
+}
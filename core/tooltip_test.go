@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// titleRecorder records Title calls and the number of paths drawn.
+type titleRecorder struct {
+	render.NullRenderer
+	titles []string
+	paths  int
+}
+
+func (r *titleRecorder) Title(text string) { r.titles = append(r.titles, text) }
+
+func (r *titleRecorder) Path(_ geom.Path, _ *render.Paint) { r.paths++ }
+
+func TestScatterTooltips(t *testing.T) {
+	s := &Scatter2D{
+		XY:      []geom.Pt{{X: 1, Y: 2}, {X: 3, Y: 4.5}},
+		Size:    4,
+		Tooltip: LabeledTooltip([]string{"first"}),
+	}
+	var r titleRecorder
+	s.Draw(&r, createTestDrawContext())
+
+	want := []string{"first", "(3, 4.5)"}
+	if len(r.titles) != len(want) || r.paths != len(want) {
+		t.Fatalf("got titles %v for %d paths, want %v", r.titles, r.paths, want)
+	}
+	for i := range want {
+		if r.titles[i] != want[i] {
+			t.Errorf("title %d: got %q want %q", i, r.titles[i], want[i])
+		}
+	}
+}
+
+func TestBarTooltips(t *testing.T) {
+	b := &Bar2D{
+		X:       []float64{0, 1},
+		Heights: []float64{2, 3},
+		Width:   0.5,
+		Tooltip: XYTooltip(nil),
+	}
+	var r titleRecorder
+	b.Draw(&r, createTestDrawContext())
+
+	if len(r.titles) != 2 || r.titles[1] != "(1, 3)" {
+		t.Fatalf("unexpected titles %v", r.titles)
+	}
+}
+
+func TestNoTooltipWithoutFunc(t *testing.T) {
+	var r titleRecorder
+	(&Scatter2D{XY: []geom.Pt{{X: 1, Y: 1}}, Size: 4}).Draw(&r, createTestDrawContext())
+	if len(r.titles) != 0 {
+		t.Fatalf("unexpected titles %v", r.titles)
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"strings"
+
+	"matplotlib-go/style"
+)
+
+// Typography selects publication forms for the numbers of ScalarFormatter
+// and LogFormatter, see the matching style.RC fields.
+type Typography struct {
+	UnicodeMinus bool // "−2" instead of "-2"
+	ThousandsSep bool // "12 500" with a thin space instead of "12500"
+	TimesTen     bool // "1.5×10⁶" instead of "1.5e+6"
+}
+
+// TypographyFormatter is a Formatter that can follow a Typography. Axes
+// apply the typography of their RC to such formatters.
+type TypographyFormatter interface {
+	Formatter
+	// WithTypography returns a copy that also uses the forms enabled in t.
+	WithTypography(t Typography) Formatter
+}
+
+// rcTypography returns the number typography of rc.
+func rcTypography(rc style.RC) Typography {
+	return Typography{UnicodeMinus: rc.UnicodeMinus, ThousandsSep: rc.ThousandsSep, TimesTen: rc.TimesTen}
+}
+
+// withRCTypography returns f following the typography of rc if it supports
+// one.
+func withRCTypography(f Formatter, rc style.RC) Formatter {
+	if tf, ok := f.(TypographyFormatter); ok {
+		return tf.WithTypography(rcTypography(rc))
+	}
+	return f
+}
+
+// union returns the typography using the forms enabled in t or u.
+func (t Typography) union(u Typography) Typography {
+	return Typography{
+		UnicodeMinus: t.UnicodeMinus || u.UnicodeMinus,
+		ThousandsSep: t.ThousandsSep || u.ThousandsSep,
+		TimesTen:     t.TimesTen || u.TimesTen,
+	}
+}
+
+const (
+	minusSign = "\u2212"
+	thinSpace = "\u2009"
+)
+
+var superscripts = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹", "-", "⁻",
+)
+
+// typeset rewrites a number formatted like "-12500.5" or "1.5e+6" in the
+// forms of t. Other text, such as "NaN", passes through.
+func (t Typography) typeset(s string) string {
+	if t == (Typography{}) {
+		return s
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	mant, exp, sci := strings.Cut(s, "e")
+	if t.ThousandsSep {
+		mant = groupThousands(mant)
+	}
+	switch {
+	case !sci:
+		s = mant
+	case t.TimesTen:
+		s = "10" + superscripts.Replace(strings.TrimPrefix(exp, "+"))
+		if mant != "1" {
+			s = mant + "×" + s
+		}
+	case t.UnicodeMinus:
+		s = mant + "e" + strings.Replace(exp, "-", minusSign, 1)
+	default:
+		s = mant + "e" + exp
+	}
+	if neg {
+		if t.UnicodeMinus {
+			return minusSign + s
+		}
+		return "-" + s
+	}
+	return s
+}
+
+// groupThousands separates the integer digits of a decimal number into
+// groups of three with thin spaces, e.g. "12500.25" to "12 500.25".
+func groupThousands(s string) string {
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if len(intPart) < 4 || strings.Trim(intPart, "0123456789") != "" {
+		return s
+	}
+	var b strings.Builder
+	for i, d := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thinSpace)
+		}
+		b.WriteRune(d)
+	}
+	if hasFrac {
+		b.WriteString("." + frac)
+	}
+	return b.String()
+}
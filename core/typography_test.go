@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/style"
+)
+
+func TestTypographyScalarFormatter(t *testing.T) {
+	all := Typography{UnicodeMinus: true, ThousandsSep: true, TimesTen: true}
+	tests := []struct {
+		x    float64
+		typo Typography
+		want string
+	}{
+		{-2.5, Typography{}, "-2.5"},
+		{-2.5, Typography{UnicodeMinus: true}, "−2.5"},
+		{12500.25, Typography{ThousandsSep: true}, "12\u2009500.25"},
+		{-1234567 / 10.0, Typography{ThousandsSep: true, UnicodeMinus: true}, "−123\u2009456.7"},
+		{999, Typography{ThousandsSep: true}, "999"},
+		{1.5e10, Typography{}, "1.5e+10"},
+		{1.5e10, Typography{TimesTen: true}, "1.5×10¹⁰"},
+		{1e-5, Typography{UnicodeMinus: true}, "1e−5"},
+		{-1e-5, all, "−10⁻⁵"},
+		{2e6, all, "2×10⁶"},
+		{0, all, "0"},
+	}
+	for _, tt := range tests {
+		if got := (ScalarFormatter{Prec: 3, Typography: tt.typo}).Format(tt.x); got != tt.want {
+			t.Errorf("Format(%v) with %+v = %q, want %q", tt.x, tt.typo, got, tt.want)
+		}
+	}
+}
+
+func TestTypographyLogFormatter(t *testing.T) {
+	f := LogFormatter{Base: 10}.WithTypography(Typography{TimesTen: true})
+	for x, want := range map[float64]string{1000: "10³", 2e-3: "2×10⁻³", 1: "10⁰", 3: "3"} {
+		if got := f.Format(x); got != want {
+			t.Errorf("Format(%v) = %q, want %q", x, got, want)
+		}
+	}
+	if got := (LogFormatter{Base: 10}).Format(1e-3); got != "1e-3" {
+		t.Errorf("default Format(1e-3) = %q, want 1e-3", got)
+	}
+}
+
+func TestTypographyFromRC(t *testing.T) {
+	fig := NewFigure(400, 300, style.WithTypography(true, false, false))
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.1, Y: 0.1}, Max: geom.Pt{X: 0.9, Y: 0.9}})
+	ax.SetXLim(-2, 2)
+	ax.SetYLim(0, 1)
+	ax.YAxis = nil
+
+	r := &fixedTextRenderer{}
+	DrawFigure(fig, r)
+	found := false
+	for _, s := range r.texts {
+		if s == "-2" {
+			t.Errorf("tick label %q drawn with a hyphen", s)
+		}
+		found = found || s == "−2"
+	}
+	if !found {
+		t.Errorf("tick labels %q, want −2", r.texts)
+	}
+}
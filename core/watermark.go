@@ -0,0 +1,191 @@
+package core
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Watermark is text or an image stamped across the middle of a figure,
+// e.g. a "DRAFT" marking or a logo. Text is drawn in the figure's font with
+// render.RotatedTextDrawer, so it stays sharp and is text in vector output;
+// images, and text on renderers without rotated text, are composited as a
+// bitmap with render.Renderer.Image, which backends that drop images (PGF)
+// omit.
+type Watermark struct {
+	Text  string       // stamped text, used when Image is nil
+	Image image.Image  // stamped image
+	Color render.Color // text color
+	Alpha float64      // opacity (0-1)
+	Angle float64      // rotation in degrees, counterclockwise
+	Scale float64      // width of the unrotated stamp as a fraction of the figure width
+	Above bool         // draw above the axes instead of below them
+}
+
+// AddWatermark stamps text diagonally across the figure, below the data.
+func (f *Figure) AddWatermark(text string) *Watermark {
+	w := &Watermark{
+		Text:  text,
+		Color: render.Color{R: 0.5, G: 0.5, B: 0.5, A: 1},
+		Alpha: 0.25,
+		Angle: 30,
+		Scale: 0.6,
+	}
+	f.Watermarks = append(f.Watermarks, w)
+	return w
+}
+
+// AddStamp stamps img in the middle of the figure, below the data.
+func (f *Figure) AddStamp(img image.Image) *Watermark {
+	w := &Watermark{Image: img, Alpha: 0.25, Scale: 0.3}
+	f.Watermarks = append(f.Watermarks, w)
+	return w
+}
+
+// drawWatermarks draws the figure's watermarks on the given side of the data.
+func drawWatermarks(fig *Figure, r render.Renderer, above bool) {
+	grouper, _ := r.(render.Grouper)
+	for i, w := range fig.Watermarks {
+		if w == nil || w.Above != above {
+			continue
+		}
+		if tr, ok := r.(render.RotatedTextDrawer); ok && w.Image == nil {
+			if grouper != nil {
+				grouper.BeginGroup(fmt.Sprintf("watermark%d", i), "watermark")
+			}
+			w.drawText(r, tr, fig)
+			if grouper != nil {
+				grouper.EndGroup()
+			}
+			continue
+		}
+		img := w.bitmap(fig.SizePx.X, textOptions(fig.RC))
+		if img == nil {
+			continue
+		}
+		if grouper != nil {
+			grouper.BeginGroup(fmt.Sprintf("watermark%d", i), "watermark")
+		}
+		b := img.Bounds()
+		min := geom.Pt{
+			X: math.Round((fig.SizePx.X - float64(b.Dx())) / 2),
+			Y: math.Round((fig.SizePx.Y - float64(b.Dy())) / 2),
+		}
+		r.Image(render.RGBAImage{RGBA: img}, geom.Rect{
+			Min: min,
+			Max: geom.Pt{X: min.X + float64(b.Dx()), Y: min.Y + float64(b.Dy())},
+		})
+		if grouper != nil {
+			grouper.EndGroup()
+		}
+	}
+}
+
+// drawText draws the text stamp centered on the figure in the figure's
+// font, sized so that the unrotated text spans Scale of the figure width.
+func (w *Watermark) drawText(r render.Renderer, tr render.RotatedTextDrawer, fig *Figure) {
+	alpha := min(w.Alpha, 1)
+	if alpha <= 0 || w.Scale <= 0 || w.Text == "" {
+		return
+	}
+	const ref = 12.0 // size the text is measured at
+	m := r.MeasureText(w.Text, ref, fig.RC.FontKey)
+	if m.W <= 0 {
+		return
+	}
+	k := w.Scale * fig.SizePx.X / m.W
+	angle := w.Angle * math.Pi / 180
+	// Offset of the text's center from its left baseline origin, rotated
+	// counterclockwise on screen (y points down).
+	dx, dy := k*m.W/2, k*(m.Descent-m.Ascent)/2
+	sin, cos := math.Sincos(angle)
+	origin := geom.Pt{
+		X: fig.SizePx.X/2 - (dx*cos + dy*sin),
+		Y: fig.SizePx.Y/2 - (dy*cos - dx*sin),
+	}
+	col := w.Color
+	col.A *= alpha
+	tr.DrawTextRotated(w.Text, origin, k*ref, angle, col)
+}
+
+// bitmap renders the stamp scaled, rotated and faded for a figure of the
+// given pixel width, text in the font of opts. It returns nil if there is
+// nothing to draw.
+func (w *Watermark) bitmap(figW float64, opts render.TextOptions) *image.RGBA {
+	alpha := w.Alpha
+	if alpha <= 0 || w.Scale <= 0 {
+		return nil
+	}
+	if alpha > 1 {
+		alpha = 1
+	}
+	src := w.source(opts)
+	if src == nil {
+		return nil
+	}
+
+	sw, sh := float64(src.Bounds().Dx()), float64(src.Bounds().Dy())
+	k := w.Scale * figW / sw
+	sin, cos := math.Sincos(w.Angle * math.Pi / 180)
+	// Bounding box of the scaled, rotated stamp; the slack absorbs rounding
+	// of sin and cos at multiples of 90°.
+	dw := math.Ceil(k*(sw*math.Abs(cos)+sh*math.Abs(sin)) - 1e-9)
+	dh := math.Ceil(k*(sw*math.Abs(sin)+sh*math.Abs(cos)) - 1e-9)
+	if dw < 1 || dh < 1 || dw*dh > 1<<26 {
+		return nil
+	}
+	out := image.NewRGBA(image.Rect(0, 0, int(dw), int(dh)))
+
+	// Source to destination pixels: rotate counterclockwise on screen
+	// (y points down) about the centers.
+	a, b, d, e := k*cos, k*sin, -k*sin, k*cos
+	sx, sy := sw/2, sh/2
+	m := f64.Aff3{
+		a, b, dw/2 - (a*sx + b*sy),
+		d, e, dh/2 - (d*sx + e*sy),
+	}
+	xdraw.BiLinear.Transform(out, m, src, src.Bounds(), xdraw.Src, nil)
+
+	if alpha < 1 {
+		for i, v := range out.Pix {
+			out.Pix[i] = uint8(float64(v)*alpha + 0.5)
+		}
+	}
+	return out
+}
+
+// source returns the unscaled stamp: the image, or the text in Color in
+// the font of opts, the fallback for renderers without rotated text.
+func (w *Watermark) source(opts render.TextOptions) *image.RGBA {
+	if w.Image != nil {
+		b := w.Image.Bounds()
+		if b.Empty() {
+			return nil
+		}
+		rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), w.Image, b.Min, draw.Src)
+		return rgba
+	}
+	if w.Text == "" {
+		return nil
+	}
+	off := gobasic.New(1, 1, render.Color{})
+	m := off.MeasureText(w.Text, 12, opts.FontKey)
+	tw, th := int(math.Ceil(m.W)), int(math.Ceil(m.Ascent+m.Descent))
+	if tw <= 0 || th <= 0 {
+		return nil
+	}
+	off = gobasic.New(tw, th, render.Color{})
+	off.SetTextOptions(opts)
+	_ = off.Begin(geom.Rect{Max: geom.Pt{X: float64(tw), Y: float64(th)}})
+	off.DrawText(w.Text, geom.Pt{X: 0, Y: m.Ascent}, 12, w.Color)
+	_ = off.End()
+	return off.GetImage()
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"matplotlib-go/backends/gobasic"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+func TestWatermarkBitmap(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 10))
+	for i := range src.Pix {
+		src.Pix[i] = 255
+	}
+	w := &Watermark{Image: src, Alpha: 0.5, Scale: 0.5}
+
+	img := w.bitmap(200, render.TextOptions{}) // 100px wide stamp
+	if b := img.Bounds(); b.Dx() != 100 || b.Dy() != 25 {
+		t.Fatalf("unrotated bitmap %v, want 100x25", b)
+	}
+	if c := img.RGBAAt(50, 12); c.A < 126 || c.A > 129 {
+		t.Errorf("alpha %d at center, want ~128", c.A)
+	}
+
+	w.Angle = 90
+	if b := w.bitmap(200, render.TextOptions{}).Bounds(); b.Dx() != 25 || b.Dy() != 100 {
+		t.Errorf("rotated bitmap %v, want 25x100", b)
+	}
+
+	w.Angle = 45
+	side := int(math.Ceil(125 / math.Sqrt2))
+	if b := w.bitmap(200, render.TextOptions{}).Bounds(); b.Dx() != side || b.Dy() != side {
+		t.Errorf("45° bitmap %v, want %dx%d", b, side, side)
+	}
+
+	if (&Watermark{Text: "x", Alpha: 0, Scale: 1}).bitmap(100, render.TextOptions{}) != nil {
+		t.Error("transparent watermark produced a bitmap")
+	}
+	if (&Watermark{Alpha: 1, Scale: 1}).bitmap(100, render.TextOptions{}) != nil {
+		t.Error("empty watermark produced a bitmap")
+	}
+}
+
+func TestWatermarkOrder(t *testing.T) {
+	red := render.Color{R: 1, A: 1}
+	opaque := 1.0
+	for _, above := range []bool{false, true} {
+		fig := NewFigure(100, 100)
+		ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+		ax.XAxis, ax.YAxis = nil, nil
+		ax.FillToBaselinePlot([]float64{0, 1}, []float64{1, 1}, FillOptions{Color: &red, Alpha: &opaque})
+
+		stamp := image.NewUniform(color.RGBA{B: 255, A: 255})
+		blue := image.NewRGBA(image.Rect(0, 0, 10, 10))
+		for y := 0; y < 10; y++ {
+			for x := 0; x < 10; x++ {
+				blue.Set(x, y, stamp)
+			}
+		}
+		w := fig.AddStamp(blue)
+		w.Alpha, w.Above = 1, above
+
+		r := gobasic.New(100, 100, render.Color{R: 1, G: 1, B: 1, A: 1})
+		DrawFigure(fig, r)
+		c := r.GetImage().RGBAAt(50, 50)
+		if above && (c.B != 255 || c.R != 0) {
+			t.Errorf("above: center %v, want the blue stamp", c)
+		}
+		if !above && (c.R != 255 || c.B != 0) {
+			t.Errorf("below: center %v, want the red fill", c)
+		}
+	}
+}
+
+func TestWatermarkText(t *testing.T) {
+	fig := NewFigure(300, 200)
+	fig.AddWatermark("DRAFT").Above = true
+	r := gobasic.New(300, 200, render.Color{R: 1, G: 1, B: 1, A: 1})
+	DrawFigure(fig, r)
+
+	img := r.GetImage()
+	var tinted, dark int
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			c := img.RGBAAt(x, y)
+			if c.R < 250 {
+				tinted++
+			}
+			if c.R < 150 {
+				dark++
+			}
+		}
+	}
+	if tinted == 0 {
+		t.Fatal("watermark text not drawn")
+	}
+	if dark > 0 {
+		t.Errorf("%d pixels darker than a 25%% gray overlay allows", dark)
+	}
+}
+
+func TestWatermarkVectorText(t *testing.T) {
+	fig := NewFigure(300, 200)
+	fig.AddWatermark("DRAFT")
+	r := &fixedTextRenderer{}
+	DrawFigure(fig, r)
+	if len(r.texts) != 1 || r.texts[0] != "DRAFT" {
+		t.Fatalf("texts %q, want one DRAFT", r.texts)
+	}
+
+	// 40px at size 12 scaled to 0.6 of 300px: 4.5 times, 180×45 pixels.
+	angle := 30 * math.Pi / 180
+	if math.Abs(r.angles[0]-angle) > 1e-12 {
+		t.Errorf("angle %v, want %v", r.angles[0], angle)
+	}
+	// The text's center, 90 right of and 13.5 above the origin before
+	// rotation, lands on the figure's center.
+	sin, cos := math.Sincos(angle)
+	o := r.origins[0]
+	center := geom.Pt{X: o.X + 90*cos - 13.5*sin, Y: o.Y - 90*sin - 13.5*cos}
+	if math.Abs(center.X-150) > 1e-9 || math.Abs(center.Y-100) > 1e-9 {
+		t.Errorf("text centered at %v, want (150, 100)", center)
+	}
+	if a := r.colors[0].A; math.Abs(a-0.25) > 1e-12 {
+		t.Errorf("alpha %v, want 0.25", a)
+	}
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"errors"
+	"math"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// WindroseOptions configures AddWindrose.
+type WindroseOptions struct {
+	Sectors int       // number of direction sectors, 0 for 16
+	Bins    []float64 // increasing lower edges of the speed categories, nil for 5 equal bins from 0
+}
+
+// Windrose is a polar histogram of directions made by AddWindrose.
+type Windrose struct {
+	Axes   *Axes        // polar axes, θ=0 at north, clockwise
+	Bars   []*PolarBars // one stacked layer per speed category, innermost first
+	Legend *Legend      // legend of the speed categories
+}
+
+// AddWindrose adds a windrose to the figure rectangle r: directions dir (in
+// degrees, clockwise from north, as in meteorology) are binned into sectors,
+// each drawn as stacked polar bars with one layer per speed category. Bar
+// lengths are percentages of all samples; samples with a speed below the
+// first bin edge or a NaN value are left out.
+func (f *Figure) AddWindrose(r geom.Rect, dir, speed []float64, opts WindroseOptions) (*Windrose, error) {
+	if len(dir) != len(speed) {
+		return nil, errors.New("windrose: dir and speed differ in length")
+	}
+	sectors := opts.Sectors
+	if sectors <= 0 {
+		sectors = 16
+	}
+	bins := opts.Bins
+	if bins == nil {
+		bins = defaultSpeedBins(speed)
+	}
+	if len(bins) == 0 {
+		return nil, errors.New("windrose: no bins")
+	}
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return nil, errors.New("windrose: bins must be increasing")
+		}
+	}
+
+	counts, total := windroseCounts(dir, speed, sectors, bins)
+
+	ax := f.AddPolarAxes(r)
+	w := &Windrose{Axes: ax}
+	_ = ax.SetThetaZeroLocation("N")
+	_ = ax.SetThetaDirection(-1)
+	grid := ax.Artists[0].(*PolarGrid)
+	grid.Formatter = CompassFormatter{}
+	grid.RFormatter = PercentFormatter{Prec: 1}
+
+	width := 2 * math.Pi / float64(sectors)
+	theta := make([]float64, sectors)
+	for i := range theta {
+		theta[i] = float64(i) * width
+	}
+	bottom := make([]float64, sectors)
+	for j := range bins {
+		heights := make([]float64, sectors)
+		for i := range heights {
+			if total > 0 {
+				heights[i] = 100 * float64(counts[i][j]) / float64(total)
+			}
+		}
+		bars := &PolarBars{
+			Theta:     theta,
+			Heights:   heights,
+			Bottom:    append([]float64(nil), bottom...),
+			Width:     width * 0.9,
+			Color:     ax.NextColor(),
+			EdgeColor: render.Color{R: 1, G: 1, B: 1, A: 1},
+			EdgeWidth: 0.5,
+			Label:     binLabel(bins, j),
+		}
+		ax.Add(bars)
+		w.Bars = append(w.Bars, bars)
+		for i := range bottom {
+			bottom[i] += heights[i]
+		}
+	}
+
+	maxTotal := 0.0
+	for _, v := range bottom {
+		maxTotal = math.Max(maxTotal, v)
+	}
+	ticks := LinearLocator{}.Ticks(0, math.Max(maxTotal, 1), 4)
+	ax.SetYLim(0, ticks[len(ticks)-1])
+
+	w.Legend = ax.AddLegend()
+	return w, nil
+}
+
+// windroseCounts bins the samples into counts[sector][bin] and returns the
+// number of samples binned.
+func windroseCounts(dir, speed []float64, sectors int, bins []float64) ([][]int, int) {
+	counts := make([][]int, sectors)
+	for i := range counts {
+		counts[i] = make([]int, len(bins))
+	}
+	width := 360 / float64(sectors)
+	total := 0
+	for k, d := range dir {
+		s := speed[k]
+		if math.IsNaN(d) || math.IsInf(d, 0) || math.IsNaN(s) || s < bins[0] {
+			continue
+		}
+		// Sector 0 is centered on north.
+		a := math.Mod(d+width/2, 360)
+		if a < 0 {
+			a += 360
+		}
+		sector := int(a/width) % sectors
+		bin := len(bins) - 1
+		for bin > 0 && s < bins[bin] {
+			bin--
+		}
+		counts[sector][bin]++
+		total++
+	}
+	return counts, total
+}
+
+// defaultSpeedBins splits [0, max speed] into 5 equal categories.
+func defaultSpeedBins(speed []float64) []float64 {
+	max := 0.0
+	for _, s := range speed {
+		if !math.IsNaN(s) && !math.IsInf(s, 0) {
+			max = math.Max(max, s)
+		}
+	}
+	if max == 0 {
+		return []float64{0}
+	}
+	ticks := LinearLocator{}.Ticks(0, max, 5)
+	// The upper edge of the last tick is open, so drop ticks at or beyond max.
+	for len(ticks) > 1 && ticks[len(ticks)-1] >= max {
+		ticks = ticks[:len(ticks)-1]
+	}
+	return ticks
+}
+
+// binLabel names speed category j, e.g. "2-4" or "8+".
+func binLabel(bins []float64, j int) string {
+	f := ScalarFormatter{Prec: 3}
+	if j == len(bins)-1 {
+		return f.Format(bins[j]) + "+"
+	}
+	return f.Format(bins[j]) + "-" + f.Format(bins[j+1])
+}
+
+// CompassFormatter formats angles in radians as compass points (N, NNE,
+// NE, ...), assuming θ=0 is north and θ grows clockwise. Angles between
+// the 16 points fall back to degrees.
+type CompassFormatter struct{}
+
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+func (CompassFormatter) Format(x float64) string {
+	k := x / (2 * math.Pi) * 16
+	if math.Abs(k-math.Round(k)) > 1e-9 {
+		return DegreeFormatter{Symbol: "-"}.Format(x)
+	}
+	i := int(math.Round(k)) % 16
+	if i < 0 {
+		i += 16
+	}
+	return compassPoints[i]
+}
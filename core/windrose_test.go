@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+func TestWindroseCounts(t *testing.T) {
+	dir := []float64{0, 359, 11, 12, 90, -90, 450, math.NaN(), 180}
+	speed := []float64{1, 3, 5, 1, 7, 1, 2, 1, -1}
+	counts, total := windroseCounts(dir, speed, 16, []float64{0, 2, 6})
+
+	// 22.5° sectors centered on N, NNE, ...: 11 is still north, 12 is NNE,
+	// -90 is west, 450 is east; NaN and the negative speed are dropped.
+	if total != 7 {
+		t.Errorf("total = %d, want 7", total)
+	}
+	want := map[[2]int]int{
+		{0, 0}:  1, // 0° at 1
+		{0, 1}:  2, // 359° at 3, 11° at 5
+		{1, 0}:  1, // 12° at 1
+		{4, 2}:  1, // 90° at 7
+		{12, 0}: 1, // -90° at 1
+		{4, 1}:  1, // 450° at 2
+	}
+	for s := range counts {
+		for b := range counts[s] {
+			if got := counts[s][b]; got != want[[2]int{s, b}] {
+				t.Errorf("counts[%d][%d] = %d, want %d", s, b, got, want[[2]int{s, b}])
+			}
+		}
+	}
+}
+
+func TestAddWindrose(t *testing.T) {
+	fig := NewFigure(300, 300)
+	dir := []float64{0, 90, 90, 180}
+	speed := []float64{1, 1, 3, 5}
+	w, err := fig.AddWindrose(geom.Rect{Max: geom.Pt{X: 1, Y: 1}}, dir, speed, WindroseOptions{Sectors: 4, Bins: []float64{0, 2, 4}})
+	if err != nil {
+		t.Fatalf("AddWindrose: %v", err)
+	}
+	if w.Axes.Polar == nil || w.Axes.Polar.ThetaDirection != -1 || w.Axes.Polar.ThetaZero != math.Pi/2 {
+		t.Errorf("polar = %+v, want north-up clockwise", w.Axes.Polar)
+	}
+	if len(w.Bars) != 3 || w.Bars[0].Label != "0-2" || w.Bars[2].Label != "4+" {
+		t.Fatalf("bars %d, labels wrong", len(w.Bars))
+	}
+	// East (sector 1) has 25% below 2 and 25% between 2 and 4, stacked.
+	if w.Bars[0].Heights[1] != 25 || w.Bars[1].Heights[1] != 25 || w.Bars[1].Bottom[1] != 25 {
+		t.Errorf("east sector: heights %v / %v, bottom %v", w.Bars[0].Heights[1], w.Bars[1].Heights[1], w.Bars[1].Bottom[1])
+	}
+	if len(w.Legend.entries()) != 3 {
+		t.Errorf("legend has %d entries, want 3", len(w.Legend.entries()))
+	}
+
+	r := &pathRecorder{}
+	w.Bars[1].Draw(r, w.Axes.drawContext(fig))
+	if len(r.paints) != 1 {
+		t.Errorf("drew %d wedges, want 1 (only east has speeds in 2-4)", len(r.paints))
+	}
+
+	for _, bins := range [][]float64{{}, {0, 2, 2}} {
+		if _, err := fig.AddWindrose(geom.Rect{Max: geom.Pt{X: 1, Y: 1}}, dir, speed, WindroseOptions{Bins: bins}); err == nil {
+			t.Errorf("bins %v: no error", bins)
+		}
+	}
+}
+
+func TestCompassFormatter(t *testing.T) {
+	f := CompassFormatter{}
+	for x, want := range map[float64]string{0: "N", math.Pi / 2: "E", 5 * math.Pi / 4: "SW", 2 * math.Pi / 16: "NNE", 2 * math.Pi: "N", 0.1: "6"} {
+		if got := f.Format(x); got != want {
+			t.Errorf("Format(%v) = %q, want %q", x, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package core
+
+import "matplotlib-go/style"
+
+// WithStyle runs fn with opts applied to the figure's RC, then restores it,
+// even if fn panics. Axes added by fn keep the scoped style, and artists
+// created by fn keep the defaults they took from it, such as line widths
+// and marker sizes; axes added earlier return to the figure's style:
+//
+//	fig.WithStyle(func() {
+//		inset := fig.AddAxes(rect)
+//		inset.Plot(x, y)
+//	}, style.WithFont("DejaVuSans", 9), style.WithLineWidth(1))
+func (f *Figure) WithStyle(fn func(), opts ...style.Option) {
+	saved, n := f.RC, len(f.Children)
+	defer func() {
+		scoped := f.RC
+		f.RC = saved
+		for _, ax := range f.Children[min(n, len(f.Children)):] {
+			if ax.RC == nil {
+				rc := scoped
+				ax.RC = &rc
+			}
+		}
+	}()
+	f.RC = style.Apply(f.RC, opts...)
+	fn()
+}
+
+// WithStyle runs fn with opts applied to the RC of the axes, then restores
+// it, even if fn panics. Artists created by fn keep the defaults they took
+// from the scoped RC, such as line widths, marker sizes and legend frames;
+// settings read when drawing, such as tick label fonts, are the axes' own
+// again afterwards.
+func (a *Axes) WithStyle(fn func(), opts ...style.Option) {
+	saved := a.RC
+	defer func() { a.RC = saved }()
+	rc := style.Apply(a.rc(), opts...)
+	a.RC = &rc
+	fn()
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/style"
+)
+
+func TestFigureWithStyle(t *testing.T) {
+	fig := NewFigure(400, 300)
+	before := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	var inset *Axes
+	fig.WithStyle(func() {
+		if before.drawContext(fig).RC.FontSize != 9 {
+			t.Error("existing axes do not see the scoped style")
+		}
+		inset = fig.AddAxes(geom.Rect{Max: geom.Pt{X: 0.5, Y: 0.5}})
+	}, style.WithFont("DejaVuSans", 9))
+
+	if fig.RC.FontSize != style.Default.FontSize || before.RC != nil {
+		t.Errorf("figure style not restored: %v", fig.RC.FontSize)
+	}
+	if inset.RC == nil || inset.RC.FontSize != 9 {
+		t.Error("axes added in the block lost the scoped style")
+	}
+}
+
+func TestAxesWithStyle(t *testing.T) {
+	fig := NewFigure(400, 300)
+	ax := fig.AddAxes(geom.Rect{Max: geom.Pt{X: 1, Y: 1}})
+	var thin *Line2D
+	ax.WithStyle(func() {
+		thin = ax.Plot([]float64{0, 1}, []float64{0, 1})
+	}, style.WithLineWidth(0.5))
+	thick := ax.Plot([]float64{0, 1}, []float64{1, 0})
+
+	if thin.W != 0.5 || thick.W != style.Default.LineWidth {
+		t.Errorf("line widths %v and %v", thin.W, thick.W)
+	}
+	if ax.RC != nil {
+		t.Error("axes RC not restored")
+	}
+
+	func() {
+		defer func() { recover() }()
+		ax.WithStyle(func() { panic("boom") }, style.WithLineWidth(4))
+	}()
+	if ax.RC != nil {
+		t.Error("axes RC not restored after panic")
+	}
+}
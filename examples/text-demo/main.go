@@ -40,10 +40,10 @@ func main() {
 
 	// Draw text at different positions
 	renderer.DrawText("matplotlib-go Text Rendering Demo", geom.Pt{X: 20, Y: 30}, 13, textColor)
-	renderer.DrawText("Built with basicfont.Face7x13", geom.Pt{X: 20, Y: 60}, 13, textColor)
+	renderer.DrawText("Built with TrueType fonts", geom.Pt{X: 20, Y: 60}, 13, textColor)
 	renderer.DrawText("Supports basic text positioning", geom.Pt{X: 20, Y: 90}, 13, textColor)
 
-	// Draw text with different "sizes" (scaling)
+	// Draw text at different sizes
 	renderer.DrawText("Small text (size 10)", geom.Pt{X: 20, Y: 120}, 10, textColor)
 	renderer.DrawText("Large text (size 16)", geom.Pt{X: 20, Y: 150}, 16, textColor)
 
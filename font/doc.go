@@ -0,0 +1,8 @@
+// Package font loads TrueType and OpenType fonts for text rendering.
+//
+// Fonts are registered process-wide under a family name, which is what
+// style.RC.FontKey refers to. The Go Regular font is built in as
+// DefaultFamily and stands in for families that are not registered. A
+// Cache turns a family and a pixel size into faces, shaped glyph runs with
+// kerning and glyph outlines for raster backends.
+package font
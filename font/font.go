@@ -0,0 +1,232 @@
+package font
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	xfont "golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// DefaultFamily is the built-in font family, used for families that are
+// not registered.
+const DefaultFamily = "Go"
+
+// DefaultSize is the size in pixels used for non-positive sizes.
+const DefaultSize = 12
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registered{}
+)
+
+// registered is a registered font and the digest of its data.
+type registered struct {
+	font *opentype.Font
+	sum  [sha256.Size]byte
+}
+
+func init() {
+	if err := Register(DefaultFamily, goregular.TTF); err != nil {
+		panic(err)
+	}
+}
+
+// Register parses the TrueType or OpenType font data and makes it
+// available as family, replacing an earlier font of that name. Caches use
+// the new font from their next call on.
+func Register(family string, data []byte) error {
+	if family == "" {
+		return errors.New("font: empty family name")
+	}
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return fmt.Errorf("font: parse %s: %w", family, err)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[family] = registered{f, sha256.Sum256(data)}
+	return nil
+}
+
+// RegisterFile reads a .ttf or .otf file and registers it as family.
+func RegisterFile(family, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("font: %w", err)
+	}
+	return Register(family, data)
+}
+
+// Families returns the registered family names in sorted order.
+func Families() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the font registered as family, or the DefaultFamily font
+// with ok false if there is none.
+func Lookup(family string) (f *opentype.Font, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if e, ok := registry[family]; ok {
+		return e.font, true
+	}
+	return registry[DefaultFamily].font, false
+}
+
+// Sum returns the SHA-256 digest of the data registered as family, or of
+// the DefaultFamily font if there is none. It changes when the family is
+// registered with other data, so caches keyed by family names can tell.
+func Sum(family string) [sha256.Size]byte {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if e, ok := registry[family]; ok {
+		return e.sum
+	}
+	return registry[DefaultFamily].sum
+}
+
+type faceKey struct {
+	font    *opentype.Font
+	size    float64
+	hinting xfont.Hinting
+}
+
+// Cache holds faces by family and size together with the scratch buffers
+// for shaping and outlines. Like the faces it returns, a Cache is not safe
+// for concurrent use; each renderer keeps its own. The zero value is ready
+// to use.
+type Cache struct {
+	// Hinting applies to faces, advances and metrics; it defaults to
+	// xfont.HintingNone.
+	Hinting xfont.Hinting
+
+	faces map[faceKey]xfont.Face
+	buf   sfnt.Buffer
+}
+
+// Face returns the face of family at size pixels per em.
+func (c *Cache) Face(family string, size float64) xfont.Face {
+	f, _ := Lookup(family)
+	key := faceKey{f, sizeOrDefault(size), c.Hinting}
+	if face, ok := c.faces[key]; ok {
+		return face
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: key.size, DPI: 72, Hinting: c.Hinting})
+	if err != nil {
+		// Only invalid sizes fail, and sizeOrDefault rules those out.
+		panic(err)
+	}
+	if c.faces == nil {
+		c.faces = map[faceKey]xfont.Face{}
+	}
+	c.faces[key] = face
+	return face
+}
+
+// Shape returns text as a glyph run with its baseline origin at origin,
+// one glyph per rune. Each glyph's advance includes the kerning towards
+// the next glyph. Runes the font lacks map to its .notdef glyph.
+func (c *Cache) Shape(text string, origin geom.Pt, size float64, family string) render.GlyphRun {
+	f, _ := Lookup(family)
+	ppem := ppem(size)
+	run := render.GlyphRun{Origin: origin, Size: sizeOrDefault(size), FontKey: family}
+	prev := sfnt.GlyphIndex(0)
+	for i, r := range []rune(text) {
+		id, _ := f.GlyphIndex(&c.buf, r)
+		if i > 0 {
+			if k, err := f.Kern(&c.buf, prev, id, ppem, c.Hinting); err == nil {
+				run.Glyphs[i-1].Advance += fixedToFloat(k)
+			}
+		}
+		adv, _ := f.GlyphAdvance(&c.buf, id, ppem, c.Hinting)
+		run.Glyphs = append(run.Glyphs, render.Glyph{ID: uint32(id), Advance: fixedToFloat(adv)})
+		prev = id
+	}
+	return run
+}
+
+// Measure returns the width of text with kerning and the line metrics of
+// family at size.
+func (c *Cache) Measure(text string, size float64, family string) render.TextMetrics {
+	f, _ := Lookup(family)
+	m, err := f.Metrics(&c.buf, ppem(size), c.Hinting)
+	if err != nil {
+		return render.TextMetrics{}
+	}
+	w := 0.0
+	for _, g := range c.Shape(text, geom.Pt{}, size, family).Glyphs {
+		w += g.Advance
+	}
+	return render.TextMetrics{
+		W:       w,
+		H:       fixedToFloat(m.Height),
+		Ascent:  fixedToFloat(m.Ascent),
+		Descent: fixedToFloat(m.Descent),
+	}
+}
+
+// GlyphPath returns the outline of glyph id of family at size with its
+// baseline origin at origin, in pixel coordinates with y pointing down.
+// Outlines fill by the nonzero rule. An unknown glyph gives an empty path.
+func (c *Cache) GlyphPath(family string, id uint32, size float64, origin geom.Pt) geom.Path {
+	f, _ := Lookup(family)
+	segs, err := f.LoadGlyph(&c.buf, sfnt.GlyphIndex(id), ppem(size), nil)
+	var p geom.Path
+	if err != nil {
+		return p
+	}
+	pt := func(q fixed.Point26_6) geom.Pt {
+		return geom.Pt{X: origin.X + fixedToFloat(q.X), Y: origin.Y + fixedToFloat(q.Y)}
+	}
+	for _, s := range segs {
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			if len(p.C) > 0 {
+				p.Close()
+			}
+			p.MoveTo(pt(s.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			p.LineTo(pt(s.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			p.QuadTo(pt(s.Args[0]), pt(s.Args[1]))
+		case sfnt.SegmentOpCubeTo:
+			p.CubicTo(pt(s.Args[0]), pt(s.Args[1]), pt(s.Args[2]))
+		}
+	}
+	if len(p.C) > 0 {
+		p.Close()
+	}
+	return p
+}
+
+func sizeOrDefault(size float64) float64 {
+	if size > 0 {
+		return size
+	}
+	return DefaultSize
+}
+
+func ppem(size float64) fixed.Int26_6 {
+	return fixed.Int26_6(sizeOrDefault(size)*64 + 0.5)
+}
+
+func fixedToFloat(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
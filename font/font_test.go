@@ -0,0 +1,114 @@
+package font
+
+import (
+	"math"
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"matplotlib-go/internal/geom"
+)
+
+func TestLookupFallsBackToDefault(t *testing.T) {
+	def, ok := Lookup(DefaultFamily)
+	if !ok || def == nil {
+		t.Fatal("default family not registered")
+	}
+	if f, ok := Lookup("NoSuchFamily"); ok || f != def {
+		t.Errorf("unknown family gave %p, %v, want the default font", f, ok)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register("Mono", gomono.TTF); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Lookup("Mono"); !ok {
+		t.Error("registered family not found")
+	}
+	if err := Register("Broken", []byte("not a font")); err == nil {
+		t.Error("invalid font data accepted")
+	}
+	if err := RegisterFile("Missing", "testdata/missing.ttf"); err == nil {
+		t.Error("missing file accepted")
+	}
+
+	var c Cache
+	// Every glyph of a monospace font has the same advance.
+	run := c.Shape("iW", geom.Pt{}, 20, "Mono")
+	if len(run.Glyphs) != 2 || run.Glyphs[0].Advance != run.Glyphs[1].Advance {
+		t.Errorf("monospace glyphs %+v, want equal advances", run.Glyphs)
+	}
+}
+
+func TestShape(t *testing.T) {
+	var c Cache
+	run := c.Shape("AV\u00e9", geom.Pt{X: 5, Y: 10}, 40, DefaultFamily)
+	if run.Origin != (geom.Pt{X: 5, Y: 10}) || run.Size != 40 || run.FontKey != DefaultFamily || len(run.Glyphs) != 3 {
+		t.Fatalf("run %+v, want three glyphs at the origin", run)
+	}
+	w := 0.0
+	for _, g := range run.Glyphs {
+		if g.ID == 0 || g.Advance <= 0 {
+			t.Errorf("glyph %+v, want a mapped glyph with an advance", g)
+		}
+		w += g.Advance
+	}
+	// The Go fonts have no kerning pairs, so the run is as wide as its
+	// glyphs measured one by one.
+	if m := c.Measure("AV\u00e9", 40, DefaultFamily); m.W != w {
+		t.Errorf("measured width %v, want the run advance %v", m.W, w)
+	}
+}
+
+func TestMeasureScales(t *testing.T) {
+	var c Cache
+	small, large := c.Measure("Axis", 10, ""), c.Measure("Axis", 20, "")
+	if small.W <= 0 || small.Ascent <= 0 || small.Descent <= 0 {
+		t.Fatalf("metrics %+v, want positive", small)
+	}
+	if math.Abs(large.W-2*small.W) > 0.1 || math.Abs(large.Ascent-2*small.Ascent) > 0.1 {
+		t.Errorf("metrics at 20px %+v, want twice those at 10px %+v", large, small)
+	}
+	if c.Face("", 10) != c.Face("NoSuchFamily", 10) {
+		t.Error("faces of the fallback family not shared")
+	}
+}
+
+func TestGlyphPath(t *testing.T) {
+	var c Cache
+	run := c.Shape("H", geom.Pt{}, 20, "")
+	p := c.GlyphPath("", run.Glyphs[0].ID, 20, geom.Pt{X: 100, Y: 50})
+	if !p.Validate() || len(p.C) == 0 {
+		t.Fatal("no outline for H")
+	}
+	// The glyph stands on the baseline, to the right of the origin.
+	for _, v := range p.V {
+		if v.X < 100 || v.X > 100+run.Glyphs[0].Advance || v.Y > 50 || v.Y < 50-20 {
+			t.Fatalf("outline point %v outside the glyph box", v)
+		}
+	}
+	if space := c.Shape(" ", geom.Pt{}, 20, ""); len(c.GlyphPath("", space.Glyphs[0].ID, 20, geom.Pt{}).C) != 0 {
+		t.Error("space has an outline")
+	}
+}
+
+func TestRegisterAgain(t *testing.T) {
+	if err := Register("Again", gomono.TTF); err != nil {
+		t.Fatal(err)
+	}
+	var c Cache
+	mono, sum := c.Face("Again", 12), Sum("Again")
+	if err := Register("Again", goregular.TTF); err != nil {
+		t.Fatal(err)
+	}
+	if Sum("Again") == sum || Sum("Again") != Sum(DefaultFamily) {
+		t.Error("Sum did not follow the new font")
+	}
+	if c.Face("Again", 12) == mono {
+		t.Error("cache kept the face of the replaced font")
+	}
+	if Sum("NoSuchFamily") != Sum(DefaultFamily) {
+		t.Error("unknown family does not sum as the default")
+	}
+}
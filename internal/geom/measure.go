@@ -0,0 +1,132 @@
+package geom
+
+import "math"
+
+// curveSteps is the number of line segments a curve is flattened into for
+// measuring.
+const curveSteps = 16
+
+// PathPoint is a position on a path together with the direction of travel.
+type PathPoint struct {
+	Pt      Pt  // position
+	Tangent Pt  // unit direction of travel
+	Dist    F64 // arc length from the start of the path
+}
+
+// Angle returns the direction of travel in radians, measured from the +X
+// axis towards +Y.
+func (pp PathPoint) Angle() F64 { return math.Atan2(pp.Tangent.Y, pp.Tangent.X) }
+
+// segment is a straight piece of a flattened path.
+type segment struct {
+	a, b Pt
+	len  F64
+}
+
+// flatten converts p into straight segments. Gaps between subpaths are not
+// part of the result, and degenerate segments are dropped.
+func (p Path) flatten() []segment {
+	var segs []segment
+	var cur, start Pt
+	add := func(to Pt) {
+		if l := math.Hypot(to.X-cur.X, to.Y-cur.Y); l > 0 {
+			segs = append(segs, segment{cur, to, l})
+		}
+		cur = to
+	}
+	vi := 0
+	for _, c := range p.C {
+		switch c {
+		case MoveTo:
+			cur, start = p.V[vi], p.V[vi]
+			vi++
+		case LineTo:
+			add(p.V[vi])
+			vi++
+		case QuadTo:
+			p0, c1, p1 := cur, p.V[vi], p.V[vi+1]
+			for i := 1; i <= curveSteps; i++ {
+				t := F64(i) / curveSteps
+				u := 1 - t
+				add(Pt{
+					X: u*u*p0.X + 2*u*t*c1.X + t*t*p1.X,
+					Y: u*u*p0.Y + 2*u*t*c1.Y + t*t*p1.Y,
+				})
+			}
+			vi += 2
+		case CubicTo:
+			p0, c1, c2, p1 := cur, p.V[vi], p.V[vi+1], p.V[vi+2]
+			for i := 1; i <= curveSteps; i++ {
+				t := F64(i) / curveSteps
+				u := 1 - t
+				add(Pt{
+					X: u*u*u*p0.X + 3*u*u*t*c1.X + 3*u*t*t*c2.X + t*t*t*p1.X,
+					Y: u*u*u*p0.Y + 3*u*u*t*c1.Y + 3*u*t*t*c2.Y + t*t*t*p1.Y,
+				})
+			}
+			vi += 3
+		case ClosePath:
+			add(start)
+		}
+	}
+	return segs
+}
+
+// Length returns the arc length of the path. Curves are measured on a
+// flattened approximation; jumps between subpaths do not count.
+func (p Path) Length() F64 {
+	var l F64
+	for _, s := range p.flatten() {
+		l += s.len
+	}
+	return l
+}
+
+// PointAt returns the point at arc length d from the start of the path.
+// d is clamped to [0, Length]; it returns false for a path without length.
+func (p Path) PointAt(d F64) (PathPoint, bool) {
+	segs := p.flatten()
+	if len(segs) == 0 {
+		return PathPoint{}, false
+	}
+	d = math.Max(d, 0)
+	var walked F64
+	for i, s := range segs {
+		if d <= walked+s.len || i == len(segs)-1 {
+			return s.at(math.Min(d-walked, s.len), walked), true
+		}
+		walked += s.len
+	}
+	return PathPoint{}, false // unreachable
+}
+
+// PointsEvery returns points spaced spacing apart along the path, starting
+// at arc length offset, e.g. to place markers, arrows or inline labels.
+// A non-positive spacing yields nil.
+func (p Path) PointsEvery(spacing, offset F64) []PathPoint {
+	if spacing <= 0 {
+		return nil
+	}
+	var out []PathPoint
+	var walked F64
+	d := math.Max(offset, 0)
+	for _, s := range p.flatten() {
+		for d <= walked+s.len {
+			out = append(out, s.at(d-walked, walked))
+			d += spacing
+		}
+		walked += s.len
+	}
+	return out
+}
+
+// at returns the point at distance t along the segment, which starts at
+// arc length walked.
+func (s segment) at(t, walked F64) PathPoint {
+	dir := Pt{X: (s.b.X - s.a.X) / s.len, Y: (s.b.Y - s.a.Y) / s.len}
+	return PathPoint{
+		Pt:      Pt{X: s.a.X + dir.X*t, Y: s.a.Y + dir.Y*t},
+		Tangent: dir,
+		Dist:    walked + t,
+	}
+}
@@ -0,0 +1,90 @@
+package geom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathLength(t *testing.T) {
+	var p Path
+	p.MoveTo(Pt{0, 0})
+	p.LineTo(Pt{3, 4})
+	p.MoveTo(Pt{10, 10}) // the jump does not count
+	p.LineTo(Pt{10, 12})
+	p.LineTo(Pt{12, 12})
+	p.Close()
+	if got, want := p.Length(), 5+2+2+2*math.Sqrt2; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Length = %v, want %v", got, want)
+	}
+
+	// A quarter circle as a cubic Bézier.
+	const k = 0.5522847498
+	var arc Path
+	arc.MoveTo(Pt{1, 0})
+	arc.CubicTo(Pt{1, k}, Pt{k, 1}, Pt{0, 1})
+	if got := arc.Length(); math.Abs(got-math.Pi/2) > 1e-3 {
+		t.Errorf("quarter circle length = %v, want %v", got, math.Pi/2)
+	}
+
+	if (Path{}).Length() != 0 {
+		t.Error("empty path has a length")
+	}
+}
+
+func TestPathPointAt(t *testing.T) {
+	var p Path
+	p.MoveTo(Pt{0, 0})
+	p.LineTo(Pt{4, 0})
+	p.LineTo(Pt{4, 3})
+
+	tests := []struct {
+		d       float64
+		pt, tan Pt
+	}{
+		{-1, Pt{0, 0}, Pt{1, 0}}, // clamped
+		{2, Pt{2, 0}, Pt{1, 0}},
+		{4, Pt{4, 0}, Pt{1, 0}},
+		{5, Pt{4, 1}, Pt{0, 1}},
+		{99, Pt{4, 3}, Pt{0, 1}}, // clamped
+	}
+	for _, tc := range tests {
+		pp, ok := p.PointAt(tc.d)
+		if !ok || !approxPt(pp.Pt, tc.pt, 1e-12) || !approxPt(pp.Tangent, tc.tan, 1e-12) {
+			t.Errorf("PointAt(%v) = %+v, %v; want %v heading %v", tc.d, pp, ok, tc.pt, tc.tan)
+		}
+	}
+	if pp, _ := p.PointAt(5); math.Abs(pp.Angle()-math.Pi/2) > 1e-12 || pp.Dist != 5 {
+		t.Errorf("PointAt(5) angle %v dist %v", pp.Angle(), pp.Dist)
+	}
+
+	var dot Path
+	dot.MoveTo(Pt{1, 1})
+	dot.LineTo(Pt{1, 1})
+	if _, ok := dot.PointAt(0); ok {
+		t.Error("PointAt succeeded on a path without length")
+	}
+}
+
+func TestPathPointsEvery(t *testing.T) {
+	var p Path
+	p.MoveTo(Pt{0, 0})
+	p.LineTo(Pt{10, 0})
+	p.LineTo(Pt{10, 5})
+
+	pts := p.PointsEvery(4, 1)
+	want := []Pt{{1, 0}, {5, 0}, {9, 0}, {10, 3}}
+	if len(pts) != len(want) {
+		t.Fatalf("got %d points, want %d: %+v", len(pts), len(want), pts)
+	}
+	for i, pp := range pts {
+		if !approxPt(pp.Pt, want[i], 1e-12) {
+			t.Errorf("point %d = %v, want %v", i, pp.Pt, want[i])
+		}
+		if pp.Dist != 1+4*float64(i) {
+			t.Errorf("point %d at distance %v, want %v", i, pp.Dist, 1+4*float64(i))
+		}
+	}
+	if p.PointsEvery(0, 0) != nil {
+		t.Error("zero spacing returned points")
+	}
+}
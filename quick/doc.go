@@ -0,0 +1,12 @@
+// Package quick makes common charts in one line, for scripts and
+// notebooks where the full core API is more than needed:
+//
+//	quick.Line(x, y, "Response").Labels("t [s]", "v").Save("out.png")
+//	quick.Hist(samples).Save("hist.pgf")
+//	quick.Heatmap(m).Save("heat.svg")
+//
+// Each function creates a 640x480 figure with one axes, plots the data,
+// adds grid lines where they help and scales the axes to the data. The
+// returned Chart exposes the figure and axes for further changes with
+// package core.
+package quick
@@ -0,0 +1,104 @@
+package quick
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"matplotlib-go/backends"
+	_ "matplotlib-go/backends/emf"
+	_ "matplotlib-go/backends/pgf"
+	_ "matplotlib-go/backends/svg"
+	"matplotlib-go/core"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+)
+
+// Size of the figures in pixels.
+const (
+	Width  = 640
+	Height = 480
+)
+
+// HistBins is the number of bins Hist uses.
+const HistBins = 10
+
+// margin widens the autoscaled limits on each side, as in matplotlib.
+const margin = 0.05
+
+// Chart is a figure with a single axes made by Line, Hist or Heatmap.
+type Chart struct {
+	Fig  *core.Figure
+	Axes *core.Axes
+}
+
+// newChart returns an empty chart titled title.
+func newChart(title string) *Chart {
+	fig := core.NewFigure(Width, Height)
+	ax := fig.AddAxes(geom.Rect{Min: geom.Pt{X: 0.12, Y: 0.1}, Max: geom.Pt{X: 0.95, Y: 0.9}})
+	ax.Title = title
+	return &Chart{Fig: fig, Axes: ax}
+}
+
+// Line plots y over x with grid lines.
+func Line(x, y []float64, title string) *Chart {
+	c := newChart(title)
+	c.Axes.Plot(x, y)
+	c.Axes.Set(core.AxesSpec{Grid: true})
+	c.Axes.AutoScale(margin)
+	return c
+}
+
+// Hist plots the distribution of data as HistBins bars spanning its
+// finite range, see Axes.Hist. NaNs and infinities are ignored.
+func Hist(data []float64) *Chart {
+	c := newChart("")
+	c.Axes.Hist(data, core.HistOptions{Bins: HistBins})
+	c.Axes.Set(core.AxesSpec{Grid: true})
+	c.Axes.AutoScale(margin)
+	return c
+}
+
+// Heatmap draws the rows of m as colored cells, the first row at the
+// top, with the viridis colormap.
+func Heatmap(m [][]float64) *Chart {
+	c := newChart("")
+	c.Axes.Heatmap(m)
+	return c
+}
+
+// Title sets the axes title.
+func (c *Chart) Title(title string) *Chart {
+	c.Axes.Title = title
+	return c
+}
+
+// Labels sets the x and y axis labels.
+func (c *Chart) Labels(x, y string) *Chart {
+	c.Axes.XLabel, c.Axes.YLabel = x, y
+	return c
+}
+
+// Save renders the chart to path in the format given by its extension:
+// .png, .pgf, .emf or .svg.
+func (c *Chart) Save(path string) error {
+	save := map[string]struct {
+		backend backends.Backend
+		save    func(*core.Figure, render.Renderer, string) error
+	}{
+		".png": {backends.GoBasic, core.SavePNG},
+		".pgf": {backends.PGF, core.SavePGF},
+		".emf": {backends.EMF, core.SaveEMF},
+		".svg": {backends.SVG, core.SaveSVG},
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	s, ok := save[ext]
+	if !ok {
+		return fmt.Errorf("quick: unsupported file type %q", ext)
+	}
+	r, err := backends.Create(s.backend, c.Fig.RendererConfig())
+	if err != nil {
+		return err
+	}
+	return s.save(c.Fig, r, path)
+}
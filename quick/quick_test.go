@@ -0,0 +1,78 @@
+package quick
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"matplotlib-go/core"
+)
+
+func TestLine(t *testing.T) {
+	c := Line([]float64{0, 10}, []float64{1, 3}, "Title").Labels("x", "y")
+	if c.Axes.Title != "Title" || c.Axes.XLabel != "x" || c.Axes.YLabel != "y" {
+		t.Errorf("title and labels %q %q %q", c.Axes.Title, c.Axes.XLabel, c.Axes.YLabel)
+	}
+	if xmin, xmax := c.Axes.XScale.Domain(); math.Abs(xmin+0.5) > 1e-9 || math.Abs(xmax-10.5) > 1e-9 {
+		t.Errorf("x limits %v..%v, want -0.5..10.5", xmin, xmax)
+	}
+	grids := 0
+	for _, art := range c.Axes.Artists {
+		if _, ok := art.(*core.Grid); ok {
+			grids++
+		}
+	}
+	if grids != 2 {
+		t.Errorf("%d grids, want 2", grids)
+	}
+}
+
+func TestHist(t *testing.T) {
+	c := Hist([]float64{0, 1, 1, 10, math.NaN()})
+	var hist *core.Hist
+	for _, art := range c.Axes.Artists {
+		if h, ok := art.(*core.Hist); ok {
+			hist = h
+		}
+	}
+	if hist == nil || len(hist.Edges) != HistBins+1 {
+		t.Fatalf("histogram %+v", hist)
+	}
+	v := hist.Values()
+	if v[0] != 1 || v[1] != 2 || v[HistBins-1] != 1 || hist.Edges[0] != 0 || hist.Edges[1] != 1 {
+		t.Errorf("heights %v, edges %v", v, hist.Edges)
+	}
+	if ymin, _ := c.Axes.YScale.Domain(); ymin > 0 {
+		t.Errorf("y limits start at %v, above the bar baseline", ymin)
+	}
+
+	// Without finite data the bins span 0 to 1 and stay empty.
+	for _, art := range Hist(nil).Axes.Artists {
+		if h, ok := art.(*core.Hist); ok {
+			for _, n := range h.Values() {
+				if n != 0 {
+					t.Errorf("empty histogram has heights %v", h.Values())
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	c := Heatmap([][]float64{{1, 2}, {3, 4}})
+	for _, name := range []string{"out.png", "out.pgf", "out.emf", "out.svg"} {
+		path := filepath.Join(dir, name)
+		if err := c.Save(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+	if err := c.Save(filepath.Join(dir, "out.gif")); err == nil {
+		t.Error("no error for an unsupported file type")
+	}
+}
@@ -0,0 +1,218 @@
+package render
+
+import "golang.org/x/text/unicode/bidi"
+
+// VisualOrder returns text in left-to-right display order by the Unicode
+// bidirectional algorithm (UAX #9), for backends that place glyphs from
+// left to right: runs of right-to-left scripts such as Hebrew and Arabic
+// are reversed and their brackets mirrored, while numbers and embedded
+// left-to-right words keep their reading order. The paragraph direction
+// follows the first strong character. Explicit embeddings and isolates are
+// not supported, and Arabic letters are not shaped into joining forms.
+// Text without right-to-left characters is returned unchanged.
+func VisualOrder(text string) string {
+	runes := []rune(text)
+	classes := make([]bidi.Class, len(runes))
+	rtl := false
+	for i, r := range runes {
+		p, _ := bidi.LookupRune(r)
+		classes[i] = p.Class()
+		rtl = rtl || classes[i] == bidi.R || classes[i] == bidi.AL
+	}
+	if !rtl {
+		return text
+	}
+	original := append([]bidi.Class(nil), classes...)
+
+	// P2, P3: the first strong character sets the paragraph level.
+	base, sor := 0, bidi.L
+	for _, c := range classes {
+		if c == bidi.L {
+			break
+		}
+		if c == bidi.R || c == bidi.AL {
+			base, sor = 1, bidi.R
+			break
+		}
+	}
+	resolveWeak(classes, sor)
+	resolveNeutral(classes, sor)
+
+	// I1, I2: implicit levels.
+	levels := make([]int, len(runes))
+	for i, c := range classes {
+		switch {
+		case base == 0 && c == bidi.R:
+			levels[i] = 1
+		case base == 0 && (c == bidi.EN || c == bidi.AN):
+			levels[i] = 2
+		case base == 1 && (c == bidi.L || c == bidi.EN || c == bidi.AN):
+			levels[i] = 2
+		default:
+			levels[i] = base
+		}
+	}
+	// L1: separators and trailing whitespace take the paragraph level.
+	trailing := true
+	for i := len(runes) - 1; i >= 0; i-- {
+		switch original[i] {
+		case bidi.S, bidi.B:
+			levels[i], trailing = base, true
+		case bidi.WS, bidi.BN:
+			if trailing {
+				levels[i] = base
+			}
+		default:
+			trailing = false
+		}
+	}
+
+	// L2: reverse runs from the highest level down to the lowest odd one.
+	maxLevel := 0
+	for _, l := range levels {
+		maxLevel = max(maxLevel, l)
+	}
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < len(runes); {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(runes) && levels[j] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				runes[a], runes[b] = runes[b], runes[a]
+				levels[a], levels[b] = levels[b], levels[a]
+			}
+			i = j
+		}
+	}
+	// L4: mirror brackets shown right to left.
+	for i, r := range runes {
+		if p, _ := bidi.LookupRune(r); levels[i]%2 == 1 && p.IsBracket() {
+			runes[i] = []rune(bidi.ReverseString(string(r)))[0]
+		}
+	}
+	return string(runes)
+}
+
+// resolveWeak applies the weak type rules W1-W7 to classes in place; sor
+// is the direction at the start of the text.
+func resolveWeak(classes []bidi.Class, sor bidi.Class) {
+	// W1: nonspacing marks take the class of the previous character.
+	prev := sor
+	for i, c := range classes {
+		if c == bidi.NSM {
+			classes[i] = prev
+		}
+		prev = classes[i]
+	}
+	// W2, W3: European numbers after Arabic letters are Arabic numbers,
+	// and Arabic letters are right to left.
+	strong := sor
+	for i, c := range classes {
+		switch c {
+		case bidi.L, bidi.R, bidi.AL:
+			strong = c
+		case bidi.EN:
+			if strong == bidi.AL {
+				classes[i] = bidi.AN
+			}
+		}
+	}
+	for i, c := range classes {
+		if c == bidi.AL {
+			classes[i] = bidi.R
+		}
+	}
+	// W4: a single separator between two numbers of the same kind joins them.
+	for i := 1; i+1 < len(classes); i++ {
+		before, after := classes[i-1], classes[i+1]
+		switch {
+		case classes[i] == bidi.ES && before == bidi.EN && after == bidi.EN:
+			classes[i] = bidi.EN
+		case classes[i] == bidi.CS && before == after && (before == bidi.EN || before == bidi.AN):
+			classes[i] = before
+		}
+	}
+	// W5: terminators such as currency signs next to European numbers.
+	for i := 0; i < len(classes); {
+		if classes[i] != bidi.ET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(classes) && classes[j] == bidi.ET {
+			j++
+		}
+		if (i > 0 && classes[i-1] == bidi.EN) || (j < len(classes) && classes[j] == bidi.EN) {
+			for k := i; k < j; k++ {
+				classes[k] = bidi.EN
+			}
+		}
+		i = j
+	}
+	// W6: remaining separators and terminators are neutral.
+	for i, c := range classes {
+		if c == bidi.ES || c == bidi.ET || c == bidi.CS {
+			classes[i] = bidi.ON
+		}
+	}
+	// W7: European numbers in left-to-right context are left to right.
+	strong = sor
+	for i, c := range classes {
+		switch c {
+		case bidi.L, bidi.R:
+			strong = c
+		case bidi.EN:
+			if strong == bidi.L {
+				classes[i] = bidi.L
+			}
+		}
+	}
+}
+
+// resolveNeutral applies N1 and N2: a run of neutrals takes the direction
+// of the text around it if both sides agree, with numbers counting as
+// right to left, and the paragraph direction sor otherwise.
+func resolveNeutral(classes []bidi.Class, sor bidi.Class) {
+	direction := func(c bidi.Class) (bidi.Class, bool) {
+		switch c {
+		case bidi.L:
+			return bidi.L, true
+		case bidi.R, bidi.EN, bidi.AN:
+			return bidi.R, true
+		}
+		return 0, false
+	}
+	for i := 0; i < len(classes); {
+		if _, strong := direction(classes[i]); strong {
+			i++
+			continue
+		}
+		j := i
+		for j < len(classes) {
+			if _, strong := direction(classes[j]); strong {
+				break
+			}
+			j++
+		}
+		before, after := sor, sor
+		if i > 0 {
+			before, _ = direction(classes[i-1])
+		}
+		if j < len(classes) {
+			after, _ = direction(classes[j])
+		}
+		resolved := sor
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			classes[k] = resolved
+		}
+		i = j
+	}
+}
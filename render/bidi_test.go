@@ -0,0 +1,24 @@
+package render
+
+import "testing"
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"ascii unchanged", "Temperature (°C)", "Temperature (°C)"},
+		{"hebrew", "שלום", "םולש"},
+		{"rtl paragraph with latin word", "שלום abc עולם", "םלוע abc םולש"},
+		{"rtl paragraph with number", "שלום 123", "123 םולש"},
+		{"ltr paragraph with hebrew word", "abc שלום def", "abc םולש def"},
+		{"mirrored brackets", "(שלום)", "(םולש)"},
+		{"arabic with decimal", "قيمة 3.5", "3.5 ةميق"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VisualOrder(tt.text); got != tt.want {
+				t.Errorf("VisualOrder(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
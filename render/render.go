@@ -2,6 +2,8 @@ package render
 
 import (
 	"errors"
+	"image"
+	"math"
 
 	"matplotlib-go/internal/geom"
 )
@@ -15,8 +17,24 @@ type Paint struct {
 	Stroke     Color
 	Fill       Color
 	Dashes     []float64 // on/off pairs, in user space units
+	DashCap    DashCap   // cap of the dash ends inside a dashed line
+	FillRule   FillRule  // which regions of overlapping subpaths are filled
+	Aliased    bool      // draw without anti-aliasing: pixels are fully covered or not at all
 }
 
+// FillRule decides which regions of a path are inside, e.g. whether a
+// subpath within another one is a hole.
+type FillRule uint8
+
+const (
+	// FillNonZero fills regions with a nonzero winding number: an inner
+	// subpath is a hole only if it runs opposite to the outer one.
+	FillNonZero FillRule = iota
+	// FillEvenOdd fills regions enclosed an odd number of times: inner
+	// subpaths are holes regardless of their direction.
+	FillEvenOdd
+)
+
 // LineJoin controls how path joins are rendered.
 type LineJoin uint8
 
@@ -35,6 +53,41 @@ const (
 	CapSquare
 )
 
+// DashCap controls the ends of the dashes of a dashed line apart from the
+// line's own start and end, which keep the LineCap, e.g. for dotted lines
+// of round dots with butt ends.
+type DashCap uint8
+
+const (
+	DashCapLine DashCap = iota // dash ends use the LineCap
+	DashCapButt
+	DashCapRound
+	DashCapSquare
+)
+
+// Cap returns the cap of dash ends for a line with cap c.
+func (d DashCap) Cap(c LineCap) LineCap {
+	switch d {
+	case DashCapButt:
+		return CapButt
+	case DashCapRound:
+		return CapRound
+	case DashCapSquare:
+		return CapSquare
+	}
+	return c
+}
+
+// StrokeCap returns the single cap of formats that cap all dash ends
+// alike, such as SVG and PDF: the dash cap for dashed lines, since most
+// of their ends are dash ends, else LineCap.
+func (p *Paint) StrokeCap() LineCap {
+	if len(p.Dashes) > 0 {
+		return p.DashCap.Cap(p.LineCap)
+	}
+	return p.LineCap
+}
+
 // Color is a simple RGBA in linear [0..1].
 type Color struct{ R, G, B, A float64 }
 
@@ -77,11 +130,39 @@ type GlyphRun struct {
 // TextMetrics provides basic text measurements.
 type TextMetrics struct{ W, H, Ascent, Descent float64 }
 
+// Bounds returns the pixel bounds of text with these metrics drawn with its
+// left baseline origin at origin, turned by angle radians counterclockwise
+// around it, e.g. to lay out rotated labels.
+func (m TextMetrics) Bounds(origin geom.Pt, angle float64) geom.Rect {
+	sin, cos := math.Sincos(angle)
+	var b geom.Rect
+	for i, c := range [4][2]float64{{0, -m.Ascent}, {m.W, -m.Ascent}, {0, m.Descent}, {m.W, m.Descent}} {
+		p := geom.Pt{X: origin.X + c[0]*cos + c[1]*sin, Y: origin.Y - c[0]*sin + c[1]*cos}
+		if i == 0 {
+			b = geom.Rect{Min: p, Max: p}
+			continue
+		}
+		b.Min.X, b.Min.Y = math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y)
+		b.Max.X, b.Max.Y = math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
+
 // Image is a minimal interface for raster images passed to renderers.
 type Image interface {
 	Size() (w, h int)
 }
 
+// RGBAImage adapts a premultiplied *image.RGBA to Image so backends can read
+// its pixels.
+type RGBAImage struct{ *image.RGBA }
+
+// Size returns the image dimensions in pixels.
+func (i RGBAImage) Size() (w, h int) {
+	b := i.Bounds()
+	return b.Dx(), b.Dy()
+}
+
 // Renderer defines the core drawing verbs.
 type Renderer interface {
 	Begin(viewport geom.Rect) error
@@ -102,6 +183,95 @@ type Renderer interface {
 	MeasureText(text string, size float64, fontKey string) TextMetrics
 }
 
+// TextDrawer is an optional Renderer extension for drawing a string directly
+// at a baseline origin in pixel coordinates. Backends that cannot shape glyph
+// runs yet (or that defer typesetting, like PGF) implement it so tick labels
+// and titles still appear.
+type TextDrawer interface {
+	DrawText(text string, origin geom.Pt, size float64, textColor Color)
+}
+
+// RotatedTextDrawer is an optional Renderer extension for text at an angle,
+// e.g. slanted tick labels. angle is in radians, counterclockwise as seen
+// on screen, and the text turns around its left baseline origin.
+type RotatedTextDrawer interface {
+	DrawTextRotated(text string, origin geom.Pt, size, angle float64, textColor Color)
+}
+
+// Grouper is an optional Renderer extension for backends with a document
+// structure (e.g. SVG). Drawing calls between BeginGroup and EndGroup belong
+// to one logical element with a stable id and class, so exported output can
+// be styled or scripted per artist. Groups nest.
+type Grouper interface {
+	BeginGroup(id, class string)
+	EndGroup()
+}
+
+// Titler is an optional Renderer extension for attaching a tooltip to the
+// next Path call. Vector backends render it natively (an SVG <title> child
+// shows as a browser tooltip); other backends ignore it.
+type Titler interface {
+	Title(text string)
+}
+
+// Describer is an optional Renderer extension for accessibility metadata in
+// document backends (e.g. SVG). Describe gives the innermost group opened
+// by Grouper.BeginGroup, or the whole document when no group is open, an
+// ARIA role such as "graphics-document" or "graphics-object", a short
+// accessible name and an optional longer description. It must be called
+// before anything is drawn into the group.
+type Describer interface {
+	Describe(role, title, desc string)
+}
+
+// VectorOutput is an optional Renderer extension implemented by vector
+// backends whose Image call embeds the bitmap in their output. Artists that
+// ask for rasterization are drawn offscreen and embedded only for such
+// renderers; raster backends draw them directly.
+type VectorOutput interface {
+	VectorOutput() bool
+}
+
+// Hinting selects how strongly glyph outlines are fitted to the pixel grid.
+type Hinting uint8
+
+const (
+	HintingDefault Hinting = iota // the backend's choice
+	HintingNone                   // outlines as designed, softer at small sizes
+	HintingSlight                 // vertical fitting only, keeping glyph shapes
+	HintingFull                   // fitting in both directions, crispest
+)
+
+// ParseHinting returns the Hinting named "none", "slight" or "full", or
+// HintingDefault for "". ok is false for other names.
+func ParseHinting(name string) (h Hinting, ok bool) {
+	switch name {
+	case "":
+		return HintingDefault, true
+	case "none":
+		return HintingNone, true
+	case "slight":
+		return HintingSlight, true
+	case "full":
+		return HintingFull, true
+	}
+	return HintingDefault, false
+}
+
+// TextOptions configures the font of text and how it is rasterized.
+type TextOptions struct {
+	FontKey  string // font family of DrawText and DrawTextRotated
+	Hinting  Hinting
+	Subpixel bool // glyphs at fractional horizontal positions instead of whole pixels
+}
+
+// TextConfigurer is an optional Renderer extension for backends that
+// rasterize outline fonts. Figures pass the text options of their RC
+// before drawing; other backends need not implement it.
+type TextConfigurer interface {
+	SetTextOptions(opts TextOptions)
+}
+
 // NullRenderer is a no-op renderer used for traversal/tests.
 type NullRenderer struct {
 	began  bool
@@ -1,6 +1,7 @@
 package render
 
 import (
+	"math"
 	"testing"
 
 	"matplotlib-go/internal/geom"
@@ -66,3 +67,17 @@ func TestNullRenderer_BeginEndOrder(t *testing.T) {
 		t.Fatalf("end: %v", err)
 	}
 }
+
+func TestTextMetricsBounds(t *testing.T) {
+	m := TextMetrics{W: 40, H: 10, Ascent: 8, Descent: 2}
+	if b := m.Bounds(geom.Pt{X: 10, Y: 20}, 0); b != (geom.Rect{Min: geom.Pt{X: 10, Y: 12}, Max: geom.Pt{X: 50, Y: 22}}) {
+		t.Errorf("upright bounds %v", b)
+	}
+	// Turned by 90°, the text runs upwards with its ascent to the left.
+	b := m.Bounds(geom.Pt{X: 10, Y: 20}, math.Pi/2)
+	want := geom.Rect{Min: geom.Pt{X: 2, Y: -20}, Max: geom.Pt{X: 12, Y: 20}}
+	if math.Abs(b.Min.X-want.Min.X) > 1e-9 || math.Abs(b.Min.Y-want.Min.Y) > 1e-9 ||
+		math.Abs(b.Max.X-want.Max.X) > 1e-9 || math.Abs(b.Max.Y-want.Max.Y) > 1e-9 {
+		t.Errorf("rotated bounds %v, want %v", b, want)
+	}
+}
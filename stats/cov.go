@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Covariance2D returns the mean and the sample covariance matrix of the
+// points (x[k], y[k]), e.g. for core.NewCovarianceEllipse. Points with a
+// NaN or infinite coordinate are left out.
+func Covariance2D(x, y []float64) (mean [2]float64, cov [2][2]float64, err error) {
+	px, py, err := finitePairs(x, y)
+	if err != nil {
+		return mean, cov, fmt.Errorf("covariance: %w", err)
+	}
+	n := len(px)
+	if n < 2 {
+		return mean, cov, errors.New("covariance: need at least two finite points")
+	}
+	for k := range px {
+		mean[0] += px[k]
+		mean[1] += py[k]
+	}
+	mean[0] /= float64(n)
+	mean[1] /= float64(n)
+	for k := range px {
+		dx, dy := px[k]-mean[0], py[k]-mean[1]
+		cov[0][0] += dx * dx
+		cov[0][1] += dx * dy
+		cov[1][1] += dy * dy
+	}
+	for _, v := range []*float64{&cov[0][0], &cov[0][1], &cov[1][1]} {
+		*v /= float64(n - 1)
+	}
+	cov[1][0] = cov[0][1]
+	return mean, cov, nil
+}
@@ -0,0 +1,25 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCovariance2D(t *testing.T) {
+	x := []float64{0, 2, 4, math.NaN()}
+	y := []float64{1, 1, 4, 0}
+	mean, cov, err := Covariance2D(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mean != [2]float64{2, 2} {
+		t.Errorf("mean %v, want [2 2]", mean)
+	}
+	// dx = -2, 0, 2; dy = -1, -1, 2
+	if want := [2][2]float64{{4, 3}, {3, 3}}; cov != want {
+		t.Errorf("cov %v, want %v", cov, want)
+	}
+	if _, _, err := Covariance2D([]float64{1}, []float64{1}); err == nil {
+		t.Error("no error for a single point")
+	}
+}
@@ -0,0 +1,4 @@
+// Package stats turns raw samples into plottable summaries, such as
+// histogram bins and counts, quantiles and outlier fences, covariances,
+// regression fits, or density grids for contour plots.
+package stats
@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// LinearBins returns n+1 equally spaced bin edges from min to max.
+func LinearBins(min, max float64, n int) []float64 {
+	if n < 1 || !(max > min) {
+		return nil
+	}
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = min + (max-min)*float64(i)/float64(n)
+	}
+	edges[n] = max
+	return edges
+}
+
+// LogBins returns n+1 bin edges from min to max spaced evenly in log
+// space, so that each bin is the same factor wider than the one before,
+// e.g. for heavy-tailed data shown on a log x axis. It returns nil unless
+// 0 < min < max.
+func LogBins(min, max float64, n int) []float64 {
+	if n < 1 || !(min > 0 && max > min) || math.IsInf(max, 0) {
+		return nil
+	}
+	lo, hi := math.Log(min), math.Log(max)
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = math.Exp(lo + (hi-lo)*float64(i)/float64(n))
+	}
+	edges[0], edges[n] = min, max
+	return edges
+}
+
+// Histogram counts the values falling into each bin of the sorted edges.
+// Bins include their left edge; the last one also includes its right
+// edge, as in numpy.histogram. NaNs and values outside the edges are not
+// counted.
+func Histogram(values, edges []float64) []float64 {
+	if len(edges) < 2 {
+		return nil
+	}
+	counts := make([]float64, len(edges)-1)
+	last := len(edges) - 1
+	for _, v := range values {
+		if !(v >= edges[0] && v <= edges[last]) {
+			continue
+		}
+		i := sort.SearchFloat64s(edges, v)
+		if i == len(edges) || edges[i] != v {
+			i-- // v lies inside bin i-1
+		}
+		counts[min(i, last-1)]++
+	}
+	return counts
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogBins(t *testing.T) {
+	edges := LogBins(1, 1000, 3)
+	want := []float64{1, 10, 100, 1000}
+	if len(edges) != len(want) {
+		t.Fatalf("LogBins = %v, want %v", edges, want)
+	}
+	for i := range want {
+		if math.Abs(edges[i]-want[i]) > 1e-9*want[i] {
+			t.Errorf("edge %d = %v, want %v", i, edges[i], want[i])
+		}
+	}
+	for _, bad := range [][2]float64{{0, 10}, {-1, 10}, {10, 1}, {5, 5}} {
+		if e := LogBins(bad[0], bad[1], 4); e != nil {
+			t.Errorf("LogBins(%v, %v) = %v, want nil", bad[0], bad[1], e)
+		}
+	}
+}
+
+func TestLinearBins(t *testing.T) {
+	edges := LinearBins(0, 1, 4)
+	want := []float64{0, 0.25, 0.5, 0.75, 1}
+	for i := range want {
+		if edges[i] != want[i] {
+			t.Errorf("edge %d = %v, want %v", i, edges[i], want[i])
+		}
+	}
+	if LinearBins(0, 1, 0) != nil {
+		t.Error("LinearBins with no bins should be nil")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	edges := []float64{1, 10, 100, 1000}
+	values := []float64{1, 5, 10, 50, 99, 100, 1000, 0.5, 2000, math.NaN()}
+	got := Histogram(values, edges)
+	want := []float64{2, 3, 2} // 1000 is counted in the last bin
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Histogram = %v, want %v", got, want)
+			break
+		}
+	}
+}
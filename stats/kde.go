@@ -0,0 +1,138 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+// Grid is a function sampled on a rectangular grid, e.g. for
+// core.Axes.Contour. Z[j][i] is the value at (X[i], Y[j]).
+type Grid struct {
+	X, Y []float64
+	Z    [][]float64
+}
+
+// Max returns the largest value of the grid, ignoring NaNs.
+func (g Grid) Max() float64 {
+	m := math.Inf(-1)
+	for _, row := range g.Z {
+		for _, v := range row {
+			if v > m {
+				m = v
+			}
+		}
+	}
+	return m
+}
+
+// KDE2DOptions configures KDE2D.
+type KDE2DOptions struct {
+	Size       int     // grid points per side, 0 for 100
+	BandwidthX float64 // kernel standard deviation along x, 0 for Scott's rule
+	BandwidthY float64 // kernel standard deviation along y, 0 for Scott's rule
+	Pad        float64 // margin around the data in bandwidths, 0 for 3
+}
+
+// KDE2D estimates the probability density of the points (x[k], y[k]) with
+// a Gaussian kernel and samples it on a grid covering the data, e.g. to
+// show dense scatter data as contours. The density integrates to 1 over
+// the plane. Points with a NaN coordinate are left out.
+func KDE2D(x, y []float64, opts KDE2DOptions) (Grid, error) {
+	if len(x) != len(y) {
+		return Grid{}, errors.New("kde2d: x and y differ in length")
+	}
+	var px, py []float64
+	for k := range x {
+		if !math.IsNaN(x[k]) && !math.IsNaN(y[k]) && !math.IsInf(x[k], 0) && !math.IsInf(y[k], 0) {
+			px = append(px, x[k])
+			py = append(py, y[k])
+		}
+	}
+	n := len(px)
+	if n < 2 {
+		return Grid{}, errors.New("kde2d: need at least two finite points")
+	}
+
+	// Scott's rule for d=2 dimensions: σ·n^(-1/(d+4)).
+	scott := math.Pow(float64(n), -1.0/6)
+	hx, hy := opts.BandwidthX, opts.BandwidthY
+	if hx <= 0 {
+		hx = stddev(px) * scott
+	}
+	if hy <= 0 {
+		hy = stddev(py) * scott
+	}
+	if hx == 0 || hy == 0 {
+		return Grid{}, errors.New("kde2d: data has no spread; set the bandwidths")
+	}
+	size := opts.Size
+	if size <= 0 {
+		size = 100
+	}
+	pad := opts.Pad
+	if pad <= 0 {
+		pad = 3
+	}
+
+	g := Grid{X: axis(px, hx*pad, size), Y: axis(py, hy*pad, size)}
+	// The Gaussian kernel is separable: weight each sample per column and
+	// per row once, then combine.
+	kx := kernel(g.X, px, hx)
+	ky := kernel(g.Y, py, hy)
+	norm := 1 / (float64(n) * 2 * math.Pi * hx * hy)
+	g.Z = make([][]float64, size)
+	for j := range g.Z {
+		row := make([]float64, size)
+		for i := range row {
+			var sum float64
+			for k := 0; k < n; k++ {
+				sum += ky[j][k] * kx[i][k]
+			}
+			row[i] = sum * norm
+		}
+		g.Z[j] = row
+	}
+	return g, nil
+}
+
+// axis returns size evenly spaced values spanning v widened by pad.
+func axis(v []float64, pad float64, size int) []float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, x := range v {
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	lo, hi = lo-pad, hi+pad
+	out := make([]float64, size)
+	for i := range out {
+		out[i] = lo + (hi-lo)*float64(i)/float64(max(size-1, 1))
+	}
+	return out
+}
+
+// kernel returns exp(-((at[i]-v[k])/h)²/2) for every grid value and sample.
+func kernel(at, v []float64, h float64) [][]float64 {
+	out := make([][]float64, len(at))
+	for i, a := range at {
+		w := make([]float64, len(v))
+		for k, x := range v {
+			d := (a - x) / h
+			w[k] = math.Exp(-d * d / 2)
+		}
+		out[i] = w
+	}
+	return out
+}
+
+// stddev returns the sample standard deviation.
+func stddev(v []float64) float64 {
+	var mean float64
+	for _, x := range v {
+		mean += x
+	}
+	mean /= float64(len(v))
+	var ss float64
+	for _, x := range v {
+		ss += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(ss / float64(len(v)-1))
+}
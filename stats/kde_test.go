@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKDE2D(t *testing.T) {
+	// Two clusters, the one around (5, 5) twice as dense.
+	var x, y []float64
+	for i := 0; i < 20; i++ {
+		a := 2 * math.Pi * float64(i) / 20
+		x = append(x, math.Cos(a)*0.5, 5+math.Cos(a)*0.5, 5+math.Sin(a)*0.3)
+		y = append(y, math.Sin(a)*0.5, 5+math.Sin(a)*0.5, 5+math.Cos(a)*0.3)
+	}
+	x = append(x, math.NaN())
+	y = append(y, 1)
+
+	g, err := KDE2D(x, y, KDE2DOptions{Size: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.X) != 60 || len(g.Y) != 60 || len(g.Z) != 60 || len(g.Z[0]) != 60 {
+		t.Fatalf("grid is %dx%d", len(g.X), len(g.Y))
+	}
+	if g.X[0] >= -0.5 || g.X[59] <= 5.5 {
+		t.Errorf("grid x [%v, %v] does not cover the data", g.X[0], g.X[59])
+	}
+
+	// The density integrates to about 1 and peaks at the denser cluster.
+	dx, dy := g.X[1]-g.X[0], g.Y[1]-g.Y[0]
+	var total float64
+	var peak [2]int
+	for j, row := range g.Z {
+		for i, v := range row {
+			total += v * dx * dy
+			if v > g.Z[peak[0]][peak[1]] {
+				peak = [2]int{j, i}
+			}
+		}
+	}
+	if math.Abs(total-1) > 0.02 {
+		t.Errorf("density integrates to %v, want 1", total)
+	}
+	if px, py := g.X[peak[1]], g.Y[peak[0]]; math.Hypot(px-5, py-5) > 1 {
+		t.Errorf("peak at (%v, %v), want near (5, 5)", px, py)
+	}
+	if g.Max() != g.Z[peak[0]][peak[1]] {
+		t.Errorf("Max = %v, want the peak", g.Max())
+	}
+}
+
+func TestKDE2DErrors(t *testing.T) {
+	if _, err := KDE2D([]float64{1, 2}, []float64{1}, KDE2DOptions{}); err == nil {
+		t.Error("mismatched lengths accepted")
+	}
+	if _, err := KDE2D([]float64{1}, []float64{1}, KDE2DOptions{}); err == nil {
+		t.Error("single point accepted")
+	}
+	if _, err := KDE2D([]float64{1, 1}, []float64{2, 2}, KDE2DOptions{}); err == nil {
+		t.Error("zero spread accepted without bandwidths")
+	}
+	if _, err := KDE2D([]float64{1, 1}, []float64{2, 2}, KDE2DOptions{BandwidthX: 1, BandwidthY: 1}); err != nil {
+		t.Errorf("explicit bandwidths: %v", err)
+	}
+}
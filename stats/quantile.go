@@ -0,0 +1,38 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Quantile returns the q-quantile (0 ≤ q ≤ 1) of the values, interpolating
+// linearly between order statistics like numpy.quantile's default. NaNs
+// are ignored; it returns NaN if no values remain or q is out of range.
+func Quantile(values []float64, q float64) float64 {
+	if !(q >= 0 && q <= 1) {
+		return math.NaN()
+	}
+	s := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			s = append(s, v)
+		}
+	}
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	sort.Float64s(s)
+	pos := q * float64(len(s)-1)
+	i := int(pos)
+	if i >= len(s)-1 {
+		return s[len(s)-1]
+	}
+	return s[i] + (s[i+1]-s[i])*(pos-float64(i))
+}
+
+// UpperFence returns Tukey's upper fence Q3 + k·IQR of the values; values
+// above it are outliers. k is commonly 1.5, or 3 for far-out values.
+func UpperFence(values []float64, k float64) float64 {
+	q1, q3 := Quantile(values, 0.25), Quantile(values, 0.75)
+	return q3 + k*(q3-q1)
+}
@@ -0,0 +1,22 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	values := []float64{4, math.NaN(), 1, 3, 2}
+	for q, want := range map[float64]float64{0: 1, 0.25: 1.75, 0.5: 2.5, 1: 4} {
+		if got := Quantile(values, q); math.Abs(got-want) > 1e-12 {
+			t.Errorf("Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+	if !math.IsNaN(Quantile(nil, 0.5)) || !math.IsNaN(Quantile(values, 1.5)) {
+		t.Error("want NaN for no values or q out of range")
+	}
+	// Q1 = 1.75, Q3 = 3.25: fence 3.25 + 1.5*1.5
+	if got := UpperFence(values, 1.5); math.Abs(got-5.5) > 1e-12 {
+		t.Errorf("UpperFence = %v, want 5.5", got)
+	}
+}
@@ -0,0 +1,174 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LinearFit is an ordinary least squares fit y = Intercept + Slope·x.
+type LinearFit struct {
+	Slope, Intercept float64
+	N                int     // number of points fitted
+	MeanX            float64 // mean of the fitted x values
+	Sxx              float64 // sum of squared x deviations from MeanX
+	ResidualStd      float64 // residual standard error, with N-2 degrees of freedom
+}
+
+// FitLinear fits a line to the points (x[k], y[k]) by ordinary least
+// squares. Points with a NaN or infinite coordinate are left out. It needs
+// two points with different x values.
+func FitLinear(x, y []float64) (LinearFit, error) {
+	px, py, err := finitePairs(x, y)
+	if err != nil {
+		return LinearFit{}, fmt.Errorf("linear fit: %w", err)
+	}
+	n := len(px)
+	if n < 2 {
+		return LinearFit{}, errors.New("linear fit: need at least two finite points")
+	}
+	var mx, my float64
+	for k := range px {
+		mx += px[k]
+		my += py[k]
+	}
+	mx /= float64(n)
+	my /= float64(n)
+	var sxx, sxy float64
+	for k := range px {
+		sxx += (px[k] - mx) * (px[k] - mx)
+		sxy += (px[k] - mx) * (py[k] - my)
+	}
+	if sxx == 0 {
+		return LinearFit{}, errors.New("linear fit: all x values are equal")
+	}
+	f := LinearFit{Slope: sxy / sxx, N: n, MeanX: mx, Sxx: sxx}
+	f.Intercept = my - f.Slope*mx
+	if n > 2 {
+		var ss float64
+		for k := range px {
+			r := py[k] - f.At(px[k])
+			ss += r * r
+		}
+		f.ResidualStd = math.Sqrt(ss / float64(n-2))
+	}
+	return f, nil
+}
+
+// At returns the fitted value at x.
+func (f LinearFit) At(x float64) float64 { return f.Intercept + f.Slope*x }
+
+// Band returns the confidence interval of the fitted mean at x for the
+// given level, e.g. 0.95, using Student's t distribution with N-2 degrees
+// of freedom. With two points the interval is empty.
+func (f LinearFit) Band(x, level float64) (lo, hi float64) {
+	y := f.At(x)
+	if f.N <= 2 {
+		return y, y
+	}
+	d := x - f.MeanX
+	half := TQuantile((1+level)/2, float64(f.N-2)) * f.ResidualStd * math.Sqrt(1/float64(f.N)+d*d/f.Sxx)
+	return y - half, y + half
+}
+
+// TQuantile returns the p-quantile of Student's t distribution with df
+// degrees of freedom. It is exact for df 1 and 2 and uses the
+// Cornish-Fisher expansion around the normal quantile otherwise, which is
+// within 1% for df ≥ 3 and p up to 0.995.
+func TQuantile(p, df float64) float64 {
+	switch {
+	case !(p > 0 && p < 1) || !(df > 0):
+		return math.NaN()
+	case df == 1:
+		return math.Tan(math.Pi * (p - 0.5))
+	case df == 2:
+		return (2*p - 1) / math.Sqrt(2*p*(1-p))
+	}
+	z := math.Sqrt2 * math.Erfinv(2*p-1)
+	z2 := z * z
+	g1 := (z2 + 1) * z / 4
+	g2 := ((5*z2+16)*z2 + 3) * z / 96
+	g3 := (((3*z2+19)*z2+17)*z2 - 15) * z / 384
+	return z + g1/df + g2/(df*df) + g3/(df*df*df)
+}
+
+// Lowess smooths the points (x[k], y[k]) with locally weighted linear
+// regression: each point's value is the fit of a line to its frac·n
+// nearest neighbours, weighted by the tricube of their distance. It
+// returns the finite points sorted by x with their smoothed values. frac
+// of 0 means 2/3, as in statsmodels; no robustness iterations are done.
+func Lowess(x, y []float64, frac float64) (xs, smooth []float64, err error) {
+	px, py, err := finitePairs(x, y)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lowess: %w", err)
+	}
+	n := len(px)
+	if n < 2 {
+		return nil, nil, errors.New("lowess: need at least two finite points")
+	}
+	if frac <= 0 {
+		frac = 2.0 / 3
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return px[idx[i]] < px[idx[j]] })
+	xs, ys := make([]float64, n), make([]float64, n)
+	for i, k := range idx {
+		xs[i], ys[i] = px[k], py[k]
+	}
+
+	k := min(max(int(math.Ceil(frac*float64(n))), 2), n)
+	smooth = make([]float64, n)
+	dist := make([]float64, n)
+	for i, x0 := range xs {
+		for j, v := range xs {
+			dist[j] = math.Abs(v - x0)
+		}
+		sorted := append([]float64(nil), dist...)
+		sort.Float64s(sorted)
+		h := sorted[k-1]
+		var sw, swx, swy, swxx, swxy float64
+		for j := range xs {
+			u := 0.0
+			if h > 0 {
+				u = dist[j] / h
+			} else if dist[j] > 0 {
+				continue // the neighbours all share x0
+			}
+			if u >= 1 {
+				continue
+			}
+			w := math.Pow(1-u*u*u, 3)
+			sw += w
+			swx += w * xs[j]
+			swy += w * ys[j]
+			swxx += w * xs[j] * xs[j]
+			swxy += w * xs[j] * ys[j]
+		}
+		mx, my := swx/sw, swy/sw
+		if vx := swxx/sw - mx*mx; vx > 1e-12*(1+mx*mx) {
+			slope := (swxy/sw - mx*my) / vx
+			smooth[i] = my + slope*(x0-mx)
+		} else {
+			smooth[i] = my
+		}
+	}
+	return xs, smooth, nil
+}
+
+// finitePairs returns the points of x and y with finite coordinates.
+func finitePairs(x, y []float64) (px, py []float64, err error) {
+	if len(x) != len(y) {
+		return nil, nil, errors.New("x and y differ in length")
+	}
+	for k := range x {
+		if !math.IsNaN(x[k]) && !math.IsNaN(y[k]) && !math.IsInf(x[k], 0) && !math.IsInf(y[k], 0) {
+			px = append(px, x[k])
+			py = append(py, y[k])
+		}
+	}
+	return px, py, nil
+}
@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitLinear(t *testing.T) {
+	x := []float64{0, 1, 2, 3, math.NaN()}
+	y := []float64{1, 3, 5, 7, 100}
+	f, err := FitLinear(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Slope != 2 || f.Intercept != 1 || f.N != 4 || f.ResidualStd != 0 {
+		t.Errorf("fit %+v, want y = 1 + 2x on 4 points", f)
+	}
+	if lo, hi := f.Band(10, 0.95); lo != 21 || hi != 21 {
+		t.Errorf("band of an exact fit %v..%v, want 21", lo, hi)
+	}
+
+	// Residuals ±1: s = sqrt(4/2), the band is narrowest at the mean x.
+	f, _ = FitLinear([]float64{0, 1, 2, 3}, []float64{1, -1, -1, 1})
+	lo0, hi0 := f.Band(1.5, 0.95)
+	lo1, hi1 := f.Band(3, 0.95)
+	want := TQuantile(0.975, 2) * math.Sqrt2 * math.Sqrt(0.25)
+	if math.Abs((hi0-lo0)/2-want) > 1e-12 || !(hi1-lo1 > hi0-lo0) {
+		t.Errorf("band %v..%v at the mean, %v..%v at the end", lo0, hi0, lo1, hi1)
+	}
+
+	if _, err := FitLinear([]float64{1, 1}, []float64{1, 2}); err == nil {
+		t.Error("no error for equal x values")
+	}
+}
+
+func TestTQuantile(t *testing.T) {
+	// Values from a t table.
+	for _, tt := range []struct{ p, df, want float64 }{
+		{0.975, 1, 12.706},
+		{0.975, 2, 4.303},
+		{0.975, 3, 3.182},
+		{0.975, 10, 2.228},
+		{0.95, 30, 1.697},
+		{0.025, 10, -2.228},
+	} {
+		if got := TQuantile(tt.p, tt.df); math.Abs(got-tt.want) > 0.01*math.Abs(tt.want) {
+			t.Errorf("TQuantile(%v, %v) = %v, want %v", tt.p, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestLowess(t *testing.T) {
+	// A line is reproduced exactly, in x order.
+	xs, smooth, err := Lowess([]float64{3, 1, 2, 0, 4}, []float64{7, 3, 5, 1, 9}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, x := range xs {
+		if x != float64(i) || math.Abs(smooth[i]-(1+2*x)) > 1e-9 {
+			t.Errorf("point %d: (%v, %v), want (%d, %v)", i, x, smooth[i], i, 1+2*float64(i))
+		}
+	}
+	// A single outlier is pulled towards its neighbours.
+	_, smooth, _ = Lowess([]float64{0, 1, 2, 3, 4, 5, 6}, []float64{0, 0, 0, 6, 0, 0, 0}, 0.6)
+	if !(smooth[3] > 0 && smooth[3] < 6) {
+		t.Errorf("outlier smoothed to %v", smooth[3])
+	}
+}
@@ -4,14 +4,57 @@ package style
 // Fields are simple value types to keep configuration immutable-ish by copy.
 type RC struct {
 	DPI        float64
-	FontKey    string
+	FontKey    string // font family registered with package font
 	FontSize   float64
-	LineWidth  float64
+	LineWidth  float64 // default width of Plot lines
+	MarkerSize float64 // default Scatter marker size
 	TextColor  [4]float64
 	LineColor  [4]float64
 	Background [4]float64
 	TickCountX int
 	TickCountY int
+
+	// Grid settings: whether new axes get x and y grid lines, and their
+	// style.
+	Grid          bool
+	GridColor     [4]float64
+	GridLineWidth float64
+
+	// Legend settings: whether the frame is drawn and the opacity of its
+	// fill.
+	LegendFrame bool
+	LegendAlpha float64
+
+	// PixelSnap aligns axis lines, grid lines and bar edges with the pixel
+	// grid so that thin lines render crisp instead of blurred over two
+	// pixels. Artists can override it, see core.SnapMode.
+	PixelSnap bool
+
+	// Text rasterization with outline fonts: TextHinting is "none",
+	// "slight" or "full", "" for the backend's default, and TextSubpixel
+	// places glyphs at fractional horizontal positions instead of whole
+	// pixels, keeping small labels evenly spaced. Backends drawing bitmap
+	// fonts ignore both.
+	TextHinting  string
+	TextSubpixel bool
+
+	// Number typography of tick labels: UnicodeMinus writes negative
+	// numbers with "−" (U+2212) instead of a hyphen, ThousandsSep groups
+	// integer digits by three with thin spaces and TimesTen writes
+	// scientific notation as "1.5×10⁶" instead of "1.5e+6".
+	UnicodeMinus bool
+	ThousandsSep bool
+	TimesTen     bool
+
+	// Seed seeds the random streams of a figure (core.Figure.Rand), which
+	// randomized helpers such as the jitter of strip plots draw from, so a
+	// figure renders the same every time.
+	Seed int64
+
+	// Output settings for saving: 0 SaveDPI uses DPI and a 0 alpha
+	// SaveFacecolor uses Background.
+	SaveDPI       float64
+	SaveFacecolor [4]float64
 }
 
 // Default contains the library defaults. Copy and apply options to customize.
@@ -19,12 +62,19 @@ var Default = RC{
 	DPI:        96,
 	FontKey:    "DejaVuSans",
 	FontSize:   12,
-	LineWidth:  1.25,
+	LineWidth:  2,
+	MarkerSize: 8,
 	TextColor:  [4]float64{0, 0, 0, 1},
 	LineColor:  [4]float64{0, 0, 0, 1},
 	Background: [4]float64{1, 1, 1, 1},
 	TickCountX: 5,
 	TickCountY: 5,
+
+	GridColor:     [4]float64{0.8, 0.8, 0.8, 1},
+	GridLineWidth: 0.5,
+
+	LegendFrame: true,
+	LegendAlpha: 0.8,
 }
 
 // Option mutates an RC. Options should be applied on a copy derived from Default.
@@ -52,6 +102,9 @@ func WithFont(key string, size float64) Option {
 // WithLineWidth sets the default line width.
 func WithLineWidth(w float64) Option { return func(rc *RC) { rc.LineWidth = w } }
 
+// WithMarkerSize sets the default marker size.
+func WithMarkerSize(s float64) Option { return func(rc *RC) { rc.MarkerSize = s } }
+
 // WithTextColor sets the default text color RGBA (0..1).
 func WithTextColor(r, g, b, a float64) Option {
 	return func(rc *RC) { rc.TextColor = [4]float64{r, g, b, a} }
@@ -69,3 +122,44 @@ func WithBackground(r, g, b, a float64) Option {
 
 // WithTickCounts sets the target tick counts for X and Y.
 func WithTickCounts(nx, ny int) Option { return func(rc *RC) { rc.TickCountX, rc.TickCountY = nx, ny } }
+
+// WithGrid turns the grid of new axes on or off.
+func WithGrid(on bool) Option { return func(rc *RC) { rc.Grid = on } }
+
+// WithGridStyle sets the grid line color RGBA (0..1) and width.
+func WithGridStyle(r, g, b, a, width float64) Option {
+	return func(rc *RC) { rc.GridColor, rc.GridLineWidth = [4]float64{r, g, b, a}, width }
+}
+
+// WithLegendFrame sets whether legends draw their frame and the opacity of
+// its fill.
+func WithLegendFrame(on bool, alpha float64) Option {
+	return func(rc *RC) { rc.LegendFrame, rc.LegendAlpha = on, alpha }
+}
+
+// WithPixelSnap sets whether axis lines, grid lines and bar edges are
+// snapped to the pixel grid.
+func WithPixelSnap(on bool) Option { return func(rc *RC) { rc.PixelSnap = on } }
+
+// WithTextRendering sets the hinting ("none", "slight", "full" or "" for
+// the backend's default) and subpixel positioning of outline font text.
+func WithTextRendering(hinting string, subpixel bool) Option {
+	return func(rc *RC) { rc.TextHinting, rc.TextSubpixel = hinting, subpixel }
+}
+
+// WithTypography sets the number typography of tick labels: Unicode minus
+// signs, thin-space thousands separators and ×10ⁿ scientific notation.
+func WithTypography(unicodeMinus, thousandsSep, timesTen bool) Option {
+	return func(rc *RC) { rc.UnicodeMinus, rc.ThousandsSep, rc.TimesTen = unicodeMinus, thousandsSep, timesTen }
+}
+
+// WithSeed sets the seed of a figure's random streams.
+func WithSeed(seed int64) Option { return func(rc *RC) { rc.Seed = seed } }
+
+// WithSaveDPI sets the DPI used when saving.
+func WithSaveDPI(d float64) Option { return func(rc *RC) { rc.SaveDPI = d } }
+
+// WithSaveFacecolor sets the background color RGBA (0..1) used when saving.
+func WithSaveFacecolor(r, g, b, a float64) Option {
+	return func(rc *RC) { rc.SaveFacecolor = [4]float64{r, g, b, a} }
+}
@@ -57,3 +57,24 @@ func TestPrecedence_SimulatedFigureAxes(t *testing.T) {
 		t.Fatalf("expected default line width inherit, got %v", axRC.LineWidth)
 	}
 }
+
+func TestArtistDefaultOptions(t *testing.T) {
+	d := Default
+	if d.LineWidth != 2 || d.MarkerSize != 8 || d.Grid || !d.LegendFrame || d.LegendAlpha != 0.8 {
+		t.Fatalf("unexpected artist defaults: %+v", d)
+	}
+	rc := Apply(Default,
+		WithMarkerSize(4),
+		WithGrid(true),
+		WithGridStyle(0.5, 0.5, 0.5, 1, 1.5),
+		WithLegendFrame(false, 0.5),
+		WithSaveDPI(300),
+		WithSaveFacecolor(0, 0, 0, 0.5),
+	)
+	if rc.MarkerSize != 4 || !rc.Grid || rc.GridColor != [4]float64{0.5, 0.5, 0.5, 1} || rc.GridLineWidth != 1.5 {
+		t.Fatalf("marker/grid options not applied: %+v", rc)
+	}
+	if rc.LegendFrame || rc.LegendAlpha != 0.5 || rc.SaveDPI != 300 || rc.SaveFacecolor != [4]float64{0, 0, 0, 0.5} {
+		t.Fatalf("legend/save options not applied: %+v", rc)
+	}
+}
@@ -201,6 +201,38 @@ func TestSaveDiffImage(t *testing.T) {
 	}
 }
 
+func TestSSIM_Identical(t *testing.T) {
+	img := createGradientImage(64, 64)
+	got, err := SSIM(img, img)
+	if err != nil {
+		t.Fatalf("SSIM failed: %v", err)
+	}
+	if math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected SSIM 1 for identical images, got %f", got)
+	}
+}
+
+func TestSSIM_Ordering(t *testing.T) {
+	base := createGradientImage(64, 64)
+	noisy, err := SSIM(base, createNoisyGradientImage(64, 64, 10))
+	if err != nil {
+		t.Fatalf("SSIM failed: %v", err)
+	}
+	flat, err := SSIM(base, createSolidImage(64, 64, color.RGBA{R: 128, G: 128, B: 128, A: 255}))
+	if err != nil {
+		t.Fatalf("SSIM failed: %v", err)
+	}
+	if !(noisy < 1 && flat < noisy) {
+		t.Errorf("Expected 1 > noisy (%f) > flat (%f)", noisy, flat)
+	}
+}
+
+func TestSSIM_DifferentSizes(t *testing.T) {
+	if _, err := SSIM(createGradientImage(32, 32), createGradientImage(32, 40)); err == nil {
+		t.Error("Expected error for different image sizes")
+	}
+}
+
 // Helper functions for creating test images
 
 func createSolidImage(width, height int, c color.RGBA) *image.RGBA {
@@ -0,0 +1,79 @@
+package imagecmp
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// SSIM window size and stride in pixels, and the stabilizing constants for
+// 8-bit luma (Wang et al., 2004).
+const (
+	ssimWindow = 8
+	ssimStride = 4
+	ssimC1     = (0.01 * 255) * (0.01 * 255)
+	ssimC2     = (0.03 * 255) * (0.03 * 255)
+)
+
+// SSIM returns the mean structural similarity of two images' luma over
+// 8×8 windows, in [-1, 1] with 1 meaning identical.
+//
+// Unlike ComparePNG it is tolerant of antialiasing and small color shifts
+// and sensitive to missing, moved, or reshaped features, which makes it
+// suited to comparing renderings from different libraries.
+func SSIM(a, b image.Image) (float64, error) {
+	ab, bb := a.Bounds(), b.Bounds()
+	if ab.Size() != bb.Size() {
+		return 0, fmt.Errorf("image dimensions differ: %v vs %v", ab.Size(), bb.Size())
+	}
+	w, h := ab.Dx(), ab.Dy()
+	if w < ssimWindow || h < ssimWindow {
+		return 0, fmt.Errorf("image %dx%d is smaller than the %d px SSIM window", w, h, ssimWindow)
+	}
+	la, lb := luma(a), luma(b)
+
+	const n = ssimWindow * ssimWindow
+	var sum float64
+	var count int
+	for y := 0; y+ssimWindow <= h; y += ssimStride {
+		for x := 0; x+ssimWindow <= w; x += ssimStride {
+			var sa, sb, saa, sbb, sab float64
+			for j := y; j < y+ssimWindow; j++ {
+				for i := x; i < x+ssimWindow; i++ {
+					va, vb := la[j*w+i], lb[j*w+i]
+					sa += va
+					sb += vb
+					saa += va * va
+					sbb += vb * vb
+					sab += va * vb
+				}
+			}
+			ma, mb := sa/n, sb/n
+			va := saa/n - ma*ma
+			vb := sbb/n - mb*mb
+			cov := sab/n - ma*mb
+			sum += ((2*ma*mb + ssimC1) * (2*cov + ssimC2)) /
+				((ma*ma + mb*mb + ssimC1) * (va + vb + ssimC2))
+			count++
+		}
+	}
+	return sum / float64(count), nil
+}
+
+// luma returns the Rec. 601 luma of img composited over white, row-major.
+func luma(img image.Image) []float64 {
+	b := img.Bounds()
+	out := make([]float64, 0, b.Dx()*b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			alpha := float64(c.A) / 255
+			bg := 255 * (1 - alpha)
+			r := float64(c.R)*alpha + bg
+			g := float64(c.G)*alpha + bg
+			bl := float64(c.B)*alpha + bg
+			out = append(out, 0.299*r+0.587*g+0.114*bl)
+		}
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"matplotlib-go/test/imagecmp"
+	"matplotlib-go/test/scenes"
+)
+
+var (
+	updateParity  = flag.Bool("update-parity", false, "Record current matplotlib parity scores as the baseline")
+	requireParity = flag.Bool("parity", false, "Fail if matplotlib reference images or baseline scores are missing")
+)
+
+const (
+	parityDir      = "../testdata/matplotlib"
+	parityBaseline = parityDir + "/parity.txt"
+	parityManifest = parityDir + "/scenes.txt"
+
+	// paritySlack is how far a scene's SSIM may fall below its recorded
+	// baseline before the test fails.
+	paritySlack = 0.02
+)
+
+// TestMatplotlibParity compares each scene with matplotlib's rendering of
+// the same figure from the reference corpus in testdata/matplotlib (see
+// generate.py there). The corpus covers the scenes listed in scenes.txt.
+// With -parity, as in CI, every listed scene must have a reference image
+// and a baseline score; without it, missing references are skipped so the
+// test does not need matplotlib installed.
+//
+// Scores are structural similarity (SSIM), which tolerates antialiasing and
+// font differences. They are tracked against parity.txt: a drop of more than
+// paritySlack fails, an improvement is logged so the baseline can be raised
+// with -update-parity.
+func TestMatplotlibParity(t *testing.T) {
+	baseline, err := loadParity(parityBaseline)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("Failed to read parity baseline: %v", err)
+	}
+	manifest, err := loadManifest(parityManifest)
+	if err != nil {
+		t.Fatalf("Failed to read parity manifest: %v", err)
+	}
+	known := map[string]bool{}
+	for _, s := range scenes.All() {
+		known[s.Name] = true
+	}
+	scores := map[string]float64{}
+
+	for _, name := range manifest {
+		if !known[name] {
+			t.Errorf("Manifest lists unknown scene %q", name)
+			continue
+		}
+		refPath := filepath.Join(parityDir, name+".png")
+		if _, err := os.Stat(refPath); err != nil {
+			if *requireParity {
+				t.Errorf("Missing reference %s; run testdata/matplotlib/generate.py", refPath)
+			}
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			want, err := imagecmp.LoadPNG(refPath)
+			if err != nil {
+				t.Fatalf("Failed to load reference %s: %v", refPath, err)
+			}
+			got := renderScene(t, name).GetImage()
+
+			score, err := imagecmp.SSIM(got, want)
+			if err != nil {
+				t.Fatalf("SSIM failed: %v", err)
+			}
+			scores[name] = score
+
+			base, ok := baseline[name]
+			switch {
+			case *updateParity:
+				t.Logf("SSIM=%.4f (recorded)", score)
+			case !ok && *requireParity:
+				t.Errorf("SSIM=%.4f has no baseline; record it with -update-parity", score)
+			case !ok:
+				t.Logf("SSIM=%.4f (no baseline)", score)
+			case score < base-paritySlack:
+				saveParityArtifacts(t, name, got, want)
+				t.Errorf("Parity regressed: SSIM=%.4f, baseline %.4f", score, base)
+			case score > base+paritySlack:
+				t.Logf("SSIM=%.4f improved on baseline %.4f; run with -update-parity", score, base)
+			default:
+				t.Logf("SSIM=%.4f (baseline %.4f)", score, base)
+			}
+		})
+	}
+
+	if len(scores) == 0 && !*requireParity {
+		t.Skip("No matplotlib reference images; run testdata/matplotlib/generate.py")
+	}
+	if *updateParity {
+		if err := saveParity(parityBaseline, scores); err != nil {
+			t.Fatalf("Failed to write parity baseline: %v", err)
+		}
+	}
+}
+
+// loadManifest reads the scene names of the corpus, one per line; blank
+// lines and # comments are ignored.
+func loadManifest(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// loadParity reads "scene score" lines; blank lines and # comments are ignored.
+func loadParity(path string) (map[string]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scores := map[string]float64{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		v, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed score in %q: %v", line, err)
+		}
+		scores[fields[0]] = v
+	}
+	return scores, sc.Err()
+}
+
+func saveParity(path string, scores map[string]float64) error {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("# SSIM of matplotlib-go against matplotlib, written by go test -update-parity\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %.4f\n", name, scores[name])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// saveParityArtifacts writes both renderings side by side for inspection.
+func saveParityArtifacts(t *testing.T, name string, got, want image.Image) {
+	artifactsDir := "../_artifacts"
+	if err := os.MkdirAll(artifactsDir, 0o755); err != nil {
+		t.Logf("Warning: could not create artifacts directory: %v", err)
+		return
+	}
+	if err := imagecmp.SavePNG(got, filepath.Join(artifactsDir, name+"_parity_got.png")); err != nil {
+		t.Logf("Warning: could not save got image: %v", err)
+	}
+	if err := imagecmp.SavePNG(want, filepath.Join(artifactsDir, name+"_parity_mpl.png")); err != nil {
+		t.Logf("Warning: could not save matplotlib image: %v", err)
+	}
+}
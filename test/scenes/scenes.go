@@ -0,0 +1,646 @@
+// Package scenes holds the reference figures behind the golden image tests.
+//
+// The same scenes are rendered by the golden tests in package test and by
+// cmd/gen-goldens, so the two can never drift apart.
+package scenes
+
+import (
+	"fmt"
+	"math"
+
+	"matplotlib-go/core"
+	"matplotlib-go/internal/geom"
+	"matplotlib-go/render"
+	"matplotlib-go/transform"
+)
+
+// Background is the canvas color every scene is rendered on.
+var Background = render.Color{R: 1, G: 1, B: 1, A: 1}
+
+// Scene is a named reference figure.
+type Scene struct {
+	Name  string
+	Build func() *core.Figure // returns a fresh figure on every call
+}
+
+var all = []Scene{
+	{"basic_line", basicLine},
+	{"joins_caps", joinsCaps},
+	{"dashes", dashes},
+	{"scatter_basic", scatterBasic},
+	{"scatter_marker_types", scatterMarkerTypes},
+	{"scatter_advanced", scatterAdvanced},
+	{"bar_basic", barBasic},
+	{"bar_horizontal", barHorizontal},
+	{"bar_grouped", barGrouped},
+	{"fill_basic", fillBasic},
+	{"fill_between", fillBetween},
+	{"fill_stacked", fillStacked},
+	{"multi_series_basic", multiSeriesBasic},
+	{"multi_series_color_cycle", multiSeriesColorCycle},
+	{"strip_plot", stripPlot},
+}
+
+// All returns every registered scene in a stable order.
+func All() []Scene {
+	return append([]Scene(nil), all...)
+}
+
+// Lookup returns the scene with the given name.
+func Lookup(name string) (Scene, bool) {
+	for _, s := range all {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Scene{}, false
+}
+
+// basicLine creates the same basic line plot as examples/lines/basic.go
+func basicLine() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.15},
+		Max: geom.Pt{X: 0.95, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 1)
+
+	// Create a line with some sample data
+	line := &core.Line2D{
+		XY: []geom.Pt{
+			{X: 0, Y: 0},
+			{X: 1, Y: 0.2},
+			{X: 3, Y: 0.9},
+			{X: 6, Y: 0.4},
+			{X: 10, Y: 0.8},
+		},
+		W:   2.0,
+		Col: render.Color{R: 0, G: 0, B: 0, A: 1}, // black line
+	}
+
+	// Add the line to the axes
+	ax.Add(line)
+
+	return fig
+}
+
+// joinsCaps creates a plot demonstrating different line joins and caps
+func joinsCaps() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 6)
+
+	// L-shaped path to demonstrate joins
+	joinPath := []geom.Pt{
+		{X: 1, Y: 5}, {X: 3, Y: 5}, {X: 3, Y: 3}, {X: 5, Y: 3},
+	}
+
+	// Miter join line (thick red)
+	miterLine := &core.Line2D{
+		XY:  joinPath,
+		W:   8.0,
+		Col: render.Color{R: 0.8, G: 0.2, B: 0.2, A: 1},
+	}
+	ax.Add(miterLine)
+
+	// Straight line for caps demo
+	capPath := []geom.Pt{
+		{X: 7, Y: 5}, {X: 9, Y: 5},
+	}
+
+	// Thick blue line with round caps
+	capLine := &core.Line2D{
+		XY:  capPath,
+		W:   8.0,
+		Col: render.Color{R: 0.2, G: 0.2, B: 0.8, A: 1},
+	}
+	ax.Add(capLine)
+
+	return fig
+}
+
+// dashes creates a plot demonstrating dash patterns
+func dashes() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 5)
+
+	// Multiple horizontal lines with different dash patterns
+	lines := []struct {
+		y      float64
+		dashes []float64
+		color  render.Color
+	}{
+		{4, []float64{}, render.Color{R: 0, G: 0, B: 0, A: 1}},             // solid
+		{3, []float64{5, 2}, render.Color{R: 0.8, G: 0, B: 0, A: 1}},       // basic dash
+		{2, []float64{3, 1, 1, 1}, render.Color{R: 0, G: 0.6, B: 0, A: 1}}, // dash-dot
+		{1, []float64{1, 1}, render.Color{R: 0, G: 0, B: 0.8, A: 1}},       // dotted
+	}
+
+	for _, lineSpec := range lines {
+		// Create line data
+		path := []geom.Pt{
+			{X: 1, Y: lineSpec.y}, {X: 9, Y: lineSpec.y},
+		}
+
+		line := &core.Line2D{
+			XY:     path,
+			W:      3.0,
+			Col:    lineSpec.color,
+			Dashes: lineSpec.dashes,
+		}
+		ax.Add(line)
+	}
+
+	return fig
+}
+
+// scatterBasic creates a basic scatter plot for golden testing
+func scatterBasic() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 10)
+
+	// Basic scatter with circles
+	basicPoints := []geom.Pt{
+		{X: 2, Y: 3}, {X: 4, Y: 6}, {X: 6, Y: 4},
+		{X: 8, Y: 7}, {X: 3, Y: 8}, {X: 7, Y: 2},
+	}
+
+	scatter := &core.Scatter2D{
+		XY:     basicPoints,
+		Size:   8.0,
+		Color:  render.Color{R: 0.8, G: 0.2, B: 0.2, A: 1}, // red
+		Marker: core.MarkerCircle,
+		Alpha:  1.0,
+	}
+	ax.Add(scatter)
+
+	return fig
+}
+
+// scatterMarkerTypes creates a plot showing all marker types
+func scatterMarkerTypes() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 8)
+	ax.YScale = transform.NewLinear(0, 8)
+
+	// All marker types with different colors
+	markerTypes := []core.MarkerType{
+		core.MarkerCircle, core.MarkerSquare, core.MarkerTriangle,
+		core.MarkerDiamond, core.MarkerPlus, core.MarkerCross,
+	}
+
+	colors := []render.Color{
+		{R: 1, G: 0, B: 0, A: 1}, // red
+		{R: 0, G: 1, B: 0, A: 1}, // green
+		{R: 0, G: 0, B: 1, A: 1}, // blue
+		{R: 1, G: 1, B: 0, A: 1}, // yellow
+		{R: 1, G: 0, B: 1, A: 1}, // magenta
+		{R: 0, G: 1, B: 1, A: 1}, // cyan
+	}
+
+	for i, markerType := range markerTypes {
+		x := float64(1 + i)
+		y := float64(4)
+
+		scatter := &core.Scatter2D{
+			XY:     []geom.Pt{{X: x, Y: y}},
+			Size:   12.0,
+			Color:  colors[i],
+			Marker: markerType,
+			Alpha:  1.0,
+		}
+		ax.Add(scatter)
+	}
+
+	return fig
+}
+
+// scatterAdvanced creates an advanced scatter plot with edges, alpha, and variable sizes
+func scatterAdvanced() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 10)
+
+	// Variable sizes and colors with edge support
+	points := []geom.Pt{
+		{X: 2, Y: 2}, {X: 4, Y: 4}, {X: 6, Y: 6}, {X: 8, Y: 8},
+		{X: 2, Y: 8}, {X: 4, Y: 6}, {X: 6, Y: 4}, {X: 8, Y: 2},
+	}
+
+	sizes := []float64{6, 10, 14, 18, 8, 12, 16, 20}
+
+	fillColors := []render.Color{
+		{R: 1, G: 0.5, B: 0.5, A: 1}, {R: 0.5, G: 1, B: 0.5, A: 1},
+		{R: 0.5, G: 0.5, B: 1, A: 1}, {R: 1, G: 1, B: 0.5, A: 1},
+		{R: 1, G: 0.5, B: 1, A: 1}, {R: 0.5, G: 1, B: 1, A: 1},
+		{R: 0.8, G: 0.8, B: 0.8, A: 1}, {R: 0.3, G: 0.3, B: 0.3, A: 1},
+	}
+
+	edgeColors := []render.Color{
+		{R: 0.5, G: 0, B: 0, A: 1}, {R: 0, G: 0.5, B: 0, A: 1},
+		{R: 0, G: 0, B: 0.5, A: 1}, {R: 0.5, G: 0.5, B: 0, A: 1},
+		{R: 0.5, G: 0, B: 0.5, A: 1}, {R: 0, G: 0.5, B: 0.5, A: 1},
+		{R: 0.4, G: 0.4, B: 0.4, A: 1}, {R: 0, G: 0, B: 0, A: 1},
+	}
+
+	scatter := &core.Scatter2D{
+		XY:         points,
+		Sizes:      sizes,
+		Colors:     fillColors,
+		EdgeColors: edgeColors,
+		EdgeWidth:  2.0,
+		Alpha:      0.8,
+		Marker:     core.MarkerCircle,
+	}
+	ax.Add(scatter)
+
+	return fig
+}
+
+// barBasic creates a basic vertical bar chart for golden testing
+func barBasic() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 6)
+	ax.YScale = transform.NewLinear(0, 10)
+
+	// Basic vertical bar chart
+	bar := &core.Bar2D{
+		X:           []float64{1, 2, 3, 4, 5},
+		Heights:     []float64{3, 7, 2, 8, 5},
+		Width:       0.6,
+		Color:       render.Color{R: 0.2, G: 0.6, B: 0.8, A: 1}, // blue
+		Baseline:    0,
+		Orientation: core.BarVertical,
+	}
+	ax.Add(bar)
+
+	return fig
+}
+
+// barHorizontal creates a horizontal bar chart for golden testing
+func barHorizontal() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(0, 6)
+
+	// Horizontal bar chart
+	bar := &core.Bar2D{
+		X:           []float64{1, 2, 3, 4, 5},
+		Heights:     []float64{3, 7, 2, 8, 5},
+		Width:       0.6,
+		Color:       render.Color{R: 0.8, G: 0.4, B: 0.2, A: 1}, // orange
+		Baseline:    0,
+		Orientation: core.BarHorizontal,
+	}
+	ax.Add(bar)
+
+	return fig
+}
+
+// barGrouped creates a grouped bar chart with variable colors and edges
+func barGrouped() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 7)
+	ax.YScale = transform.NewLinear(0, 10)
+
+	// First series - shifted left
+	bar1 := &core.Bar2D{
+		X:           []float64{1.2, 2.2, 3.2, 4.2, 5.2},
+		Heights:     []float64{3, 7, 2, 8, 5},
+		Width:       0.35,
+		Color:       render.Color{R: 0.8, G: 0.2, B: 0.2, A: 1}, // red
+		EdgeColor:   render.Color{R: 0.5, G: 0, B: 0, A: 1},     // dark red edge
+		EdgeWidth:   1.0,
+		Baseline:    0,
+		Orientation: core.BarVertical,
+	}
+	ax.Add(bar1)
+
+	// Second series - shifted right
+	bar2 := &core.Bar2D{
+		X:           []float64{1.8, 2.8, 3.8, 4.8, 5.8},
+		Heights:     []float64{5, 4, 6, 3, 7},
+		Width:       0.35,
+		Color:       render.Color{R: 0.2, G: 0.8, B: 0.2, A: 1}, // green
+		EdgeColor:   render.Color{R: 0, G: 0.5, B: 0, A: 1},     // dark green edge
+		EdgeWidth:   1.0,
+		Baseline:    0,
+		Orientation: core.BarVertical,
+	}
+	ax.Add(bar2)
+
+	return fig
+}
+
+// fillBasic creates a basic fill to baseline for golden testing
+func fillBasic() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 10)
+	ax.YScale = transform.NewLinear(-1, 3)
+
+	// Create simple curve data
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	y := []float64{0.5, 1.8, 2.3, 1.2, 2.8, 1.9, 2.1, 1.5, 0.8}
+
+	// Fill to baseline with edge
+	fill := &core.Fill2D{
+		X:         x,
+		Y1:        y,
+		Baseline:  0,
+		Color:     render.Color{R: 0.3, G: 0.7, B: 0.9, A: 0.7}, // semi-transparent blue
+		EdgeColor: render.Color{R: 0.1, G: 0.3, B: 0.5, A: 1.0}, // darker blue edge
+		EdgeWidth: 2.0,
+		Alpha:     1.0,
+	}
+	ax.Add(fill)
+
+	return fig
+}
+
+// fillBetween creates a fill between two curves for golden testing
+func fillBetween() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 6.28)
+	ax.YScale = transform.NewLinear(-1.5, 1.5)
+
+	// Generate sine and cosine curves
+	n := 50
+	x := make([]float64, n)
+	y1 := make([]float64, n) // sine
+	y2 := make([]float64, n) // cosine * 0.8
+
+	for i := 0; i < n; i++ {
+		t := 6.28 * float64(i) / float64(n-1)
+		x[i] = t
+		y1[i] = math.Sin(t)
+		y2[i] = 0.8 * math.Cos(t)
+	}
+
+	// Fill between curves
+	fill := core.FillBetween(x, y1, y2, render.Color{R: 0.8, G: 0.3, B: 0.3, A: 0.6})
+	fill.EdgeColor = render.Color{R: 0.5, G: 0.1, B: 0.1, A: 1.0}
+	fill.EdgeWidth = 1.5
+
+	ax.Add(fill)
+
+	// Add the curves themselves as lines
+	sineLine := &core.Line2D{
+		XY:  make([]geom.Pt, n),
+		W:   2.0,
+		Col: render.Color{R: 1, G: 0, B: 0, A: 1}, // red
+	}
+	cosLine := &core.Line2D{
+		XY:  make([]geom.Pt, n),
+		W:   2.0,
+		Col: render.Color{R: 0, G: 0, B: 1, A: 1}, // blue
+	}
+
+	for i := 0; i < n; i++ {
+		sineLine.XY[i] = geom.Pt{X: x[i], Y: y1[i]}
+		cosLine.XY[i] = geom.Pt{X: x[i], Y: y2[i]}
+	}
+
+	ax.Add(sineLine)
+	ax.Add(cosLine)
+
+	return fig
+}
+
+// fillStacked creates a stacked area chart for golden testing
+func fillStacked() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 8)
+	ax.YScale = transform.NewLinear(0, 8)
+
+	// Create stacked data
+	x := []float64{1, 2, 3, 4, 5, 6, 7}
+	layer1 := []float64{1, 1.5, 2, 1.8, 2.2, 1.9, 1.6}
+	layer2 := make([]float64, len(layer1))
+	layer3 := make([]float64, len(layer1))
+
+	// Stack the layers
+	for i := range layer1 {
+		layer2[i] = layer1[i] + 1.5 + 0.3*math.Sin(float64(i))
+		layer3[i] = layer2[i] + 1.2 + 0.4*math.Cos(float64(i))
+	}
+
+	// Bottom layer (to baseline)
+	fill1 := core.FillToBaseline(x, layer1, 0, render.Color{R: 0.8, G: 0.2, B: 0.2, A: 0.8})
+	fill1.EdgeColor = render.Color{R: 0.5, G: 0, B: 0, A: 1}
+	fill1.EdgeWidth = 1.0
+
+	// Middle layer (between layer1 and layer2)
+	fill2 := core.FillBetween(x, layer1, layer2, render.Color{R: 0.2, G: 0.8, B: 0.2, A: 0.8})
+	fill2.EdgeColor = render.Color{R: 0, G: 0.5, B: 0, A: 1}
+	fill2.EdgeWidth = 1.0
+
+	// Top layer (between layer2 and layer3)
+	fill3 := core.FillBetween(x, layer2, layer3, render.Color{R: 0.2, G: 0.2, B: 0.8, A: 0.8})
+	fill3.EdgeColor = render.Color{R: 0, G: 0, B: 0.5, A: 1}
+	fill3.EdgeWidth = 1.0
+
+	ax.Add(fill1)
+	ax.Add(fill2)
+	ax.Add(fill3)
+
+	return fig
+}
+
+// multiSeriesBasic creates a plot with multiple series using different plot types
+func multiSeriesBasic() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 8)
+	ax.YScale = transform.NewLinear(0, 6)
+
+	// Generate sample data
+	x1 := []float64{1, 2, 3, 4, 5, 6}
+	y1 := []float64{1.5, 2.8, 2.2, 3.5, 3.8, 4.2}
+
+	x2 := []float64{1.5, 2.5, 3.5, 4.5, 5.5}
+	y2 := []float64{2.2, 3.1, 2.9, 4.1, 4.5}
+
+	x3 := []float64{2, 3, 4, 5}
+	y3 := []float64{3.8, 2.5, 4.8, 3.2}
+
+	// Use convenience methods with automatic color cycling
+	ax.Plot(x1, y1, core.PlotOptions{Label: "Series 1"})
+	ax.Scatter(x2, y2, core.ScatterOptions{Label: "Series 2"})
+
+	width := 0.4
+	ax.Bar(x3, y3, core.BarOptions{Label: "Series 3", Width: &width})
+
+	return fig
+}
+
+// multiSeriesColorCycle creates a plot demonstrating automatic color cycling
+func multiSeriesColorCycle() *core.Figure {
+	// Create a figure with dimensions 640x360
+	fig := core.NewFigure(640, 360)
+
+	// Add axes that take up most of the figure space
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	// Set up coordinate scales
+	ax.XScale = transform.NewLinear(0, 2*math.Pi)
+	ax.YScale = transform.NewLinear(-1.2, 1.2)
+
+	// Generate sine waves with different frequencies
+	nPoints := 50
+	x := make([]float64, nPoints)
+	for i := 0; i < nPoints; i++ {
+		x[i] = 2 * math.Pi * float64(i) / float64(nPoints-1)
+	}
+
+	// Create 4 different sine waves with automatic color cycling
+	for freq := 1; freq <= 4; freq++ {
+		y := make([]float64, nPoints)
+		for i := 0; i < nPoints; i++ {
+			y[i] = math.Sin(float64(freq) * x[i])
+		}
+
+		label := fmt.Sprintf("f=%d", freq)
+		ax.Plot(x, y, core.PlotOptions{Label: label})
+	}
+
+	return fig
+}
+
+// stripPlot creates a strip plot jittered from the figure's default seed,
+// which must render the same on every run
+func stripPlot() *core.Figure {
+	fig := core.NewFigure(640, 360)
+	ax := fig.AddAxes(geom.Rect{
+		Min: geom.Pt{X: 0.1, Y: 0.1},
+		Max: geom.Pt{X: 0.9, Y: 0.9},
+	})
+
+	groups := make([][]float64, 3)
+	for i := range groups {
+		for j := 0; j < 20; j++ {
+			t := float64(j) / 19
+			groups[i] = append(groups[i], float64(i+1)+2*t*t)
+		}
+	}
+	ax.StripPlot(groups, core.CategoryOptions{Labels: []string{"a", "b", "c"}})
+
+	return fig
+}
@@ -0,0 +1,137 @@
+#!/usr/bin/env python3
+"""Render the matplotlib reference corpus for the parity harness.
+
+Each function below mirrors the scene of the same name in test/scenes with
+matplotlib itself. Sizes are converted from matplotlib-go's pixel units at
+96 DPI (1 px = 0.75 pt), and axes rectangles from its top-down figure
+fractions to matplotlib's bottom-up ones.
+
+Usage (from the repository root):
+
+    python3 testdata/matplotlib/generate.py            # scenes in scenes.txt
+    python3 testdata/matplotlib/generate.py basic_line # selected scenes
+
+Then record the baseline scores with:
+
+    go test ./test/ -run Parity -update-parity
+"""
+
+import math
+import os
+import sys
+
+import matplotlib
+
+matplotlib.use("Agg")
+import matplotlib.pyplot as plt  # noqa: E402
+
+DPI = 96
+W, H = 640, 360
+OUT = os.path.dirname(os.path.abspath(__file__))
+
+
+def px(v):
+    """Convert matplotlib-go pixels to points."""
+    return v * 72 / DPI
+
+
+def figure(x0, y0, x1, y1):
+    """Create a 640x360 figure with axes at a top-down fraction rect."""
+    fig = plt.figure(figsize=(W / DPI, H / DPI), dpi=DPI, facecolor="white")
+    ax = fig.add_axes([x0, 1 - y1, x1 - x0, y1 - y0])
+    return fig, ax
+
+
+def basic_line():
+    fig, ax = figure(0.1, 0.15, 0.95, 0.9)
+    ax.set_xlim(0, 10)
+    ax.set_ylim(0, 1)
+    ax.plot([0, 1, 3, 6, 10], [0, 0.2, 0.9, 0.4, 0.8], color="black", lw=px(2))
+    return fig
+
+
+def scatter_basic():
+    fig, ax = figure(0.1, 0.1, 0.9, 0.9)
+    ax.set_xlim(0, 10)
+    ax.set_ylim(0, 10)
+    xs = [2, 4, 6, 8, 3, 7]
+    ys = [3, 6, 4, 7, 8, 2]
+    # matplotlib-go sizes are radii in pixels; matplotlib's are areas in pt^2.
+    ax.scatter(xs, ys, s=(2 * px(8)) ** 2, color=(0.8, 0.2, 0.2), linewidths=0)
+    return fig
+
+
+def bar_basic():
+    fig, ax = figure(0.1, 0.1, 0.9, 0.9)
+    ax.set_xlim(0, 6)
+    ax.set_ylim(0, 10)
+    ax.bar([1, 2, 3, 4, 5], [3, 7, 2, 8, 5], width=0.6, color=(0.2, 0.6, 0.8))
+    return fig
+
+
+def bar_horizontal():
+    fig, ax = figure(0.1, 0.1, 0.9, 0.9)
+    ax.set_xlim(0, 10)
+    ax.set_ylim(0, 6)
+    ax.barh([1, 2, 3, 4, 5], [3, 7, 2, 8, 5], height=0.6, color=(0.8, 0.4, 0.2))
+    return fig
+
+
+def fill_basic():
+    fig, ax = figure(0.1, 0.1, 0.9, 0.9)
+    ax.set_xlim(0, 10)
+    ax.set_ylim(-1, 3)
+    x = [1, 2, 3, 4, 5, 6, 7, 8, 9]
+    y = [0.5, 1.8, 2.3, 1.2, 2.8, 1.9, 2.1, 1.5, 0.8]
+    ax.fill_between(x, y, 0, facecolor=(0.3, 0.7, 0.9, 0.7),
+                    edgecolor=(0.1, 0.3, 0.5), linewidth=px(2))
+    return fig
+
+
+def fill_between():
+    fig, ax = figure(0.1, 0.1, 0.9, 0.9)
+    ax.set_xlim(0, 6.28)
+    ax.set_ylim(-1.5, 1.5)
+    n = 50
+    x = [6.28 * i / (n - 1) for i in range(n)]
+    y1 = [math.sin(t) for t in x]
+    y2 = [0.8 * math.cos(t) for t in x]
+    ax.fill_between(x, y1, y2, facecolor=(0.8, 0.3, 0.3, 0.6),
+                    edgecolor=(0.5, 0.1, 0.1), linewidth=px(1.5))
+    ax.plot(x, y1, color="red", lw=px(2))
+    ax.plot(x, y2, color="blue", lw=px(2))
+    return fig
+
+
+SCENES = {
+    "basic_line": basic_line,
+    "scatter_basic": scatter_basic,
+    "bar_basic": bar_basic,
+    "bar_horizontal": bar_horizontal,
+    "fill_basic": fill_basic,
+    "fill_between": fill_between,
+}
+
+
+def manifest():
+    """Return the scene names listed in scenes.txt."""
+    with open(os.path.join(OUT, "scenes.txt")) as f:
+        lines = (line.strip() for line in f)
+        return [line for line in lines if line and not line.startswith("#")]
+
+
+def main(names):
+    names = names or manifest()
+    missing = [name for name in names if name not in SCENES]
+    if missing:
+        sys.exit("no matplotlib version of scenes: " + ", ".join(missing))
+    for name in names:
+        fig = SCENES[name]()
+        path = os.path.join(OUT, name + ".png")
+        fig.savefig(path, dpi=DPI, facecolor="white")
+        plt.close(fig)
+        print(path)
+
+
+if __name__ == "__main__":
+    main(sys.argv[1:])
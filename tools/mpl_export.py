@@ -0,0 +1,157 @@
+#!/usr/bin/env python3
+"""Export a matplotlib figure for matplotlib-go's core.ImportMatplotlib.
+
+Usage (put tools/ on PYTHONPATH):
+
+    from mpl_export import export_figure
+    export_figure(fig, "figure.json")
+
+Only the subset described in docs/matplotlib-import.md is written: lines,
+scatters, bar containers and fill_between areas with their colors and
+labels, and each axes' position, limits, scales, labels, grid and legend.
+Other artists are skipped with a warning.
+"""
+
+import json
+import math
+import warnings
+
+import numpy as np
+from matplotlib.collections import PathCollection, PolyCollection
+from matplotlib.colors import to_rgba
+from matplotlib.container import BarContainer
+
+
+def _rgba(c):
+    return [float(v) for v in to_rgba(c)]
+
+
+def _floats(a):
+    return [_value(float(v)) for v in np.asarray(a, dtype=float).ravel()]
+
+
+def _value(v):
+    """Spell NaN and infinities, which JSON lacks, as core.Values reads them."""
+    if math.isnan(v):
+        return None
+    if math.isinf(v):
+        return "Infinity" if v > 0 else "-Infinity"
+    return v
+
+
+def _line(line):
+    x, y = line.get_data()
+    return {
+        "x": _floats(x),
+        "y": _floats(y),
+        "color": _rgba(line.get_color()),
+        "linewidth": float(line.get_linewidth()),
+        "linestyle": line.get_linestyle(),
+        "marker": str(line.get_marker()),
+        "markersize": float(line.get_markersize()),
+        "label": line.get_label(),
+    }
+
+
+def _scatter(coll):
+    colors = coll.get_facecolors()
+    return {
+        "offsets": [[float(x), float(y)] for x, y in coll.get_offsets()],
+        "color": [float(v) for v in colors[0]] if len(colors) else None,
+        "sizes": _floats(coll.get_sizes()),
+        "marker": "o",
+        "label": coll.get_label(),
+    }
+
+
+def _bars(container):
+    patches = container.patches
+    horizontal = getattr(container, "orientation", "vertical") == "horizontal"
+    out = {"x": [], "height": [], "width": [], "bottom": [], "label": container.get_label()}
+    for p in patches:
+        if horizontal:
+            out["x"].append(float(p.get_y() + p.get_height() / 2))
+            out["height"].append(float(p.get_width()))
+            out["width"].append(float(p.get_height()))
+            out["bottom"].append(float(p.get_x()))
+        else:
+            out["x"].append(float(p.get_x() + p.get_width() / 2))
+            out["height"].append(float(p.get_height()))
+            out["width"].append(float(p.get_width()))
+            out["bottom"].append(float(p.get_y()))
+    out["orientation"] = "horizontal" if horizontal else "vertical"
+    out["color"] = _rgba(patches[0].get_facecolor()) if patches else None
+    return out
+
+
+def _fill(coll):
+    # fill_between outlines the area as: start on y2, forward along y1,
+    # back along y2. Split the outline into its two edges.
+    verts = coll.get_paths()[0].vertices
+    n = (len(verts) - 1) // 2
+    top = verts[1 : n + 1]
+    bottom = verts[n + 1 : 2 * n + 1][::-1]
+    colors = coll.get_facecolors()
+    return {
+        "x": _floats(top[:, 0]),
+        "y1": _floats(top[:, 1]),
+        "y2": _floats(bottom[:, 1]),
+        "color": [float(v) for v in colors[0]] if len(colors) else None,
+        "label": coll.get_label(),
+    }
+
+
+def _scale_params(axis):
+    # Log and symlog transforms carry their base and linear threshold.
+    t = axis.get_transform()
+    return {k: float(getattr(t, k)) for k in ("base", "linthresh") if hasattr(t, k)}
+
+
+def _axes(ax):
+    pos = ax.get_position()
+    bar_patches = set()
+    bars = []
+    for c in ax.containers:
+        if isinstance(c, BarContainer):
+            bars.append(_bars(c))
+            bar_patches.update(c.patches)
+    scatters, fills = [], []
+    for coll in ax.collections:
+        if isinstance(coll, PathCollection):
+            scatters.append(_scatter(coll))
+        elif isinstance(coll, PolyCollection):
+            fills.append(_fill(coll))
+        else:
+            warnings.warn(f"mpl_export: skipping {type(coll).__name__}")
+    for p in ax.patches:
+        if p not in bar_patches:
+            warnings.warn(f"mpl_export: skipping {type(p).__name__}")
+    return {
+        "position": [pos.x0, pos.y0, pos.width, pos.height],
+        "title": ax.get_title(),
+        "xlabel": ax.get_xlabel(),
+        "ylabel": ax.get_ylabel(),
+        "xlim": [float(v) for v in ax.get_xlim()],
+        "ylim": [float(v) for v in ax.get_ylim()],
+        "xscale": ax.get_xscale(),
+        "yscale": ax.get_yscale(),
+        "xscaleparams": _scale_params(ax.xaxis),
+        "yscaleparams": _scale_params(ax.yaxis),
+        "grid": any(l.get_visible() for l in ax.get_xgridlines() + ax.get_ygridlines()),
+        "legend": ax.get_legend() is not None,
+        "lines": [_line(l) for l in ax.get_lines()],
+        "scatters": scatters,
+        "bars": bars,
+        "fills": fills,
+    }
+
+
+def export_figure(fig, path):
+    """Write fig to path as JSON for core.ImportMatplotlib."""
+    data = {
+        "figsize": [float(v) for v in fig.get_size_inches()],
+        "dpi": float(fig.dpi),
+        "axes": [_axes(ax) for ax in fig.axes],
+    }
+    with open(path, "w") as f:
+        json.dump(data, f, indent=1, allow_nan=False)
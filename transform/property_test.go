@@ -0,0 +1,316 @@
+package transform
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"matplotlib-go/internal/geom"
+)
+
+// Property tests: for every scale family, over randomized (including
+// reversed, tiny, huge, and degenerate) domains,
+//
+//   - Inv(Fwd(x)) ≈ x for x in the domain,
+//   - Fwd(Inv(u)) ≈ u for u in [0,1],
+//   - Inv never reports success with a non-finite value,
+//   - degenerate domains are reported by Inv instead of producing garbage.
+//
+// New scales join by adding a generator to scaleGens.
+
+const propertyTrials = 500
+
+// scaleCase is a randomly generated scale with a sampler for points inside
+// its domain.
+type scaleCase struct {
+	s      Scale
+	sample func(r *rand.Rand) float64
+}
+
+type scaleGen struct {
+	name string
+	gen  func(r *rand.Rand) scaleCase
+}
+
+var scaleGens = []scaleGen{
+	{"linear", genLinear},
+	{"log", genLog},
+	{"time", genTime},
+	{"symlog", genSymLog},
+	{"power", genPower},
+}
+
+// randDomain returns a non-degenerate interval drawn from a mix of shapes
+// that commonly break scales.
+func randDomain(r *rand.Rand) (min, max float64) {
+	switch r.Intn(5) {
+	case 0: // ordinary
+		min = r.Float64()*200 - 100
+		max = min + r.Float64()*100 + 1e-3
+	case 1: // huge magnitudes
+		min = (r.Float64()*2 - 1) * 1e300
+		max = min + r.Float64()*1e300 + 1e290
+	case 2: // tiny span far from zero
+		min = (r.Float64()*2 - 1) * 1e6
+		max = min + 1e-6*(1+r.Float64())
+	case 3: // tiny values around zero
+		min = (r.Float64()*2 - 1) * 1e-300
+		max = min + 1e-300*(1+r.Float64())
+	default: // crosses zero symmetrically
+		max = r.Float64()*1e3 + 1e-3
+		min = -max
+	}
+	if r.Intn(2) == 0 { // inverted axis
+		min, max = max, min
+	}
+	return min, max
+}
+
+func genLinear(r *rand.Rand) scaleCase {
+	min, max := randDomain(r)
+	return scaleCase{
+		s:      NewLinear(min, max),
+		sample: func(r *rand.Rand) float64 { return min + r.Float64()*(max-min) },
+	}
+}
+
+func genLog(r *rand.Rand) scaleCase {
+	bases := []float64{2, math.E, 10, 1 + r.Float64()*99}
+	base := bases[r.Intn(len(bases))]
+	lo := r.Float64()*600 - 300 // log10 of the lower bound
+	hi := lo + math.Max(r.Float64()*(300-lo), 1e-6)
+	min, max := math.Pow(10, lo), math.Pow(10, hi)
+	if r.Intn(2) == 0 {
+		min, max = max, min
+	}
+	return scaleCase{
+		s: NewLog(min, max, base),
+		sample: func(r *rand.Rand) float64 {
+			return math.Pow(10, lo+r.Float64()*(hi-lo))
+		},
+	}
+}
+
+func genTime(r *rand.Rand) scaleCase {
+	min := (r.Float64()*2 - 1) * 4e9            // Unix seconds, about 1843 to 2096
+	max := min + math.Pow(10, r.Float64()*12-3) // a millisecond to decades
+	if r.Intn(2) == 0 {
+		min, max = max, min
+	}
+	return scaleCase{
+		s:      Time{Min: min, Max: max},
+		sample: func(r *rand.Rand) float64 { return min + r.Float64()*(max-min) },
+	}
+}
+
+func genSymLog(r *rand.Rand) scaleCase {
+	min, max := randDomain(r)
+	// A threshold below the largest limit, so the domain reaches into the
+	// logarithmic range.
+	linThresh := math.Max(math.Abs(min), math.Abs(max)) * math.Pow(10, -6*r.Float64())
+	bases := []float64{2, math.E, 10}
+	return scaleCase{
+		s:      NewSymLog(min, max, linThresh, bases[r.Intn(len(bases))]),
+		sample: func(r *rand.Rand) float64 { return min + r.Float64()*(max-min) },
+	}
+}
+
+func genPower(r *rand.Rand) scaleCase {
+	exps := []float64{0.5, 2, 3, 1.0 / 3, 0.2 + r.Float64()*3}
+	exp := exps[r.Intn(len(exps))]
+	var s Power
+	for !s.valid() { // skip domains whose powers overflow or collapse
+		min, max := randDomain(r)
+		s = NewPower(min, max, exp)
+	}
+	return scaleCase{
+		s:      s,
+		sample: func(r *rand.Rand) float64 { return s.Min + r.Float64()*(s.Max-s.Min) },
+	}
+}
+
+// closeRel reports whether a and b agree to about 1e-9 relative to scale.
+func closeRel(a, b, scale float64) bool {
+	return math.Abs(a-b) <= 1e-9*scale
+}
+
+func forAllScales(t *testing.T, prop func(c scaleCase, r *rand.Rand) error) {
+	for i, g := range scaleGens {
+		t.Run(g.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(100 + i)))
+			for n := 0; n < propertyTrials; n++ {
+				c := g.gen(r)
+				if err := prop(c, r); err != nil {
+					t.Fatalf("trial %d, %#v: %v", n, c.s, err)
+				}
+			}
+		})
+	}
+}
+
+func TestScaleProperty_InvFwd(t *testing.T) {
+	forAllScales(t, func(c scaleCase, r *rand.Rand) error {
+		min, max := c.s.Domain()
+		for j := 0; j < 10; j++ {
+			x := c.sample(r)
+			u := c.s.Fwd(x)
+			if math.IsNaN(u) || math.IsInf(u, 0) {
+				return fmt.Errorf("Fwd(%v) = %v inside the domain", x, u)
+			}
+			xr, ok := c.s.Inv(u)
+			if !ok {
+				return fmt.Errorf("Inv(%v) failed for x=%v", u, x)
+			}
+			scale := math.Max(math.Abs(x), math.Max(math.Abs(min), math.Abs(max)))
+			if _, isLog := c.s.(Log); isLog {
+				scale = math.Abs(x) // log scales are accurate relative to x itself
+			}
+			if !closeRel(x, xr, scale) {
+				return fmt.Errorf("Inv(Fwd(%v)) = %v", x, xr)
+			}
+		}
+		return nil
+	})
+}
+
+func TestScaleProperty_FwdInv(t *testing.T) {
+	forAllScales(t, func(c scaleCase, r *rand.Rand) error {
+		for j := 0; j < 10; j++ {
+			u := r.Float64()
+			x, ok := c.s.Inv(u)
+			if !ok {
+				return fmt.Errorf("Inv(%v) failed", u)
+			}
+			if ur := c.s.Fwd(x); !closeRel(u, ur, 1e3+1e-3*unitCond(c.s)) {
+				return fmt.Errorf("Fwd(Inv(%v)) = %v", u, ur)
+			}
+		}
+		return nil
+	})
+}
+
+func TestScaleProperty_Endpoints(t *testing.T) {
+	forAllScales(t, func(c scaleCase, _ *rand.Rand) error {
+		min, max := c.s.Domain()
+		if u := c.s.Fwd(min); !closeRel(u, 0, 1e3) {
+			return fmt.Errorf("Fwd(min) = %v, want 0", u)
+		}
+		if u := c.s.Fwd(max); !closeRel(u, 1, 1e3) {
+			return fmt.Errorf("Fwd(max) = %v, want 1", u)
+		}
+		return nil
+	})
+}
+
+// TestScaleProperty_InvFinite checks that Inv never claims success for a
+// value it cannot represent, even far outside [0,1].
+func TestScaleProperty_InvFinite(t *testing.T) {
+	forAllScales(t, func(c scaleCase, r *rand.Rand) error {
+		for _, u := range []float64{-1e6, -1, 2, 1e6, (r.Float64()*2 - 1) * 1e300} {
+			if x, ok := c.s.Inv(u); ok && (math.IsNaN(x) || math.IsInf(x, 0)) {
+				return fmt.Errorf("Inv(%v) = %v, true", u, x)
+			}
+		}
+		return nil
+	})
+}
+
+func TestScaleProperty_Degenerate(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	cases := []Scale{
+		NewLinear(3, 3),
+		NewLinear(0, inf),
+		NewLinear(-inf, inf),
+		NewLinear(nan, 1),
+		NewLinear(-math.MaxFloat64, math.MaxFloat64), // span overflows
+		Time{Min: 3, Max: 3},
+		NewSymLog(1, 1, 1, 10),
+		NewSymLog(-1, 1, 0, 10),
+		NewSymLog(-1, 1, 1, 1),
+		NewSymLog(1e300, math.Nextafter(1e300, inf), 1, 10), // limits transform together
+		NewPower(2, 2, 0.5),
+		NewPower(0, 1, 0),
+		NewPower(1e200, 2e200, 2),  // powers overflow
+		NewPower(1, 1+1e-15, 1e-3), // powers round together
+		NewLog(1, 1, 10),
+		NewLog(-1, 10, 10),
+		NewLog(1, 10, 1),
+		NewLog(1, 10, 0.5),
+		NewLog(1, inf, 10),
+		NewLog(nan, 10, 10),
+		NewLog(1, 10, nan),
+	}
+	r := rand.New(rand.NewSource(7))
+	for _, s := range cases {
+		for j := 0; j < 20; j++ {
+			u := r.Float64()
+			if x, ok := s.Inv(u); ok {
+				t.Errorf("%#v: Inv(%v) = %v, true; want false", s, u, x)
+				break
+			}
+			if v := s.Fwd(u); math.IsNaN(v) || math.IsInf(v, 0) {
+				t.Errorf("%#v: Fwd(%v) = %v; want finite", s, u, v)
+				break
+			}
+		}
+	}
+}
+
+func TestAxes2DProperty_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for n := 0; n < propertyTrials; n++ {
+		xc := scaleGens[r.Intn(len(scaleGens))].gen(r)
+		yc := scaleGens[r.Intn(len(scaleGens))].gen(r)
+
+		// Axes->pixel maps as produced by layout: positive width, flipped
+		// height, arbitrary offset, occasionally rotated or sheared.
+		m := geom.Affine{
+			A: r.Float64()*4000 + 1,
+			D: -(r.Float64()*4000 + 1),
+			E: r.Float64()*1000 - 500,
+			F: r.Float64()*1000 - 500,
+		}
+		if r.Intn(4) == 0 {
+			m.B, m.C = r.Float64()*10-5, r.Float64()*10-5
+		}
+		tr := NewAxes2D(xc.s, yc.s, NewAffine(m))
+		chain := Chain{A: tr, B: NewAffine(geom.Affine{A: 2, D: 2, E: 3, F: -7})}
+
+		for j := 0; j < 10; j++ {
+			p := geom.Pt{X: xc.sample(r), Y: yc.sample(r)}
+			for _, c := range []T{tr, chain} {
+				pr, ok := c.Invert(c.Apply(p))
+				if !ok {
+					t.Fatalf("trial %d: Invert failed for %+v through %#v", n, p, c)
+				}
+				if !closeRel(p.X, pr.X, axisScale(xc.s, p.X)) || !closeRel(p.Y, pr.Y, axisScale(yc.s, p.Y)) {
+					t.Fatalf("trial %d: Invert(Apply(%+v)) = %+v through %#v", n, p, pr, c)
+				}
+			}
+		}
+	}
+}
+
+// axisScale is the magnitude that round-trip error of x is measured against.
+// The pixel mapping loses precision relative to the unit interval, which a
+// log scale stretches by the number of e-folds in its domain.
+func axisScale(s Scale, x float64) float64 {
+	min, max := s.Domain()
+	if _, isLog := s.(Log); isLog {
+		return math.Abs(x) * math.Max(1, math.Abs(math.Log(max/min)))
+	}
+	return math.Max(math.Abs(x), math.Max(math.Abs(min), math.Abs(max)))
+}
+
+// unitCond is the condition number of the unit coordinate: how much a
+// relative error in x is amplified in u. Narrow domains far from zero lose
+// precision in u no matter how the scale is implemented.
+func unitCond(s Scale) float64 {
+	min, max := s.Domain()
+	if _, isLog := s.(Log); isLog {
+		lmin, lmax := math.Abs(math.Log(min)), math.Abs(math.Log(max))
+		return 1 + math.Max(lmin, lmax)/math.Abs(math.Log(max/min))
+	}
+	return 1 + math.Max(math.Abs(min), math.Abs(max))/math.Abs(max-min)
+}
@@ -0,0 +1,107 @@
+package transform
+
+import "math"
+
+// SymLog maps [Min,Max] to [0,1] linearly within ±LinThresh and
+// logarithmically beyond, so data spanning negative and positive decades
+// can share an axis: each decade beyond LinThresh takes as much room as
+// the linear range from 0 to LinThresh. LinThresh > 0, Base > 1.
+type SymLog struct{ Min, Max, LinThresh, Base float64 }
+
+func NewSymLog(min, max, linThresh, base float64) SymLog {
+	return SymLog{Min: min, Max: max, LinThresh: linThresh, Base: base}
+}
+
+func (s SymLog) Domain() (float64, float64) { return s.Min, s.Max }
+
+// valid also requires the limits to transform to distinct finite values,
+// which fails for limits that differ by less than rounding.
+func (s SymLog) valid() bool {
+	if !finite(s.Min) || !finite(s.Max) || s.Min == s.Max ||
+		!finite(s.LinThresh) || s.LinThresh <= 0 || !finite(s.Base) || s.Base <= 1 {
+		return false
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	return finite(lo) && finite(hi) && lo != hi
+}
+
+// fwd is the unnormalized transform: x/LinThresh inside the linear range,
+// ±(1 + log(|x|/LinThresh)) outside.
+func (s SymLog) fwd(x float64) float64 {
+	a := math.Abs(x)
+	if a <= s.LinThresh {
+		return x / s.LinThresh
+	}
+	return math.Copysign(1+math.Log(a/s.LinThresh)/math.Log(s.Base), x)
+}
+
+func (s SymLog) inv(v float64) float64 {
+	a := math.Abs(v)
+	if a <= 1 {
+		return v * s.LinThresh
+	}
+	return math.Copysign(s.LinThresh*math.Pow(s.Base, a-1), v)
+}
+
+func (s SymLog) Fwd(x float64) float64 {
+	if !s.valid() {
+		return 0
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	return (s.fwd(x) - lo) / (hi - lo)
+}
+
+func (s SymLog) Inv(u float64) (float64, bool) {
+	if !s.valid() {
+		return s.Min, false
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	x := s.inv(lo + u*(hi-lo))
+	if !finite(x) {
+		return 0, false
+	}
+	return x, true
+}
+
+// Power maps [Min,Max] to [0,1] through x^Exp, mirrored for negative x:
+// Exp 0.5 is a square root scale that spreads out small values, Exp 2
+// spreads out large ones. Exp > 0.
+type Power struct{ Min, Max, Exp float64 }
+
+func NewPower(min, max, exp float64) Power { return Power{Min: min, Max: max, Exp: exp} }
+
+func (s Power) Domain() (float64, float64) { return s.Min, s.Max }
+
+// valid also requires the limits to transform to distinct finite values,
+// which fails when a large Exp overflows them or a small one rounds them
+// together.
+func (s Power) valid() bool {
+	if !finite(s.Min) || !finite(s.Max) || s.Min == s.Max || !finite(s.Exp) || s.Exp <= 0 {
+		return false
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	return finite(lo) && finite(hi) && lo != hi
+}
+
+func (s Power) fwd(x float64) float64 { return math.Copysign(math.Pow(math.Abs(x), s.Exp), x) }
+
+func (s Power) Fwd(x float64) float64 {
+	if !s.valid() {
+		return 0
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	return (s.fwd(x) - lo) / (hi - lo)
+}
+
+func (s Power) Inv(u float64) (float64, bool) {
+	if !s.valid() {
+		return s.Min, false
+	}
+	lo, hi := s.fwd(s.Min), s.fwd(s.Max)
+	v := lo + u*(hi-lo)
+	x := math.Copysign(math.Pow(math.Abs(v), 1/s.Exp), v)
+	if !finite(x) {
+		return 0, false
+	}
+	return x, true
+}
@@ -0,0 +1,42 @@
+package transform
+
+import (
+	"math"
+	"time"
+)
+
+// Time maps instants [Min,Max], given in Unix seconds, linearly to [0,1].
+// Data plotted on a time axis is in Unix seconds too, see Seconds.
+type Time struct{ Min, Max float64 }
+
+// NewTime returns the time scale from min to max.
+func NewTime(min, max time.Time) Time { return Time{Min: Seconds(min), Max: Seconds(max)} }
+
+func (s Time) Domain() (float64, float64) { return s.Min, s.Max }
+
+func (s Time) Fwd(x float64) float64 { return Linear(s).Fwd(x) }
+
+func (s Time) Inv(u float64) (float64, bool) { return Linear(s).Inv(u) }
+
+// Seconds returns t in Unix seconds with sub-second precision, the data
+// coordinate of t on a time axis.
+func Seconds(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/1e9
+}
+
+// SecondsOf returns the Unix seconds of each of ts, e.g. the x values of a
+// time series.
+func SecondsOf(ts []time.Time) []float64 {
+	out := make([]float64, len(ts))
+	for i, t := range ts {
+		out[i] = Seconds(t)
+	}
+	return out
+}
+
+// TimeAt returns the instant at s Unix seconds, rounded to the microsecond
+// since float64 seconds carry no finer precision for present-day dates.
+func TimeAt(s float64) time.Time {
+	sec := math.Floor(s)
+	return time.Unix(int64(sec), 0).Add(time.Duration(math.Round((s-sec)*1e6)) * time.Microsecond)
+}
@@ -17,6 +17,17 @@ type AffineT struct{ M geom.Affine }
 
 func NewAffine(M geom.Affine) AffineT { return AffineT{M: M} }
 
+// Rotation returns a rotation by theta radians about center. In pixel
+// space, where y points down, positive angles turn clockwise on screen.
+func Rotation(theta float64, center geom.Pt) AffineT {
+	sin, cos := math.Sincos(theta)
+	return AffineT{M: geom.Affine{
+		A: cos, B: sin, C: -sin, D: cos,
+		E: center.X - cos*center.X + sin*center.Y,
+		F: center.Y - sin*center.X - cos*center.Y,
+	}}
+}
+
 func (a AffineT) Apply(p geom.Pt) geom.Pt { return a.M.Apply(p) }
 
 func (a AffineT) Invert(p geom.Pt) (geom.Pt, bool) {
@@ -41,20 +52,29 @@ func NewLinear(min, max float64) Linear { return Linear{Min: min, Max: max} }
 
 func (s Linear) Domain() (float64, float64) { return s.Min, s.Max }
 
-func (s Linear) Fwd(x float64) float64 {
+// valid reports whether the domain is finite and non-empty. A span that
+// overflows float64 counts as degenerate too.
+func (s Linear) valid() bool {
 	den := s.Max - s.Min
-	if den == 0 { // degenerate domain
+	return den != 0 && finite(den)
+}
+
+func (s Linear) Fwd(x float64) float64 {
+	if !s.valid() { // degenerate domain
 		return 0
 	}
-	return (x - s.Min) / den
+	return (x - s.Min) / (s.Max - s.Min)
 }
 
 func (s Linear) Inv(u float64) (float64, bool) {
-	den := s.Max - s.Min
-	if den == 0 {
+	if !s.valid() {
 		return s.Min, false
 	}
-	return s.Min + u*den, true
+	x := s.Min + u*(s.Max-s.Min)
+	if !finite(x) {
+		return 0, false
+	}
+	return x, true
 }
 
 // Log maps (Min,Max], Min>0, Base>1 to [0,1] using log with the given base.
@@ -65,6 +85,9 @@ func NewLog(min, max, base float64) Log { return Log{Min: min, Max: max, Base: b
 func (s Log) Domain() (float64, float64) { return s.Min, s.Max }
 
 func (s Log) valid() bool {
+	if !finite(s.Min) || !finite(s.Max) || !finite(s.Base) {
+		return false
+	}
 	if s.Base <= 1 {
 		return false
 	}
@@ -100,12 +123,15 @@ func (s Log) Inv(u float64) (float64, bool) {
 	hi := math.Log(s.Max) / lb
 	vx := lo + u*(hi-lo)
 	x := math.Pow(s.Base, vx)
-	if x <= 0 {
+	if x <= 0 || !finite(x) {
 		return 0, false
 	}
 	return x, true
 }
 
+// finite reports whether v is neither NaN nor infinite.
+func finite(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) }
+
 // Chain composes two transforms: Apply(p) = B(A(p))
 type Chain struct{ A, B T }
 
@@ -124,6 +150,41 @@ func (c Chain) Invert(p geom.Pt) (geom.Pt, bool) {
 	return pa, true
 }
 
+// Blended takes x from X and y from Y, e.g. data x with axes-fraction y
+// for marks spanning the full height of an axes at a data position. Both
+// transforms must be separable: the x they produce may depend only on the
+// input x, and the y only on the input y.
+type Blended struct{ X, Y T }
+
+func (b Blended) Apply(p geom.Pt) geom.Pt {
+	return geom.Pt{X: b.X.Apply(p).X, Y: b.Y.Apply(p).Y}
+}
+
+func (b Blended) Invert(p geom.Pt) (geom.Pt, bool) {
+	px, okx := b.X.Invert(p)
+	py, oky := b.Y.Invert(p)
+	if !okx || !oky {
+		return geom.Pt{}, false
+	}
+	return geom.Pt{X: px.X, Y: py.Y}, true
+}
+
+// Offset shifts the output of Base by a fixed Delta, e.g. to place a label
+// a few pixels from a data point regardless of zoom.
+type Offset struct {
+	Base  T
+	Delta geom.Pt
+}
+
+func (o Offset) Apply(p geom.Pt) geom.Pt {
+	q := o.Base.Apply(p)
+	return geom.Pt{X: q.X + o.Delta.X, Y: q.Y + o.Delta.Y}
+}
+
+func (o Offset) Invert(p geom.Pt) (geom.Pt, bool) {
+	return o.Base.Invert(geom.Pt{X: p.X - o.Delta.X, Y: p.Y - o.Delta.Y})
+}
+
 // Axes2D composes per-axis scales with an axes->pixel affine transform.
 type Axes2D struct {
 	X           Scale
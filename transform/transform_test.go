@@ -4,6 +4,7 @@ import (
 	"math"
 	"math/rand"
 	"testing"
+	"time"
 
 	"matplotlib-go/internal/geom"
 )
@@ -127,3 +128,94 @@ func TestEdgeCases(t *testing.T) {
 		t.Fatalf("expected inv=false for min==max")
 	}
 }
+
+func TestBlendedAndOffset(t *testing.T) {
+	px := NewAffine(geom.Affine{A: 200, D: -100, E: 10, F: 120}) // axes -> pixels
+	data := NewAxes2D(NewLinear(0, 10), NewLog(1, 100, 10), px)
+	b := Blended{X: data, Y: px} // data x, axes-fraction y
+
+	got := b.Apply(geom.Pt{X: 5, Y: 1})
+	if want := (geom.Pt{X: 110, Y: 20}); !approxPt(got, want, 1e-9) {
+		t.Errorf("Blended.Apply = %v, want %v", got, want)
+	}
+	back, ok := b.Invert(got)
+	if !ok || !approxPt(back, geom.Pt{X: 5, Y: 1}, 1e-9) {
+		t.Errorf("Blended.Invert = %v, %v", back, ok)
+	}
+
+	o := Offset{Base: b, Delta: geom.Pt{X: 3, Y: -4}}
+	if got := o.Apply(geom.Pt{X: 5, Y: 1}); !approxPt(got, geom.Pt{X: 113, Y: 16}, 1e-9) {
+		t.Errorf("Offset.Apply = %v", got)
+	}
+	if back, ok := o.Invert(geom.Pt{X: 113, Y: 16}); !ok || !approxPt(back, geom.Pt{X: 5, Y: 1}, 1e-9) {
+		t.Errorf("Offset.Invert = %v, %v", back, ok)
+	}
+
+	if _, ok := (Blended{X: data, Y: NewAffine(geom.Affine{})}).Invert(geom.Pt{}); ok {
+		t.Error("Blended.Invert succeeded with a singular part")
+	}
+}
+
+func TestRotation(t *testing.T) {
+	r := Rotation(math.Pi/2, geom.Pt{X: 10, Y: 10})
+	if got := r.Apply(geom.Pt{X: 10, Y: 10}); !approxPt(got, geom.Pt{X: 10, Y: 10}, 1e-12) {
+		t.Errorf("center moved to %v", got)
+	}
+	// A quarter turn in y-down pixels takes east to south.
+	if got := r.Apply(geom.Pt{X: 15, Y: 10}); !approxPt(got, geom.Pt{X: 10, Y: 15}, 1e-12) {
+		t.Errorf("east point rotated to %v, want (10, 15)", got)
+	}
+}
+
+func TestTimeScale(t *testing.T) {
+	start := time.Date(2024, 2, 29, 23, 59, 59, 250_000_000, time.UTC)
+	if s := Seconds(start); s != float64(start.Unix())+0.25 {
+		t.Errorf("Seconds = %v", s)
+	}
+	if back := TimeAt(Seconds(start)); !back.Equal(start) {
+		t.Errorf("TimeAt(Seconds(t)) = %v, want %v", back, start)
+	}
+	s := NewTime(start, start.Add(4*time.Second))
+	if u := s.Fwd(Seconds(start.Add(time.Second))); !approx(u, 0.25, 1e-9) {
+		t.Errorf("Fwd = %v, want 0.25", u)
+	}
+	if x, ok := s.Inv(0.5); !ok || !TimeAt(x).Equal(start.Add(2*time.Second)) {
+		t.Errorf("Inv(0.5) = %v, %v", TimeAt(x), ok)
+	}
+	if xs := SecondsOf([]time.Time{start}); len(xs) != 1 || xs[0] != Seconds(start) {
+		t.Errorf("SecondsOf = %v", xs)
+	}
+}
+
+func TestSymLogScale(t *testing.T) {
+	s := NewSymLog(-1000, 1000, 1, 10)
+	// Three decades and the linear range on each side: 8 equal parts.
+	for x, want := range map[float64]float64{-1000: 0, -10: 0.25, -1: 3.0 / 8, 0: 0.5, 0.5: 9.0 / 16, 100: 7.0 / 8, 1000: 1} {
+		if u := s.Fwd(x); !approx(u, want, 1e-12) {
+			t.Errorf("Fwd(%v) = %v, want %v", x, u, want)
+		}
+		if back, ok := s.Inv(s.Fwd(x)); !ok || !approx(back, x, 1e-9*(1+math.Abs(x))) {
+			t.Errorf("Inv(Fwd(%v)) = %v, %v", x, back, ok)
+		}
+	}
+	if u := NewSymLog(0, 1, 0, 10).Fwd(0.5); u != 0 {
+		t.Errorf("zero threshold Fwd = %v, want 0", u)
+	}
+}
+
+func TestPowerScale(t *testing.T) {
+	s := NewPower(0, 100, 0.5)
+	if u := s.Fwd(25); !approx(u, 0.5, 1e-12) {
+		t.Errorf("sqrt Fwd(25) = %v, want 0.5", u)
+	}
+	if x, ok := s.Inv(0.1); !ok || !approx(x, 1, 1e-12) {
+		t.Errorf("sqrt Inv(0.1) = %v, %v", x, ok)
+	}
+	sym := NewPower(-4, 4, 2)
+	if u := sym.Fwd(-2); !approx(u, 0.375, 1e-12) {
+		t.Errorf("mirrored Fwd(-2) = %v, want 0.375", u)
+	}
+	if x, ok := sym.Inv(0.375); !ok || !approx(x, -2, 1e-12) {
+		t.Errorf("mirrored Inv(0.375) = %v, %v", x, ok)
+	}
+}